@@ -566,7 +566,13 @@ func (ep *endpoint) addDriverInfoToCluster() error {
 	}
 
 	for _, te := range ep.joinInfo.driverTableEntries {
-		if err := agent.networkDB.CreateEntry(te.tableName, n.ID(), te.key, te.value); err != nil {
+		err := agent.networkDB.CreateEntry(te.tableName, n.ID(), te.key, te.value)
+		if err != nil && ep.readvertise {
+			// The node the endpoint was checkpointed on may not have
+			// withdrawn the entry yet
+			err = agent.networkDB.UpdateEntry(te.tableName, n.ID(), te.key, te.value)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -122,6 +122,7 @@ func (h *httpHandler) initRouter() {
 			{"/networks", nil, procCreateNetwork},
 			{"/networks/" + nwID + "/endpoints", nil, procCreateEndpoint},
 			{"/networks/" + nwID + "/endpoints/" + epID + "/sandboxes", nil, procJoinEndpoint},
+			{"/networks/" + nwID + "/endpoints/" + epID + "/mirror", nil, procMirrorEndpoint},
 			{"/services", nil, procPublishService},
 			{"/services/" + epID + "/backend", nil, procAttachBackend},
 			{"/sandboxes", nil, procCreateSandbox},
@@ -130,6 +131,7 @@ func (h *httpHandler) initRouter() {
 			{"/networks/" + nwID, nil, procDeleteNetwork},
 			{"/networks/" + nwID + "/endpoints/" + epID, nil, procDeleteEndpoint},
 			{"/networks/" + nwID + "/endpoints/" + epID + "/sandboxes/" + sbID, nil, procLeaveEndpoint},
+			{"/networks/" + nwID + "/endpoints/" + epID + "/mirror", nil, procUnmirrorEndpoint},
 			{"/services/" + epID, nil, procUnpublishService},
 			{"/services/" + epID + "/backend/" + sbID, nil, procDetachBackend},
 			{"/sandboxes/" + sbID, nil, procDeleteSandbox},
@@ -312,6 +314,10 @@ func procCreateNetwork(c libnetwork.NetworkController, vars map[string]string, b
 		ipamV4Conf := &libnetwork.IpamConf{
 			PreferredPool: create.IPv4Conf[0].PreferredPool,
 			SubPool:       create.IPv4Conf[0].SubPool,
+			Ranges:        create.IPv4Conf[0].Ranges,
+			Exclusions:    create.IPv4Conf[0].Exclusions,
+			GatewayPolicy: create.IPv4Conf[0].GatewayPolicy,
+			Driver:        create.IPv4Conf[0].Driver,
 		}
 
 		options = append(options, libnetwork.NetworkOptionIpam("default", "", []*libnetwork.IpamConf{ipamV4Conf}, nil, nil))
@@ -541,6 +547,46 @@ func procLeaveEndpoint(c libnetwork.NetworkController, vars map[string]string, b
 	return nil, &successResponse
 }
 
+func procMirrorEndpoint(c libnetwork.NetworkController, vars map[string]string, body []byte) (interface{}, *responseStatus) {
+	var em endpointMirror
+	err := json.Unmarshal(body, &em)
+	if err != nil {
+		return nil, &responseStatus{Status: "Invalid body: " + err.Error(), StatusCode: http.StatusBadRequest}
+	}
+
+	nwT, nwBy := detectNetworkTarget(vars)
+	epT, epBy := detectEndpointTarget(vars)
+
+	ep, errRsp := findEndpoint(c, nwT, epT, nwBy, epBy)
+	if !errRsp.isOK() {
+		return nil, errRsp
+	}
+
+	err = ep.Mirror(libnetwork.MirrorTarget{HostInterface: em.HostInterface, Endpoint: em.Endpoint, File: em.File})
+	if err != nil {
+		return nil, convertNetworkError(err)
+	}
+
+	return nil, &successResponse
+}
+
+func procUnmirrorEndpoint(c libnetwork.NetworkController, vars map[string]string, body []byte) (interface{}, *responseStatus) {
+	nwT, nwBy := detectNetworkTarget(vars)
+	epT, epBy := detectEndpointTarget(vars)
+
+	ep, errRsp := findEndpoint(c, nwT, epT, nwBy, epBy)
+	if !errRsp.isOK() {
+		return nil, errRsp
+	}
+
+	err := ep.Unmirror()
+	if err != nil {
+		return nil, convertNetworkError(err)
+	}
+
+	return nil, &successResponse
+}
+
 func procDeleteEndpoint(c libnetwork.NetworkController, vars map[string]string, body []byte) (interface{}, *responseStatus) {
 	nwT, nwBy := detectNetworkTarget(vars)
 	epT, epBy := detectEndpointTarget(vars)
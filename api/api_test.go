@@ -1634,8 +1634,12 @@ func (l *localReader) Read(p []byte) (n int, err error) {
 	if l.data == nil || len(l.data) == 0 {
 		return 0, io.EOF
 	}
-	copy(p[:], l.data[:])
-	return len(l.data), io.EOF
+	n = copy(p, l.data)
+	l.data = l.data[n:]
+	if len(l.data) == 0 {
+		return n, io.EOF
+	}
+	return n, nil
 }
 
 type localResponseWriter struct {
@@ -37,6 +37,10 @@ type ipamConf struct {
 	SubPool       string
 	Gateway       string
 	AuxAddresses  map[string]string
+	Ranges        []string
+	Exclusions    []string
+	GatewayPolicy string
+	Driver        string
 }
 
 // networkCreate is the expected body of the "create network" http request message
@@ -76,6 +80,13 @@ type endpointJoin struct {
 	Aliases   []string `json:"aliases"`
 }
 
+// endpointMirror represents the body of the "mirror endpoint" http request message
+type endpointMirror struct {
+	HostInterface string `json:"host_interface"`
+	Endpoint      string `json:"endpoint"`
+	File          string `json:"file"`
+}
+
 // servicePublish represents the body of the "publish service" http request message
 type servicePublish struct {
 	Name      string   `json:"name"`
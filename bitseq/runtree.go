@@ -0,0 +1,366 @@
+package bitseq
+
+import (
+	"math/rand"
+)
+
+// The sequences of a handle are indexed by a treap ordered by block
+// position, whose nodes are the sequences themselves. The next links of
+// the sequences are kept threaded in order, so that the list rooted at the
+// handle head is the run-length encoded bitmask at all times. Each node
+// carries the number of blocks and whether all of them are full in its
+// subtree, for the sequence containing a bit and the first one with an
+// available bit to be found in logarithmic time.
+
+// update recomputes the subtree aggregates of the node from its children
+func (s *sequence) update() {
+	s.blocks = s.count
+	s.full = s.block == blockMAX || s.count == 0
+	if s.left != nil {
+		s.blocks += s.left.blocks
+		s.full = s.full && s.left.full
+	}
+	if s.right != nil {
+		s.blocks += s.right.blocks
+		s.full = s.full && s.right.full
+	}
+}
+
+func numBlocks(t *sequence) uint64 {
+	if t == nil {
+		return 0
+	}
+	return t.blocks
+}
+
+// buildRunTree indexes the list rooted at head, dropping the empty
+// sequences and merging the adjacent ones with the same block on the way.
+// It returns the new head of the list along with the root of the tree.
+func buildRunTree(head *sequence) (*sequence, *sequence) {
+	var (
+		first, last *sequence
+		stack       []*sequence
+	)
+	for s := head; s != nil; s = s.next {
+		if s.count == 0 {
+			continue
+		}
+		if last != nil && last.block == s.block {
+			last.count += s.count
+			continue
+		}
+		// Reuse the node, as it may be referenced by the caller
+		s.left, s.right, s.prio = nil, nil, rand.Uint32()
+		if last == nil {
+			first = s
+		} else {
+			last.next = s
+		}
+		last = s
+
+		// Cartesian tree construction on the node priorities
+		var child *sequence
+		for len(stack) > 0 && stack[len(stack)-1].prio < s.prio {
+			child = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+		}
+		s.left = child
+		if len(stack) > 0 {
+			stack[len(stack)-1].right = s
+		}
+		stack = append(stack, s)
+	}
+	if last != nil {
+		last.next = nil
+	}
+	if first == nil {
+		return nil, nil
+	}
+	updateTree(stack[0])
+	return first, stack[0]
+}
+
+// updateTree recomputes the aggregates of the whole tree rooted at t
+func updateTree(t *sequence) {
+	if t == nil {
+		return
+	}
+	updateTree(t.left)
+	updateTree(t.right)
+	t.update()
+}
+
+// splitRuns splits the tree t into the trees holding its first k blocks
+// and the remaining ones. A sequence straddling the split point is cut in
+// two, the list stays threaded.
+func splitRuns(t *sequence, k uint64) (*sequence, *sequence) {
+	if t == nil {
+		return nil, nil
+	}
+	lb := numBlocks(t.left)
+	switch {
+	case k <= lb:
+		l, r := splitRuns(t.left, k)
+		t.left = r
+		t.update()
+		return l, t
+	case k >= lb+t.count:
+		l, r := splitRuns(t.right, k-lb-t.count)
+		t.right = l
+		t.update()
+		return t, r
+	default:
+		// Same priority as t for the heap order to hold with its children
+		n := &sequence{block: t.block, count: lb + t.count - k, next: t.next, prio: t.prio}
+		t.count = k - lb
+		t.next = n
+		n.right = t.right
+		t.right = nil
+		n.update()
+		t.update()
+		return t, n
+	}
+}
+
+// joinRuns joins the trees a and b, all the blocks of a preceding the ones
+// of b
+func joinRuns(a, b *sequence) *sequence {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.prio > b.prio {
+		a.right = joinRuns(a.right, b)
+		a.update()
+		return a
+	}
+	b.left = joinRuns(a, b.left)
+	b.update()
+	return b
+}
+
+func firstRun(t *sequence) *sequence {
+	for t != nil && t.left != nil {
+		t = t.left
+	}
+	return t
+}
+
+func lastRun(t *sequence) *sequence {
+	for t != nil && t.right != nil {
+		t = t.right
+	}
+	return t
+}
+
+// growLast adds delta blocks to the last sequence of the tree t
+func growLast(t *sequence, delta uint64) {
+	if t == nil {
+		return
+	}
+	if t.right != nil {
+		growLast(t.right, delta)
+	} else {
+		t.count += delta
+	}
+	t.update()
+}
+
+// removeFirst removes the first sequence from the tree t and returns the
+// new root. The list is not relinked.
+func removeFirst(t *sequence) *sequence {
+	if t == nil {
+		return nil
+	}
+	if t.left == nil {
+		return t.right
+	}
+	t.left = removeFirst(t.left)
+	t.update()
+	return t
+}
+
+// findRun returns the sequence of the tree t containing the block at
+// position b, along with the number of blocks preceding it inside the
+// sequence. It returns nil if b is outside of the tree.
+func findRun(t *sequence, b uint64) (*sequence, uint64) {
+	for t != nil {
+		lb := numBlocks(t.left)
+		switch {
+		case b < lb:
+			t = t.left
+		case b < lb+t.count:
+			return t, b - lb
+		default:
+			b -= lb + t.count
+			t = t.right
+		}
+	}
+	return nil, 0
+}
+
+// firstFreeBlock returns the position of the first block of the tree t at
+// or after position b which has an available bit
+func firstFreeBlock(t *sequence, b uint64) uint64 {
+	if t == nil || t.full || b >= t.blocks {
+		return invalidPos
+	}
+	lb := numBlocks(t.left)
+	if b < lb {
+		if p := firstFreeBlock(t.left, b); p != invalidPos {
+			return p
+		}
+		b = lb
+	}
+	if b < lb+t.count && t.block != blockMAX {
+		return b
+	}
+	if b < lb+t.count {
+		b = lb + t.count
+	}
+	if p := firstFreeBlock(t.right, b-lb-t.count); p != invalidPos {
+		return lb + t.count + p
+	}
+	return invalidPos
+}
+
+// copyRunTree returns a copy of the tree t, along with the head of the list
+// threading the copied sequences in order
+func copyRunTree(t *sequence) (*sequence, *sequence) {
+	var head, last *sequence
+	var copyNode func(s *sequence) *sequence
+	copyNode = func(s *sequence) *sequence {
+		if s == nil {
+			return nil
+		}
+		n := &sequence{block: s.block, count: s.count, prio: s.prio, blocks: s.blocks, full: s.full}
+		n.left = copyNode(s.left)
+		if last == nil {
+			head = n
+		} else {
+			last.next = n
+		}
+		last = n
+		n.right = copyNode(s.right)
+		return n
+	}
+	root := copyNode(t)
+	return head, root
+}
+
+// copySequences returns a copy of the handle sequences along with their
+// index, when it is up to date, so that the copy does not have to index
+// them again. It must be called with the handle lock held.
+func (h *Handle) copySequences() (head, root, indexed *sequence) {
+	if h.root != nil && h.indexed == h.head {
+		head, root = copyRunTree(h.root)
+		return head, root, head
+	}
+	return h.head.getCopy(), nil, nil
+}
+
+// runs returns the root of the index of the handle sequences, (re)building
+// it if the head was replaced since it was last indexed
+func (h *Handle) runs() *sequence {
+	if h.indexed == nil || h.indexed != h.head {
+		h.head, h.root = buildRunTree(h.head)
+		h.indexed = h.head
+	}
+	return h.root
+}
+
+// firstAvailable returns the first unset bit at or after ordinal from
+func (h *Handle) firstAvailable(from uint64) (uint64, error) {
+	root := h.runs()
+	b := from / uint64(blockLen)
+	if s, _ := findRun(root, b); s != nil {
+		if bytePos, bitPos, err := s.getAvailableBit(from % uint64(blockLen)); err == nil {
+			return b*uint64(blockLen) + posToOrdinal(bytePos, bitPos), nil
+		}
+	}
+	if b = firstFreeBlock(root, b+1); b == invalidPos {
+		return invalidPos, ErrNoBitAvailable
+	}
+	s, _ := findRun(root, b)
+	bytePos, bitPos, err := s.getAvailableBit(0)
+	if err != nil {
+		return invalidPos, err
+	}
+	return b*uint64(blockLen) + posToOrdinal(bytePos, bitPos), nil
+}
+
+// availableFromCurrent looks for an available ordinal in [start, end]
+// from the current ordinal, rolling over to start if none is found
+func (h *Handle) availableFromCurrent(start, curr, end uint64) (uint64, error) {
+	if curr != 0 && curr > start {
+		if ret, err := h.firstAvailable(curr); err == nil && ret <= end {
+			return ret, nil
+		}
+	}
+	ret, err := h.firstAvailable(start)
+	if err != nil || ret > end {
+		return invalidPos, ErrNoBitAvailable
+	}
+	return ret, nil
+}
+
+// isAvailable checks if the bit of the specified ordinal is unset
+func (h *Handle) isAvailable(ordinal uint64) bool {
+	s, _ := findRun(h.runs(), ordinal/uint64(blockLen))
+	if s == nil {
+		return false
+	}
+	return s.block&(blockFirstBit>>(ordinal%uint64(blockLen))) == 0
+}
+
+// pushBit sets or resets the bit of the specified ordinal in the handle
+// sequences, cutting the sequence containing it and merging the result
+// with its neighbours as needed
+func (h *Handle) pushBit(ordinal uint64, release bool) {
+	root := h.runs()
+	b := ordinal / uint64(blockLen)
+	s, _ := findRun(root, b)
+	if s == nil {
+		return
+	}
+	bitSel := blockFirstBit >> (ordinal % uint64(blockLen))
+	newBlock := s.block
+	if release {
+		newBlock &^= bitSel
+	} else {
+		newBlock |= bitSel
+	}
+	// Quit if it was a redundant request
+	if s.block == newBlock {
+		return
+	}
+
+	l, r := splitRuns(root, b)
+	m, r := splitRuns(r, 1)
+	m.block = newBlock
+	m.update()
+
+	prev, next := lastRun(l), firstRun(r)
+	cur := m
+	if prev != nil && prev.block == m.block {
+		prev.next = m.next
+		growLast(l, 1)
+		cur, m = prev, nil
+	}
+	if next != nil && next.block == cur.block {
+		cur.next = next.next
+		r = removeFirst(r)
+		if m != nil {
+			m.count += next.count
+			m.update()
+		} else {
+			growLast(l, next.count)
+		}
+	}
+
+	h.root = joinRuns(joinRuns(l, m), r)
+	h.head = firstRun(h.root)
+	h.indexed = h.head
+}
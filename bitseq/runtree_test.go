@@ -0,0 +1,356 @@
+package bitseq
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/docker/libnetwork/datastore"
+)
+
+// listSetAny sets the first unset bit in [start, end] from curr of the
+// plain list of sequences, the way the handle did before indexing them
+func listSetAny(head *sequence, start, curr, end uint64) (*sequence, uint64, error) {
+	bytePos, bitPos, err := getAvailableFromCurrent(head, start, curr, end)
+	if err != nil {
+		return head, invalidPos, err
+	}
+	return pushReservation(bytePos, bitPos, head, false), posToOrdinal(bytePos, bitPos), nil
+}
+
+func listUnset(head *sequence, ordinal uint64) *sequence {
+	bytePos, bitPos := ordinalToPos(ordinal)
+	return pushReservation(bytePos, bitPos, head, true)
+}
+
+func TestRunTreeMatchesList(t *testing.T) {
+	const numBits = 4096
+	hnd, err := NewHandle("", nil, "", numBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := &sequence{block: 0x0, count: getNumBlocks(numBits)}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 20000; i++ {
+		o := uint64(r.Int63n(numBits))
+		bytePos, bitPos := ordinalToPos(o)
+		switch r.Intn(3) {
+		case 0:
+			if err := hnd.Unset(o); err != nil {
+				t.Fatal(err)
+			}
+			list = listUnset(list, o)
+		case 1:
+			err := hnd.Set(o)
+			if _, _, lerr := checkIfAvailable(list, o); lerr != nil {
+				if err != ErrBitAllocated {
+					t.Fatalf("Expected bit %d to be allocated, got %v", o, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			list = pushReservation(bytePos, bitPos, list, false)
+		case 2:
+			var lo uint64
+			ho, err := hnd.SetAnyInRange(o, numBits-1, false)
+			list, lo, _ = listSetAny(list, o, 0, numBits-1)
+			if ho != lo {
+				t.Fatalf("Got ordinal %d (%v), expected %d", ho, err, lo)
+			}
+		}
+		if !hnd.head.equal(list) {
+			t.Fatalf("Sequences differ after %d operations:\n%s\n%s", i, hnd.head.toString(), list.toString())
+		}
+		if hnd.root.blocks != getNumBlocks(numBits) {
+			t.Fatalf("Unexpected number of indexed blocks: %d", hnd.root.blocks)
+		}
+	}
+}
+
+func TestRunTreeReindex(t *testing.T) {
+	hnd, err := NewHandle("", nil, "", 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hnd.Set(100); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replace the sequences, as done when reading the handle from store,
+	// with zero length and unmerged ones
+	hnd.head = &sequence{block: 0xffffffff, count: 1, next: &sequence{block: 0x1, count: 0,
+		next: &sequence{block: 0x0, count: 10, next: &sequence{block: 0x0, count: 21}}}}
+
+	if hnd.IsSet(100) || !hnd.IsSet(31) {
+		t.Fatalf("Unexpected bits set in %s", hnd)
+	}
+	o, err := hnd.SetAny(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o != 32 {
+		t.Fatalf("Expected ordinal 32, got %d", o)
+	}
+	exp := "(0xffffffff, 1)->(0x80000000, 1)->(0x0, 30)->end"
+	if hnd.head.toString() != exp {
+		t.Fatalf("Expected %s, got %s", exp, hnd.head.toString())
+	}
+}
+
+func TestRunTreeCopy(t *testing.T) {
+	hnd, err := NewHandle("", nil, "", 1<<16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hnd.head = fragmentedList(rand.New(rand.NewSource(1)))
+	hnd.runs()
+
+	for _, c := range []*Handle{hnd.getCopy(), {}} {
+		if c.head == nil {
+			if err := hnd.CopyTo(c); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if c.indexed != c.head || c.root == nil {
+			t.Fatal("Expected the copy to be indexed")
+		}
+		if c.head == hnd.head || c.root == hnd.root {
+			t.Fatal("Expected the copy not to share the sequences")
+		}
+		if c.head.toString() != hnd.head.toString() {
+			t.Fatalf("Unexpected copy %s", c.head.toString())
+		}
+		if err := c.Set(0); err != nil {
+			t.Fatal(err)
+		}
+		if hnd.IsSet(0) {
+			t.Fatal("Expected the original to be left untouched")
+		}
+	}
+}
+
+func TestWriteCoalescing(t *testing.T) {
+	ds, err := randomLocalStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hnd, err := NewHandle("bitseq-test/data/", ds, "test_coalescing", 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hnd.EnableWriteCoalescing(0); err == nil {
+		t.Fatal("Expected failure on invalid interval")
+	}
+	if err := hnd.EnableWriteCoalescing(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	stored := func() *Handle {
+		sh := &Handle{app: hnd.app, id: hnd.id, store: ds}
+		if err := ds.GetObject(datastore.Key(sh.Key()...), sh); err != nil {
+			t.Fatal(err)
+		}
+		return sh
+	}
+
+	if err := hnd.Set(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := hnd.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if !stored().IsSet(1) {
+		t.Fatal("Expected bit 1 to be written to store")
+	}
+
+	for i := uint64(2); i < 100; i++ {
+		if err := hnd.Set(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sh := stored()
+	if sh.IsSet(2) || sh.Unselected() != 1023 {
+		t.Fatalf("Unexpected write to store: %s", sh)
+	}
+
+	if err := hnd.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	sh = stored()
+	if !sh.IsSet(99) || sh.Unselected() != hnd.Unselected() {
+		t.Fatalf("Expected handle to be written to store: %s", sh)
+	}
+
+	if err := hnd.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fragmentedList returns the sequences of a /16 worth of bits, all set but
+// a random half of them
+func fragmentedList(r *rand.Rand) *sequence {
+	const numBits = 1 << 16
+	head := &sequence{block: blockMAX, count: getNumBlocks(numBits)}
+	for i := 0; i < numBits/2; i++ {
+		head = listUnset(head, uint64(r.Int63n(numBits)))
+	}
+	return head
+}
+
+func BenchmarkSetAnyFragmented(b *testing.B) {
+	const numBits = 1 << 16
+	r := rand.New(rand.NewSource(1))
+	list := fragmentedList(r)
+
+	b.Run("list", func(b *testing.B) {
+		head := list.getCopy()
+		var curr uint64
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			var o uint64
+			var err error
+			if head, o, err = listSetAny(head, 0, curr, numBits-1); err != nil {
+				head = listUnset(head, curr-1)
+				continue
+			}
+			curr = o + 1
+			head = listUnset(head, uint64(r.Int63n(int64(o+1))))
+		}
+	})
+
+	b.Run("tree", func(b *testing.B) {
+		hnd, _ := NewHandle("", nil, "", numBits)
+		hnd.head = list.getCopy()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			o, err := hnd.SetAny(true)
+			if err != nil {
+				hnd.Unset(hnd.curr - 1)
+				continue
+			}
+			hnd.Unset(uint64(r.Int63n(int64(o + 1))))
+		}
+	})
+}
+
+func BenchmarkSetAnyFragmentedStore(b *testing.B) {
+	const numBits = 1 << 16
+	r := rand.New(rand.NewSource(1))
+	list := fragmentedList(r)
+
+	for _, bc := range []struct {
+		name     string
+		coalesce time.Duration
+	}{
+		{"per-op", 0},
+		{"coalesced", DefaultWriteCoalescing},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ds, err := randomLocalStore()
+			if err != nil {
+				b.Fatal(err)
+			}
+			hnd, err := NewHandle("bitseq-test/data/", ds, "bench_fragmented_"+bc.name, numBits)
+			if err != nil {
+				b.Fatal(err)
+			}
+			hnd.head = list.getCopy()
+			if err := hnd.writeToStore(); err != nil {
+				b.Fatal(err)
+			}
+			if bc.coalesce != 0 {
+				if err := hnd.EnableWriteCoalescing(bc.coalesce); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				o, err := hnd.SetAny(true)
+				if err != nil {
+					hnd.Unset(hnd.curr - 1)
+					continue
+				}
+				hnd.Unset(uint64(r.Int63n(int64(o + 1))))
+			}
+			b.StopTimer()
+			if err := hnd.Flush(); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
+
+func BenchmarkIsSetFragmented(b *testing.B) {
+	const numBits = 1 << 16
+	r := rand.New(rand.NewSource(1))
+	list := fragmentedList(r)
+
+	b.Run("list", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			checkIfAvailable(list, uint64(r.Int63n(numBits)))
+		}
+	})
+
+	b.Run("tree", func(b *testing.B) {
+		hnd, _ := NewHandle("", nil, "", numBits)
+		hnd.head = list.getCopy()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			hnd.IsSet(uint64(r.Int63n(numBits)))
+		}
+	})
+}
+
+func BenchmarkSetUnsetStore(b *testing.B) {
+	for _, bc := range []struct {
+		name     string
+		coalesce time.Duration
+	}{
+		{"per-op", 0},
+		{"coalesced", 100 * time.Millisecond},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ds, err := randomLocalStore()
+			if err != nil {
+				b.Fatal(err)
+			}
+			hnd, err := NewHandle("bitseq-test/data/", ds, "bench_"+bc.name, 1<<16)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if bc.coalesce != 0 {
+				if err := hnd.EnableWriteCoalescing(bc.coalesce); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				o, err := hnd.SetAny(false)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if i%2 == 0 {
+					hnd.Unset(o)
+				}
+				if i%(1<<15) == 0 {
+					// Keep room in the bitmask
+					b.StopTimer()
+					hnd.Destroy()
+					hnd, _ = NewHandle("bitseq-test/data/", ds, "bench_"+bc.name, 1<<16)
+					if bc.coalesce != 0 {
+						hnd.EnableWriteCoalescing(bc.coalesce)
+					}
+					b.StartTimer()
+				}
+			}
+			b.StopTimer()
+			if err := hnd.Flush(); err != nil {
+				b.Fatal(err)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/docker/libnetwork/datastore"
 	"github.com/docker/libnetwork/types"
@@ -25,6 +26,10 @@ const (
 	invalidPos    = uint64(0xFFFFFFFFFFFFFFFF)
 )
 
+// DefaultWriteCoalescing is the suggested write coalescing interval of the
+// handles on a local scope datastore, for the callers opting in
+const DefaultWriteCoalescing = 100 * time.Millisecond
+
 var (
 	// ErrNoBitAvailable is returned when no more bits are available to set
 	ErrNoBitAvailable = errors.New("no bit available")
@@ -43,6 +48,14 @@ type Handle struct {
 	dbExists   bool
 	curr       uint64
 	store      datastore.DataStore
+	// Index of the sequences, see runs()
+	root    *sequence
+	indexed *sequence
+	// Write coalescing, see EnableWriteCoalescing()
+	coalesce   time.Duration
+	dirty      bool
+	flushTimer *time.Timer
+	flushLock  sync.Mutex
 	sync.Mutex
 }
 
@@ -84,6 +97,11 @@ type sequence struct {
 	block uint32    // block is a symbol representing 4 byte long allocation bitmask
 	count uint64    // number of consecutive blocks (symbols)
 	next  *sequence // next sequence
+	// Run tree fields, see runtree.go
+	left, right *sequence
+	prio        uint32
+	blocks      uint64
+	full        bool
 }
 
 // String returns a string representation of the block sequence starting from this block
@@ -191,16 +209,20 @@ func (s *sequence) fromByteArray(data []byte) error {
 }
 
 func (h *Handle) getCopy() *Handle {
+	head, root, indexed := h.copySequences()
 	return &Handle{
 		bits:       h.bits,
 		unselected: h.unselected,
-		head:       h.head.getCopy(),
+		head:       head,
+		root:       root,
+		indexed:    indexed,
 		app:        h.app,
 		id:         h.id,
 		dbIndex:    h.dbIndex,
 		dbExists:   h.dbExists,
 		store:      h.store,
 		curr:       h.curr,
+		coalesce:   h.coalesce,
 	}
 }
 
@@ -248,9 +270,8 @@ func (h *Handle) IsSet(ordinal uint64) bool {
 		return false
 	}
 	h.Lock()
-	_, _, err := checkIfAvailable(h.head, ordinal)
-	h.Unlock()
-	return err != nil
+	defer h.Unlock()
+	return !h.isAvailable(ordinal)
 }
 
 func (h *Handle) runConsistencyCheck() bool {
@@ -258,6 +279,7 @@ func (h *Handle) runConsistencyCheck() bool {
 	for p, c := h.head, h.head.next; c != nil; c = c.next {
 		if c.count == 0 {
 			corrupted = true
+			h.indexed = nil
 			p.next = c.next
 			continue // keep same p
 		}
@@ -269,6 +291,9 @@ func (h *Handle) runConsistencyCheck() bool {
 // CheckConsistency checks if the bit sequence is in an inconsistent state and attempts to fix it.
 // It looks for a corruption signature that may happen in docker 1.9.0 and 1.9.1.
 func (h *Handle) CheckConsistency() error {
+	if err := h.Flush(); err != nil {
+		return err
+	}
 	for {
 		h.Lock()
 		store := h.store
@@ -308,10 +333,8 @@ func (h *Handle) CheckConsistency() error {
 // set/reset the bit
 func (h *Handle) set(ordinal, start, end uint64, any bool, release bool, serial bool) (uint64, error) {
 	var (
-		bitPos  uint64
-		bytePos uint64
-		ret     uint64
-		err     error
+		ret uint64
+		err error
 	)
 
 	for {
@@ -319,7 +342,8 @@ func (h *Handle) set(ordinal, start, end uint64, any bool, release bool, serial
 		curr := uint64(0)
 		h.Lock()
 		store = h.store
-		if store != nil {
+		// With write coalescing, this handle holds the latest state
+		if store != nil && h.coalesce == 0 {
 			h.Unlock() // The lock is acquired in the GetObject
 			if err := store.GetObject(datastore.Key(h.Key()...), h); err != nil && err != datastore.ErrKeyNotFound {
 				return ret, err
@@ -331,17 +355,18 @@ func (h *Handle) set(ordinal, start, end uint64, any bool, release bool, serial
 		}
 		// Get position if available
 		if release {
-			bytePos, bitPos = ordinalToPos(ordinal)
+			ret = ordinal
 		} else {
 			if any {
-				bytePos, bitPos, err = getAvailableFromCurrent(h.head, start, curr, end)
-				ret = posToOrdinal(bytePos, bitPos)
+				ret, err = h.availableFromCurrent(start, curr, end)
 				if err == nil {
 					h.curr = ret + 1
 				}
 			} else {
-				bytePos, bitPos, err = checkIfAvailable(h.head, ordinal)
 				ret = ordinal
+				if !h.isAvailable(ordinal) {
+					err = ErrBitAllocated
+				}
 			}
 		}
 		if err != nil {
@@ -349,32 +374,38 @@ func (h *Handle) set(ordinal, start, end uint64, any bool, release bool, serial
 			return ret, err
 		}
 
+		if store == nil || h.coalesce != 0 {
+			h.pushBit(ret, release)
+			h.updateUnselected(release)
+			if store != nil {
+				h.markDirty()
+			}
+			h.Unlock()
+			return ret, nil
+		}
+
 		// Create a private copy of h and work on it
 		nh := h.getCopy()
 
-		nh.head = pushReservation(bytePos, bitPos, nh.head, release)
-		if release {
-			nh.unselected++
-		} else {
-			nh.unselected--
-		}
+		nh.pushBit(ret, release)
+		nh.updateUnselected(release)
 
-		if h.store != nil {
-			h.Unlock()
-			// Attempt to write private copy to store
-			if err := nh.writeToStore(); err != nil {
-				if _, ok := err.(types.RetryError); !ok {
-					return ret, fmt.Errorf("internal failure while setting the bit: %v", err)
-				}
-				// Retry
-				continue
+		h.Unlock()
+		// Attempt to write private copy to store
+		if err := nh.writeToStore(); err != nil {
+			if _, ok := err.(types.RetryError); !ok {
+				return ret, fmt.Errorf("internal failure while setting the bit: %v", err)
 			}
-			h.Lock()
+			// Retry
+			continue
 		}
+		h.Lock()
 
 		// Previous atomic push was successful. Save private copy to local copy
 		h.unselected = nh.unselected
 		h.head = nh.head
+		h.root = nh.root
+		h.indexed = nh.indexed
 		h.dbExists = nh.dbExists
 		h.dbIndex = nh.dbIndex
 		h.Unlock()
@@ -382,6 +413,14 @@ func (h *Handle) set(ordinal, start, end uint64, any bool, release bool, serial
 	}
 }
 
+func (h *Handle) updateUnselected(release bool) {
+	if release {
+		h.unselected++
+	} else {
+		h.unselected--
+	}
+}
+
 // checks is needed because to cover the case where the number of bits is not a multiple of blockLen
 func (h *Handle) validateOrdinal(ordinal uint64) error {
 	h.Lock()
@@ -394,6 +433,14 @@ func (h *Handle) validateOrdinal(ordinal uint64) error {
 
 // Destroy removes from the datastore the data belonging to this handle
 func (h *Handle) Destroy() error {
+	h.Lock()
+	if h.flushTimer != nil {
+		h.flushTimer.Stop()
+		h.flushTimer = nil
+	}
+	h.dirty = false
+	h.Unlock()
+
 	for {
 		if err := h.deleteFromStore(); err != nil {
 			if _, ok := err.(types.RetryError); !ok {
@@ -450,6 +497,33 @@ func (h *Handle) FromByteArray(ba []byte) error {
 	return nil
 }
 
+// WalkSet calls fn with the ordinals of the set bits, in increasing order,
+// until fn returns false
+func (h *Handle) WalkSet(fn func(ordinal uint64) bool) {
+	h.Lock()
+	defer h.Unlock()
+
+	var base uint64
+	for s := h.head; s != nil; s = s.next {
+		if s.block == 0 {
+			base += s.count * uint64(blockLen)
+			continue
+		}
+		for c := uint64(0); c < s.count; c++ {
+			for b := uint32(0); b < blockLen; b++ {
+				ordinal := base + uint64(b)
+				if ordinal >= h.bits {
+					return
+				}
+				if s.block&(blockFirstBit>>b) != 0 && !fn(ordinal) {
+					return
+				}
+			}
+			base += uint64(blockLen)
+		}
+	}
+}
+
 // Bits returns the length of the bit sequence
 func (h *Handle) Bits() uint64 {
 	return h.bits
@@ -501,224 +575,6 @@ func (h *Handle) UnmarshalJSON(data []byte) error {
 	return h.FromByteArray(b)
 }
 
-// getFirstAvailable looks for the first unset bit in passed mask starting from start
-func getFirstAvailable(head *sequence, start uint64) (uint64, uint64, error) {
-	// Find sequence which contains the start bit
-	byteStart, bitStart := ordinalToPos(start)
-	current, _, precBlocks, inBlockBytePos := findSequence(head, byteStart)
-	// Derive the this sequence offsets
-	byteOffset := byteStart - inBlockBytePos
-	bitOffset := inBlockBytePos*8 + bitStart
-	for current != nil {
-		if current.block != blockMAX {
-			// If the current block is not full, check if there is any bit
-			// from the current bit in the current block. If not, before proceeding to the
-			// next block node, make sure we check for available bit in the next
-			// instance of the same block. Due to RLE same block signature will be
-			// compressed.
-		retry:
-			bytePos, bitPos, err := current.getAvailableBit(bitOffset)
-			if err != nil && precBlocks == current.count-1 {
-				// This is the last instance in the same block node,
-				// so move to the next block.
-				goto next
-			}
-			if err != nil {
-				// There are some more instances of the same block, so add the offset
-				// and be optimistic that you will find the available bit in the next
-				// instance of the same block.
-				bitOffset = 0
-				byteOffset += blockBytes
-				precBlocks++
-				goto retry
-			}
-			return byteOffset + bytePos, bitPos, err
-		}
-		// Moving to next block: Reset bit offset.
-	next:
-		bitOffset = 0
-		byteOffset += (current.count * blockBytes) - (precBlocks * blockBytes)
-		precBlocks = 0
-		current = current.next
-	}
-	return invalidPos, invalidPos, ErrNoBitAvailable
-}
-
-// getAvailableFromCurrent will look for available ordinal from the current ordinal.
-// If none found then it will loop back to the start to check of the available bit.
-// This can be further optimized to check from start till curr in case of a rollover
-func getAvailableFromCurrent(head *sequence, start, curr, end uint64) (uint64, uint64, error) {
-	var bytePos, bitPos uint64
-	var err error
-	if curr != 0 && curr > start {
-		bytePos, bitPos, err = getFirstAvailable(head, curr)
-		ret := posToOrdinal(bytePos, bitPos)
-		if end < ret || err != nil {
-			goto begin
-		}
-		return bytePos, bitPos, nil
-	}
-
-begin:
-	bytePos, bitPos, err = getFirstAvailable(head, start)
-	ret := posToOrdinal(bytePos, bitPos)
-	if end < ret || err != nil {
-		return invalidPos, invalidPos, ErrNoBitAvailable
-	}
-	return bytePos, bitPos, nil
-}
-
-// checkIfAvailable checks if the bit correspondent to the specified ordinal is unset
-// If the ordinal is beyond the sequence limits, a negative response is returned
-func checkIfAvailable(head *sequence, ordinal uint64) (uint64, uint64, error) {
-	bytePos, bitPos := ordinalToPos(ordinal)
-
-	// Find the sequence containing this byte
-	current, _, _, inBlockBytePos := findSequence(head, bytePos)
-	if current != nil {
-		// Check whether the bit corresponding to the ordinal address is unset
-		bitSel := blockFirstBit >> (inBlockBytePos*8 + bitPos)
-		if current.block&bitSel == 0 {
-			return bytePos, bitPos, nil
-		}
-	}
-
-	return invalidPos, invalidPos, ErrBitAllocated
-}
-
-// Given the byte position and the sequences list head, return the pointer to the
-// sequence containing the byte (current), the pointer to the previous sequence,
-// the number of blocks preceding the block containing the byte inside the current sequence.
-// If bytePos is outside of the list, function will return (nil, nil, 0, invalidPos)
-func findSequence(head *sequence, bytePos uint64) (*sequence, *sequence, uint64, uint64) {
-	// Find the sequence containing this byte
-	previous := head
-	current := head
-	n := bytePos
-	for current.next != nil && n >= (current.count*blockBytes) { // Nil check for less than 32 addresses masks
-		n -= (current.count * blockBytes)
-		previous = current
-		current = current.next
-	}
-
-	// If byte is outside of the list, let caller know
-	if n >= (current.count * blockBytes) {
-		return nil, nil, 0, invalidPos
-	}
-
-	// Find the byte position inside the block and the number of blocks
-	// preceding the block containing the byte inside this sequence
-	precBlocks := n / blockBytes
-	inBlockBytePos := bytePos % blockBytes
-
-	return current, previous, precBlocks, inBlockBytePos
-}
-
-// PushReservation pushes the bit reservation inside the bitmask.
-// Given byte and bit positions, identify the sequence (current) which holds the block containing the affected bit.
-// Create a new block with the modified bit according to the operation (allocate/release).
-// Create a new sequence containing the new block and insert it in the proper position.
-// Remove current sequence if empty.
-// Check if new sequence can be merged with neighbour (previous/next) sequences.
-//
-//
-// Identify "current" sequence containing block:
-//                                      [prev seq] [current seq] [next seq]
-//
-// Based on block position, resulting list of sequences can be any of three forms:
-//
-//        block position                        Resulting list of sequences
-// A) block is first in current:         [prev seq] [new] [modified current seq] [next seq]
-// B) block is last in current:          [prev seq] [modified current seq] [new] [next seq]
-// C) block is in the middle of current: [prev seq] [curr pre] [new] [curr post] [next seq]
-func pushReservation(bytePos, bitPos uint64, head *sequence, release bool) *sequence {
-	// Store list's head
-	newHead := head
-
-	// Find the sequence containing this byte
-	current, previous, precBlocks, inBlockBytePos := findSequence(head, bytePos)
-	if current == nil {
-		return newHead
-	}
-
-	// Construct updated block
-	bitSel := blockFirstBit >> (inBlockBytePos*8 + bitPos)
-	newBlock := current.block
-	if release {
-		newBlock &^= bitSel
-	} else {
-		newBlock |= bitSel
-	}
-
-	// Quit if it was a redundant request
-	if current.block == newBlock {
-		return newHead
-	}
-
-	// Current sequence inevitably looses one block, upadate count
-	current.count--
-
-	// Create new sequence
-	newSequence := &sequence{block: newBlock, count: 1}
-
-	// Insert the new sequence in the list based on block position
-	if precBlocks == 0 { // First in sequence (A)
-		newSequence.next = current
-		if current == head {
-			newHead = newSequence
-			previous = newHead
-		} else {
-			previous.next = newSequence
-		}
-		removeCurrentIfEmpty(&newHead, newSequence, current)
-		mergeSequences(previous)
-	} else if precBlocks == current.count { // Last in sequence (B)
-		newSequence.next = current.next
-		current.next = newSequence
-		mergeSequences(current)
-	} else { // In between the sequence (C)
-		currPre := &sequence{block: current.block, count: precBlocks, next: newSequence}
-		currPost := current
-		currPost.count -= precBlocks
-		newSequence.next = currPost
-		if currPost == head {
-			newHead = currPre
-		} else {
-			previous.next = currPre
-		}
-		// No merging or empty current possible here
-	}
-
-	return newHead
-}
-
-// Removes the current sequence from the list if empty, adjusting the head pointer if needed
-func removeCurrentIfEmpty(head **sequence, previous, current *sequence) {
-	if current.count == 0 {
-		if current == *head {
-			*head = current.next
-		} else {
-			previous.next = current.next
-			current = current.next
-		}
-	}
-}
-
-// Given a pointer to a sequence, it checks if it can be merged with any following sequences
-// It stops when no more merging is possible.
-// TODO: Optimization: only attempt merge from start to end sequence, no need to scan till the end of the list
-func mergeSequences(seq *sequence) {
-	if seq != nil {
-		// Merge all what possible from seq
-		for seq.next != nil && seq.block == seq.next.block {
-			seq.count += seq.next.count
-			seq.next = seq.next.next
-		}
-		// Move to next
-		mergeSequences(seq.next)
-	}
-}
-
 func getNumBlocks(numBits uint64) uint64 {
 	numBlocks := numBits / uint64(blockLen)
 	if numBits%uint64(blockLen) != 0 {
@@ -727,10 +583,6 @@ func getNumBlocks(numBits uint64) uint64 {
 	return numBlocks
 }
 
-func ordinalToPos(ordinal uint64) (uint64, uint64) {
-	return ordinal / 8, ordinal % 8
-}
-
 func posToOrdinal(bytePos, bitPos uint64) uint64 {
 	return bytePos*8 + bitPos
 }
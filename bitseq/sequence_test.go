@@ -633,6 +633,37 @@ func TestSetUnset(t *testing.T) {
 	}
 }
 
+func TestWalkSet(t *testing.T) {
+	hnd, err := NewHandle("", nil, "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []uint64{0, 31, 32, 33, 500, 999}
+	for _, o := range expected {
+		if err := hnd.Set(o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var ordinals []uint64
+	hnd.WalkSet(func(o uint64) bool {
+		ordinals = append(ordinals, o)
+		return true
+	})
+	if fmt.Sprint(ordinals) != fmt.Sprint(expected) {
+		t.Fatalf("Expected set bits %v, got %v", expected, ordinals)
+	}
+
+	ordinals = nil
+	hnd.WalkSet(func(o uint64) bool {
+		ordinals = append(ordinals, o)
+		return len(ordinals) < 2
+	})
+	if len(ordinals) != 2 {
+		t.Fatalf("Expected the walk to stop after 2 bits, got %v", ordinals)
+	}
+}
+
 func TestOffsetSetUnset(t *testing.T) {
 	numBits := uint64(32 * blockLen)
 	var o uint64
@@ -3,9 +3,11 @@ package bitseq
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/docker/libnetwork/datastore"
 	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
 )
 
 // Key provides the Key to be used in KV Store
@@ -81,7 +83,7 @@ func (h *Handle) CopyTo(o datastore.KVObject) error {
 	dstH.Lock()
 	dstH.bits = h.bits
 	dstH.unselected = h.unselected
-	dstH.head = h.head.getCopy()
+	dstH.head, dstH.root, dstH.indexed = h.copySequences()
 	dstH.app = h.app
 	dstH.id = h.id
 	dstH.dbIndex = h.dbIndex
@@ -140,3 +142,83 @@ func (h *Handle) deleteFromStore() error {
 	}
 	return store.DeleteObjectAtomic(h)
 }
+
+// EnableWriteCoalescing makes the handle write its changes to the datastore
+// at most once per interval, rather than on every Set/Unset. Changes not
+// yet written are lost on a crash. It is only allowed on a local scope
+// datastore, where this handle is the only writer of its data.
+func (h *Handle) EnableWriteCoalescing(interval time.Duration) error {
+	h.Lock()
+	defer h.Unlock()
+
+	if h.store == nil {
+		return nil
+	}
+	if h.store.Scope() != datastore.LocalScope {
+		return types.ForbiddenErrorf("write coalescing is not supported on %s scope datastore", h.store.Scope())
+	}
+	if interval <= 0 {
+		return types.BadRequestErrorf("invalid write coalescing interval %v", interval)
+	}
+	h.coalesce = interval
+	return nil
+}
+
+// markDirty records that the handle has changes to be written and arms
+// the flush timer. It must be called with the handle lock held.
+func (h *Handle) markDirty() {
+	h.dirty = true
+	if h.flushTimer == nil {
+		h.flushTimer = time.AfterFunc(h.coalesce, func() {
+			if err := h.Flush(); err != nil {
+				logrus.Warnf("Failed to write bit sequence %s/%s to store: %v", h.app, h.id, err)
+			}
+		})
+	}
+}
+
+// Flush writes the changes of the handle not yet written to the datastore
+// because of write coalescing
+func (h *Handle) Flush() error {
+	h.flushLock.Lock()
+	defer h.flushLock.Unlock()
+
+	h.Lock()
+	if h.flushTimer != nil {
+		h.flushTimer.Stop()
+		h.flushTimer = nil
+	}
+	if !h.dirty {
+		h.Unlock()
+		return nil
+	}
+	h.dirty = false
+	nh := h.getCopy()
+	h.Unlock()
+
+	for {
+		err := nh.writeToStore()
+		if err == nil {
+			break
+		}
+		if _, ok := err.(types.RetryError); !ok {
+			h.Lock()
+			h.dirty = true
+			h.Unlock()
+			return fmt.Errorf("internal failure while writing the bit sequence: %v", err)
+		}
+		// The in-memory state is the reference, overwrite the stored one
+		ch := &Handle{app: nh.app, id: nh.id, store: nh.store}
+		if err := nh.store.GetObject(datastore.Key(ch.Key()...), ch); err != nil && err != datastore.ErrKeyNotFound {
+			return fmt.Errorf("failed to fetch the bit sequence from store: %v", err)
+		}
+		nh.dbIndex = ch.Index()
+		nh.dbExists = ch.Exists()
+	}
+
+	h.Lock()
+	h.dbIndex = nh.dbIndex
+	h.dbExists = nh.dbExists
+	h.Unlock()
+	return nil
+}
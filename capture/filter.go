@@ -0,0 +1,57 @@
+package capture
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxInstructions is the largest number of instructions of a socket filter
+const maxInstructions = 4096
+
+// Instruction is a classic BPF instruction
+type Instruction struct {
+	Op uint16
+	Jt uint8
+	Jf uint8
+	K  uint32
+}
+
+// ParseFilter parses a filter compiled to classic BPF, in the format of the
+// `tcpdump -ddd` output with the lines joined by commas: the number of
+// instructions followed by the instructions, as in
+// "4,40 0 0 12,21 0 1 2048,6 0 0 65535,6 0 0 0".
+func ParseFilter(s string) ([]Instruction, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' })
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty filter")
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter length %q: %v", fields[0], err)
+	}
+	if count <= 0 || count > maxInstructions || count != len(fields)-1 {
+		return nil, fmt.Errorf("filter length %d does not match its %d instructions", count, len(fields)-1)
+	}
+
+	filter := make([]Instruction, 0, count)
+	for _, f := range fields[1:] {
+		var (
+			ins    Instruction
+			values [4]uint64
+		)
+		parts := strings.Fields(f)
+		if len(parts) != len(values) {
+			return nil, fmt.Errorf("invalid filter instruction %q", f)
+		}
+		for i, bits := range []int{16, 8, 8, 32} {
+			if values[i], err = strconv.ParseUint(parts[i], 10, bits); err != nil {
+				return nil, fmt.Errorf("invalid filter instruction %q: %v", f, err)
+			}
+		}
+		ins.Op, ins.Jt, ins.Jf, ins.K = uint16(values[0]), uint8(values[1]), uint8(values[2]), uint32(values[3])
+		filter = append(filter, ins)
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,37 @@
+package capture
+
+import "testing"
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter("4,40 0 0 12,21 0 1 2048,6 0 0 65535,6 0 0 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Instruction{{40, 0, 0, 12}, {21, 0, 1, 2048}, {6, 0, 0, 65535}, {6, 0, 0, 0}}
+	if len(filter) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, filter)
+	}
+	for i := range expected {
+		if filter[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, filter)
+		}
+	}
+
+	// The tcpdump -ddd output is accepted as is
+	if _, err := ParseFilter("1\n6 0 0 65535\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, bad := range []string{
+		"",
+		"2,6 0 0 65535",
+		"x,6 0 0 65535",
+		"1,6 0 0",
+		"1,6 0 256 65535",
+		"1,65536 0 0 0",
+	} {
+		if _, err := ParseFilter(bad); err == nil {
+			t.Fatalf("Expected failure parsing filter %q", bad)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package capture
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrSizeLimit is returned by the writers of LimitWriter once the limit
+// is reached
+var ErrSizeLimit = errors.New("capture size limit reached")
+
+type limitWriter struct {
+	w    io.Writer
+	left int64
+}
+
+// LimitWriter returns a writer to w failing with ErrSizeLimit the writes
+// which would take the total size written over n bytes.
+func LimitWriter(w io.Writer, n int64) io.Writer {
+	return &limitWriter{w: w, left: n}
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.left {
+		return 0, ErrSizeLimit
+	}
+	n, err := l.w.Write(p)
+	l.left -= int64(n)
+	return n, err
+}
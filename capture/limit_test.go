@@ -0,0 +1,23 @@
+package capture
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLimitWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := LimitWriter(&b, 10)
+	if _, err := w.Write(make([]byte, 6)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(make([]byte, 6)); err != ErrSizeLimit {
+		t.Fatalf("Expected the size limit error, got %v", err)
+	}
+	if _, err := w.Write(make([]byte, 4)); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != 10 {
+		t.Fatalf("Expected 10 bytes written, got %d", b.Len())
+	}
+}
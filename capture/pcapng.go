@@ -0,0 +1,98 @@
+// Package capture writes the packets seen on a network interface in the
+// pcapng format.
+package capture
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+const (
+	blockSectionHeader    = 0x0a0d0d0a
+	blockInterfaceDesc    = 0x00000001
+	blockEnhancedPacket   = 0x00000006
+	byteOrderMagic        = 0x1a2b3c4d
+	linkTypeEthernet      = 1
+	optionEnd             = 0
+	optionInterfaceName   = 2
+	sectionLengthUnknown  = 0xffffffffffffffff
+	interfaceDescBaseSize = 20
+	enhancedPacketBase    = 32
+)
+
+// SnapLen is the largest number of bytes recorded from a packet
+const SnapLen = 65535
+
+// Writer writes the packets of a single interface as a pcapng section
+type Writer struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewWriter writes the section header and the description of the
+// ethernet interface ifName to w, and returns a Writer for its packets.
+func NewWriter(w io.Writer, ifName string) (*Writer, error) {
+	pw := &Writer{w: w}
+
+	shb := make([]byte, 28)
+	binary.LittleEndian.PutUint32(shb[0:], blockSectionHeader)
+	binary.LittleEndian.PutUint32(shb[4:], uint32(len(shb)))
+	binary.LittleEndian.PutUint32(shb[8:], byteOrderMagic)
+	binary.LittleEndian.PutUint16(shb[12:], 1)
+	binary.LittleEndian.PutUint16(shb[14:], 0)
+	binary.LittleEndian.PutUint64(shb[16:], sectionLengthUnknown)
+	binary.LittleEndian.PutUint32(shb[24:], uint32(len(shb)))
+	if _, err := w.Write(shb); err != nil {
+		return nil, err
+	}
+
+	name := padded(len(ifName))
+	idb := make([]byte, interfaceDescBaseSize+4+name+4)
+	binary.LittleEndian.PutUint32(idb[0:], blockInterfaceDesc)
+	binary.LittleEndian.PutUint32(idb[4:], uint32(len(idb)))
+	binary.LittleEndian.PutUint16(idb[8:], linkTypeEthernet)
+	binary.LittleEndian.PutUint32(idb[12:], SnapLen)
+	binary.LittleEndian.PutUint16(idb[16:], optionInterfaceName)
+	binary.LittleEndian.PutUint16(idb[18:], uint16(len(ifName)))
+	copy(idb[20:], ifName)
+	binary.LittleEndian.PutUint16(idb[20+name:], optionEnd)
+	binary.LittleEndian.PutUint32(idb[len(idb)-4:], uint32(len(idb)))
+	if _, err := w.Write(idb); err != nil {
+		return nil, err
+	}
+
+	return pw, nil
+}
+
+// WritePacket records the packet data captured at ts. origLen is the
+// length of the packet on the wire, data may be truncated.
+func (pw *Writer) WritePacket(ts time.Time, data []byte, origLen int) error {
+	size := enhancedPacketBase + padded(len(data))
+	if cap(pw.buf) < size {
+		pw.buf = make([]byte, size)
+	}
+	b := pw.buf[:size]
+	for i := range b {
+		b[i] = 0
+	}
+
+	usec := uint64(ts.UnixNano() / int64(time.Microsecond))
+	binary.LittleEndian.PutUint32(b[0:], blockEnhancedPacket)
+	binary.LittleEndian.PutUint32(b[4:], uint32(size))
+	binary.LittleEndian.PutUint32(b[8:], 0)
+	binary.LittleEndian.PutUint32(b[12:], uint32(usec>>32))
+	binary.LittleEndian.PutUint32(b[16:], uint32(usec))
+	binary.LittleEndian.PutUint32(b[20:], uint32(len(data)))
+	binary.LittleEndian.PutUint32(b[24:], uint32(origLen))
+	copy(b[28:], data)
+	binary.LittleEndian.PutUint32(b[size-4:], uint32(size))
+
+	_, err := pw.w.Write(b)
+	return err
+}
+
+// padded returns n rounded up to the 32 bits boundary of pcapng fields
+func padded(n int) int {
+	return (n + 3) &^ 3
+}
@@ -0,0 +1,61 @@
+package capture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestWriter(t *testing.T) {
+	var b bytes.Buffer
+	w, err := NewWriter(&b, "eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Unix(1500000000, 123456000)
+	if err := w.WritePacket(ts, []byte{1, 2, 3, 4, 5}, 60); err != nil {
+		t.Fatal(err)
+	}
+
+	data := b.Bytes()
+	var blocks []uint32
+	for off := 0; off < len(data); {
+		if len(data)-off < 12 {
+			t.Fatalf("Truncated block at offset %d", off)
+		}
+		btype := binary.LittleEndian.Uint32(data[off:])
+		size := int(binary.LittleEndian.Uint32(data[off+4:]))
+		if size%4 != 0 || off+size > len(data) {
+			t.Fatalf("Invalid size %d of block %x at offset %d", size, btype, off)
+		}
+		if trailer := int(binary.LittleEndian.Uint32(data[off+size-4:])); trailer != size {
+			t.Fatalf("Trailing size %d does not match block size %d", trailer, size)
+		}
+		blocks = append(blocks, btype)
+
+		if btype == blockEnhancedPacket {
+			usec := uint64(binary.LittleEndian.Uint32(data[off+12:]))<<32 | uint64(binary.LittleEndian.Uint32(data[off+16:]))
+			if usec != uint64(ts.UnixNano()/1000) {
+				t.Fatalf("Unexpected timestamp %d", usec)
+			}
+			if caplen, origlen := binary.LittleEndian.Uint32(data[off+20:]), binary.LittleEndian.Uint32(data[off+24:]); caplen != 5 || origlen != 60 {
+				t.Fatalf("Unexpected lengths %d/%d", caplen, origlen)
+			}
+			if !bytes.Equal(data[off+28:off+33], []byte{1, 2, 3, 4, 5}) {
+				t.Fatalf("Unexpected packet data %v", data[off+28:off+33])
+			}
+		}
+		off += size
+	}
+
+	expected := []uint32{blockSectionHeader, blockInterfaceDesc, blockEnhancedPacket}
+	if len(blocks) != len(expected) {
+		t.Fatalf("Expected blocks %x, got %x", expected, blocks)
+	}
+	for i := range expected {
+		if blocks[i] != expected[i] {
+			t.Fatalf("Expected blocks %x, got %x", expected, blocks)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package capture
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// readTimeout bounds how long a read waits for a packet, for the capture
+// loop to notice it was stopped
+const readTimeout = 200 * time.Millisecond
+
+// Socket is a packet socket receiving all the packets seen on an interface
+type Socket struct {
+	fd     int
+	ifName string
+}
+
+// Open opens a packet socket on the interface ifName of the current
+// network namespace. Only the packets accepted by filter are received,
+// when one is passed.
+func Open(ifName string, filter []Instruction) (*Socket, error) {
+	iface, err := net.InterfaceByName(ifName)
+	if err != nil {
+		return nil, fmt.Errorf("could not find interface %s: %v", ifName, err)
+	}
+
+	// The socket receives no packet until it is bound to the interface
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not open packet socket: %v", err)
+	}
+	tv := unix.NsecToTimeval(readTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("could not set packet socket timeout: %v", err)
+	}
+	// Attach the filter ahead of binding, for no packet to be received
+	// unfiltered
+	if len(filter) > 0 {
+		prog := make([]unix.SockFilter, len(filter))
+		for i, ins := range filter {
+			prog[i] = unix.SockFilter{Code: ins.Op, Jt: ins.Jt, Jf: ins.Jf, K: ins.K}
+		}
+		fprog := &unix.SockFprog{Len: uint16(len(prog)), Filter: &prog[0]}
+		if err := unix.SetsockoptSockFprog(fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, fprog); err != nil {
+			unix.Close(fd)
+			return nil, fmt.Errorf("could not attach the filter to the packet socket: %v", err)
+		}
+	}
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(unix.ETH_P_ALL), Ifindex: iface.Index}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("could not bind packet socket to %s: %v", ifName, err)
+	}
+
+	return &Socket{fd: fd, ifName: ifName}, nil
+}
+
+// Capture writes the packets received on the socket to w until stop is
+// closed.
+func (s *Socket) Capture(w *Writer, stop <-chan struct{}) error {
+	buf := make([]byte, SnapLen)
+	for {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+
+		n, _, err := unix.Recvfrom(s.fd, buf, unix.MSG_TRUNC)
+		if err != nil {
+			if err == unix.EAGAIN || err == unix.EINTR {
+				continue
+			}
+			return fmt.Errorf("failed to read from %s: %v", s.ifName, err)
+		}
+		data := buf
+		if n < len(buf) {
+			data = buf[:n]
+		}
+		if err := w.WritePacket(time.Now(), data, n); err != nil {
+			return err
+		}
+	}
+}
+
+// Close closes the socket
+func (s *Socket) Close() error {
+	return unix.Close(s.fd)
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
@@ -0,0 +1,97 @@
+package capture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/docker/libnetwork/testutils"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func TestSocketCapture(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "capA"}, PeerName: "capB"}
+	if err := netlink.LinkAdd(veth); err != nil {
+		t.Fatal(err)
+	}
+	defer netlink.LinkDel(veth)
+	for _, name := range []string{"capA", "capB"} {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := netlink.LinkSetUp(link); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Frames sent out of capB with the experimental ethertype 0x88b5
+	send := func() {
+		iface, err := net.InterfaceByName("capB")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer unix.Close(fd)
+		frame := make([]byte, 60)
+		copy(frame, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+		copy(frame[6:], iface.HardwareAddr)
+		binary.BigEndian.PutUint16(frame[12:], 0x88b5)
+		addr := &unix.SockaddrLinklayer{Ifindex: iface.Index, Halen: 6}
+		copy(addr.Addr[:], frame[:6])
+		for i := 0; i < 3; i++ {
+			if err := unix.Sendto(fd, frame, 0, addr); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	run := func(filter string) int {
+		f, err := ParseFilter(filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, err := Open("capA", f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+
+		var b bytes.Buffer
+		w, err := NewWriter(&b, "capA")
+		if err != nil {
+			t.Fatal(err)
+		}
+		header := b.Len()
+		stop := make(chan struct{})
+		time.AfterFunc(time.Second, func() { close(stop) })
+		send()
+		if err := s.Capture(w, stop); err != nil {
+			t.Fatal(err)
+		}
+
+		var packets int
+		for off := header; off < b.Len(); {
+			packets++
+			off += int(binary.LittleEndian.Uint32(b.Bytes()[off+4:]))
+		}
+		return packets
+	}
+
+	// Only the test frames are accepted, other traffic such as IPv6
+	// router solicitations may show up on the link
+	if n := run("4,40 0 0 12,21 0 1 34997,6 0 0 65535,6 0 0 0"); n != 3 {
+		t.Fatalf("Expected 3 packets captured, got %d", n)
+	}
+	if n := run("1,6 0 0 0"); n != 0 {
+		t.Fatalf("Expected no packet captured, got %d", n)
+	}
+}
@@ -0,0 +1,25 @@
+// +build !linux
+
+package capture
+
+import "fmt"
+
+// Socket is a packet socket receiving all the packets seen on an interface
+type Socket struct{}
+
+// Open opens a packet socket on the interface ifName of the current
+// network namespace.
+func Open(ifName string, filter []Instruction) (*Socket, error) {
+	return nil, fmt.Errorf("packet capture is not supported on this platform")
+}
+
+// Capture writes the packets received on the socket to w until stop is
+// closed.
+func (s *Socket) Capture(w *Writer, stop <-chan struct{}) error {
+	return fmt.Errorf("packet capture is not supported on this platform")
+}
+
+// Close closes the socket
+func (s *Socket) Close() error {
+	return nil
+}
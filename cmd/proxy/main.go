@@ -9,14 +9,15 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/docker/libnetwork/portmapper/proxy"
 	"github.com/ishidawataru/sctp"
 )
 
 func main() {
 	f := os.NewFile(3, "signal-parent")
-	host, container := parseHostContainerAddrs()
+	host, container, config := parseHostContainerAddrs()
 
-	p, err := NewProxy(host, container)
+	p, err := proxy.NewProxyWithConfig(host, container, config)
 	if err != nil {
 		fmt.Fprintf(f, "1\n%s", err)
 		f.Close()
@@ -31,14 +32,15 @@ func main() {
 }
 
 // parseHostContainerAddrs parses the flags passed on reexec to create the TCP/UDP/SCTP
-// net.Addrs to map the host and container ports
-func parseHostContainerAddrs() (host net.Addr, container net.Addr) {
+// net.Addrs to map the host and container ports, and the proxy settings
+func parseHostContainerAddrs() (host net.Addr, container net.Addr, config proxy.Config) {
 	var (
 		proto         = flag.String("proto", "tcp", "proxy protocol")
 		hostIP        = flag.String("host-ip", "", "host ip")
 		hostPort      = flag.Int("host-port", -1, "host port")
 		containerIP   = flag.String("container-ip", "", "container ip")
 		containerPort = flag.Int("container-port", -1, "container port")
+		proxyProtocol = flag.Int("proxy-protocol", 0, "PROXY protocol header version sent to the container, 0 for none")
 	)
 
 	flag.Parse()
@@ -57,10 +59,12 @@ func parseHostContainerAddrs() (host net.Addr, container net.Addr) {
 		log.Fatalf("unsupported protocol %s", *proto)
 	}
 
-	return host, container
+	config.ProxyProtocol = *proxyProtocol
+
+	return host, container, config
 }
 
-func handleStopSignals(p Proxy) {
+func handleStopSignals(p proxy.Proxy) {
 	s := make(chan os.Signal, 10)
 	signal.Notify(s, os.Interrupt, syscall.SIGTERM)
 
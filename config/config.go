@@ -29,6 +29,7 @@ type Config struct {
 	Cluster         ClusterCfg
 	Scopes          map[string]*datastore.ScopeCfg
 	ActiveSandboxes map[string]interface{}
+	ReconcileDryRun bool
 	PluginGetter    plugingetter.PluginGetter
 }
 
@@ -45,6 +46,8 @@ type DaemonCfg struct {
 	ClusterProvider        cluster.Provider
 	NetworkControlPlaneMTU int
 	DefaultAddressPool     []*ipamutils.NetworkToSplit
+	JoinLeaveConcurrency   int
+	JoinLeaveQueueLen      int
 }
 
 // ClusterCfg represents cluster configuration
@@ -272,6 +275,22 @@ func OptionNetworkControlPlaneMTU(exp int) Option {
 	}
 }
 
+// OptionJoinLeaveConcurrency function returns an option setter for the
+// number of joins and leaves run concurrently on the networks of a driver
+func OptionJoinLeaveConcurrency(n int) Option {
+	return func(c *Config) {
+		c.Daemon.JoinLeaveConcurrency = n
+	}
+}
+
+// OptionJoinLeaveQueueLen function returns an option setter for the number
+// of joins waiting for their turn past which new joins are held back
+func OptionJoinLeaveQueueLen(n int) Option {
+	return func(c *Config) {
+		c.Daemon.JoinLeaveQueueLen = n
+	}
+}
+
 // ProcessOptions processes options and stores it in config
 func (c *Config) ProcessOptions(options ...Option) {
 	for _, opt := range options {
@@ -326,3 +345,12 @@ func OptionActiveSandboxes(sandboxes map[string]interface{}) Option {
 		c.ActiveSandboxes = sandboxes
 	}
 }
+
+// OptionReconcileDryRun function returns an option setter for only reporting
+// the drift found by the reconciliation of the restored sandboxes, instead of
+// repairing it
+func OptionReconcileDryRun(dryRun bool) Option {
+	return func(c *Config) {
+		c.ReconcileDryRun = dryRun
+	}
+}
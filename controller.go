@@ -107,6 +107,10 @@ type NetworkController interface {
 	// NewSandbox creates a new network sandbox for the passed container id
 	NewSandbox(containerID string, options ...SandboxOption) (Sandbox, error)
 
+	// RestoreSandbox recreates a sandbox from the data returned by its
+	// Checkpoint method, with the same ID, endpoints and addresses.
+	RestoreSandbox(data []byte, options ...SandboxOption) (Sandbox, error)
+
 	// Sandboxes returns the list of Sandbox(s) managed by this controller.
 	Sandboxes() []Sandbox
 
@@ -143,6 +147,15 @@ type NetworkController interface {
 	StopDiagnostic()
 	// IsDiagnosticEnabled returns true if the diagnostic is enabled
 	IsDiagnosticEnabled() bool
+
+	// Reconcile verifies the kernel state of the sandboxes and the drivers
+	// against the controller state and repairs the drift found, unless
+	// dryRun is set
+	Reconcile(dryRun bool) (*ReconcileReport, error)
+
+	// JoinLeaveStats returns the metrics of the scheduler of the sandbox
+	// joins and leaves
+	JoinLeaveStats() JoinLeaveStats
 }
 
 // NetworkWalker is a client provided function which will be used to walk the Networks.
@@ -168,6 +181,8 @@ type controller struct {
 	svcRecords             map[string]svcInfo
 	nmap                   map[string]*netWatch
 	serviceBindings        map[serviceKey]*service
+	mirrors                map[string]*endpointMirror
+	joinLeave              *joinLeaveScheduler
 	defOsSbox              osl.Sandbox
 	ingressSandbox         *sandbox
 	sboxOnce               sync.Once
@@ -194,11 +209,13 @@ func New(cfgOptions ...config.Option) (NetworkController, error) {
 		sandboxes:        sandboxTable{},
 		svcRecords:       make(map[string]svcInfo),
 		serviceBindings:  make(map[serviceKey]*service),
+		mirrors:          make(map[string]*endpointMirror),
 		agentInitDone:    make(chan struct{}),
 		networkLocker:    locker.New(),
 		DiagnosticServer: diagnostic.New(),
 	}
 	c.DiagnosticServer.Init()
+	c.joinLeave = newJoinLeaveScheduler(c.cfg.Daemon.JoinLeaveConcurrency, c.cfg.Daemon.JoinLeaveQueueLen)
 
 	if err := c.initStores(); err != nil {
 		return nil, err
@@ -210,24 +227,20 @@ func New(cfgOptions ...config.Option) (NetworkController, error) {
 	}
 
 	for _, i := range getInitializers(c.cfg.Daemon.Experimental) {
-		var dcfg map[string]interface{}
-
-		// External plugins don't need config passed through daemon. They can
-		// bootstrap themselves
-		if i.ntype != "remote" {
-			dcfg = c.makeDriverConfig(i.ntype)
-		}
-
-		if err := drvRegistry.AddDriver(i.ntype, i.fn, dcfg); err != nil {
+		// External plugins bootstrap themselves, the remote driver config
+		// only holds the settings of the calls to the plugins
+		if err := drvRegistry.AddDriver(i.ntype, i.fn, c.makeDriverConfig(i.ntype)); err != nil {
 			return nil, err
 		}
 	}
 
-	if err = initIPAMDrivers(drvRegistry, nil, c.getStore(datastore.GlobalScope), c.cfg.Daemon.DefaultAddressPool); err != nil {
+	if err = initIPAMDrivers(drvRegistry, nil, c.getStore(datastore.GlobalScope), c.cfg.Daemon.DefaultAddressPool, c.makeDriverConfig("remote")); err != nil {
 		return nil, err
 	}
 
 	c.drvRegistry = drvRegistry
+	c.watchIPAMPools()
+	c.DiagnosticServer.RegisterHandler(c, ctrlPaths2Func)
 
 	if c.cfg != nil && c.cfg.Cluster.Watcher != nil {
 		if err := c.initDiscovery(c.cfg.Cluster.Watcher); err != nil {
@@ -249,6 +262,14 @@ func New(cfgOptions ...config.Option) (NetworkController, error) {
 	c.cleanupLocalEndpoints()
 	c.networkCleanup()
 
+	// Make sure the dataplane of the sandboxes surviving the restart is the
+	// one the restored state expects
+	if len(c.cfg.ActiveSandboxes) > 0 {
+		if _, err := c.Reconcile(c.cfg.ReconcileDryRun); err != nil {
+			logrus.Warnf("Failed to reconcile the restored sandboxes: %v", err)
+		}
+	}
+
 	if err := c.startExternalKeyListener(); err != nil {
 		return nil, err
 	}
@@ -751,6 +772,9 @@ func (c *controller) NewNetwork(networkType, name string, id string, options ...
 		return nil, err
 	}
 
+	j := c.newJournal(opCreateNetwork, network, "", "")
+	defer func() { j.finish(err) }()
+
 	// Reset network types, force local scope and skip allocation and
 	// plumbing for configuration networks. Reset of the config-only
 	// network drivers is needed so that this special network is not
@@ -815,16 +839,21 @@ func (c *controller) NewNetwork(networkType, name string, id string, options ...
 		}()
 	}
 
+	if err = j.record(stepIpamPools, nil); err != nil {
+		return nil, err
+	}
 	err = network.ipamAllocate()
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err != nil {
-			network.ipamRelease()
-		}
-	}()
+	j.undo(network.ipamRelease)
+	if err = j.update(&journalPools{IpamType: network.ipamType, V4Info: network.ipamV4Info, V6Info: network.ipamV6Info}); err != nil {
+		return nil, err
+	}
 
+	if err = j.record(stepDriverNetwork, nil); err != nil {
+		return nil, err
+	}
 	err = c.addNetwork(network)
 	if err != nil {
 		if _, ok := err.(types.MaskableError); ok {
@@ -835,13 +864,11 @@ func (c *controller) NewNetwork(networkType, name string, id string, options ...
 			return nil, err
 		}
 	}
-	defer func() {
-		if err != nil {
-			if e := network.deleteNetwork(); e != nil {
-				logrus.Warnf("couldn't roll back driver network on network %s creation failure: %v", network.name, err)
-			}
+	j.undo(func() {
+		if e := network.deleteNetwork(); e != nil {
+			logrus.Warnf("couldn't roll back driver network on network %s creation failure: %v", network.name, e)
 		}
-	}()
+	})
 
 	// XXX If the driver type is "overlay" check the options for DSR
 	// being set.  If so, set the network's load balancing mode to DSR.
@@ -862,48 +889,53 @@ addToStore:
 	// First store the endpoint count, then the network. To avoid to
 	// end up with a datastore containing a network and not an epCnt,
 	// in case of an ungraceful shutdown during this function call.
+	if err = j.record(stepStoreNetwork, nil); err != nil {
+		return nil, err
+	}
 	epCnt := &endpointCnt{n: network}
 	if err = c.updateToStore(epCnt); err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err != nil {
-			if e := c.deleteFromStore(epCnt); e != nil {
-				logrus.Warnf("could not rollback from store, epCnt %v on failure (%v): %v", epCnt, err, e)
-			}
+	j.undo(func() {
+		if e := c.deleteFromStore(epCnt); e != nil {
+			logrus.Warnf("could not rollback from store, epCnt %v on failure: %v", epCnt, e)
 		}
-	}()
+	})
 
 	network.epCnt = epCnt
 	if err = c.updateToStore(network); err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err != nil {
-			if e := c.deleteFromStore(network); e != nil {
-				logrus.Warnf("could not rollback from store, network %v on failure (%v): %v", network, err, e)
-			}
+	j.undo(func() {
+		if e := c.deleteFromStore(network); e != nil {
+			logrus.Warnf("could not rollback from store, network %v on failure: %v", network, e)
 		}
-	}()
+	})
 
 	if network.configOnly {
+		if err = j.commit(); err != nil {
+			return nil, err
+		}
 		return network, nil
 	}
 
 	joinCluster(network)
-	defer func() {
-		if err != nil {
-			network.cancelDriverWatches()
-			if e := network.leaveCluster(); e != nil {
-				logrus.Warnf("Failed to leave agent cluster on network %s on failure (%v): %v", network.name, err, e)
-			}
+	j.undo(func() {
+		network.cancelDriverWatches()
+		if e := network.leaveCluster(); e != nil {
+			logrus.Warnf("Failed to leave agent cluster on network %s on failure: %v", network.name, e)
 		}
-	}()
+	})
 
 	if network.hasLoadBalancerEndpoint() {
 		if err = network.createLoadBalancerSandbox(); err != nil {
 			return nil, err
 		}
+		j.undo(func() {
+			if e := network.deleteLoadBalancerSandbox(); e != nil {
+				logrus.Warnf("Failed to delete the load balancer sandbox of network %s on failure: %v", network.name, e)
+			}
+		})
 	}
 
 	if !c.isDistributedControl() {
@@ -913,6 +945,9 @@ addToStore:
 	}
 	arrangeUserFilterRule()
 
+	if err = j.commit(); err != nil {
+		return nil, err
+	}
 	return network, nil
 }
 
@@ -946,10 +981,10 @@ func (c *controller) reservePools() {
 		autoIPv4 := (len(n.ipamV4Config) == 0 || (len(n.ipamV4Config) == 1 && n.ipamV4Config[0].PreferredPool == "")) && len(n.ipamV4Info) > 0
 		autoIPv6 := (len(n.ipamV6Config) == 0 || (len(n.ipamV6Config) == 1 && n.ipamV6Config[0].PreferredPool == "")) && len(n.ipamV6Info) > 0
 		if autoIPv4 {
-			n.ipamV4Config = []*IpamConf{{PreferredPool: n.ipamV4Info[0].Pool.String()}}
+			n.ipamV4Config = []*IpamConf{{PreferredPool: n.ipamV4Info[0].Pool.String(), Driver: n.ipamV4Info[0].Driver}}
 		}
 		if n.enableIPv6 && autoIPv6 {
-			n.ipamV6Config = []*IpamConf{{PreferredPool: n.ipamV6Info[0].Pool.String()}}
+			n.ipamV6Config = []*IpamConf{{PreferredPool: n.ipamV6Info[0].Pool.String(), Driver: n.ipamV6Info[0].Driver}}
 		}
 		// Account current network gateways
 		for i, c := range n.ipamV4Config {
@@ -965,40 +1000,62 @@ func (c *controller) reservePools() {
 			}
 		}
 		// Reserve pools
-		if err := n.ipamAllocate(); err != nil {
+		if err := n.ipamReplay(); err != nil {
 			logrus.Warnf("Failed to allocate ipam pool(s) for network %q (%s): %v", n.Name(), n.ID(), err)
 		}
 		// Reserve existing endpoints' addresses
-		ipam, _, err := n.getController().getIPAMDriver(n.ipamType)
-		if err != nil {
-			logrus.Warnf("Failed to retrieve ipam driver for network %q (%s) during address reservation", n.Name(), n.ID())
-			continue
-		}
 		epl, err := n.getEndpointsFromStore()
 		if err != nil {
 			logrus.Warnf("Failed to retrieve list of current endpoints on network %q (%s)", n.Name(), n.ID())
 			continue
 		}
-		for _, ep := range epl {
-			if ep.Iface() == nil {
-				logrus.Warnf("endpoint interface is empty for %q (%s)", ep.Name(), ep.ID())
-				continue
-			}
-			if err := ep.assignAddress(ipam, true, ep.Iface().AddressIPv6() != nil); err != nil {
-				logrus.Warnf("Failed to reserve current address for endpoint %q (%s) on network %q (%s)",
-					ep.Name(), ep.ID(), n.Name(), n.ID())
+		n.reserveAddresses(epl)
+	}
+}
+
+// watchIPAMPools listens to the events of the IPAM drivers whose pools can
+// change over time
+func (c *controller) watchIPAMPools() {
+	c.drvRegistry.WalkIPAMs(func(name string, driver ipamapi.Ipam, cap *ipamapi.Capability) bool {
+		if w, ok := driver.(ipamapi.PoolWatcher); ok {
+			go c.handlePoolEvents(name, w.WatchPools())
+		}
+		return false
+	})
+}
+
+// handlePoolEvents reports the networks whose pool must be renumbered
+func (c *controller) handlePoolEvents(name string, events <-chan ipamapi.PoolEvent) {
+	for ev := range events {
+		for _, n := range c.getNetworksFromStore() {
+			for _, d := range append(n.getIPInfo(4), n.getIPInfo(6)...) {
+				if d.PoolID != ev.PoolID || n.infoDriverName(d) != name {
+					continue
+				}
+				if ev.Renumbered == nil {
+					logrus.Warnf("Pool %s of network %q (%s) is no longer part of the prefix of ipam driver %s, and no pool is available in place of it", ev.Pool, n.Name(), n.ID(), name)
+					continue
+				}
+				logrus.Warnf("Pool %s of network %q (%s) is no longer part of the prefix of ipam driver %s, the network must be renumbered to %s", ev.Pool, n.Name(), n.ID(), name, ev.Renumbered)
 			}
 		}
 	}
 }
 
+// doReplayPoolReserve tells whether any of the IPAM drivers of the network
+// requires its pools to be requested again on start
 func doReplayPoolReserve(n *network) bool {
-	_, caps, err := n.getController().getIPAMDriver(n.ipamType)
-	if err != nil {
-		logrus.Warnf("Failed to retrieve ipam driver for network %q (%s): %v", n.Name(), n.ID(), err)
-		return false
+	for _, name := range n.ipamDriverNames() {
+		_, caps, err := n.getController().getIPAMDriver(name)
+		if err != nil {
+			logrus.Warnf("Failed to retrieve ipam driver %s for network %q (%s): %v", name, n.Name(), n.ID(), err)
+			continue
+		}
+		if caps.RequiresRequestReplay {
+			return true
+		}
 	}
-	return caps.RequiresRequestReplay
+	return false
 }
 
 func (c *controller) addNetwork(n *network) error {
@@ -1334,11 +1391,35 @@ func (c *controller) getIPAMDriver(name string) (ipamapi.Ipam, *ipamapi.Capabili
 }
 
 func (c *controller) Stop() {
-	c.closeStores()
 	c.stopExternalKeyListener()
+	// The drivers may still have state to write to the stores
+	c.closeDrivers()
+	c.closeStores()
 	osl.GC()
 }
 
+func (c *controller) closeDrivers() {
+	if c.drvRegistry == nil {
+		return
+	}
+	c.drvRegistry.WalkDrivers(func(name string, driver driverapi.Driver, capability driverapi.Capability) bool {
+		if cl, ok := driver.(driverapi.Closer); ok {
+			if err := cl.Close(); err != nil {
+				logrus.Warnf("Failed to close driver %s: %v", name, err)
+			}
+		}
+		return false
+	})
+	c.drvRegistry.WalkIPAMs(func(name string, driver ipamapi.Ipam, capability *ipamapi.Capability) bool {
+		if cl, ok := driver.(ipamapi.Closer); ok {
+			if err := cl.Close(); err != nil {
+				logrus.Warnf("Failed to close ipam driver %s: %v", name, err)
+			}
+		}
+		return false
+	})
+}
+
 // StartDiagnostic start the network dias mode
 func (c *controller) StartDiagnostic(port int) {
 	c.Lock()
@@ -0,0 +1,167 @@
+// Package dad detects the addresses already in use on the link of a host
+// interface, with ARP probes (RFC 5227) for IPv4 and neighbor solicitations
+// for duplicate address detection (RFC 4862) for IPv6.
+package dad
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"time"
+)
+
+// Config holds the timing of the probes for an address
+type Config struct {
+	// Number of probes sent
+	Probes int
+	// Time waited for an answer after each probe
+	Interval time.Duration
+}
+
+// DefaultConfig is shorter than the RFC 5227 timings, for the creation of
+// an endpoint not to be held for seconds
+var DefaultConfig = Config{Probes: 3, Interval: 200 * time.Millisecond}
+
+const (
+	etherTypeARP  = 0x0806
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86dd
+
+	arpRequest = 1
+
+	protoICMPv6 = 58
+
+	icmpNeighborSolicitation  = 135
+	icmpNeighborAdvertisement = 136
+
+	// Ethernet frames are padded to their minimum length
+	minFrameLen = 60
+)
+
+var broadcastMAC = net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+// arpProbe returns the Ethernet frame of the ARP probe for ip, sent from
+// the hardware address mac
+func arpProbe(mac net.HardwareAddr, ip net.IP) []byte {
+	f := make([]byte, minFrameLen)
+	copy(f[0:6], broadcastMAC)
+	copy(f[6:12], mac)
+	binary.BigEndian.PutUint16(f[12:14], etherTypeARP)
+
+	a := f[14:]
+	binary.BigEndian.PutUint16(a[0:2], 1) // Ethernet
+	binary.BigEndian.PutUint16(a[2:4], etherTypeIPv4)
+	a[4] = 6
+	a[5] = 4
+	binary.BigEndian.PutUint16(a[6:8], arpRequest)
+	copy(a[8:14], mac)
+	// The sender protocol address and the target hardware address are
+	// left zeroed
+	copy(a[24:28], ip.To4())
+	return f
+}
+
+// arpConflict tells whether the frame f is an ARP packet showing that the
+// address ip is used by another host than the one with hardware address
+// mac: either sent from ip, or a probe for ip from another host
+func arpConflict(f []byte, mac net.HardwareAddr, ip net.IP) bool {
+	if len(f) < 42 || binary.BigEndian.Uint16(f[12:14]) != etherTypeARP {
+		return false
+	}
+	a := f[14:]
+	if binary.BigEndian.Uint16(a[2:4]) != etherTypeIPv4 || a[4] != 6 || a[5] != 4 {
+		return false
+	}
+	sha, spa, tpa := a[8:14], net.IP(a[14:18]), net.IP(a[24:28])
+	if bytes.Equal(sha, mac) {
+		return false
+	}
+	if spa.Equal(ip) {
+		return true
+	}
+	// Another host probing for the same address at the same time
+	return binary.BigEndian.Uint16(a[6:8]) == arpRequest && spa.Equal(net.IPv4zero) && tpa.Equal(ip)
+}
+
+// solicitedNodeAddress returns the solicited-node multicast address of ip
+func solicitedNodeAddress(ip net.IP) net.IP {
+	snm := net.ParseIP("ff02::1:ff00:0")
+	copy(snm[13:], ip.To16()[13:])
+	return snm
+}
+
+// neighborSolicitation returns the Ethernet frame of the neighbor
+// solicitation for duplicate address detection of ip, sent from the
+// hardware address mac
+func neighborSolicitation(mac net.HardwareAddr, ip net.IP) []byte {
+	dst := solicitedNodeAddress(ip)
+
+	f := make([]byte, 14+40+24)
+	f[0], f[1] = 0x33, 0x33
+	copy(f[2:6], dst[12:16])
+	copy(f[6:12], mac)
+	binary.BigEndian.PutUint16(f[12:14], etherTypeIPv6)
+
+	h := f[14:54]
+	h[0] = 6 << 4
+	binary.BigEndian.PutUint16(h[4:6], 24)
+	h[6] = protoICMPv6
+	h[7] = 255
+	// The source address is left unspecified
+	copy(h[24:40], dst)
+
+	m := f[54:]
+	m[0] = icmpNeighborSolicitation
+	copy(m[8:24], ip.To16())
+	binary.BigEndian.PutUint16(m[2:4], icmpv6Checksum(net.IPv6unspecified, dst, m))
+	return f
+}
+
+// ndConflict tells whether the frame f is a neighbor discovery packet
+// showing that the address ip is used by another host than the one with
+// hardware address mac: either an advertisement for ip, or a solicitation
+// for duplicate address detection of ip from another host
+func ndConflict(f []byte, mac net.HardwareAddr, ip net.IP) bool {
+	if len(f) < 14+40+24 || binary.BigEndian.Uint16(f[12:14]) != etherTypeIPv6 {
+		return false
+	}
+	if bytes.Equal(f[6:12], mac) {
+		return false
+	}
+	h, m := f[14:54], f[54:]
+	if h[6] != protoICMPv6 || h[7] != 255 || !net.IP(m[8:24]).Equal(ip) {
+		return false
+	}
+	switch m[0] {
+	case icmpNeighborAdvertisement:
+		return true
+	case icmpNeighborSolicitation:
+		return net.IP(h[8:24]).Equal(net.IPv6unspecified)
+	}
+	return false
+}
+
+// icmpv6Checksum returns the checksum of the ICMPv6 message m sent from
+// src to dst
+func icmpv6Checksum(src, dst net.IP, m []byte) uint16 {
+	var sum uint32
+	add := func(b []byte) {
+		for i := 0; i+1 < len(b); i += 2 {
+			sum += uint32(b[i])<<8 | uint32(b[i+1])
+		}
+		if len(b)%2 == 1 {
+			sum += uint32(b[len(b)-1]) << 8
+		}
+	}
+	add(src.To16())
+	add(dst.To16())
+	var l [8]byte
+	binary.BigEndian.PutUint32(l[0:4], uint32(len(m)))
+	l[7] = protoICMPv6
+	add(l[:])
+	add(m)
+	for sum > 0xffff {
+		sum = sum>>16 + sum&0xffff
+	}
+	return ^uint16(sum)
+}
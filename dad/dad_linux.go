@@ -0,0 +1,82 @@
+package dad
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// Probe reports whether the address ip is in use on the link of the host
+// interface ifName of the current network namespace. The probes are sent
+// from the hardware address of the interface.
+func Probe(ifName string, ip net.IP, cfg Config) (bool, error) {
+	iface, err := net.InterfaceByName(ifName)
+	if err != nil {
+		return false, fmt.Errorf("could not find interface %s: %v", ifName, err)
+	}
+	mac := iface.HardwareAddr
+	if len(mac) != 6 {
+		return false, fmt.Errorf("interface %s has no Ethernet address", ifName)
+	}
+
+	var (
+		proto    uint16
+		frame    []byte
+		conflict func([]byte, net.HardwareAddr, net.IP) bool
+	)
+	if ip.To4() != nil {
+		proto, frame, conflict = etherTypeARP, arpProbe(mac, ip), arpConflict
+	} else {
+		proto, frame, conflict = etherTypeIPv6, neighborSolicitation(mac, ip), ndConflict
+	}
+
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(htons(proto)))
+	if err != nil {
+		return false, fmt.Errorf("could not open packet socket: %v", err)
+	}
+	defer unix.Close(fd)
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(proto), Ifindex: iface.Index}); err != nil {
+		return false, fmt.Errorf("could not bind packet socket to %s: %v", ifName, err)
+	}
+
+	dst := &unix.SockaddrLinklayer{Ifindex: iface.Index, Halen: 6}
+	copy(dst.Addr[:], frame[0:6])
+	buf := make([]byte, 1500)
+	for i := 0; i < cfg.Probes; i++ {
+		if err := unix.Sendto(fd, frame, 0, dst); err != nil {
+			return false, fmt.Errorf("failed to send probe for %s on %s: %v", ip, ifName, err)
+		}
+		deadline := time.Now().Add(cfg.Interval)
+		for {
+			left := time.Until(deadline)
+			if left <= 0 {
+				break
+			}
+			tv := unix.NsecToTimeval(left.Nanoseconds())
+			if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+				return false, fmt.Errorf("could not set packet socket timeout: %v", err)
+			}
+			n, from, err := unix.Recvfrom(fd, buf, 0)
+			if err != nil {
+				if err == unix.EAGAIN || err == unix.EINTR {
+					continue
+				}
+				return false, fmt.Errorf("failed to read from %s: %v", ifName, err)
+			}
+			// Skip the probes sent, the socket sees them too
+			if ll, ok := from.(*unix.SockaddrLinklayer); ok && ll.Pkttype == unix.PACKET_OUTGOING {
+				continue
+			}
+			if conflict(buf[:n], mac, ip) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
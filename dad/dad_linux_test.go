@@ -0,0 +1,110 @@
+package dad
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/docker/libnetwork/testutils"
+	"github.com/docker/libnetwork/types"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	probeIf     = "dadp0"
+	responderIf = "dadr0"
+)
+
+// setupResponder creates a veth pair whose responder end is moved to a
+// network namespace of its own, where it is assigned the passed addresses.
+// The kernel of that namespace answers the probes for them.
+func setupResponder(t *testing.T, addrs ...string) func() {
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: probeIf}, PeerName: responderIf}
+	if err := netlink.LinkAdd(veth); err != nil {
+		t.Fatal(err)
+	}
+	link, err := netlink.LinkByName(probeIf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		t.Fatal(err)
+	}
+
+	origin, err := netns.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer origin.Close()
+	responderNs, err := netns.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// netns.New switched to the new namespace
+	if err := netns.Set(origin); err != nil {
+		t.Fatal(err)
+	}
+
+	peer, err := netlink.LinkByName(responderIf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := netlink.LinkSetNsFd(peer, int(responderNs)); err != nil {
+		t.Fatal(err)
+	}
+	nh, err := netlink.NewHandleAt(responderNs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nh.Delete()
+	if peer, err = nh.LinkByName(responderIf); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range addrs {
+		ipNet, err := types.ParseCIDR(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := nh.AddrAdd(peer, &netlink.Addr{IPNet: ipNet, Flags: unix.IFA_F_NODAD}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := nh.LinkSetUp(peer); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		netlink.LinkDel(veth)
+		responderNs.Close()
+	}
+}
+
+func TestProbe(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+	defer setupResponder(t, "192.168.77.2/24", "fd77::2/64")()
+
+	cfg := Config{Probes: 2, Interval: 300 * time.Millisecond}
+	for _, tc := range []struct {
+		ip    string
+		inUse bool
+	}{
+		{"192.168.77.2", true},
+		{"192.168.77.3", false},
+		{"fd77::2", true},
+		{"fd77::3", false},
+	} {
+		inUse, err := Probe(probeIf, net.ParseIP(tc.ip), cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if inUse != tc.inUse {
+			t.Fatalf("Expected address %s in use to be %t", tc.ip, tc.inUse)
+		}
+	}
+
+	if _, err := Probe("dadnone0", net.ParseIP("192.168.77.2"), cfg); err == nil {
+		t.Fatal("Expected failure on missing interface")
+	}
+}
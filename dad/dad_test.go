@@ -0,0 +1,78 @@
+package dad
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+)
+
+var (
+	macA, _ = net.ParseMAC("02:42:ac:11:00:02")
+	macB, _ = net.ParseMAC("02:42:ac:11:00:03")
+)
+
+func TestARPConflict(t *testing.T) {
+	ip := net.ParseIP("192.168.1.10")
+
+	probe := arpProbe(macA, ip)
+	if len(probe) != minFrameLen {
+		t.Fatalf("Unexpected probe length %d", len(probe))
+	}
+	if arpConflict(probe, macA, ip) {
+		t.Fatal("Own probe reported as a conflict")
+	}
+	if !arpConflict(arpProbe(macB, ip), macA, ip) {
+		t.Fatal("Probe from another host not reported as a conflict")
+	}
+	if arpConflict(arpProbe(macB, net.ParseIP("192.168.1.11")), macA, ip) {
+		t.Fatal("Probe for another address reported as a conflict")
+	}
+
+	// A reply sent from the address
+	reply := arpProbe(macB, net.ParseIP("192.168.1.20"))
+	binary.BigEndian.PutUint16(reply[20:22], 2)
+	copy(reply[28:32], ip.To4())
+	if !arpConflict(reply, macA, ip) {
+		t.Fatal("Reply from the address not reported as a conflict")
+	}
+	if arpConflict(reply[:30], macA, ip) {
+		t.Fatal("Truncated frame reported as a conflict")
+	}
+}
+
+func TestNDConflict(t *testing.T) {
+	ip := net.ParseIP("2001:db8::42")
+
+	ns := neighborSolicitation(macA, ip)
+	if exp := []byte{0x33, 0x33, 0xff, 0x00, 0x00, 0x42}; net.HardwareAddr(ns[0:6]).String() != net.HardwareAddr(exp).String() {
+		t.Fatalf("Unexpected destination %s", net.HardwareAddr(ns[0:6]))
+	}
+	if dst := net.IP(ns[38:54]); !dst.Equal(net.ParseIP("ff02::1:ff00:42")) {
+		t.Fatalf("Unexpected destination address %s", dst)
+	}
+	// The checksum of a message including its checksum is zero
+	if c := icmpv6Checksum(net.IPv6unspecified, net.IP(ns[38:54]), ns[54:]); c != 0 {
+		t.Fatalf("Invalid checksum, got %#x", c)
+	}
+
+	if ndConflict(ns, macA, ip) {
+		t.Fatal("Own solicitation reported as a conflict")
+	}
+	if !ndConflict(neighborSolicitation(macB, ip), macA, ip) {
+		t.Fatal("Solicitation from another host not reported as a conflict")
+	}
+	if ndConflict(neighborSolicitation(macB, net.ParseIP("2001:db8::43")), macA, ip) {
+		t.Fatal("Solicitation for another address reported as a conflict")
+	}
+
+	na := neighborSolicitation(macB, ip)
+	na[54] = icmpNeighborAdvertisement
+	copy(na[22:38], net.ParseIP("2001:db8::42"))
+	if !ndConflict(na, macA, ip) {
+		t.Fatal("Advertisement for the address not reported as a conflict")
+	}
+	na[21] = 64
+	if ndConflict(na, macA, ip) {
+		t.Fatal("Advertisement with a hop limit other than 255 reported as a conflict")
+	}
+}
@@ -0,0 +1,14 @@
+// +build !linux
+
+package dad
+
+import (
+	"fmt"
+	"net"
+)
+
+// Probe reports whether the address ip is in use on the link of the host
+// interface ifName.
+func Probe(ifName string, ip net.IP, cfg Config) (bool, error) {
+	return false, fmt.Errorf("duplicate address detection is not supported on this platform")
+}
@@ -0,0 +1,358 @@
+package libnetwork
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/docker/libnetwork/capture"
+	"github.com/docker/libnetwork/diagnostic"
+	"github.com/docker/libnetwork/driverapi"
+	"github.com/docker/libnetwork/internal/caller"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+// ctrlPaths2Func are the diagnostic server handlers of the controller
+var ctrlPaths2Func = map[string]diagnostic.HTTPHandlerFunc{
+	"/pluginhealth": pluginHealth,
+	"/mirror":       mirrorEndpoint,
+	"/unmirror":     unmirrorEndpoint,
+	"/mirrors":      listMirrors,
+	"/capture":      capturePackets,
+	"/joinleave":    joinLeaveStats,
+}
+
+const (
+	// defaultCaptureDuration and maxCaptureDuration bound how long a
+	// packet capture runs
+	defaultCaptureDuration = 10 * time.Second
+	maxCaptureDuration     = 5 * time.Minute
+	// defaultCaptureSize and maxCaptureSize bound the size of the pcapng
+	// stream of a packet capture
+	defaultCaptureSize = 10 << 20
+	maxCaptureSize     = 100 << 20
+)
+
+func pluginHealth(ctx interface{}, w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	diagnostic.DebugHTTPForm(r)
+	_, json := diagnostic.ParseHTTPFormOptions(r)
+
+	// audit logs
+	log := logrus.WithFields(logrus.Fields{"component": "diagnostic", "remoteIP": r.RemoteAddr, "method": caller.Name(0), "url": r.URL.String()})
+	log.Info("plugin health")
+
+	c, ok := ctx.(*controller)
+	if !ok || c.drvRegistry == nil {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(fmt.Errorf("driver registry not available")), json)
+		return
+	}
+
+	var elements []*diagnostic.PluginHealthObj
+	add := func(name, ptype string, h *types.PluginHealth) {
+		elements = append(elements, &diagnostic.PluginHealthObj{
+			Name:      name,
+			Type:      ptype,
+			Healthy:   h.Healthy,
+			Failures:  h.Failures,
+			LastError: h.LastError,
+			Since:     h.Since.Format(time.RFC3339),
+		})
+	}
+	c.drvRegistry.WalkDrivers(func(name string, driver driverapi.Driver, capability driverapi.Capability) bool {
+		if capability.Health != nil {
+			add(name, driverapi.NetworkPluginEndpointType, capability.Health)
+		}
+		return false
+	})
+	c.drvRegistry.WalkIPAMs(func(name string, driver ipamapi.Ipam, capability *ipamapi.Capability) bool {
+		if capability.Health != nil {
+			add(name, ipamapi.PluginEndpointType, capability.Health)
+		}
+		return false
+	})
+	sort.Slice(elements, func(i, j int) bool {
+		if elements[i].Type != elements[j].Type {
+			return elements[i].Type < elements[j].Type
+		}
+		return elements[i].Name < elements[j].Name
+	})
+
+	rsp := &diagnostic.TableObj{Length: len(elements)}
+	for i, e := range elements {
+		e.Index = i
+		rsp.Elements = append(rsp.Elements, e)
+	}
+	log.Info("plugin health done")
+	diagnostic.HTTPReply(w, diagnostic.CommandSucceed(rsp), json)
+}
+
+func joinLeaveStats(ctx interface{}, w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	diagnostic.DebugHTTPForm(r)
+	_, json := diagnostic.ParseHTTPFormOptions(r)
+
+	// audit logs
+	log := logrus.WithFields(logrus.Fields{"component": "diagnostic", "remoteIP": r.RemoteAddr, "method": caller.Name(0), "url": r.URL.String()})
+	log.Info("join/leave stats")
+
+	c, ok := ctx.(*controller)
+	if !ok {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(fmt.Errorf("controller not available")), json)
+		return
+	}
+
+	st := c.JoinLeaveStats()
+	rsp := &diagnostic.JoinLeaveStatsResult{
+		Running:      st.Running,
+		QueuedJoins:  st.QueuedJoins,
+		QueuedLeaves: st.QueuedLeaves,
+		Dispatched:   st.Dispatched,
+		Throttled:    st.Throttled,
+		WaitTotal:    st.WaitTotal.String(),
+		WaitMax:      st.WaitMax.String(),
+	}
+	log.Info("join/leave stats done")
+	diagnostic.HTTPReply(w, diagnostic.CommandSucceed(rsp), json)
+}
+
+func mirrorEndpoint(ctx interface{}, w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	diagnostic.DebugHTTPForm(r)
+	_, json := diagnostic.ParseHTTPFormOptions(r)
+
+	// audit logs
+	log := logrus.WithFields(logrus.Fields{"component": "diagnostic", "remoteIP": r.RemoteAddr, "method": caller.Name(0), "url": r.URL.String()})
+	log.Info("mirror endpoint")
+
+	target := MirrorTarget{
+		HostInterface: r.Form.Get("hostif"),
+		Endpoint:      r.Form.Get("endpoint"),
+	}
+	if r.Form.Get("ep") == "" || target.validate() != nil {
+		rsp := diagnostic.WrongCommand("missing parameter", fmt.Sprintf("%s?ep=id&(hostif=name|endpoint=id)", r.URL.Path))
+		log.Error("mirror endpoint failed, wrong input")
+		diagnostic.HTTPReply(w, rsp, json)
+		return
+	}
+
+	c, ok := ctx.(*controller)
+	if !ok {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(fmt.Errorf("controller not available")), json)
+		return
+	}
+	_, ep := c.sandboxEndpoint(r.Form.Get("ep"))
+	if ep == nil {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(fmt.Errorf("endpoint %s is not connected to a sandbox", r.Form.Get("ep"))), json)
+		return
+	}
+	if err := ep.Mirror(target); err != nil {
+		log.WithError(err).Error("mirror endpoint failed")
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(err), json)
+		return
+	}
+	log.Info("mirror endpoint done")
+	diagnostic.HTTPReply(w, diagnostic.CommandSucceed(nil), json)
+}
+
+func unmirrorEndpoint(ctx interface{}, w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	diagnostic.DebugHTTPForm(r)
+	_, json := diagnostic.ParseHTTPFormOptions(r)
+
+	// audit logs
+	log := logrus.WithFields(logrus.Fields{"component": "diagnostic", "remoteIP": r.RemoteAddr, "method": caller.Name(0), "url": r.URL.String()})
+	log.Info("unmirror endpoint")
+
+	if r.Form.Get("ep") == "" {
+		rsp := diagnostic.WrongCommand("missing parameter", fmt.Sprintf("%s?ep=id", r.URL.Path))
+		log.Error("unmirror endpoint failed, wrong input")
+		diagnostic.HTTPReply(w, rsp, json)
+		return
+	}
+
+	c, ok := ctx.(*controller)
+	if !ok {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(fmt.Errorf("controller not available")), json)
+		return
+	}
+	_, ep := c.sandboxEndpoint(r.Form.Get("ep"))
+	if ep == nil {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(fmt.Errorf("endpoint %s is not connected to a sandbox", r.Form.Get("ep"))), json)
+		return
+	}
+	if err := ep.Unmirror(); err != nil {
+		log.WithError(err).Error("unmirror endpoint failed")
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(err), json)
+		return
+	}
+	log.Info("unmirror endpoint done")
+	diagnostic.HTTPReply(w, diagnostic.CommandSucceed(nil), json)
+}
+
+func listMirrors(ctx interface{}, w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	diagnostic.DebugHTTPForm(r)
+	_, json := diagnostic.ParseHTTPFormOptions(r)
+
+	// audit logs
+	log := logrus.WithFields(logrus.Fields{"component": "diagnostic", "remoteIP": r.RemoteAddr, "method": caller.Name(0), "url": r.URL.String()})
+	log.Info("list mirrors")
+
+	c, ok := ctx.(*controller)
+	if !ok {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(fmt.Errorf("controller not available")), json)
+		return
+	}
+
+	var elements []*diagnostic.MirrorObj
+	c.Lock()
+	for eid, m := range c.mirrors {
+		if m == nil {
+			continue
+		}
+		target := "file " + m.target.File
+		if m.target.HostInterface != "" {
+			target = "host interface " + m.target.HostInterface
+		} else if m.target.Endpoint != "" {
+			target = "endpoint " + m.target.Endpoint
+		}
+		elements = append(elements, &diagnostic.MirrorObj{Endpoint: eid, Target: target})
+	}
+	c.Unlock()
+	sort.Slice(elements, func(i, j int) bool { return elements[i].Endpoint < elements[j].Endpoint })
+
+	rsp := &diagnostic.TableObj{Length: len(elements)}
+	for i, e := range elements {
+		e.Index = i
+		rsp.Elements = append(rsp.Elements, e)
+	}
+	log.Info("list mirrors done")
+	diagnostic.HTTPReply(w, diagnostic.CommandSucceed(rsp), json)
+}
+
+func capturePackets(ctx interface{}, w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	diagnostic.DebugHTTPForm(r)
+	_, json := diagnostic.ParseHTTPFormOptions(r)
+
+	// audit logs
+	log := logrus.WithFields(logrus.Fields{"component": "diagnostic", "remoteIP": r.RemoteAddr, "method": caller.Name(0), "url": r.URL.String()})
+	log.Info("capture packets")
+
+	usage := fmt.Sprintf("%s?sandbox=id&iface=name[&filter=bpf][&duration=10s][&size=bytes]", r.URL.Path)
+	sid, ifName := r.Form.Get("sandbox"), r.Form.Get("iface")
+	if sid == "" || ifName == "" {
+		log.Error("capture packets failed, wrong input")
+		diagnostic.HTTPReply(w, diagnostic.WrongCommand("missing parameter", usage), json)
+		return
+	}
+
+	duration := defaultCaptureDuration
+	if v := r.Form.Get("duration"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 || d > maxCaptureDuration {
+			log.Error("capture packets failed, wrong duration")
+			diagnostic.HTTPReply(w, diagnostic.WrongCommand(fmt.Sprintf("invalid duration, at most %s", maxCaptureDuration), usage), json)
+			return
+		}
+		duration = d
+	}
+	size := int64(defaultCaptureSize)
+	if v := r.Form.Get("size"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 || n > maxCaptureSize {
+			log.Error("capture packets failed, wrong size")
+			diagnostic.HTTPReply(w, diagnostic.WrongCommand(fmt.Sprintf("invalid size, at most %d bytes", maxCaptureSize), usage), json)
+			return
+		}
+		size = n
+	}
+	var filter []capture.Instruction
+	if v := r.Form.Get("filter"); v != "" {
+		var err error
+		if filter, err = capture.ParseFilter(v); err != nil {
+			log.WithError(err).Error("capture packets failed, wrong filter")
+			diagnostic.HTTPReply(w, diagnostic.WrongCommand(err.Error(), usage), json)
+			return
+		}
+	}
+
+	c, ok := ctx.(*controller)
+	if !ok {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(fmt.Errorf("controller not available")), json)
+		return
+	}
+	sbox, err := c.SandboxByID(sid)
+	if err != nil {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(err), json)
+		return
+	}
+	sb := sbox.(*sandbox)
+	sb.Lock()
+	osSbox := sb.osSbox
+	sb.Unlock()
+	if osSbox == nil {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(fmt.Errorf("sandbox %s is not set up", sid)), json)
+		return
+	}
+
+	// The packet socket captures in the namespace it is opened in
+	var s *capture.Socket
+	if err := osSbox.InvokeFunc(func() {
+		s, err = capture.Open(ifName, filter)
+	}); err != nil {
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(err), json)
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("capture packets failed")
+		diagnostic.HTTPReply(w, diagnostic.FailCommand(err), json)
+		return
+	}
+	defer s.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%.12s-%s.pcapng", sid, ifName)))
+	pw, err := capture.NewWriter(&flushWriter{w: capture.LimitWriter(w, size), f: w}, ifName)
+	if err != nil {
+		log.WithError(err).Error("capture packets failed")
+		return
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(stop)
+		select {
+		case <-time.After(duration):
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}()
+	if err := s.Capture(pw, stop); err != nil && err != capture.ErrSizeLimit {
+		log.WithError(err).Error("capture packets stopped")
+		return
+	}
+	log.Info("capture packets done")
+}
+
+// flushWriter flushes the writes to a streamed http response
+type flushWriter struct {
+	w io.Writer
+	f http.ResponseWriter
+}
+
+func (fw *flushWriter) Write(p []byte) (int, error) {
+	n, err := fw.w.Write(p)
+	if fl, ok := fw.f.(http.Flusher); ok {
+		fl.Flush()
+	}
+	return n, err
+}
@@ -1,6 +1,9 @@
 package diagnostic
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 // StringInterface interface that has to be implemented by messages
 type StringInterface interface {
@@ -130,3 +133,62 @@ type NetworkStatsResult struct {
 func (n *NetworkStatsResult) String() string {
 	return fmt.Sprintf("entries: %d, qlen: %d\n", n.Entries, n.QueueLen)
 }
+
+// PluginHealthObj health of a remote driver plugin
+type PluginHealthObj struct {
+	Index     int    `json:"-"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Healthy   bool   `json:"healthy"`
+	Failures  int    `json:"failures"`
+	LastError string `json:"lasterror,omitempty"`
+	Since     string `json:"since"`
+}
+
+func (p *PluginHealthObj) String() string {
+	state := "healthy"
+	if !p.Healthy {
+		state = "unhealthy"
+	}
+	output := fmt.Sprintf("%d) %s (%s): %s since %s, %d consecutive failures", p.Index, p.Name, p.Type, state, p.Since, p.Failures)
+	if p.LastError != "" {
+		output += fmt.Sprintf(", last error: %s", p.LastError)
+	}
+	return output + "\n"
+}
+
+// JoinLeaveStatsResult metrics of the sandbox join/leave scheduler
+type JoinLeaveStatsResult struct {
+	Running      map[string]int `json:"running"`
+	QueuedJoins  int            `json:"queuedjoins"`
+	QueuedLeaves int            `json:"queuedleaves"`
+	Dispatched   uint64         `json:"dispatched"`
+	Throttled    uint64         `json:"throttled"`
+	WaitTotal    string         `json:"waittotal"`
+	WaitMax      string         `json:"waitmax"`
+}
+
+func (j *JoinLeaveStatsResult) String() string {
+	drivers := make([]string, 0, len(j.Running))
+	for d := range j.Running {
+		drivers = append(drivers, d)
+	}
+	sort.Strings(drivers)
+	output := fmt.Sprintf("queued joins: %d, queued leaves: %d, dispatched: %d, throttled: %d, wait total: %s, wait max: %s\n",
+		j.QueuedJoins, j.QueuedLeaves, j.Dispatched, j.Throttled, j.WaitTotal, j.WaitMax)
+	for _, d := range drivers {
+		output += fmt.Sprintf("%s: %d running\n", d, j.Running[d])
+	}
+	return output
+}
+
+// MirrorObj mirroring of an endpoint traffic
+type MirrorObj struct {
+	Index    int    `json:"-"`
+	Endpoint string `json:"endpoint"`
+	Target   string `json:"target"`
+}
+
+func (m *MirrorObj) String() string {
+	return fmt.Sprintf("%d) %s -> %s\n", m.Index, m.Endpoint, m.Target)
+}
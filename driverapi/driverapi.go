@@ -5,6 +5,7 @@ import (
 
 	"github.com/docker/docker/pkg/plugingetter"
 	"github.com/docker/libnetwork/discoverapi"
+	"github.com/docker/libnetwork/types"
 )
 
 // NetworkPluginEndpointType represents the Endpoint Type used by Plugin system
@@ -156,6 +157,33 @@ type JoinInfo interface {
 	AddTableEntry(tableName string, key string, value []byte) error
 }
 
+// InterfaceOptions tells how an interface is moved into the sandbox.
+type InterfaceOptions struct {
+	// HostDevice marks an existing host netdev, as opposed to a link created
+	// by the driver for the endpoint. Its MAC address is restored when it is
+	// moved back to the host.
+	HostDevice bool
+	// KeepName keeps the interface name in the sandbox instead of naming it
+	// after the destination prefix.
+	KeepName bool
+	// KeepMAC leaves the MAC address of the interface untouched.
+	KeepMAC bool
+}
+
+// InterfacesJoinInfo is implemented by the JoinInfo able to hand several
+// interfaces to the sandbox. Drivers type assert the JoinInfo passed to
+// Join to make use of it.
+type InterfacesJoinInfo interface {
+	// SetInterfaceOptions sets how the endpoint interface named through
+	// InterfaceName is moved into the sandbox.
+	SetInterfaceOptions(opts InterfaceOptions) error
+
+	// AddInterface adds the host interface srcName to the sandbox, in
+	// addition to the endpoint interface. The returned InterfaceInfo sets
+	// its addresses.
+	AddInterface(srcName, dstPrefix string, opts InterfaceOptions) (InterfaceInfo, error)
+}
+
 // DriverCallback provides a Callback interface for Drivers into LibNetwork
 type DriverCallback interface {
 	// GetPluginGetter returns the pluginv2 getter.
@@ -168,6 +196,44 @@ type DriverCallback interface {
 type Capability struct {
 	DataScope         string
 	ConnectivityScope string
+	// RequiresGateway is set by the drivers which cannot create a network
+	// without a gateway address, as the bridge driver assigns it to the
+	// bridge interface
+	RequiresGateway bool
+	// Health is set by the driver registry walks, for the drivers
+	// implementing HealthChecker
+	Health *types.PluginHealth
+}
+
+// HealthChecker is implemented by the drivers tracking their health, as the
+// remote drivers do with the calls to their plugin
+type HealthChecker interface {
+	// Health returns the current health of the driver
+	Health() types.PluginHealth
+}
+
+// AddressProber is implemented by the drivers whose endpoints share the link
+// of a host interface, for libnetwork to detect the addresses already in use
+// on it before assigning them
+type AddressProber interface {
+	// ProbeInterface returns the name of the host interface the addresses
+	// of the network are probed from
+	ProbeInterface(nid string) (string, error)
+}
+
+// Reconciler is implemented by the drivers able to verify the kernel state
+// they programmed, as after a live restore, against their own state
+type Reconciler interface {
+	// Reconcile returns the drift found between the kernel state and the
+	// driver state, repaired unless dryRun is set
+	Reconcile(dryRun bool) ([]types.Drift, error)
+}
+
+// Closer is implemented by the drivers running goroutines or holding
+// resources, as listeners, to release when the controller stops
+type Closer interface {
+	// Close releases the resources of the driver
+	Close() error
 }
 
 // IPAMData represents the per-network ip related
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/docker/libnetwork/datastore"
 	"github.com/docker/libnetwork/discoverapi"
@@ -33,6 +34,9 @@ const (
 	vethLen                    = 7
 	defaultContainerVethPrefix = "eth"
 	maxAllocatePortAttempts    = 10
+	// proxyDrainTimeout is the time given to the connections of an
+	// in-process userland proxy to complete when its port is unmapped
+	proxyDrainTimeout = 10 * time.Second
 )
 
 const (
@@ -42,6 +46,15 @@ const (
 	DefaultGatewayV6AuxKey = "DefaultGatewayIPv6"
 )
 
+const (
+	// IPv6ModeNAT masquerades the IPv6 traffic leaving the network (NAT66).
+	// This is the default, it allows the use of unique local (ULA) subnets.
+	IPv6ModeNAT = "nat"
+	// IPv6ModeRouted expects the network IPv6 prefix to be routed to the
+	// host, container addresses are not translated on egress.
+	IPv6ModeRouted = "routed"
+)
+
 type defaultBridgeNetworkConflict struct {
 	ID string
 }
@@ -60,6 +73,12 @@ type configuration struct {
 	EnableIP6Tables     bool
 	EnableUserlandProxy bool
 	UserlandProxyPath   string
+	// UserlandProxyMode selects between a docker-proxy process per
+	// published port and proxies running inside the daemon
+	UserlandProxyMode string
+	// UserlandProxyMaxConns bounds the connections tracked by each
+	// in-process proxy, 0 means no limit
+	UserlandProxyMaxConns int
 }
 
 // networkConfiguration for network specific configuration
@@ -75,6 +94,7 @@ type networkConfiguration struct {
 	DefaultBridge        bool
 	HostIP               net.IP
 	ContainerIfacePrefix string
+	IPv6Mode             string
 	// Internal fields set after ipam data parsing
 	AddressIPv4        *net.IPNet
 	AddressIPv6        *net.IPNet
@@ -152,6 +172,7 @@ type driver struct {
 	isolationChain1V6 *iptables.ChainInfo
 	isolationChain2V6 *iptables.ChainInfo
 	networks          map[string]*bridgeNetwork
+	proxyManager      *portmapper.ProxyManager
 	store             datastore.DataStore
 	nlh               *netlink.Handle
 	configNetwork     sync.Mutex
@@ -173,6 +194,7 @@ func Init(dc driverapi.DriverCallback, config map[string]interface{}) error {
 	c := driverapi.Capability{
 		DataScope:         datastore.LocalScope,
 		ConnectivityScope: datastore.LocalScope,
+		RequiresGateway:   true,
 	}
 	return dc.RegisterDriver(networkType, d, c)
 }
@@ -200,9 +222,20 @@ func (c *networkConfiguration) Validate() error {
 			return &ErrInvalidGateway{}
 		}
 	}
+
+	switch c.IPv6Mode {
+	case "", IPv6ModeNAT, IPv6ModeRouted:
+	default:
+		return ErrInvalidIPv6Mode(c.IPv6Mode)
+	}
 	return nil
 }
 
+// nat66 returns whether the IPv6 traffic of the network is masqueraded
+func (c *networkConfiguration) nat66() bool {
+	return c.EnableIPMasquerade && c.IPv6Mode != IPv6ModeRouted
+}
+
 // Conflicts check if two NetworkConfiguration objects overlap
 func (c *networkConfiguration) Conflicts(o *networkConfiguration) error {
 	if o == nil {
@@ -265,6 +298,8 @@ func (c *networkConfiguration) fromLabels(labels map[string]string) error {
 			}
 		case netlabel.ContainerIfacePrefix:
 			c.ContainerIfacePrefix = value
+		case IPv6Mode:
+			c.IPv6Mode = value
 		case netlabel.HostIP:
 			if c.HostIP = net.ParseIP(value); c.HostIP == nil {
 				return parseErr(label, value, "nil ip")
@@ -378,6 +413,22 @@ func (d *driver) configure(option map[string]interface{}) error {
 		return &ErrInvalidDriverConfig{}
 	}
 
+	var proxyManager *portmapper.ProxyManager
+	switch config.UserlandProxyMode {
+	case "", portmapper.ProxyModeProcess:
+	case portmapper.ProxyModeInProcess:
+		// The proxies of a previous in-process configuration keep running
+		d.Lock()
+		proxyManager = d.proxyManager
+		d.Unlock()
+		if proxyManager == nil {
+			proxyManager = portmapper.NewProxyManager(config.UserlandProxyMaxConns, proxyDrainTimeout)
+		}
+	default:
+		return types.BadRequestErrorf("invalid userland proxy mode %q: must be %q or %q",
+			config.UserlandProxyMode, portmapper.ProxyModeProcess, portmapper.ProxyModeInProcess)
+	}
+
 	if config.EnableIPTables || config.EnableIP6Tables {
 		if _, err := os.Stat("/proc/sys/net/bridge"); err != nil {
 			if out, err := exec.Command("modprobe", "-va", "bridge", "br_netfilter").CombinedOutput(); err != nil {
@@ -433,9 +484,19 @@ func (d *driver) configure(option map[string]interface{}) error {
 	d.filterChainV6 = filterChainV6
 	d.isolationChain1V6 = isolationChain1V6
 	d.isolationChain2V6 = isolationChain2V6
+	prevProxyManager := d.proxyManager
+	d.proxyManager = proxyManager
 	d.config = config
 	d.Unlock()
 
+	if prevProxyManager != nil && prevProxyManager != proxyManager {
+		for _, n := range d.getNetworks() {
+			n.portMapper.SetProxyManager(nil)
+			n.portMapperV6.SetProxyManager(nil)
+		}
+		prevProxyManager.Close()
+	}
+
 	err = d.initStore(option)
 	if err != nil {
 		return err
@@ -605,6 +666,16 @@ func (d *driver) DecodeTableEntry(tablename string, key string, value []byte) (s
 	return "", nil
 }
 
+// ProbeInterface returns the bridge of the network, the endpoint addresses
+// are probed from
+func (d *driver) ProbeInterface(nid string) (string, error) {
+	n, err := d.getNetwork(nid)
+	if err != nil {
+		return "", err
+	}
+	return n.getNetworkBridgeName(), nil
+}
+
 // Create a new network using bridge plugin
 func (d *driver) CreateNetwork(id string, option map[string]interface{}, nInfo driverapi.NetworkInfo, ipV4Data, ipV6Data []driverapi.IPAMData) error {
 	if len(ipV4Data) == 0 || ipV4Data[0].Pool.String() == "0.0.0.0/0" {
@@ -703,6 +774,10 @@ func (d *driver) createNetwork(config *networkConfiguration) (err error) {
 		bridge:       bridgeIface,
 		driver:       d,
 	}
+	if d.proxyManager != nil {
+		network.portMapper.SetProxyManager(d.proxyManager)
+		network.portMapperV6.SetProxyManager(d.proxyManager)
+	}
 
 	d.Lock()
 	d.networks[config.ID] = network
@@ -1495,6 +1570,19 @@ func (d *driver) IsBuiltIn() bool {
 	return true
 }
 
+// Close stops the userland proxies run in process
+func (d *driver) Close() error {
+	d.Lock()
+	proxyManager := d.proxyManager
+	d.proxyManager = nil
+	d.Unlock()
+
+	if proxyManager != nil {
+		proxyManager.Close()
+	}
+	return nil
+}
+
 // DiscoverNew is a notification for a new discovery event, such as a new node joining a cluster
 func (d *driver) DiscoverNew(dType discoverapi.DiscoveryType, data interface{}) error {
 	return nil
@@ -277,6 +277,7 @@ func TestCreateFullOptionsLabels(t *testing.T) {
 		EnableIPMasquerade: "true",
 		DefaultBindingIP:   bndIPs,
 		netlabel.HostIP:    testHostIP,
+		IPv6Mode:           IPv6ModeRouted,
 	}
 
 	netOption := make(map[string]interface{})
@@ -337,6 +338,10 @@ func TestCreateFullOptionsLabels(t *testing.T) {
 		t.Fatalf("Unexpected: %v", nw.config.DefaultGatewayIPv6)
 	}
 
+	if nw.config.IPv6Mode != IPv6ModeRouted || nw.config.nat66() {
+		t.Fatalf("Unexpected IPv6 mode: %q", nw.config.IPv6Mode)
+	}
+
 	// In short here we are testing --fixed-cidr-v6 daemon option
 	// plus --mac-address run option
 	mac, _ := net.ParseMAC("aa:bb:cc:dd:ee:ff")
@@ -937,6 +942,22 @@ func TestValidateConfig(t *testing.T) {
 	if err == nil {
 		t.Fatal("Failed to detect invalid v6 default gateway")
 	}
+
+	// Test IPv6 mode
+	c = networkConfiguration{EnableIPv6: true, EnableIPMasquerade: true}
+	if err := c.Validate(); err != nil || !c.nat66() {
+		t.Fatalf("Expected NAT66 by default, got %v", err)
+	}
+
+	c.IPv6Mode = IPv6ModeRouted
+	if err := c.Validate(); err != nil || c.nat66() {
+		t.Fatalf("Expected routed IPv6, got %v", err)
+	}
+
+	c.IPv6Mode = "nat64"
+	if err := c.Validate(); err == nil {
+		t.Fatal("Failed to detect invalid IPv6 mode")
+	}
 }
 
 func TestSetDefaultGw(t *testing.T) {
@@ -16,6 +16,7 @@ func Init(dc driverapi.DriverCallback, config map[string]interface{}) error {
 	c := driverapi.Capability{
 		DataScope:         datastore.LocalScope,
 		ConnectivityScope: datastore.LocalScope,
+		RequiresGateway:   true,
 	}
 	return dc.RegisterDriver(networkType, &driver{}, c)
 }
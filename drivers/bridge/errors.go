@@ -115,6 +115,16 @@ func (eim ErrInvalidMtu) Error() string {
 // BadRequest denotes the type of this error
 func (eim ErrInvalidMtu) BadRequest() {}
 
+// ErrInvalidIPv6Mode is returned when the user provided IPv6 mode is not valid.
+type ErrInvalidIPv6Mode string
+
+func (eim ErrInvalidIPv6Mode) Error() string {
+	return fmt.Sprintf("invalid IPv6 mode %q: must be %q or %q", string(eim), IPv6ModeNAT, IPv6ModeRouted)
+}
+
+// BadRequest denotes the type of this error
+func (eim ErrInvalidIPv6Mode) BadRequest() {}
+
 // ErrInvalidPort is returned when the container or host port specified in the port binding is not valid.
 type ErrInvalidPort string
 
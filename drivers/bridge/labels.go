@@ -18,4 +18,7 @@ const (
 
 	// DefaultBridge label
 	DefaultBridge = "com.docker.network.bridge.default_bridge"
+
+	// IPv6Mode label
+	IPv6Mode = "com.docker.network.bridge.ipv6_mode"
 )
@@ -7,6 +7,7 @@ import (
 	"net"
 	"sync"
 
+	"github.com/docker/libnetwork/portmapper"
 	"github.com/docker/libnetwork/types"
 	"github.com/ishidawataru/sctp"
 	"github.com/sirupsen/logrus"
@@ -34,14 +35,41 @@ func (n *bridgeNetwork) allocatePorts(ep *bridgeEndpoint, reqDefBindIP net.IP, u
 	return pb, nil
 }
 
+// portBatches are the batches of the IPv4 and IPv6 port mappers of the
+// network, for the forwarding rules of a set of port bindings to be
+// programmed at once
+type portBatches struct {
+	v4, v6 *portmapper.Batch
+}
+
+func (n *bridgeNetwork) newPortBatches() *portBatches {
+	return &portBatches{v4: n.portMapper.NewBatch(), v6: n.portMapperV6.NewBatch()}
+}
+
+// get returns the batch of the port mapper of the host address
+func (pb *portBatches) get(hostIP net.IP) *portmapper.Batch {
+	if hostIP.To4() == nil {
+		return pb.v6
+	}
+	return pb.v4
+}
+
+func (pb *portBatches) apply() error {
+	if err := pb.v4.Apply(); err != nil {
+		return err
+	}
+	return pb.v6.Apply()
+}
+
 func (n *bridgeNetwork) allocatePortsInternal(bindings []types.PortBinding, containerIPv4, containerIPv6, defHostIP net.IP, ulPxyEnabled bool) ([]types.PortBinding, error) {
+	pb := n.newPortBatches()
 	bs := make([]types.PortBinding, 0, len(bindings))
 	for _, c := range bindings {
 		bIPv4 := c.GetCopy()
 		bIPv6 := c.GetCopy()
 		// Allocate IPv4 Port mappings
 		if ok := n.validatePortBindingIPv4(&bIPv4, containerIPv4, defHostIP); ok {
-			if err := n.allocatePort(&bIPv4, ulPxyEnabled); err != nil {
+			if err := n.allocatePort(pb, &bIPv4, ulPxyEnabled); err != nil {
 				// On allocation failure, release previously allocated ports. On cleanup error, just log a warning message
 				if cuErr := n.releasePortsInternal(bs); cuErr != nil {
 					logrus.Warnf("allocation failure for %v, failed to clear previously allocated ipv4 port bindings: %v", bIPv4, cuErr)
@@ -67,7 +95,7 @@ func (n *bridgeNetwork) allocatePortsInternal(bindings []types.PortBinding, cont
 			containerIP = containerIPv4
 		}
 		if ok := n.validatePortBindingIPv6(&bIPv6, containerIP, defHostIP); ok {
-			if err := n.allocatePort(&bIPv6, ulPxyEnabled); err != nil {
+			if err := n.allocatePort(pb, &bIPv6, ulPxyEnabled || n.loopbackProxyV6(&bIPv6)); err != nil {
 				// On allocation failure, release previously allocated ports. On cleanup error, just log a warning message
 				if cuErr := n.releasePortsInternal(bs); cuErr != nil {
 					logrus.Warnf("allocation failure for %v, failed to clear previously allocated ipv6 port bindings: %v", bIPv6, cuErr)
@@ -77,6 +105,13 @@ func (n *bridgeNetwork) allocatePortsInternal(bindings []types.PortBinding, cont
 			bs = append(bs, bIPv6)
 		}
 	}
+
+	if err := pb.apply(); err != nil {
+		if cuErr := n.releasePortsInternal(bs); cuErr != nil {
+			logrus.Warnf("failed to clear the port bindings after failing to program their forwarding rules: %v", cuErr)
+		}
+		return nil, err
+	}
 	return bs, nil
 }
 
@@ -128,7 +163,28 @@ func (n *bridgeNetwork) validatePortBindingIPv6(bnd *types.PortBinding, containe
 	return true
 }
 
-func (n *bridgeNetwork) allocatePort(bnd *types.PortBinding, ulPxyEnabled bool) error {
+// loopbackProxyV6 returns whether the userland proxy must be used for the IPv6
+// binding even if it is disabled. Connections to ::1 cannot be DNATed to the
+// container like it is done for 127.0.0.1 with route_localnet, so the proxy is
+// started to serve them whenever the binding includes the loopback address.
+func (n *bridgeNetwork) loopbackProxyV6(bnd *types.PortBinding) bool {
+	if !bnd.HostIP.IsUnspecified() && !bnd.HostIP.IsLoopback() {
+		return false
+	}
+
+	n.Lock()
+	d := n.driver
+	n.Unlock()
+	if d == nil {
+		return false
+	}
+
+	d.Lock()
+	defer d.Unlock()
+	return d.config.EnableIP6Tables && (d.config.UserlandProxyPath != "" || d.proxyManager != nil)
+}
+
+func (n *bridgeNetwork) allocatePort(pb *portBatches, bnd *types.PortBinding, ulPxyEnabled bool) error {
 	var (
 		host net.Addr
 		err  error
@@ -139,21 +195,35 @@ func (n *bridgeNetwork) allocatePort(bnd *types.PortBinding, ulPxyEnabled bool)
 		bnd.HostPortEnd = bnd.HostPort
 	}
 
+	// The PROXY protocol header is sent by the userland proxy, for tcp only
+	if bnd.ProxyProtocol != 0 {
+		if bnd.Proto != types.TCP {
+			return ErrInvalidPort(fmt.Sprintf("PROXY protocol is not supported for %s port bindings", bnd.Proto))
+		}
+		if bnd.ProxyProtocol != types.ProxyProtocolV1 && bnd.ProxyProtocol != types.ProxyProtocolV2 {
+			return ErrInvalidPort(fmt.Sprintf("unsupported PROXY protocol version %d", bnd.ProxyProtocol))
+		}
+		ulPxyEnabled = true
+	}
+
+	// A container port range is mapped to a block of host ports of the same size
+	size := bnd.PortRange()
+	if bnd.HostPort != 0 && int(bnd.HostPortEnd-bnd.HostPort)+1 < size {
+		return ErrInvalidPort(fmt.Sprintf("host port range %d-%d is smaller than container port range %d-%d",
+			bnd.HostPort, bnd.HostPortEnd, bnd.Port, bnd.PortEnd))
+	}
+
 	// Construct the container side transport address
 	container, err := bnd.ContainerAddr()
 	if err != nil {
 		return err
 	}
 
-	portmapper := n.portMapper
-
-	if bnd.HostIP.To4() == nil {
-		portmapper = n.portMapperV6
-	}
+	batch := pb.get(bnd.HostIP)
 
 	// Try up to maxAllocatePortAttempts times to get a port that's not already allocated.
 	for i := 0; i < maxAllocatePortAttempts; i++ {
-		if host, err = portmapper.MapRange(container, bnd.HostIP, int(bnd.HostPort), int(bnd.HostPortEnd), ulPxyEnabled); err == nil {
+		if host, err = batch.MapPortRange(container, size, bnd.HostIP, int(bnd.HostPort), int(bnd.HostPortEnd), ulPxyEnabled, int(bnd.ProxyProtocol)); err == nil {
 			break
 		}
 		// There is no point in immediately retrying to map an explicitly chosen port.
@@ -171,17 +241,19 @@ func (n *bridgeNetwork) allocatePort(bnd *types.PortBinding, ulPxyEnabled bool)
 	switch netAddr := host.(type) {
 	case *net.TCPAddr:
 		bnd.HostPort = uint16(host.(*net.TCPAddr).Port)
-		return nil
 	case *net.UDPAddr:
 		bnd.HostPort = uint16(host.(*net.UDPAddr).Port)
-		return nil
 	case *sctp.SCTPAddr:
 		bnd.HostPort = uint16(host.(*sctp.SCTPAddr).Port)
-		return nil
 	default:
 		// For completeness
 		return ErrUnsupportedAddressType(fmt.Sprintf("%T", netAddr))
 	}
+	// and the end of the host port block for a port range mapping
+	if size > 1 {
+		bnd.HostPortEnd = bnd.HostPort + uint16(size-1)
+	}
+	return nil
 }
 
 func (n *bridgeNetwork) releasePorts(ep *bridgeEndpoint) error {
@@ -192,11 +264,15 @@ func (n *bridgeNetwork) releasePortsInternal(bindings []types.PortBinding) error
 	var errorBuf bytes.Buffer
 
 	// Attempt to release all port bindings, do not stop on failure
+	pb := n.newPortBatches()
 	for _, m := range bindings {
-		if err := n.releasePort(m); err != nil {
+		if err := n.releasePort(pb, m); err != nil {
 			errorBuf.WriteString(fmt.Sprintf("\ncould not release %v because of %v", m, err))
 		}
 	}
+	if err := pb.apply(); err != nil {
+		errorBuf.WriteString(fmt.Sprintf("\ncould not remove the forwarding rules of the port bindings: %v", err))
+	}
 
 	if errorBuf.Len() != 0 {
 		return errors.New(errorBuf.String())
@@ -204,20 +280,14 @@ func (n *bridgeNetwork) releasePortsInternal(bindings []types.PortBinding) error
 	return nil
 }
 
-func (n *bridgeNetwork) releasePort(bnd types.PortBinding) error {
+func (n *bridgeNetwork) releasePort(pb *portBatches, bnd types.PortBinding) error {
 	// Construct the host side transport address
 	host, err := bnd.HostAddr()
 	if err != nil {
 		return err
 	}
 
-	portmapper := n.portMapper
-
-	if bnd.HostIP.To4() == nil {
-		portmapper = n.portMapperV6
-	}
-
-	return portmapper.Unmap(host)
+	return pb.get(bnd.HostIP).Unmap(host)
 }
 
 var (
@@ -1,11 +1,14 @@
 package bridge
 
 import (
+	"net"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/pkg/reexec"
 	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/portmapper"
 	"github.com/docker/libnetwork/testutils"
 	"github.com/docker/libnetwork/types"
 )
@@ -166,3 +169,88 @@ func TestPortMappingV6Config(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestLoopbackProxyV6(t *testing.T) {
+	d := newDriver()
+	n := &bridgeNetwork{driver: d}
+
+	unspecified := &types.PortBinding{HostIP: net.IPv6zero}
+	loopback := &types.PortBinding{HostIP: net.IPv6loopback}
+	specific := &types.PortBinding{HostIP: net.ParseIP("fd00:dead:beef::1")}
+
+	if n.loopbackProxyV6(unspecified) {
+		t.Fatal("Unexpected proxy fallback without ip6tables")
+	}
+
+	d.config = &configuration{EnableIP6Tables: true}
+	if n.loopbackProxyV6(unspecified) {
+		t.Fatal("Unexpected proxy fallback without userland proxy binary")
+	}
+
+	d.config.UserlandProxyPath = "/usr/bin/docker-proxy"
+	if !n.loopbackProxyV6(unspecified) || !n.loopbackProxyV6(loopback) {
+		t.Fatal("Expected proxy fallback for bindings including ::1")
+	}
+	if n.loopbackProxyV6(specific) {
+		t.Fatal("Unexpected proxy fallback for a binding not including ::1")
+	}
+
+	d.config.UserlandProxyPath = ""
+	d.proxyManager = portmapper.NewProxyManager(0, time.Second)
+	if !n.loopbackProxyV6(loopback) {
+		t.Fatal("Expected proxy fallback with the in-process userland proxy")
+	}
+}
+
+func TestUserlandProxyMode(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	d := newDriver()
+	option := map[string]interface{}{netlabel.GenericData: &configuration{UserlandProxyMode: "thread"}}
+	if err := d.configure(option); err == nil {
+		t.Fatal("Expected failure on invalid userland proxy mode")
+	}
+
+	option[netlabel.GenericData] = &configuration{EnableUserlandProxy: true}
+	if err := d.configure(option); err != nil {
+		t.Fatal(err)
+	}
+	if d.proxyManager != nil {
+		t.Fatal("Unexpected in-process proxy manager in the default mode")
+	}
+
+	option[netlabel.GenericData] = &configuration{EnableUserlandProxy: true, UserlandProxyMode: portmapper.ProxyModeInProcess}
+	if err := d.configure(option); err != nil {
+		t.Fatal(err)
+	}
+	if d.proxyManager == nil {
+		t.Fatal("Expected an in-process proxy manager")
+	}
+
+	pm := d.proxyManager
+	if err := d.configure(option); err != nil {
+		t.Fatal(err)
+	}
+	if d.proxyManager != pm {
+		t.Fatal("Expected the in-process proxy manager kept across configurations of the same mode")
+	}
+
+	option[netlabel.GenericData] = &configuration{EnableUserlandProxy: true, UserlandProxyMode: portmapper.ProxyModeProcess}
+	if err := d.configure(option); err != nil {
+		t.Fatal(err)
+	}
+	if d.proxyManager != nil {
+		t.Fatal("Expected the in-process proxy manager dropped on a mode change")
+	}
+
+	option[netlabel.GenericData] = &configuration{EnableUserlandProxy: true, UserlandProxyMode: portmapper.ProxyModeInProcess}
+	if err := d.configure(option); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if d.proxyManager != nil {
+		t.Fatal("Expected the in-process proxy manager dropped on close")
+	}
+}
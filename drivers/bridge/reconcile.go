@@ -0,0 +1,244 @@
+package bridge
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/docker/libnetwork/iptables"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+// Reconcile verifies the iptables chains of the driver and the rules of
+// each network, port mappings included, against the driver state. Missing
+// chains and rules are programmed back the way a firewalld reload does and
+// the rules left in the driver chains for bridges no network owns are
+// removed.
+func (d *driver) Reconcile(dryRun bool) ([]types.Drift, error) {
+	d.configNetwork.Lock()
+	defer d.configNetwork.Unlock()
+
+	d.Lock()
+	config := d.config
+	d.Unlock()
+	if config == nil {
+		return nil, nil
+	}
+
+	r := &types.DriftReport{DryRun: dryRun}
+	networks := d.getNetworks()
+
+	bridges := make(map[string]bool, len(networks))
+	for _, n := range networks {
+		bridges[n.getNetworkBridgeName()] = true
+	}
+
+	for _, version := range []iptables.IPVersion{iptables.IPv4, iptables.IPv6} {
+		if version == iptables.IPv4 && !config.EnableIPTables ||
+			version == iptables.IPv6 && !config.EnableIP6Tables {
+			continue
+		}
+		reconcileChains(r, config, version)
+		for _, n := range networks {
+			n.reconcileRules(r, version)
+		}
+		if err := reconcileOrphanRules(r, version, bridges); err != nil {
+			return r.Drifts, err
+		}
+	}
+
+	return r.Drifts, nil
+}
+
+// iptablesCmd names the rules of the version in the drift reported
+func iptablesCmd(version iptables.IPVersion) string {
+	if version == iptables.IPv6 {
+		return "ip6tables"
+	}
+	return "iptables"
+}
+
+func (rule iptRule) String() string {
+	return strings.Join(append([]string{"-t", string(rule.table), "-A", rule.chain}, rule.args...), " ")
+}
+
+// reconcileChains verifies the chains created by setupIPChains along with
+// their RETURN rules, setupIPChains creating again whatever is missing
+func reconcileChains(r *types.DriftReport, config *configuration, version iptables.IPVersion) {
+	iptable := iptables.GetIptable(version)
+	var once sync.Once
+	var err error
+	repair := func() error {
+		once.Do(func() {
+			_, _, _, _, err = setupIPChains(config, version)
+		})
+		return err
+	}
+
+	for _, c := range []struct {
+		name  string
+		table iptables.Table
+	}{
+		{DockerChain, iptables.Nat},
+		{DockerChain, iptables.Filter},
+		{IsolationChain1, iptables.Filter},
+		{IsolationChain2, iptables.Filter},
+	} {
+		if !iptable.ExistChain(c.name, c.table) {
+			r.Add(types.DriftMissing, iptablesCmd(version)+" chain", fmt.Sprintf("%s in table %s", c.name, c.table), repair)
+			continue
+		}
+		if c.table == iptables.Filter && c.name != DockerChain {
+			rule := iptRule{table: iptables.Filter, chain: c.name, args: []string{"-j", "RETURN"}}
+			if !iptable.Exists(rule.table, rule.chain, rule.args...) {
+				r.Add(types.DriftMissing, iptablesCmd(version)+" rule", rule.String(), repair)
+			}
+		}
+	}
+}
+
+// expectedRules returns the rules setupIPTables and isolateNetwork program
+// for the network
+func (n *bridgeNetwork) expectedRules(version iptables.IPVersion) []iptRule {
+	n.Lock()
+	config := n.config
+	i := n.bridge
+	n.Unlock()
+
+	bridgeAddr := i.bridgeIPv4
+	if version == iptables.IPv6 {
+		if !config.EnableIPv6 {
+			return nil
+		}
+		bridgeAddr = i.bridgeIPv6
+	}
+	if bridgeAddr == nil {
+		return nil
+	}
+
+	maskedAddr := &net.IPNet{IP: bridgeAddr.IP.Mask(bridgeAddr.Mask), Mask: bridgeAddr.Mask}
+	if config.Internal {
+		return internalNetworkRules(config.BridgeName, maskedAddr, config.EnableICC)
+	}
+
+	iptable := iptables.GetIptable(version)
+	hostIP, ipmasq, hairpinMode, loopbackDNAT := n.ipTablesOptions(version, config)
+	rules := bridgeRules(hostIP, config.BridgeName, maskedAddr, config.EnableICC, ipmasq, hairpinMode)
+
+	chainRules := []iptables.Rule{iptables.JumpRule("FORWARD", IsolationChain1)}
+	if natChain, filterChain, _, _, err := n.getDriverChains(version); err == nil && natChain != nil && filterChain != nil {
+		chainRules = append(chainRules, iptable.ChainRules(natChain, config.BridgeName, loopbackDNAT)...)
+		chainRules = append(chainRules, iptable.ChainRules(filterChain, config.BridgeName, hairpinMode)...)
+	}
+	for _, r := range chainRules {
+		rules = append(rules, iptRule{table: r.Table, chain: r.Chain, args: r.Args})
+	}
+
+	return append(rules, incRules(config.BridgeName)...)
+}
+
+// reconcileRules verifies the rules and the port mappings of the network.
+// Any missing rule has the network rules programmed again, along with its
+// port mappings.
+func (n *bridgeNetwork) reconcileRules(r *types.DriftReport, version iptables.IPVersion) {
+	n.Lock()
+	config := n.config
+	i := n.bridge
+	pm := n.portMapper
+	if version == iptables.IPv6 {
+		pm = n.portMapperV6
+	}
+	n.Unlock()
+
+	var once sync.Once
+	var err error
+	repair := func() error {
+		once.Do(func() {
+			setup := n.setupIP4Tables
+			if version == iptables.IPv6 {
+				setup = n.setupIP6Tables
+			}
+			if err = setup(config, i); err != nil {
+				return
+			}
+			if !config.Internal {
+				if err = setINC(version, config.BridgeName, true); err != nil {
+					return
+				}
+			}
+			pm.ReMapAll()
+		})
+		return err
+	}
+
+	iptable := iptables.GetIptable(version)
+	for _, rule := range n.expectedRules(version) {
+		if !iptable.Exists(rule.table, rule.chain, rule.args...) {
+			r.Add(types.DriftMissing, iptablesCmd(version)+" rule", rule.String(), repair)
+		}
+	}
+
+	if pm == nil {
+		return
+	}
+	for _, m := range pm.MissingMappings() {
+		r.Add(types.DriftMissing, "port mapping", fmt.Sprintf("%s on %s", m, config.BridgeName), repair)
+	}
+}
+
+// reconcileOrphanRules removes from the driver chains the rules matching a
+// bridge no network owns, as left behind by a network deleted while the
+// daemon was down
+func reconcileOrphanRules(r *types.DriftReport, version iptables.IPVersion, bridges map[string]bool) error {
+	iptable := iptables.GetIptable(version)
+	for _, c := range []struct {
+		name  string
+		table iptables.Table
+	}{
+		{DockerChain, iptables.Nat},
+		{DockerChain, iptables.Filter},
+		{IsolationChain1, iptables.Filter},
+		{IsolationChain2, iptables.Filter},
+	} {
+		if !iptable.ExistChain(c.name, c.table) {
+			continue
+		}
+		out, err := iptable.Raw("-t", string(c.table), "-S", c.name)
+		if err != nil {
+			return fmt.Errorf("failed to list the rules of chain %s in table %s: %v", c.name, c.table, err)
+		}
+		for _, rule := range orphanRules(c.table, c.name, string(out), bridges) {
+			rule := rule
+			r.Add(types.DriftOrphan, iptablesCmd(version)+" rule", rule.String(), func() error {
+				return iptable.RawCombinedOutput(append([]string{"-t", string(rule.table), "-D", rule.chain}, rule.args...)...)
+			})
+		}
+	}
+	return nil
+}
+
+// orphanRules parses the iptables -S output of a chain and returns its rules
+// matching an input or output interface out of bridges
+func orphanRules(table iptables.Table, chain, output string, bridges map[string]bool) []iptRule {
+	var rules []iptRule
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "-A" || fields[1] != chain {
+			continue
+		}
+		args := fields[2:]
+		for i := 0; i < len(args)-1; i++ {
+			if args[i] != "-i" && args[i] != "-o" {
+				continue
+			}
+			if !bridges[args[i+1]] {
+				logrus.Debugf("Rule %q of chain %s references the unknown bridge %s", line, chain, args[i+1])
+				rules = append(rules, iptRule{table: table, chain: chain, args: args})
+				break
+			}
+		}
+	}
+	return rules
+}
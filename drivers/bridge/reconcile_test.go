@@ -0,0 +1,38 @@
+package bridge
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/libnetwork/iptables"
+)
+
+func TestOrphanRules(t *testing.T) {
+	output := `-N DOCKER-ISOLATION-STAGE-1
+-A DOCKER-ISOLATION-STAGE-1 -i docker0 ! -o docker0 -j DOCKER-ISOLATION-STAGE-2
+-A DOCKER-ISOLATION-STAGE-1 -i br-gone ! -o br-gone -j DOCKER-ISOLATION-STAGE-2
+-A DOCKER-ISOLATION-STAGE-1 -i br-int ! -d 172.20.0.0/16 -j DROP
+-A DOCKER-ISOLATION-STAGE-1 -j RETURN
+-A DOCKER -d 172.17.0.2/32 ! -i docker0 -o docker0 -p tcp -m tcp --dport 80 -j ACCEPT
+`
+	bridges := map[string]bool{"docker0": true, "br-int": true}
+
+	rules := orphanRules(iptables.Filter, IsolationChain1, output, bridges)
+	expected := []iptRule{{
+		table: iptables.Filter,
+		chain: IsolationChain1,
+		args:  []string{"-i", "br-gone", "!", "-o", "br-gone", "-j", IsolationChain2},
+	}}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("unexpected orphan rules: %v", rules)
+	}
+	if s := rules[0].String(); s != "-t filter -A DOCKER-ISOLATION-STAGE-1 -i br-gone ! -o br-gone -j DOCKER-ISOLATION-STAGE-2" {
+		t.Fatalf("unexpected rule string %q", s)
+	}
+
+	delete(bridges, "br-int")
+	rules = orphanRules(iptables.Filter, IsolationChain1, output, bridges)
+	if len(rules) != 2 || rules[1].args[1] != "br-int" {
+		t.Fatalf("unexpected orphan rules: %v", rules)
+	}
+}
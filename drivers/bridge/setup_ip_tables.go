@@ -136,14 +136,8 @@ func (n *bridgeNetwork) setupIPTables(ipVersion iptables.IPVersion, maskedAddr *
 	var err error
 
 	d := n.driver
-	d.Lock()
-	driverConfig := d.config
-	d.Unlock()
-
-	// Pickup this configuration option from driver
-	hairpinMode := !driverConfig.EnableUserlandProxy
-
 	iptable := iptables.GetIptable(ipVersion)
+	hostIP, ipmasq, hairpinMode, loopbackDNAT := n.ipTablesOptions(ipVersion, config)
 
 	if config.Internal {
 		if err = setupInternalNetworkRules(config.BridgeName, maskedAddr, config.EnableICC, true); err != nil {
@@ -153,18 +147,18 @@ func (n *bridgeNetwork) setupIPTables(ipVersion iptables.IPVersion, maskedAddr *
 			return setupInternalNetworkRules(config.BridgeName, maskedAddr, config.EnableICC, false)
 		})
 	} else {
-		if err = setupIPTablesInternal(config.HostIP, config.BridgeName, maskedAddr, config.EnableICC, config.EnableIPMasquerade, hairpinMode, true); err != nil {
+		if err = setupIPTablesInternal(hostIP, config.BridgeName, maskedAddr, config.EnableICC, ipmasq, hairpinMode, true); err != nil {
 			return fmt.Errorf("Failed to Setup IP tables: %s", err.Error())
 		}
 		n.registerIptCleanFunc(func() error {
-			return setupIPTablesInternal(config.HostIP, config.BridgeName, maskedAddr, config.EnableICC, config.EnableIPMasquerade, hairpinMode, false)
+			return setupIPTablesInternal(hostIP, config.BridgeName, maskedAddr, config.EnableICC, ipmasq, hairpinMode, false)
 		})
 		natChain, filterChain, _, _, err := n.getDriverChains(ipVersion)
 		if err != nil {
 			return fmt.Errorf("Failed to setup IP tables, cannot acquire chain info %s", err.Error())
 		}
 
-		err = iptable.ProgramChain(natChain, config.BridgeName, hairpinMode, true)
+		err = iptable.ProgramChain(natChain, config.BridgeName, loopbackDNAT, true)
 		if err != nil {
 			return fmt.Errorf("Failed to program NAT chain: %s", err.Error())
 		}
@@ -191,21 +185,54 @@ func (n *bridgeNetwork) setupIPTables(ipVersion iptables.IPVersion, maskedAddr *
 	return err
 }
 
+// ipTablesOptions returns the options setupIPTables programs the rules of
+// the network with for the IP version
+func (n *bridgeNetwork) ipTablesOptions(ipVersion iptables.IPVersion, config *networkConfiguration) (hostIP net.IP, ipmasq, hairpinMode, loopbackDNAT bool) {
+	d := n.driver
+	d.Lock()
+	driverConfig := d.config
+	d.Unlock()
+
+	// Pickup this configuration option from driver
+	hairpinMode = !driverConfig.EnableUserlandProxy
+
+	ipmasq = config.EnableIPMasquerade
+	if ipVersion == iptables.IPv6 {
+		ipmasq = config.nat66()
+	}
+
+	// The SNAT source address must belong to the network address family
+	hostIP = config.HostIP
+	if hostIP != nil && (hostIP.To4() == nil) != (ipVersion == iptables.IPv6) {
+		hostIP = nil
+	}
+
+	// There is no route_localnet equivalent for IPv6, connections to ::1
+	// cannot be DNATed to the containers and are left to the userland proxy.
+	loopbackDNAT = hairpinMode && ipVersion == iptables.IPv4
+
+	return hostIP, ipmasq, hairpinMode, loopbackDNAT
+}
+
 type iptRule struct {
 	table   iptables.Table
 	chain   string
 	preArgs []string
 	args    []string
+	descr   string
 }
 
-func setupIPTablesInternal(hostIP net.IP, bridgeIface string, addr *net.IPNet, icc, ipmasq, hairpin, enable bool) error {
+// iccRuleDescr describes the ICC rule, which setIcc programs
+const iccRuleDescr = "ICC"
 
+// bridgeRules returns the rules setupIPTablesInternal programs for the
+// bridge, in the order it programs them
+func bridgeRules(hostIP net.IP, bridgeIface string, addr *net.IPNet, icc, ipmasq, hairpin bool) []iptRule {
 	var (
 		address   = addr.String()
-		skipDNAT  = iptRule{table: iptables.Nat, chain: DockerChain, preArgs: []string{"-t", "nat"}, args: []string{"-i", bridgeIface, "-j", "RETURN"}}
-		outRule   = iptRule{table: iptables.Filter, chain: "FORWARD", args: []string{"-i", bridgeIface, "!", "-o", bridgeIface, "-j", "ACCEPT"}}
 		natArgs   []string
 		hpNatArgs []string
+		rules     []iptRule
 	)
 	// if hostIP is set use this address as the src-ip during SNAT
 	if hostIP != nil {
@@ -218,42 +245,53 @@ func setupIPTablesInternal(hostIP net.IP, bridgeIface string, addr *net.IPNet, i
 		hpNatArgs = []string{"-m", "addrtype", "--src-type", "LOCAL", "-o", bridgeIface, "-j", "MASQUERADE"}
 	}
 
-	natRule := iptRule{table: iptables.Nat, chain: "POSTROUTING", preArgs: []string{"-t", "nat"}, args: natArgs}
-	hpNatRule := iptRule{table: iptables.Nat, chain: "POSTROUTING", preArgs: []string{"-t", "nat"}, args: hpNatArgs}
-
-	ipVersion := iptables.IPv4
-
-	if addr.IP.To4() == nil {
-		ipVersion = iptables.IPv6
-	}
-
 	// Set NAT.
 	if ipmasq {
-		if err := programChainRule(ipVersion, natRule, "NAT", enable); err != nil {
-			return err
-		}
+		rules = append(rules, iptRule{table: iptables.Nat, chain: "POSTROUTING", preArgs: []string{"-t", "nat"}, args: natArgs, descr: "NAT"})
 	}
 
 	if ipmasq && !hairpin {
-		if err := programChainRule(ipVersion, skipDNAT, "SKIP DNAT", enable); err != nil {
-			return err
-		}
+		rules = append(rules, iptRule{table: iptables.Nat, chain: DockerChain, preArgs: []string{"-t", "nat"}, args: []string{"-i", bridgeIface, "-j", "RETURN"}, descr: "SKIP DNAT"})
 	}
 
 	// In hairpin mode, masquerade traffic from localhost
 	if hairpin {
-		if err := programChainRule(ipVersion, hpNatRule, "MASQ LOCAL HOST", enable); err != nil {
-			return err
-		}
+		rules = append(rules, iptRule{table: iptables.Nat, chain: "POSTROUTING", preArgs: []string{"-t", "nat"}, args: hpNatArgs, descr: "MASQ LOCAL HOST"})
 	}
 
-	// Set Inter Container Communication.
-	if err := setIcc(ipVersion, bridgeIface, icc, enable); err != nil {
-		return err
+	return append(rules,
+		// Set Inter Container Communication.
+		iccRule(bridgeIface, icc),
+		// Set Accept on all non-intercontainer outgoing packets.
+		iptRule{table: iptables.Filter, chain: "FORWARD", args: []string{"-i", bridgeIface, "!", "-o", bridgeIface, "-j", "ACCEPT"}, descr: "ACCEPT NON_ICC OUTGOING"},
+	)
+}
+
+func setupIPTablesInternal(hostIP net.IP, bridgeIface string, addr *net.IPNet, icc, ipmasq, hairpin, enable bool) error {
+	return programRules(bridgeRules(hostIP, bridgeIface, addr, icc, ipmasq, hairpin), bridgeIface, addr, icc, enable)
+}
+
+// programRules programs the rules of the bridge, the ICC rule through setIcc
+func programRules(rules []iptRule, bridgeIface string, addr *net.IPNet, icc, insert bool) error {
+	version := iptables.IPv4
+
+	if addr.IP.To4() == nil {
+		version = iptables.IPv6
 	}
 
-	// Set Accept on all non-intercontainer outgoing packets.
-	return programChainRule(ipVersion, outRule, "ACCEPT NON_ICC OUTGOING", enable)
+	for _, rule := range rules {
+		var err error
+		if rule.descr == iccRuleDescr {
+			err = setIcc(version, bridgeIface, icc, insert)
+		} else {
+			err = programChainRule(version, rule, rule.descr, insert)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func programChainRule(version iptables.IPVersion, rule iptRule, ruleDescr string, insert bool) error {
@@ -289,14 +327,22 @@ func programChainRule(version iptables.IPVersion, rule iptRule, ruleDescr string
 	return nil
 }
 
+// iccRule returns the rule setIcc programs
+func iccRule(bridgeIface string, iccEnable bool) iptRule {
+	target := "ACCEPT"
+	if !iccEnable {
+		target = "DROP"
+	}
+	return iptRule{table: iptables.Filter, chain: "FORWARD", args: []string{"-i", bridgeIface, "-o", bridgeIface, "-j", target}, descr: iccRuleDescr}
+}
+
 func setIcc(version iptables.IPVersion, bridgeIface string, iccEnable, insert bool) error {
 	iptable := iptables.GetIptable(version)
 	var (
 		table      = iptables.Filter
 		chain      = "FORWARD"
-		args       = []string{"-i", bridgeIface, "-o", bridgeIface, "-j"}
-		acceptArgs = append(args, "ACCEPT")
-		dropArgs   = append(args, "DROP")
+		acceptArgs = iccRule(bridgeIface, true).args
+		dropArgs   = iccRule(bridgeIface, false).args
 	)
 
 	if insert {
@@ -333,17 +379,21 @@ func setIcc(version iptables.IPVersion, bridgeIface string, iccEnable, insert bo
 	return nil
 }
 
+// incRules returns the rules setINC programs
+func incRules(iface string) []iptRule {
+	return []iptRule{
+		{table: iptables.Filter, chain: IsolationChain1, args: []string{"-i", iface, "!", "-o", iface, "-j", IsolationChain2}},
+		{table: iptables.Filter, chain: IsolationChain2, args: []string{"-o", iface, "-j", "DROP"}},
+	}
+}
+
 // Control Inter Network Communication. Install[Remove] only if it is [not] present.
 func setINC(version iptables.IPVersion, iface string, enable bool) error {
 	iptable := iptables.GetIptable(version)
 	var (
 		action    = iptables.Insert
 		actionMsg = "add"
-		chains    = []string{IsolationChain1, IsolationChain2}
-		rules     = [][]string{
-			{"-i", iface, "!", "-o", iface, "-j", IsolationChain2},
-			{"-o", iface, "-j", "DROP"},
-		}
+		rules     = incRules(iface)
 	)
 
 	if !enable {
@@ -351,13 +401,13 @@ func setINC(version iptables.IPVersion, iface string, enable bool) error {
 		actionMsg = "remove"
 	}
 
-	for i, chain := range chains {
-		if err := iptable.ProgramRule(iptables.Filter, chain, action, rules[i]); err != nil {
+	for i, rule := range rules {
+		if err := iptable.ProgramRule(rule.table, rule.chain, action, rule.args); err != nil {
 			msg := fmt.Sprintf("unable to %s inter-network communication rule: %v", actionMsg, err)
 			if enable {
 				if i == 1 {
 					// Rollback the rule installed on first chain
-					if err2 := iptable.ProgramRule(iptables.Filter, chains[0], iptables.Delete, rules[0]); err2 != nil {
+					if err2 := iptable.ProgramRule(rules[0].table, rules[0].chain, iptables.Delete, rules[0].args); err2 != nil {
 						logrus.Warnf("Failed to rollback iptables rule after failure (%v): %v", err, err2)
 					}
 				}
@@ -394,26 +444,19 @@ func removeIPChains(version iptables.IPVersion) {
 	}
 }
 
-func setupInternalNetworkRules(bridgeIface string, addr *net.IPNet, icc, insert bool) error {
-	var (
-		inDropRule  = iptRule{table: iptables.Filter, chain: IsolationChain1, args: []string{"-i", bridgeIface, "!", "-d", addr.String(), "-j", "DROP"}}
-		outDropRule = iptRule{table: iptables.Filter, chain: IsolationChain1, args: []string{"-o", bridgeIface, "!", "-s", addr.String(), "-j", "DROP"}}
-	)
-
-	version := iptables.IPv4
-
-	if addr.IP.To4() == nil {
-		version = iptables.IPv6
+// internalNetworkRules returns the rules setupInternalNetworkRules programs
+// for the bridge, in the order it programs them
+func internalNetworkRules(bridgeIface string, addr *net.IPNet, icc bool) []iptRule {
+	return []iptRule{
+		{table: iptables.Filter, chain: IsolationChain1, args: []string{"-i", bridgeIface, "!", "-d", addr.String(), "-j", "DROP"}, descr: "DROP INCOMING"},
+		{table: iptables.Filter, chain: IsolationChain1, args: []string{"-o", bridgeIface, "!", "-s", addr.String(), "-j", "DROP"}, descr: "DROP OUTGOING"},
+		// Set Inter Container Communication.
+		iccRule(bridgeIface, icc),
 	}
+}
 
-	if err := programChainRule(version, inDropRule, "DROP INCOMING", insert); err != nil {
-		return err
-	}
-	if err := programChainRule(version, outDropRule, "DROP OUTGOING", insert); err != nil {
-		return err
-	}
-	// Set Inter Container Communication.
-	return setIcc(version, bridgeIface, icc, insert)
+func setupInternalNetworkRules(bridgeIface string, addr *net.IPNet, icc, insert bool) error {
+	return programRules(internalNetworkRules(bridgeIface, addr, icc), bridgeIface, addr, icc, insert)
 }
 
 func clearEndpointConnections(nlh *netlink.Handle, ep *bridgeEndpoint) {
@@ -0,0 +1,366 @@
+// Package hostdev implements a driver moving existing host network devices,
+// such as SR-IOV virtual functions, into the containers instead of creating
+// a veth pair for them.
+package hostdev
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/docker/libnetwork/datastore"
+	"github.com/docker/libnetwork/discoverapi"
+	"github.com/docker/libnetwork/driverapi"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/ns"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	networkType       = "hostdev"
+	containerIfPrefix = "eth"
+	devicesOpt        = "devices"       // network devices pool -o devices=ens1f0v0,ens1f0v1
+	deviceOpt         = "device"        // endpoint device, picked from the pool if unset
+	extraDevicesOpt   = "extra_devices" // endpoint devices moved unaddressed, keeping their name
+	devicesSep        = ","
+)
+
+type driver struct {
+	networks map[string]*network
+	store    datastore.DataStore
+	sync.Mutex
+}
+
+type network struct {
+	id        string
+	devices   []string
+	internal  bool
+	gw        net.IP
+	gw6       net.IP
+	endpoints map[string]*endpoint
+	dbIndex   uint64
+	dbExists  bool
+}
+
+type endpoint struct {
+	id       string
+	nid      string
+	device   string
+	extra    []string
+	keepMAC  bool
+	dbIndex  uint64
+	dbExists bool
+}
+
+// Init registers a new instance of hostdev driver
+func Init(dc driverapi.DriverCallback, config map[string]interface{}) error {
+	c := driverapi.Capability{
+		DataScope:         datastore.LocalScope,
+		ConnectivityScope: datastore.LocalScope,
+	}
+	d := &driver{networks: map[string]*network{}}
+	if err := d.initStore(config); err != nil {
+		return err
+	}
+	return dc.RegisterDriver(networkType, d, c)
+}
+
+func (d *driver) NetworkAllocate(id string, option map[string]string, ipV4Data, ipV6Data []driverapi.IPAMData) (map[string]string, error) {
+	return nil, types.NotImplementedErrorf("not implemented")
+}
+
+func (d *driver) NetworkFree(id string) error {
+	return types.NotImplementedErrorf("not implemented")
+}
+
+func (d *driver) EventNotify(etype driverapi.EventType, nid, tableName, key string, value []byte) {
+}
+
+func (d *driver) DecodeTableEntry(tablename string, key string, value []byte) (string, map[string]string) {
+	return "", nil
+}
+
+func (d *driver) CreateNetwork(id string, option map[string]interface{}, nInfo driverapi.NetworkInfo, ipV4Data, ipV6Data []driverapi.IPAMData) error {
+	n := &network{id: id, endpoints: map[string]*endpoint{}}
+	if opts, ok := option[netlabel.GenericData].(map[string]string); ok {
+		n.devices = splitDevices(opts[devicesOpt])
+	}
+	if internal, ok := option[netlabel.Internal].(bool); ok {
+		n.internal = internal
+	}
+	if len(ipV4Data) > 0 && ipV4Data[0].Gateway != nil {
+		n.gw = ipV4Data[0].Gateway.IP
+	}
+	if len(ipV6Data) > 0 && ipV6Data[0].Gateway != nil {
+		n.gw6 = ipV6Data[0].Gateway.IP
+	}
+
+	d.Lock()
+	defer d.Unlock()
+	for _, other := range d.networks {
+		for _, dev := range n.devices {
+			for _, used := range other.devices {
+				if dev == used {
+					return types.ForbiddenErrorf("device %s is already in the pool of network %.7s", dev, other.id)
+				}
+			}
+		}
+	}
+	if err := d.storeUpdate(n); err != nil {
+		return fmt.Errorf("failed to save hostdev network %.7s to store: %v", id, err)
+	}
+	d.networks[id] = n
+
+	return nil
+}
+
+func (d *driver) DeleteNetwork(nid string) error {
+	d.Lock()
+	defer d.Unlock()
+
+	n, ok := d.networks[nid]
+	if !ok {
+		return types.NotFoundErrorf("network %s not found", nid)
+	}
+	if len(n.endpoints) != 0 {
+		return types.ForbiddenErrorf("network %s has active endpoints", nid)
+	}
+	if err := d.storeDelete(n); err != nil {
+		logrus.Warnf("Failed to remove hostdev network %.7s from store: %v", nid, err)
+	}
+	delete(d.networks, nid)
+
+	return nil
+}
+
+func (d *driver) network(nid string) (*network, error) {
+	n, ok := d.networks[nid]
+	if !ok {
+		return nil, types.NotFoundErrorf("network %s not found", nid)
+	}
+	return n, nil
+}
+
+// assigned returns the endpoint the device is assigned to, if any
+func (d *driver) assigned(dev string) *endpoint {
+	for _, n := range d.networks {
+		for _, ep := range n.endpoints {
+			if ep.device == dev {
+				return ep
+			}
+			for _, e := range ep.extra {
+				if e == dev {
+					return ep
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *driver) CreateEndpoint(nid, eid string, ifInfo driverapi.InterfaceInfo, epOptions map[string]interface{}) error {
+	d.Lock()
+	defer d.Unlock()
+
+	n, err := d.network(nid)
+	if err != nil {
+		return err
+	}
+	ep := &endpoint{id: eid, nid: nid}
+	if dev, ok := epOptions[deviceOpt].(string); ok {
+		ep.device = dev
+	}
+	if extra, ok := epOptions[extraDevicesOpt].(string); ok {
+		ep.extra = splitDevices(extra)
+	}
+
+	if contains(ep.extra, ep.device) {
+		return types.BadRequestErrorf("device %s cannot be both the endpoint and an extra device", ep.device)
+	}
+	for _, dev := range append([]string{ep.device}, ep.extra...) {
+		if dev == "" {
+			continue
+		}
+		if other := d.assigned(dev); other != nil {
+			return types.ForbiddenErrorf("device %s is already assigned to endpoint %.7s", dev, other.id)
+		}
+	}
+	// Pick the first free device of the pool
+	if ep.device == "" {
+		for _, dev := range n.devices {
+			if d.assigned(dev) == nil && !contains(ep.extra, dev) {
+				ep.device = dev
+				break
+			}
+		}
+		if ep.device == "" {
+			return types.NoServiceErrorf("no device left in the pool of network %.7s", nid)
+		}
+	}
+
+	link, err := ns.NlHandle().LinkByName(ep.device)
+	if err != nil {
+		return types.NotFoundErrorf("failed to find host device %s: %v", ep.device, err)
+	}
+	for _, dev := range ep.extra {
+		if _, err := ns.NlHandle().LinkByName(dev); err != nil {
+			return types.NotFoundErrorf("failed to find host device %s: %v", dev, err)
+		}
+	}
+
+	// Report the MAC address of the device unless one was requested, as the
+	// one of a virtual function may not be settable from the host
+	if ifInfo.MacAddress() == nil {
+		ep.keepMAC = true
+		if mac := link.Attrs().HardwareAddr; len(mac) != 0 {
+			if err := ifInfo.SetMacAddress(mac); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := d.storeUpdate(ep); err != nil {
+		return fmt.Errorf("failed to save hostdev endpoint %.7s to store: %v", eid, err)
+	}
+	n.endpoints[eid] = ep
+	logrus.Debugf("hostdev endpoint %.7s assigned device %s", eid, ep.device)
+
+	return nil
+}
+
+func (d *driver) DeleteEndpoint(nid, eid string) error {
+	d.Lock()
+	defer d.Unlock()
+
+	n, err := d.network(nid)
+	if err != nil {
+		return err
+	}
+	if ep, ok := n.endpoints[eid]; ok {
+		if err := d.storeDelete(ep); err != nil {
+			logrus.Warnf("Failed to remove hostdev endpoint %.7s from store: %v", eid, err)
+		}
+	}
+	delete(n.endpoints, eid)
+
+	return nil
+}
+
+func (d *driver) EndpointOperInfo(nid, eid string) (map[string]interface{}, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	n, err := d.network(nid)
+	if err != nil {
+		return nil, err
+	}
+	ep, ok := n.endpoints[eid]
+	if !ok {
+		return nil, types.NotFoundErrorf("endpoint %s not found", eid)
+	}
+	info := map[string]interface{}{deviceOpt: ep.device}
+	if len(ep.extra) != 0 {
+		info[extraDevicesOpt] = strings.Join(ep.extra, devicesSep)
+	}
+	return info, nil
+}
+
+// Join method is invoked when a Sandbox is attached to an endpoint.
+func (d *driver) Join(nid, eid string, sboxKey string, jinfo driverapi.JoinInfo, options map[string]interface{}) error {
+	d.Lock()
+	n, err := d.network(nid)
+	if err != nil {
+		d.Unlock()
+		return err
+	}
+	ep, ok := n.endpoints[eid]
+	d.Unlock()
+	if !ok {
+		return types.NotFoundErrorf("endpoint %s not found", eid)
+	}
+
+	ijinfo, ok := jinfo.(driverapi.InterfacesJoinInfo)
+	if !ok {
+		return types.NotImplementedErrorf("%s driver needs a sandbox supporting host devices", networkType)
+	}
+	if err := jinfo.InterfaceName().SetNames(ep.device, containerIfPrefix); err != nil {
+		return err
+	}
+	if err := ijinfo.SetInterfaceOptions(driverapi.InterfaceOptions{HostDevice: true, KeepMAC: ep.keepMAC}); err != nil {
+		return err
+	}
+	for _, dev := range ep.extra {
+		if _, err := ijinfo.AddInterface(dev, "", driverapi.InterfaceOptions{HostDevice: true, KeepName: true, KeepMAC: true}); err != nil {
+			return fmt.Errorf("failed to add device %s to endpoint %.7s: %v", dev, eid, err)
+		}
+	}
+
+	if n.internal {
+		return nil
+	}
+	if n.gw != nil {
+		if err := jinfo.SetGateway(n.gw); err != nil {
+			return err
+		}
+	}
+	if n.gw6 != nil {
+		if err := jinfo.SetGatewayIPv6(n.gw6); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Leave method is invoked when a Sandbox detaches from an endpoint. The
+// devices are moved back to the host along with the sandbox interfaces.
+func (d *driver) Leave(nid, eid string) error {
+	return nil
+}
+
+func (d *driver) ProgramExternalConnectivity(nid, eid string, options map[string]interface{}) error {
+	return nil
+}
+
+func (d *driver) RevokeExternalConnectivity(nid, eid string) error {
+	return nil
+}
+
+func (d *driver) Type() string {
+	return networkType
+}
+
+func (d *driver) IsBuiltIn() bool {
+	return true
+}
+
+// DiscoverNew is a notification for a new discovery event, such as a new node joining a cluster
+func (d *driver) DiscoverNew(dType discoverapi.DiscoveryType, data interface{}) error {
+	return nil
+}
+
+// DiscoverDelete is a notification for a discovery delete event, such as a node leaving a cluster
+func (d *driver) DiscoverDelete(dType discoverapi.DiscoveryType, data interface{}) error {
+	return nil
+}
+
+func splitDevices(s string) []string {
+	var devices []string
+	for _, dev := range strings.Split(s, devicesSep) {
+		if dev = strings.TrimSpace(dev); dev != "" {
+			devices = append(devices, dev)
+		}
+	}
+	return devices
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
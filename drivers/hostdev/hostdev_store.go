@@ -0,0 +1,298 @@
+package hostdev
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/docker/libnetwork/datastore"
+	"github.com/docker/libnetwork/discoverapi"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	hostdevPrefix         = "hostdev"
+	hostdevNetworkPrefix  = hostdevPrefix + "/network"
+	hostdevEndpointPrefix = hostdevPrefix + "/endpoint"
+)
+
+// initStore drivers are responsible for caching their own persistent state
+func (d *driver) initStore(option map[string]interface{}) error {
+	if data, ok := option[netlabel.LocalKVClient]; ok {
+		var err error
+		dsc, ok := data.(discoverapi.DatastoreConfigData)
+		if !ok {
+			return types.InternalErrorf("incorrect data in datastore configuration: %v", data)
+		}
+		d.store, err = datastore.NewDataStoreFromConfig(dsc)
+		if err != nil {
+			return types.InternalErrorf("hostdev driver failed to initialize data store: %v", err)
+		}
+
+		if err = d.populateNetworks(); err != nil {
+			return err
+		}
+		if err = d.populateEndpoints(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// populateNetworks is invoked at driver init to recreate persistently stored networks
+func (d *driver) populateNetworks() error {
+	kvol, err := d.store.List(datastore.Key(hostdevNetworkPrefix), &network{})
+	if err != nil && err != datastore.ErrKeyNotFound {
+		return fmt.Errorf("failed to get hostdev networks from store: %v", err)
+	}
+	// If empty it simply means no hostdev networks have been created yet
+	if err == datastore.ErrKeyNotFound {
+		return nil
+	}
+	for _, kvo := range kvol {
+		n := kvo.(*network)
+		n.endpoints = map[string]*endpoint{}
+		d.networks[n.id] = n
+		logrus.Debugf("Network (%.7s) restored", n.id)
+	}
+
+	return nil
+}
+
+func (d *driver) populateEndpoints() error {
+	kvol, err := d.store.List(datastore.Key(hostdevEndpointPrefix), &endpoint{})
+	if err != nil && err != datastore.ErrKeyNotFound {
+		return fmt.Errorf("failed to get hostdev endpoints from store: %v", err)
+	}
+
+	if err == datastore.ErrKeyNotFound {
+		return nil
+	}
+
+	for _, kvo := range kvol {
+		ep := kvo.(*endpoint)
+		n, ok := d.networks[ep.nid]
+		if !ok {
+			logrus.Debugf("Network (%.7s) not found for restored hostdev endpoint (%.7s)", ep.nid, ep.id)
+			logrus.Debugf("Deleting stale hostdev endpoint (%.7s) from store", ep.id)
+			if err := d.storeDelete(ep); err != nil {
+				logrus.Debugf("Failed to delete stale hostdev endpoint (%.7s) from store", ep.id)
+			}
+			continue
+		}
+		n.endpoints[ep.id] = ep
+		logrus.Debugf("Endpoint (%.7s) restored to network (%.7s)", ep.id, ep.nid)
+	}
+
+	return nil
+}
+
+// storeUpdate used to update persistent hostdev records as they are created
+func (d *driver) storeUpdate(kvObject datastore.KVObject) error {
+	if d.store == nil {
+		logrus.Warnf("hostdev store not initialized. kv object %s is not added to the store", datastore.Key(kvObject.Key()...))
+		return nil
+	}
+	if err := d.store.PutObjectAtomic(kvObject); err != nil {
+		return fmt.Errorf("failed to update hostdev store for object type %T: %v", kvObject, err)
+	}
+
+	return nil
+}
+
+// storeDelete used to delete hostdev records from persistent cache as they are deleted
+func (d *driver) storeDelete(kvObject datastore.KVObject) error {
+	if d.store == nil {
+		logrus.Debugf("hostdev store not initialized. kv object %s is not deleted from store", datastore.Key(kvObject.Key()...))
+		return nil
+	}
+retry:
+	if err := d.store.DeleteObjectAtomic(kvObject); err != nil {
+		if err == datastore.ErrKeyModified {
+			if err := d.store.GetObject(datastore.Key(kvObject.Key()...), kvObject); err != nil {
+				return fmt.Errorf("could not update the kvobject to latest when trying to delete: %v", err)
+			}
+			goto retry
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (n *network) MarshalJSON() ([]byte, error) {
+	nMap := make(map[string]interface{})
+	nMap["ID"] = n.id
+	nMap["Devices"] = n.devices
+	nMap["Internal"] = n.internal
+	if n.gw != nil {
+		nMap["Gateway"] = n.gw.String()
+	}
+	if n.gw6 != nil {
+		nMap["GatewayIPv6"] = n.gw6.String()
+	}
+	return json.Marshal(nMap)
+}
+
+func (n *network) UnmarshalJSON(b []byte) error {
+	var nMap struct {
+		ID          string
+		Devices     []string
+		Internal    bool
+		Gateway     string
+		GatewayIPv6 string
+	}
+	if err := json.Unmarshal(b, &nMap); err != nil {
+		return fmt.Errorf("failed to unmarshal to hostdev network: %v", err)
+	}
+	n.id = nMap.ID
+	n.devices = nMap.Devices
+	n.internal = nMap.Internal
+	if nMap.Gateway != "" {
+		if n.gw = net.ParseIP(nMap.Gateway); n.gw == nil {
+			return types.InternalErrorf("failed to decode hostdev network gateway (%s) after json unmarshal", nMap.Gateway)
+		}
+	}
+	if nMap.GatewayIPv6 != "" {
+		if n.gw6 = net.ParseIP(nMap.GatewayIPv6); n.gw6 == nil {
+			return types.InternalErrorf("failed to decode hostdev network IPv6 gateway (%s) after json unmarshal", nMap.GatewayIPv6)
+		}
+	}
+
+	return nil
+}
+
+func (n *network) Key() []string {
+	return []string{hostdevNetworkPrefix, n.id}
+}
+
+func (n *network) KeyPrefix() []string {
+	return []string{hostdevNetworkPrefix}
+}
+
+func (n *network) Value() []byte {
+	b, err := json.Marshal(n)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func (n *network) SetValue(value []byte) error {
+	return json.Unmarshal(value, n)
+}
+
+func (n *network) Index() uint64 {
+	return n.dbIndex
+}
+
+func (n *network) SetIndex(index uint64) {
+	n.dbIndex = index
+	n.dbExists = true
+}
+
+func (n *network) Exists() bool {
+	return n.dbExists
+}
+
+func (n *network) Skip() bool {
+	return false
+}
+
+func (n *network) New() datastore.KVObject {
+	return &network{}
+}
+
+func (n *network) CopyTo(o datastore.KVObject) error {
+	dstN := o.(*network)
+	*dstN = *n
+	return nil
+}
+
+func (n *network) DataScope() string {
+	return datastore.LocalScope
+}
+
+func (ep *endpoint) MarshalJSON() ([]byte, error) {
+	epMap := make(map[string]interface{})
+	epMap["id"] = ep.id
+	epMap["nid"] = ep.nid
+	epMap["Device"] = ep.device
+	epMap["ExtraDevices"] = ep.extra
+	epMap["KeepMAC"] = ep.keepMAC
+	return json.Marshal(epMap)
+}
+
+func (ep *endpoint) UnmarshalJSON(b []byte) error {
+	var epMap struct {
+		ID           string `json:"id"`
+		Nid          string `json:"nid"`
+		Device       string
+		ExtraDevices []string
+		KeepMAC      bool
+	}
+	if err := json.Unmarshal(b, &epMap); err != nil {
+		return fmt.Errorf("failed to unmarshal to hostdev endpoint: %v", err)
+	}
+	ep.id = epMap.ID
+	ep.nid = epMap.Nid
+	ep.device = epMap.Device
+	ep.extra = epMap.ExtraDevices
+	ep.keepMAC = epMap.KeepMAC
+
+	return nil
+}
+
+func (ep *endpoint) Key() []string {
+	return []string{hostdevEndpointPrefix, ep.id}
+}
+
+func (ep *endpoint) KeyPrefix() []string {
+	return []string{hostdevEndpointPrefix}
+}
+
+func (ep *endpoint) Value() []byte {
+	b, err := json.Marshal(ep)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func (ep *endpoint) SetValue(value []byte) error {
+	return json.Unmarshal(value, ep)
+}
+
+func (ep *endpoint) Index() uint64 {
+	return ep.dbIndex
+}
+
+func (ep *endpoint) SetIndex(index uint64) {
+	ep.dbIndex = index
+	ep.dbExists = true
+}
+
+func (ep *endpoint) Exists() bool {
+	return ep.dbExists
+}
+
+func (ep *endpoint) Skip() bool {
+	return false
+}
+
+func (ep *endpoint) New() datastore.KVObject {
+	return &endpoint{}
+}
+
+func (ep *endpoint) CopyTo(o datastore.KVObject) error {
+	dstEp := o.(*endpoint)
+	*dstEp = *ep
+	return nil
+}
+
+func (ep *endpoint) DataScope() string {
+	return datastore.LocalScope
+}
@@ -0,0 +1,241 @@
+package hostdev
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/docker/libkv/store"
+	"github.com/docker/libkv/store/boltdb"
+	"github.com/docker/libnetwork/datastore"
+	"github.com/docker/libnetwork/discoverapi"
+	"github.com/docker/libnetwork/driverapi"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/ns"
+	"github.com/docker/libnetwork/testutils"
+	"github.com/docker/libnetwork/types"
+	"github.com/vishvananda/netlink"
+)
+
+func init() {
+	boltdb.Register()
+}
+
+type testInterface struct {
+	mac  net.HardwareAddr
+	addr *net.IPNet
+}
+
+func (i *testInterface) SetMacAddress(mac net.HardwareAddr) error {
+	i.mac = mac
+	return nil
+}
+
+func (i *testInterface) SetIPAddress(addr *net.IPNet) error {
+	i.addr = addr
+	return nil
+}
+
+func (i *testInterface) MacAddress() net.HardwareAddr { return i.mac }
+func (i *testInterface) Address() *net.IPNet          { return i.addr }
+func (i *testInterface) AddressIPv6() *net.IPNet      { return nil }
+
+type testJoinInfo struct {
+	srcName, dstPrefix string
+	gw                 net.IP
+	options            driverapi.InterfaceOptions
+	extra              map[string]driverapi.InterfaceOptions
+}
+
+func (j *testJoinInfo) InterfaceName() driverapi.InterfaceNameInfo { return j }
+
+func (j *testJoinInfo) SetNames(srcName, dstPrefix string) error {
+	j.srcName, j.dstPrefix = srcName, dstPrefix
+	return nil
+}
+
+func (j *testJoinInfo) SetGateway(gw net.IP) error {
+	j.gw = gw
+	return nil
+}
+
+func (j *testJoinInfo) SetGatewayIPv6(net.IP) error { return nil }
+func (j *testJoinInfo) AddStaticRoute(*net.IPNet, int, net.IP) error {
+	return nil
+}
+func (j *testJoinInfo) DisableGatewayService()                     {}
+func (j *testJoinInfo) AddTableEntry(string, string, []byte) error { return nil }
+
+func (j *testJoinInfo) SetInterfaceOptions(opts driverapi.InterfaceOptions) error {
+	j.options = opts
+	return nil
+}
+
+func (j *testJoinInfo) AddInterface(srcName, dstPrefix string, opts driverapi.InterfaceOptions) (driverapi.InterfaceInfo, error) {
+	if j.extra == nil {
+		j.extra = make(map[string]driverapi.InterfaceOptions)
+	}
+	j.extra[srcName] = opts
+	return &testInterface{}, nil
+}
+
+func addDevice(t *testing.T, name string, mac net.HardwareAddr) {
+	if err := ns.NlHandle().LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: name, HardwareAddr: mac},
+		PeerName:  name + "p",
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHostdevPool(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	mac, _ := net.ParseMAC("02:42:ac:11:00:10")
+	addDevice(t, "vf0", mac)
+	addDevice(t, "vf1", mac)
+	addDevice(t, "mgmt0", mac)
+
+	d := &driver{networks: map[string]*network{}}
+	if d.Type() != networkType {
+		t.Fatal("Unexpected network type returned by driver")
+	}
+
+	gw, _ := types.ParseCIDR("172.30.0.1/24")
+	option := map[string]interface{}{netlabel.GenericData: map[string]string{devicesOpt: "vf0, vf1"}}
+	if err := d.CreateNetwork("net1", option, nil, []driverapi.IPAMData{{Gateway: gw}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	option = map[string]interface{}{netlabel.GenericData: map[string]string{devicesOpt: "vf1"}}
+	if err := d.CreateNetwork("net2", option, nil, nil, nil); err == nil {
+		t.Fatal("Expected failure sharing a device between two pools")
+	}
+
+	// The first free device is picked, the extra ones are named explicitly
+	ep1 := &testInterface{}
+	if err := d.CreateEndpoint("net1", "ep1", ep1, map[string]interface{}{extraDevicesOpt: "mgmt0"}); err != nil {
+		t.Fatal(err)
+	}
+	if ep1.mac.String() != mac.String() {
+		t.Fatalf("Expected the device MAC %s to be reported, got %s", mac, ep1.mac)
+	}
+	requested, _ := net.ParseMAC("02:42:ac:11:00:20")
+	ep2 := &testInterface{mac: requested}
+	if err := d.CreateEndpoint("net1", "ep2", ep2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.CreateEndpoint("net1", "ep3", &testInterface{}, nil); err == nil {
+		t.Fatal("Expected failure on an exhausted pool")
+	}
+	if err := d.CreateEndpoint("net1", "ep3", &testInterface{}, map[string]interface{}{deviceOpt: "mgmt0"}); err == nil {
+		t.Fatal("Expected failure on a device already assigned")
+	}
+
+	jinfo := &testJoinInfo{}
+	if err := d.Join("net1", "ep1", "", jinfo, nil); err != nil {
+		t.Fatal(err)
+	}
+	if jinfo.srcName != "vf0" || jinfo.dstPrefix != containerIfPrefix || !jinfo.gw.Equal(gw.IP) {
+		t.Fatalf("Unexpected join info %+v", jinfo)
+	}
+	if !jinfo.options.HostDevice || !jinfo.options.KeepMAC {
+		t.Fatalf("Expected a host device keeping its MAC address, got %+v", jinfo.options)
+	}
+	if opts, ok := jinfo.extra["mgmt0"]; !ok || !opts.HostDevice || !opts.KeepName {
+		t.Fatalf("Expected mgmt0 as an extra host device keeping its name, got %+v", jinfo.extra)
+	}
+
+	// The MAC address requested for the endpoint is programmed
+	jinfo = &testJoinInfo{}
+	if err := d.Join("net1", "ep2", "", jinfo, nil); err != nil {
+		t.Fatal(err)
+	}
+	if jinfo.srcName != "vf1" || jinfo.options.KeepMAC {
+		t.Fatalf("Unexpected join info %+v", jinfo)
+	}
+
+	// The devices go back to the pool
+	if err := d.DeleteEndpoint("net1", "ep1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.CreateEndpoint("net1", "ep3", &testInterface{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if d.networks["net1"].endpoints["ep3"].device != "vf0" {
+		t.Fatalf("Expected vf0 to be assigned again, got %s", d.networks["net1"].endpoints["ep3"].device)
+	}
+	if err := d.DeleteNetwork("net1"); err == nil {
+		t.Fatal("Expected failure deleting a network with endpoints")
+	}
+}
+
+func TestHostdevRestore(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	mac, _ := net.ParseMAC("02:42:ac:11:00:10")
+	addDevice(t, "vf0", mac)
+	addDevice(t, "vf1", mac)
+
+	tmp, err := ioutil.TempFile("", "hostdev-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+	config := map[string]interface{}{
+		netlabel.LocalKVClient: discoverapi.DatastoreConfigData{
+			Scope:    datastore.LocalScope,
+			Provider: "boltdb",
+			Address:  tmp.Name(),
+			Config:   &store.Config{Bucket: "libnetwork", ConnectionTimeout: 3 * time.Second},
+		},
+	}
+
+	d := &driver{networks: map[string]*network{}}
+	if err := d.initStore(config); err != nil {
+		t.Fatal(err)
+	}
+	gw, _ := types.ParseCIDR("172.30.0.1/24")
+	option := map[string]interface{}{netlabel.GenericData: map[string]string{devicesOpt: "vf0,vf1"}}
+	if err := d.CreateNetwork("net1", option, nil, []driverapi.IPAMData{{Gateway: gw}}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.CreateEndpoint("net1", "ep1", &testInterface{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	d.store.Close()
+
+	// The networks and endpoints are restored, the devices staying assigned
+	d = &driver{networks: map[string]*network{}}
+	if err := d.initStore(config); err != nil {
+		t.Fatal(err)
+	}
+	defer d.store.Close()
+	jinfo := &testJoinInfo{}
+	if err := d.Join("net1", "ep1", "", jinfo, nil); err != nil {
+		t.Fatal(err)
+	}
+	if jinfo.srcName != "vf0" || !jinfo.options.KeepMAC || !jinfo.gw.Equal(gw.IP) {
+		t.Fatalf("Unexpected join info %+v", jinfo)
+	}
+	if err := d.CreateEndpoint("net1", "ep2", &testInterface{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if dev := d.networks["net1"].endpoints["ep2"].device; dev != "vf1" {
+		t.Fatalf("Expected vf1 to be assigned, got %s", dev)
+	}
+
+	for _, eid := range []string{"ep1", "ep2"} {
+		if err := d.DeleteEndpoint("net1", eid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := d.DeleteNetwork("net1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.populateNetworks(); err != nil || len(d.networks) != 0 {
+		t.Fatalf("Expected no network left in store, got %v: %v", d.networks, err)
+	}
+}
@@ -81,6 +81,16 @@ func (d *driver) EndpointOperInfo(nid, eid string) (map[string]interface{}, erro
 	return make(map[string]interface{}, 0), nil
 }
 
+// ProbeInterface returns the parent interface of the network, the endpoint
+// addresses are probed from
+func (d *driver) ProbeInterface(nid string) (string, error) {
+	n, err := d.getNetwork(nid)
+	if err != nil {
+		return "", err
+	}
+	return n.config.Parent, nil
+}
+
 func (d *driver) Type() string {
 	return ipvlanType
 }
@@ -26,6 +26,14 @@ type GetCapabilityResponse struct {
 	Response
 	Scope             string
 	ConnectivityScope string
+	// GossipSupport tells the driver takes part in the gossip
+	// of the networks table entries across the cluster
+	GossipSupport bool
+	// GRPCAddress is the unix socket the driver serves the gRPC
+	// NetworkDriver protocol on, under the plugin sockets directory. Once set,
+	// the driver calls use gRPC if the plugin manifest declares the gRPC
+	// protocol scheme.
+	GRPCAddress string
 }
 
 // AllocateNetworkRequest requests allocation of new network by manager
@@ -76,6 +84,22 @@ type CreateNetworkRequest struct {
 // CreateNetworkResponse is the response to the CreateNetworkRequest.
 type CreateNetworkResponse struct {
 	Response
+	// Tables lists the gossip tables the driver wants to be notified
+	// of the events of, for this network
+	Tables []TableRegistration
+}
+
+// TableRegistration is the registration of the driver interest in a gossip table.
+type TableRegistration struct {
+	Name       string
+	ObjectType driverapi.ObjectType
+}
+
+// TableEntry is the plain JSON representation of a gossip table entry.
+type TableEntry struct {
+	TableName string
+	Key       string
+	Value     []byte
 }
 
 // DeleteNetworkRequest is the request to delete an existing network.
@@ -173,6 +197,9 @@ type JoinResponse struct {
 	GatewayIPv6           string
 	StaticRoutes          []StaticRoute
 	DisableGatewayService bool
+	// TableEntries are added to the network gossip tables
+	// on behalf of the endpoint
+	TableEntries []TableEntry
 }
 
 // LeaveRequest describes the API for detaching an endpoint from a sandbox.
@@ -219,3 +246,32 @@ type DiscoveryNotification struct {
 type DiscoveryResponse struct {
 	Response
 }
+
+// EventNotifyRequest notifies the driver of an event on a gossip table entry
+type EventNotifyRequest struct {
+	EventType driverapi.EventType
+	NetworkID string
+	TableName string
+	Key       string
+	Value     []byte
+}
+
+// EventNotifyResponse is used by libnetwork to log any plugin error processing the table events
+type EventNotifyResponse struct {
+	Response
+}
+
+// DecodeTableEntryRequest asks the driver to decode a gossip table entry
+type DecodeTableEntryRequest struct {
+	TableName string
+	Key       string
+	Value     []byte
+}
+
+// DecodeTableEntryResponse is the response to a DecodeTableEntryRequest.
+type DecodeTableEntryResponse struct {
+	Response
+	// ID is the identifier of the libnetwork object the entry is about
+	ID   string
+	Info map[string]string
+}
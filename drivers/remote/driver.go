@@ -10,30 +10,59 @@ import (
 	"github.com/docker/libnetwork/discoverapi"
 	"github.com/docker/libnetwork/driverapi"
 	"github.com/docker/libnetwork/drivers/remote/api"
+	"github.com/docker/libnetwork/drivers/remote/rpc"
+	"github.com/docker/libnetwork/internal/plugincall"
+	"github.com/docker/libnetwork/internal/plugingrpc"
 	"github.com/docker/libnetwork/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 type driver struct {
-	endpoint    *plugins.Client
+	endpoint    pluginClient
 	networkType string
+	// gossip is set when the plugin takes part in the table entries gossip
+	gossip bool
+	// guard bounds the plugin calls and tracks the plugin health
+	guard *plugincall.Guard
+}
+
+// idempotentCalls are the plugin calls retried on failure
+var idempotentCalls = []string{
+	driverapi.NetworkPluginEndpointType + ".EndpointOperInfo",
+	driverapi.NetworkPluginEndpointType + ".Leave",
+	driverapi.NetworkPluginEndpointType + ".DeleteEndpoint",
 }
 
 type maybeError interface {
 	GetError() string
 }
 
-func newDriver(name string, client *plugins.Client) driverapi.Driver {
-	return &driver{networkType: name, endpoint: client}
+// pluginClient is the transport of the plugin calls, the HTTP plugin client
+// or the gRPC one depending on the protocol the plugin speaks.
+type pluginClient interface {
+	Call(serviceMethod string, args, ret interface{}) error
+}
+
+func newDriver(name string, client pluginClient) driverapi.Driver {
+	return newDriverWithConfig(name, client, plugincall.Config{})
+}
+
+func newDriverWithConfig(name string, client pluginClient, cfg plugincall.Config) driverapi.Driver {
+	return &driver{networkType: name, endpoint: client, guard: plugincall.New(name, cfg, idempotentCalls...)}
 }
 
 // Init makes sure a remote driver is registered when a network driver
 // plugin is activated.
 func Init(dc driverapi.DriverCallback, config map[string]interface{}) error {
-	newPluginHandler := func(name string, client *plugins.Client) {
+	cfg, err := plugincall.ParseConfig(config)
+	if err != nil {
+		return err
+	}
+
+	newPluginHandler := func(name string, client pluginClient) {
 		// negotiate driver capability with client
-		d := newDriver(name, client)
+		d := newDriverWithConfig(name, client, cfg)
 		c, err := d.(*driver).getCapabilities()
 		if err != nil {
 			logrus.Errorf("error getting capability for %s due to %v", name, err)
@@ -57,12 +86,54 @@ func Init(dc driverapi.DriverCallback, config map[string]interface{}) error {
 			newPluginHandler(ap.Name(), client)
 		}
 	}
-	handleFunc(driverapi.NetworkPluginEndpointType, newPluginHandler)
+	handleFunc(driverapi.NetworkPluginEndpointType, func(name string, client *plugins.Client) {
+		newPluginHandler(name, handledPluginClient(dc.GetPluginGetter(), name, client))
+	})
 
 	return nil
 }
 
-func getPluginClient(p plugingetter.CompatPlugin) (*plugins.Client, error) {
+// handledPluginClient returns the client of the plugin name activated
+// through the plugin handlers, which give it client
+func handledPluginClient(pg plugingetter.PluginGetter, name string, client *plugins.Client) pluginClient {
+	return plugincall.NewHandledClient(name, client, func() (plugincall.Caller, error) {
+		var (
+			p   plugingetter.CompatPlugin
+			err error
+		)
+		if pg != nil {
+			p, err = pg.Get(name, driverapi.NetworkPluginEndpointType, plugingetter.Lookup)
+		} else {
+			p, err = plugins.Get(name, driverapi.NetworkPluginEndpointType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return getPluginClient(p)
+	})
+}
+
+func getPluginClient(p plugingetter.CompatPlugin) (pluginClient, error) {
+	if pa, ok := p.(plugingetter.PluginAddr); ok && pa.Protocol() == rpc.ProtocolSchemeGRPCV1 {
+		addr := pa.Addr()
+		if addr.Network() != "unix" {
+			return nil, errors.Errorf("unsupported gRPC plugin address %s://%s", addr.Network(), addr)
+		}
+		client, err := newGRPCClient(addr.String(), pa.Timeout())
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating plugin gRPC client")
+		}
+		return client, nil
+	}
+
+	if v1, ok := p.(*plugins.Plugin); ok {
+		client, err := plugincall.NewHTTPClient(v1.Addr, v1.TLSConfig, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating plugin client")
+		}
+		return client, nil
+	}
+
 	if v1, ok := p.(plugingetter.PluginWithV1Client); ok {
 		return v1.Client(), nil
 	}
@@ -77,7 +148,7 @@ func getPluginClient(p plugingetter.CompatPlugin) (*plugins.Client, error) {
 	}
 
 	addr := pa.Addr()
-	client, err := plugins.NewClientWithTimeout(addr.Network()+"://"+addr.String(), nil, pa.Timeout())
+	client, err := plugincall.NewHTTPClient(addr.Network()+"://"+addr.String(), nil, pa.Timeout())
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating plugin client")
 	}
@@ -112,6 +183,22 @@ func (d *driver) getCapabilities() (*driverapi.Capability, error) {
 		return nil, fmt.Errorf("invalid capability: expecting 'local' or 'global', got %s", capResp.Scope)
 	}
 
+	d.gossip = capResp.GossipSupport
+
+	if _, ok := d.endpoint.(*grpcClient); !ok && capResp.GRPCAddress != "" {
+		addr, err := plugingrpc.Address(d.networkType, driverapi.NetworkPluginEndpointType, capResp.GRPCAddress)
+		if err != nil {
+			return nil, err
+		}
+		if addr != "" {
+			client, err := newGRPCClient(addr, 0)
+			if err != nil {
+				return nil, fmt.Errorf("error creating plugin gRPC client for %s: %v", addr, err)
+			}
+			d.endpoint = client
+		}
+	}
+
 	return c, nil
 }
 
@@ -124,7 +211,7 @@ func (d *driver) Config(option map[string]interface{}) error {
 
 func (d *driver) call(methodName string, arg interface{}, retVal maybeError) error {
 	method := driverapi.NetworkPluginEndpointType + "." + methodName
-	err := d.endpoint.Call(method, arg, retVal)
+	err := d.guard.Call(d.endpoint, method, arg, retVal)
 	if err != nil {
 		return err
 	}
@@ -134,6 +221,11 @@ func (d *driver) call(methodName string, arg interface{}, retVal maybeError) err
 	return nil
 }
 
+// Health returns the health of the plugin, as tracked by its calls
+func (d *driver) Health() types.PluginHealth {
+	return d.guard.Health()
+}
+
 func (d *driver) NetworkAllocate(id string, options map[string]string, ipV4Data, ipV6Data []driverapi.IPAMData) (map[string]string, error) {
 	create := &api.AllocateNetworkRequest{
 		NetworkID: id,
@@ -151,11 +243,39 @@ func (d *driver) NetworkFree(id string) error {
 	return d.call("FreeNetwork", fr, &api.FreeNetworkResponse{})
 }
 
+// EventNotify pushes the events on the gossip tables the plugin registered for
 func (d *driver) EventNotify(etype driverapi.EventType, nid, tableName, key string, value []byte) {
+	if !d.gossip {
+		return
+	}
+	notif := &api.EventNotifyRequest{
+		EventType: etype,
+		NetworkID: nid,
+		TableName: tableName,
+		Key:       key,
+		Value:     value,
+	}
+	if err := d.call("EventNotify", notif, &api.EventNotifyResponse{}); err != nil {
+		logrus.Errorf("remote driver %s failed to process event on table %s for key %s: %v", d.networkType, tableName, key, err)
+	}
 }
 
+// DecodeTableEntry asks the plugin to decode an entry of a gossip table it registered for
 func (d *driver) DecodeTableEntry(tablename string, key string, value []byte) (string, map[string]string) {
-	return "", nil
+	if !d.gossip {
+		return "", nil
+	}
+	req := &api.DecodeTableEntryRequest{
+		TableName: tablename,
+		Key:       key,
+		Value:     value,
+	}
+	var res api.DecodeTableEntryResponse
+	if err := d.call("DecodeTableEntry", req, &res); err != nil {
+		logrus.Errorf("remote driver %s failed to decode entry of table %s for key %s: %v", d.networkType, tablename, key, err)
+		return "", nil
+	}
+	return res.ID, res.Info
 }
 
 func (d *driver) CreateNetwork(id string, options map[string]interface{}, nInfo driverapi.NetworkInfo, ipV4Data, ipV6Data []driverapi.IPAMData) error {
@@ -165,7 +285,20 @@ func (d *driver) CreateNetwork(id string, options map[string]interface{}, nInfo
 		IPv4Data:  ipV4Data,
 		IPv6Data:  ipV6Data,
 	}
-	return d.call("CreateNetwork", create, &api.CreateNetworkResponse{})
+	var res api.CreateNetworkResponse
+	if err := d.call("CreateNetwork", create, &res); err != nil {
+		return err
+	}
+
+	if len(res.Tables) > 0 && !d.gossip {
+		return errorWithRollback("driver requested gossip tables without gossip support", d.DeleteNetwork(id))
+	}
+	for _, t := range res.Tables {
+		if err := nInfo.TableEventRegister(t.Name, t.ObjectType); err != nil {
+			return errorWithRollback(fmt.Sprintf("failed to register table %s: %v", t.Name, err), d.DeleteNetwork(id))
+		}
+	}
+	return nil
 }
 
 func (d *driver) DeleteNetwork(nid string) error {
@@ -310,6 +443,14 @@ func (d *driver) Join(nid, eid string, sboxKey string, jinfo driverapi.JoinInfo,
 	if res.DisableGatewayService {
 		jinfo.DisableGatewayService()
 	}
+	if len(res.TableEntries) > 0 && !d.gossip {
+		return errorWithRollback("driver returned table entries without gossip support", d.Leave(nid, eid))
+	}
+	for _, e := range res.TableEntries {
+		if err := jinfo.AddTableEntry(e.TableName, e.Key, e.Value); err != nil {
+			return errorWithRollback(fmt.Sprintf("failed to add entry %s to table %s: %v", e.Key, e.TableName, err), d.Leave(nid, eid))
+		}
+	}
 	return nil
 }
 
@@ -330,7 +471,7 @@ func (d *driver) ProgramExternalConnectivity(nid, eid string, options map[string
 		Options:    options,
 	}
 	err := d.call("ProgramExternalConnectivity", data, &api.ProgramExternalConnectivityResponse{})
-	if err != nil && plugins.IsNotFound(err) {
+	if err != nil && isNotFound(err) {
 		// It is not mandatory yet to support this method
 		return nil
 	}
@@ -344,13 +485,21 @@ func (d *driver) RevokeExternalConnectivity(nid, eid string) error {
 		EndpointID: eid,
 	}
 	err := d.call("RevokeExternalConnectivity", data, &api.RevokeExternalConnectivityResponse{})
-	if err != nil && plugins.IsNotFound(err) {
+	if err != nil && isNotFound(err) {
 		// It is not mandatory yet to support this method
 		return nil
 	}
 	return err
 }
 
+// isNotFound tells whether the plugin does not implement the called method
+func isNotFound(err error) bool {
+	if _, ok := err.(types.NotImplementedError); ok {
+		return true
+	}
+	return plugins.IsNotFound(err)
+}
+
 func (d *driver) Type() string {
 	return d.networkType
 }
@@ -11,12 +11,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 
 	"github.com/docker/docker/pkg/plugins"
 	"github.com/docker/libnetwork/datastore"
 	"github.com/docker/libnetwork/discoverapi"
 	"github.com/docker/libnetwork/driverapi"
+	"github.com/docker/libnetwork/internal/plugincall"
+	"github.com/docker/libnetwork/netlabel"
 	_ "github.com/docker/libnetwork/testutils"
 	"github.com/docker/libnetwork/types"
 )
@@ -40,7 +43,7 @@ func handle(t *testing.T, mux *http.ServeMux, method string, h func(map[string]i
 	})
 }
 
-func setupPlugin(t *testing.T, name string, mux *http.ServeMux) func() {
+func setupPlugin(t *testing.T, name string, mux *http.ServeMux, implements ...string) func() {
 	if err := os.MkdirAll("/etc/docker/plugins", 0755); err != nil {
 		t.Fatal(err)
 	}
@@ -56,7 +59,7 @@ func setupPlugin(t *testing.T, name string, mux *http.ServeMux) func() {
 
 	mux.HandleFunc("/Plugin.Activate", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
-		fmt.Fprintf(w, `{"Implements": ["%s"]}`, driverapi.NetworkPluginEndpointType)
+		json.NewEncoder(w).Encode(map[string][]string{"Implements": append([]string{driverapi.NetworkPluginEndpointType}, implements...)})
 	})
 
 	return func() {
@@ -82,6 +85,7 @@ type testEndpoint struct {
 	destination           string
 	routeType             int
 	disableGatewayService bool
+	tableEntries          map[string][]byte
 }
 
 func (test *testEndpoint) Interface() driverapi.InterfaceInfo {
@@ -201,9 +205,27 @@ func (test *testEndpoint) DisableGatewayService() {
 }
 
 func (test *testEndpoint) AddTableEntry(tableName string, key string, value []byte) error {
+	if test.tableEntries == nil {
+		test.tableEntries = make(map[string][]byte)
+	}
+	test.tableEntries[tableName+"/"+key] = value
+	return nil
+}
+
+type testNetwork struct {
+	tables map[string]driverapi.ObjectType
+}
+
+func (n *testNetwork) TableEventRegister(tableName string, objType driverapi.ObjectType) error {
+	if n.tables == nil {
+		n.tables = make(map[string]driverapi.ObjectType)
+	}
+	n.tables[tableName] = objType
 	return nil
 }
 
+func (n *testNetwork) UpdateIpamConfig(ipV4Data []driverapi.IPAMData) {}
+
 func TestGetEmptyCapabilities(t *testing.T) {
 	var plugin = "test-net-driver-empty-cap"
 
@@ -607,3 +629,174 @@ func TestRollback(t *testing.T) {
 		t.Fatal("Expected to have had DeleteEndpoint called")
 	}
 }
+
+func TestGossip(t *testing.T) {
+	var plugin = "test-net-driver-gossip"
+
+	mux := http.NewServeMux()
+	defer setupPlugin(t, plugin, mux)()
+
+	var events []map[string]interface{}
+	gossip := true
+
+	handle(t, mux, "GetCapabilities", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"Scope":         "global",
+			"GossipSupport": gossip,
+		}
+	})
+	handle(t, mux, "CreateNetwork", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"Tables": []map[string]interface{}{
+				{"Name": "peers", "ObjectType": driverapi.EndpointObject},
+			},
+		}
+	})
+	handle(t, mux, "DeleteNetwork", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{}
+	})
+	handle(t, mux, "Join", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"TableEntries": []map[string]interface{}{
+				{"TableName": "peers", "Key": msg["EndpointID"], "Value": []byte("10.0.0.1")},
+			},
+		}
+	})
+	handle(t, mux, "Leave", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{}
+	})
+	handle(t, mux, "EventNotify", func(msg map[string]interface{}) interface{} {
+		events = append(events, msg)
+		return map[string]interface{}{}
+	})
+	handle(t, mux, "DecodeTableEntry", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"ID":   msg["Key"],
+			"Info": map[string]string{"Host IP": "10.0.0.1"},
+		}
+	})
+
+	p, err := plugins.Get(plugin, driverapi.NetworkPluginEndpointType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := getPluginClient(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newDriver(plugin, client)
+	if _, err := d.(*driver).getCapabilities(); err != nil {
+		t.Fatal(err)
+	}
+
+	nInfo := &testNetwork{}
+	if err := d.CreateNetwork("dummy-network", map[string]interface{}{}, nInfo, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if ot, ok := nInfo.tables["peers"]; !ok || ot != driverapi.EndpointObject {
+		t.Fatalf("Expected the peers table to be registered, got %v", nInfo.tables)
+	}
+
+	ep := &testEndpoint{t: t}
+	if err := d.Join("dummy-network", "dummy-endpoint", "sandbox-key", ep, nil); err != nil {
+		t.Fatal(err)
+	}
+	if v := ep.tableEntries["peers/dummy-endpoint"]; string(v) != "10.0.0.1" {
+		t.Fatalf("Expected the endpoint entry to be added to the peers table, got %v", ep.tableEntries)
+	}
+
+	d.EventNotify(driverapi.Create, "dummy-network", "peers", "remote-endpoint", []byte("10.0.0.2"))
+	if len(events) != 1 || events[0]["Key"] != "remote-endpoint" || events[0]["NetworkID"] != "dummy-network" {
+		t.Fatalf("Unexpected event notifications %v", events)
+	}
+
+	id, info := d.DecodeTableEntry("peers", "remote-endpoint", []byte("10.0.0.2"))
+	if id != "remote-endpoint" || info["Host IP"] != "10.0.0.1" {
+		t.Fatalf("Unexpected decoded entry %s %v", id, info)
+	}
+
+	// Without the capability, the plugin is not notified and cannot register tables
+	gossip = false
+	if _, err := d.(*driver).getCapabilities(); err != nil {
+		t.Fatal(err)
+	}
+	d.EventNotify(driverapi.Delete, "dummy-network", "peers", "remote-endpoint", nil)
+	if len(events) != 1 {
+		t.Fatalf("Unexpected event notification without gossip support %v", events[1:])
+	}
+	if err := d.CreateNetwork("dummy-network", map[string]interface{}{}, &testNetwork{}, nil, nil); err == nil {
+		t.Fatal("Expected failure on table registration without gossip support")
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	var plugin = "test-net-driver-timeout"
+
+	mux := http.NewServeMux()
+	defer setupPlugin(t, plugin, mux)()
+	release := make(chan struct{})
+	defer close(release)
+
+	handle(t, mux, "GetCapabilities", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{"Scope": "local"}
+	})
+	handle(t, mux, "Join", func(msg map[string]interface{}) interface{} {
+		<-release
+		return map[string]interface{}{}
+	})
+	var leaves int32
+	handle(t, mux, "Leave", func(msg map[string]interface{}) interface{} {
+		if atomic.AddInt32(&leaves, 1) < 3 {
+			<-release
+		}
+		return map[string]interface{}{}
+	})
+
+	p, err := plugins.Get(plugin, driverapi.NetworkPluginEndpointType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := getPluginClient(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := plugincall.ParseConfig(map[string]interface{}{
+		netlabel.RemoteCallTimeout:      "100ms",
+		netlabel.RemoteBreakerThreshold: "4",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newDriverWithConfig(plugin, client, cfg)
+
+	// A hung call times out
+	if err := d.Join("dummy", "dummy", "sbox", &testEndpoint{t: t}, nil); err == nil {
+		t.Fatal("Expected the hung call to time out")
+	}
+
+	// Idempotent calls are retried
+	if err := d.Leave("dummy", "dummy"); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&leaves); n != 3 {
+		t.Fatalf("Expected the call to be retried twice, got %d calls", n)
+	}
+	if h := d.(*driver).Health(); !h.Healthy || h.Failures != 0 {
+		t.Fatalf("Expected the plugin to be healthy, got %+v", h)
+	}
+
+	// The breaker opens on consecutive failures and fails the calls fast
+	for i := 0; i < 4; i++ {
+		if err := d.Join("dummy", "dummy", "sbox", &testEndpoint{t: t}, nil); err == nil {
+			t.Fatal("Expected the hung call to time out")
+		}
+	}
+	if h := d.(*driver).Health(); h.Healthy || h.Failures != 4 {
+		t.Fatalf("Expected the plugin to be unhealthy, got %+v", h)
+	}
+	if _, err := d.EndpointOperInfo("dummy", "dummy"); err == nil {
+		t.Fatal("Expected the call to fail fast")
+	} else if _, ok := err.(types.NoServiceError); !ok {
+		t.Fatalf("Expected the call to fail fast, got %v", err)
+	}
+}
@@ -0,0 +1,337 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/libnetwork/driverapi"
+	"github.com/docker/libnetwork/drivers/remote/api"
+	"github.com/docker/libnetwork/drivers/remote/rpc"
+	"github.com/docker/libnetwork/internal/plugingrpc"
+	"google.golang.org/grpc"
+)
+
+// grpcClient is the pluginClient of the plugins speaking the gRPC
+// NetworkDriver protocol. It translates the calls of the JSON protocol
+// into their gRPC counterpart, so that the driver logic is shared by
+// both transports.
+type grpcClient struct {
+	conn    *grpc.ClientConn
+	client  rpc.NetworkDriverClient
+	timeout time.Duration
+
+	// notifications stream, opened on the first notification
+	sync.Mutex
+	stream  rpc.NetworkDriver_NotifyClient
+	seq     uint64
+	pending map[uint64]chan string
+}
+
+func newGRPCClient(addr string, timeout time.Duration) (*grpcClient, error) {
+	if timeout == 0 {
+		timeout = plugingrpc.DefaultTimeout
+	}
+	conn, err := plugingrpc.Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcClient{
+		conn:    conn,
+		client:  rpc.NewNetworkDriverClient(conn),
+		timeout: timeout,
+		pending: make(map[uint64]chan string),
+	}, nil
+}
+
+// Call invokes the gRPC method standing for the JSON protocol serviceMethod.
+func (c *grpcClient) Call(serviceMethod string, args, ret interface{}) error {
+	return c.CallWithTimeout(serviceMethod, args, ret, c.timeout)
+}
+
+// CallWithTimeout is Call with a deadline other than the client one.
+func (c *grpcClient) CallWithTimeout(serviceMethod string, args, ret interface{}, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var err error
+	switch serviceMethod {
+	case driverapi.NetworkPluginEndpointType + ".GetCapabilities":
+		err = c.getCapabilities(ctx, ret.(*api.GetCapabilityResponse))
+	case driverapi.NetworkPluginEndpointType + ".AllocateNetwork":
+		err = c.allocateNetwork(ctx, args.(*api.AllocateNetworkRequest), ret.(*api.AllocateNetworkResponse))
+	case driverapi.NetworkPluginEndpointType + ".FreeNetwork":
+		_, err = c.client.FreeNetwork(ctx, &rpc.FreeNetworkRequest{NetworkID: args.(*api.FreeNetworkRequest).NetworkID})
+	case driverapi.NetworkPluginEndpointType + ".CreateNetwork":
+		err = c.createNetwork(ctx, args.(*api.CreateNetworkRequest), ret.(*api.CreateNetworkResponse))
+	case driverapi.NetworkPluginEndpointType + ".DeleteNetwork":
+		_, err = c.client.DeleteNetwork(ctx, &rpc.DeleteNetworkRequest{NetworkID: args.(*api.DeleteNetworkRequest).NetworkID})
+	case driverapi.NetworkPluginEndpointType + ".CreateEndpoint":
+		err = c.createEndpoint(ctx, args.(*api.CreateEndpointRequest), ret.(*api.CreateEndpointResponse))
+	case driverapi.NetworkPluginEndpointType + ".DeleteEndpoint":
+		req := args.(*api.DeleteEndpointRequest)
+		_, err = c.client.DeleteEndpoint(ctx, &rpc.DeleteEndpointRequest{NetworkID: req.NetworkID, EndpointID: req.EndpointID})
+	case driverapi.NetworkPluginEndpointType + ".EndpointOperInfo":
+		err = c.endpointOperInfo(ctx, args.(*api.EndpointInfoRequest), ret.(*api.EndpointInfoResponse))
+	case driverapi.NetworkPluginEndpointType + ".Join":
+		err = c.join(ctx, args.(*api.JoinRequest), ret.(*api.JoinResponse))
+	case driverapi.NetworkPluginEndpointType + ".Leave":
+		req := args.(*api.LeaveRequest)
+		_, err = c.client.Leave(ctx, &rpc.LeaveRequest{NetworkID: req.NetworkID, EndpointID: req.EndpointID})
+	case driverapi.NetworkPluginEndpointType + ".ProgramExternalConnectivity":
+		req := args.(*api.ProgramExternalConnectivityRequest)
+		var options []byte
+		if options, err = json.Marshal(req.Options); err == nil {
+			_, err = c.client.ProgramExternalConnectivity(ctx, &rpc.ProgramExternalConnectivityRequest{NetworkID: req.NetworkID, EndpointID: req.EndpointID, Options: options})
+		}
+	case driverapi.NetworkPluginEndpointType + ".RevokeExternalConnectivity":
+		req := args.(*api.RevokeExternalConnectivityRequest)
+		_, err = c.client.RevokeExternalConnectivity(ctx, &rpc.RevokeExternalConnectivityRequest{NetworkID: req.NetworkID, EndpointID: req.EndpointID})
+	case driverapi.NetworkPluginEndpointType + ".DecodeTableEntry":
+		err = c.decodeTableEntry(ctx, args.(*api.DecodeTableEntryRequest), ret.(*api.DecodeTableEntryResponse))
+	case driverapi.NetworkPluginEndpointType + ".DiscoverNew", driverapi.NetworkPluginEndpointType + ".DiscoverDelete":
+		req := args.(*api.DiscoveryNotification)
+		n := &rpc.DiscoveryNotification{
+			Delete:        serviceMethod == driverapi.NetworkPluginEndpointType+".DiscoverDelete",
+			DiscoveryType: uint32(req.DiscoveryType),
+		}
+		if n.DiscoveryData, err = json.Marshal(req.DiscoveryData); err == nil {
+			err = c.notify(ctx, &rpc.Notification{Notification: &rpc.Notification_Discovery{Discovery: n}}, &ret.(*api.DiscoveryResponse).Err)
+		}
+	case driverapi.NetworkPluginEndpointType + ".EventNotify":
+		req := args.(*api.EventNotifyRequest)
+		n := &rpc.EventNotification{
+			EventType: uint32(req.EventType),
+			NetworkID: req.NetworkID,
+			TableName: req.TableName,
+			Key:       req.Key,
+			Value:     req.Value,
+		}
+		err = c.notify(ctx, &rpc.Notification{Notification: &rpc.Notification_Event{Event: n}}, &ret.(*api.EventNotifyResponse).Err)
+	default:
+		return fmt.Errorf("%s: not supported by the gRPC transport", serviceMethod)
+	}
+	return plugingrpc.Error(serviceMethod, err)
+}
+
+func (c *grpcClient) getCapabilities(ctx context.Context, ret *api.GetCapabilityResponse) error {
+	res, err := c.client.GetCapabilities(ctx, &rpc.GetCapabilityRequest{})
+	if err != nil {
+		return err
+	}
+	ret.Scope = res.Scope
+	ret.ConnectivityScope = res.ConnectivityScope
+	ret.GossipSupport = res.GossipSupport
+	return nil
+}
+
+func (c *grpcClient) allocateNetwork(ctx context.Context, req *api.AllocateNetworkRequest, ret *api.AllocateNetworkResponse) error {
+	res, err := c.client.AllocateNetwork(ctx, &rpc.AllocateNetworkRequest{
+		NetworkID: req.NetworkID,
+		Options:   req.Options,
+		IPv4Data:  toRPCIPAMData(req.IPv4Data),
+		IPv6Data:  toRPCIPAMData(req.IPv6Data),
+	})
+	if err != nil {
+		return err
+	}
+	ret.Options = res.Options
+	return nil
+}
+
+func (c *grpcClient) createNetwork(ctx context.Context, req *api.CreateNetworkRequest, ret *api.CreateNetworkResponse) error {
+	options, err := json.Marshal(req.Options)
+	if err != nil {
+		return err
+	}
+	res, err := c.client.CreateNetwork(ctx, &rpc.CreateNetworkRequest{
+		NetworkID: req.NetworkID,
+		Options:   options,
+		IPv4Data:  toRPCIPAMData(req.IPv4Data),
+		IPv6Data:  toRPCIPAMData(req.IPv6Data),
+	})
+	if err != nil {
+		return err
+	}
+	for _, t := range res.Tables {
+		ret.Tables = append(ret.Tables, api.TableRegistration{Name: t.Name, ObjectType: driverapi.ObjectType(t.ObjectType)})
+	}
+	return nil
+}
+
+func (c *grpcClient) createEndpoint(ctx context.Context, req *api.CreateEndpointRequest, ret *api.CreateEndpointResponse) error {
+	options, err := json.Marshal(req.Options)
+	if err != nil {
+		return err
+	}
+	create := &rpc.CreateEndpointRequest{
+		NetworkID:  req.NetworkID,
+		EndpointID: req.EndpointID,
+		Options:    options,
+	}
+	if req.Interface != nil {
+		create.Interface = &rpc.EndpointInterface{
+			Address:     req.Interface.Address,
+			AddressIPv6: req.Interface.AddressIPv6,
+			MacAddress:  req.Interface.MacAddress,
+		}
+	}
+	res, err := c.client.CreateEndpoint(ctx, create)
+	if err != nil {
+		return err
+	}
+	if res.Interface != nil {
+		ret.Interface = &api.EndpointInterface{
+			Address:     res.Interface.Address,
+			AddressIPv6: res.Interface.AddressIPv6,
+			MacAddress:  res.Interface.MacAddress,
+		}
+	}
+	return nil
+}
+
+func (c *grpcClient) endpointOperInfo(ctx context.Context, req *api.EndpointInfoRequest, ret *api.EndpointInfoResponse) error {
+	res, err := c.client.EndpointOperInfo(ctx, &rpc.EndpointInfoRequest{NetworkID: req.NetworkID, EndpointID: req.EndpointID})
+	if err != nil {
+		return err
+	}
+	if len(res.Value) == 0 {
+		return nil
+	}
+	return json.Unmarshal(res.Value, &ret.Value)
+}
+
+func (c *grpcClient) join(ctx context.Context, req *api.JoinRequest, ret *api.JoinResponse) error {
+	options, err := json.Marshal(req.Options)
+	if err != nil {
+		return err
+	}
+	res, err := c.client.Join(ctx, &rpc.JoinRequest{
+		NetworkID:  req.NetworkID,
+		EndpointID: req.EndpointID,
+		SandboxKey: req.SandboxKey,
+		Options:    options,
+	})
+	if err != nil {
+		return err
+	}
+	if res.InterfaceName != nil {
+		ret.InterfaceName = &api.InterfaceName{
+			SrcName:   res.InterfaceName.SrcName,
+			DstName:   res.InterfaceName.DstName,
+			DstPrefix: res.InterfaceName.DstPrefix,
+		}
+	}
+	ret.Gateway = res.Gateway
+	ret.GatewayIPv6 = res.GatewayIPv6
+	for _, r := range res.StaticRoutes {
+		ret.StaticRoutes = append(ret.StaticRoutes, api.StaticRoute{Destination: r.Destination, RouteType: int(r.RouteType), NextHop: r.NextHop})
+	}
+	ret.DisableGatewayService = res.DisableGatewayService
+	for _, e := range res.TableEntries {
+		ret.TableEntries = append(ret.TableEntries, api.TableEntry{TableName: e.TableName, Key: e.Key, Value: e.Value})
+	}
+	return nil
+}
+
+func (c *grpcClient) decodeTableEntry(ctx context.Context, req *api.DecodeTableEntryRequest, ret *api.DecodeTableEntryResponse) error {
+	res, err := c.client.DecodeTableEntry(ctx, &rpc.DecodeTableEntryRequest{TableName: req.TableName, Key: req.Key, Value: req.Value})
+	if err != nil {
+		return err
+	}
+	ret.ID = res.ID
+	ret.Info = res.Info
+	return nil
+}
+
+func toRPCIPAMData(data []driverapi.IPAMData) []*rpc.IPAMData {
+	var out []*rpc.IPAMData
+	for _, d := range data {
+		o := &rpc.IPAMData{AddressSpace: d.AddressSpace}
+		if d.Pool != nil {
+			o.Pool = d.Pool.String()
+		}
+		if d.Gateway != nil {
+			o.Gateway = d.Gateway.String()
+		}
+		if len(d.AuxAddresses) > 0 {
+			o.AuxAddresses = make(map[string]string, len(d.AuxAddresses))
+			for k, v := range d.AuxAddresses {
+				o.AuxAddresses[k] = v.String()
+			}
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+// notify sends the notification over the notifications stream and waits
+// for the plugin to process it. The error the plugin met is stored in perr.
+func (c *grpcClient) notify(ctx context.Context, n *rpc.Notification, perr *string) error {
+	c.Lock()
+	if c.stream == nil {
+		// The stream outlives the call, it is only closed on error.
+		// Opening it fails fast as it cannot be bound by the call timeout.
+		stream, err := c.client.Notify(context.Background(), grpc.WaitForReady(false))
+		if err != nil {
+			c.Unlock()
+			return err
+		}
+		c.stream = stream
+		go c.receiveResults(stream)
+	}
+	c.seq++
+	n.Seq = c.seq
+	result := make(chan string, 1)
+	c.pending[n.Seq] = result
+	err := c.stream.Send(n)
+	if err != nil {
+		delete(c.pending, n.Seq)
+	}
+	c.Unlock()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case e, ok := <-result:
+		if !ok {
+			return fmt.Errorf("notifications stream closed before the plugin processed notification %d", n.Seq)
+		}
+		*perr = e
+		return nil
+	case <-ctx.Done():
+		c.Lock()
+		delete(c.pending, n.Seq)
+		c.Unlock()
+		return ctx.Err()
+	}
+}
+
+// receiveResults dispatches the notification results streamed by the plugin
+// until the stream fails, in which case the pending notifications are failed
+// and the stream is reopened on the next notification.
+func (c *grpcClient) receiveResults(stream rpc.NetworkDriver_NotifyClient) {
+	for {
+		res, err := stream.Recv()
+		c.Lock()
+		if err != nil {
+			if c.stream == stream {
+				c.stream = nil
+			}
+			for seq, result := range c.pending {
+				close(result)
+				delete(c.pending, seq)
+			}
+			c.Unlock()
+			return
+		}
+		if result, ok := c.pending[res.Seq]; ok {
+			result <- res.Err
+			delete(c.pending, res.Seq)
+		}
+		c.Unlock()
+	}
+}
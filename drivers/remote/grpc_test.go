@@ -0,0 +1,331 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/libnetwork/discoverapi"
+	"github.com/docker/libnetwork/driverapi"
+	"github.com/docker/libnetwork/drivers/remote/rpc"
+	"github.com/docker/libnetwork/internal/plugingrpc"
+	"github.com/docker/libnetwork/types"
+	"google.golang.org/grpc"
+)
+
+// grpcPlugin is a managed plugin serving the gRPC protocol
+type grpcPlugin struct {
+	name string
+	addr net.Addr
+}
+
+func (p *grpcPlugin) Name() string               { return p.name }
+func (p *grpcPlugin) ScopedPath(s string) string { return s }
+func (p *grpcPlugin) IsV1() bool                 { return false }
+func (p *grpcPlugin) Client() *plugins.Client    { return nil }
+func (p *grpcPlugin) Addr() net.Addr             { return p.addr }
+func (p *grpcPlugin) Timeout() time.Duration     { return 5 * time.Second }
+func (p *grpcPlugin) Protocol() string           { return rpc.ProtocolSchemeGRPCV1 }
+
+// grpcDriver is an in-process gRPC network plugin
+type grpcDriver struct {
+	rpc.UnimplementedNetworkDriverServer
+	sync.Mutex
+	createNetwork *rpc.CreateNetworkRequest
+	join          *rpc.JoinRequest
+	notifications []*rpc.Notification
+}
+
+func (d *grpcDriver) GetCapabilities(ctx context.Context, req *rpc.GetCapabilityRequest) (*rpc.GetCapabilityResponse, error) {
+	return &rpc.GetCapabilityResponse{Scope: "local", GossipSupport: true}, nil
+}
+
+func (d *grpcDriver) CreateNetwork(ctx context.Context, req *rpc.CreateNetworkRequest) (*rpc.CreateNetworkResponse, error) {
+	d.createNetwork = req
+	return &rpc.CreateNetworkResponse{
+		Tables: []*rpc.TableRegistration{{Name: "peers", ObjectType: uint32(driverapi.EndpointObject)}},
+	}, nil
+}
+
+func (d *grpcDriver) DeleteNetwork(ctx context.Context, req *rpc.DeleteNetworkRequest) (*rpc.DeleteNetworkResponse, error) {
+	return nil, errors.New("network is in use")
+}
+
+func (d *grpcDriver) CreateEndpoint(ctx context.Context, req *rpc.CreateEndpointRequest) (*rpc.CreateEndpointResponse, error) {
+	return &rpc.CreateEndpointResponse{
+		Interface: &rpc.EndpointInterface{MacAddress: "ab:cd:ef:ee:ee:ee"},
+	}, nil
+}
+
+func (d *grpcDriver) EndpointOperInfo(ctx context.Context, req *rpc.EndpointInfoRequest) (*rpc.EndpointInfoResponse, error) {
+	return &rpc.EndpointInfoResponse{Value: []byte(`{"Arbitrary": "key"}`)}, nil
+}
+
+func (d *grpcDriver) Join(ctx context.Context, req *rpc.JoinRequest) (*rpc.JoinResponse, error) {
+	d.join = req
+	return &rpc.JoinResponse{
+		InterfaceName: &rpc.InterfaceName{SrcName: "vethsrc", DstPrefix: "vethdst"},
+		Gateway:       "192.168.0.1",
+		StaticRoutes:  []*rpc.StaticRoute{{Destination: "10.0.0.0/8", RouteType: 1, NextHop: "10.0.0.1"}},
+		TableEntries:  []*rpc.TableEntry{{TableName: "peers", Key: req.EndpointID, Value: []byte("192.168.5.7")}},
+	}, nil
+}
+
+func (d *grpcDriver) Leave(ctx context.Context, req *rpc.LeaveRequest) (*rpc.LeaveResponse, error) {
+	return &rpc.LeaveResponse{}, nil
+}
+
+func (d *grpcDriver) Notify(stream rpc.NetworkDriver_NotifyServer) error {
+	for {
+		n, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		d.Lock()
+		d.notifications = append(d.notifications, n)
+		d.Unlock()
+
+		res := &rpc.NotificationResult{Seq: n.Seq}
+		if dn, ok := n.Notification.(*rpc.Notification_Discovery); ok && dn.Discovery.Delete {
+			res.Err = "unknown node"
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+func setupGRPCPlugin(t *testing.T, name string, srv rpc.NetworkDriverServer) (*grpcPlugin, func()) {
+	dir, err := ioutil.TempDir("", "grpc-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("unix", filepath.Join(dir, name+".sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	rpc.RegisterNetworkDriverServer(s, srv)
+	go s.Serve(l)
+
+	return &grpcPlugin{name: name, addr: l.Addr()}, func() {
+		s.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestGRPCDriver(t *testing.T) {
+	srv := &grpcDriver{}
+	p, cleanup := setupGRPCPlugin(t, "test-grpc-driver", srv)
+	defer cleanup()
+
+	client, err := getPluginClient(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := client.(*grpcClient); !ok {
+		t.Fatalf("Expected a gRPC client for protocol %s, got %T", p.Protocol(), client)
+	}
+	d := newDriver(p.Name(), client)
+
+	c, err := d.(*driver).getCapabilities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.DataScope != "local" || !d.(*driver).gossip {
+		t.Fatalf("Unexpected capability %+v, gossip %v", c, d.(*driver).gossip)
+	}
+
+	_, pool, _ := net.ParseCIDR("192.168.0.0/16")
+	gw := types.GetIPNetCopy(pool)
+	gw.IP = net.ParseIP("192.168.0.1")
+	ipV4Data := []driverapi.IPAMData{{AddressSpace: "local", Pool: pool, Gateway: gw}}
+	nInfo := &testNetwork{}
+	if err := d.CreateNetwork("dummy", map[string]interface{}{"foo": "fooValue"}, nInfo, ipV4Data, nil); err != nil {
+		t.Fatal(err)
+	}
+	req := srv.createNetwork
+	if req.NetworkID != "dummy" || len(req.IPv4Data) != 1 || req.IPv4Data[0].Pool != "192.168.0.0/16" || req.IPv4Data[0].Gateway != "192.168.0.1/16" {
+		t.Fatalf("Unexpected CreateNetwork request %+v", req)
+	}
+	var options map[string]interface{}
+	if err := json.Unmarshal(req.Options, &options); err != nil || options["foo"] != "fooValue" {
+		t.Fatalf("Unexpected CreateNetwork options %s: %v", req.Options, err)
+	}
+	if _, ok := nInfo.tables["peers"]; !ok {
+		t.Fatal("Expected the peers table to be registered")
+	}
+
+	ep := &testEndpoint{
+		t:           t,
+		src:         "vethsrc",
+		dst:         "vethdst",
+		gateway:     "192.168.0.1",
+		destination: "10.0.0.0/8",
+		nextHop:     "10.0.0.1",
+		routeType:   1,
+	}
+	if err := d.CreateEndpoint("dummy", "dummy-ep", ep, nil); err != nil {
+		t.Fatal(err)
+	}
+	if ep.macAddress != "ab:cd:ef:ee:ee:ee" {
+		t.Fatalf("Expected the MAC address set by the plugin, got %q", ep.macAddress)
+	}
+	if err := d.Join("dummy", "dummy-ep", "sbox", ep, map[string]interface{}{"foo": "fooValue"}); err != nil {
+		t.Fatal(err)
+	}
+	if srv.join.SandboxKey != "sbox" {
+		t.Fatalf("Unexpected Join request %+v", srv.join)
+	}
+	if string(ep.tableEntries["peers/dummy-ep"]) != "192.168.5.7" {
+		t.Fatalf("Expected the endpoint table entry, got %v", ep.tableEntries)
+	}
+	info, err := d.EndpointOperInfo("dummy", "dummy-ep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info["Arbitrary"] != "key" {
+		t.Fatalf("Unexpected endpoint info %v", info)
+	}
+	if err := d.Leave("dummy", "dummy-ep"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Methods the plugin does not implement
+	if err := d.RevokeExternalConnectivity("dummy", "dummy-ep"); err != nil {
+		t.Fatalf("Expected the unimplemented method to be ignored, got %v", err)
+	}
+	if err := d.DeleteEndpoint("dummy", "dummy-ep"); !isNotFound(err) {
+		t.Fatalf("Expected a not implemented error, got %v", err)
+	}
+	if err := d.DeleteNetwork("dummy"); err == nil || err.Error() != "remote: network is in use" {
+		t.Fatalf("Expected the plugin error, got %v", err)
+	}
+
+	// Notifications are streamed
+	node := map[string]interface{}{"Address": "10.1.1.1", "self": false}
+	if err := d.DiscoverNew(discoverapi.NodeDiscovery, node); err != nil {
+		t.Fatal(err)
+	}
+	d.EventNotify(driverapi.Create, "dummy", "peers", "other-ep", []byte("192.168.5.8"))
+	if err := d.DiscoverDelete(discoverapi.NodeDiscovery, node); err == nil || err.Error() != "remote: unknown node" {
+		t.Fatalf("Expected the plugin error processing the notification, got %v", err)
+	}
+	srv.Lock()
+	defer srv.Unlock()
+	if len(srv.notifications) != 3 {
+		t.Fatalf("Expected 3 notifications, got %d", len(srv.notifications))
+	}
+	dn, ok := srv.notifications[0].Notification.(*rpc.Notification_Discovery)
+	if !ok || dn.Discovery.Delete || dn.Discovery.DiscoveryType != uint32(discoverapi.NodeDiscovery) {
+		t.Fatalf("Unexpected discovery notification %+v", srv.notifications[0])
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(dn.Discovery.DiscoveryData, &data); err != nil || data["Address"] != "10.1.1.1" {
+		t.Fatalf("Unexpected discovery data %s: %v", dn.Discovery.DiscoveryData, err)
+	}
+	en, ok := srv.notifications[1].Notification.(*rpc.Notification_Event)
+	if !ok || en.Event.Key != "other-ep" || en.Event.EventType != uint32(driverapi.Create) {
+		t.Fatalf("Unexpected event notification %+v", srv.notifications[1])
+	}
+}
+
+func TestGRPCDriverNotifyReconnect(t *testing.T) {
+	srv := &grpcDriver{}
+	p, cleanup := setupGRPCPlugin(t, "test-grpc-driver-reconnect", srv)
+	defer cleanup()
+
+	client, err := getPluginClient(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newDriver(p.Name(), client)
+	if _, err := d.(*driver).getCapabilities(); err != nil {
+		t.Fatal(err)
+	}
+	node := map[string]interface{}{"Address": "10.1.1.1"}
+	if err := d.DiscoverNew(discoverapi.NodeDiscovery, node); err != nil {
+		t.Fatal(err)
+	}
+
+	// The stream ends, as on a plugin restart
+	client.(*grpcClient).Lock()
+	stream := client.(*grpcClient).stream
+	client.(*grpcClient).Unlock()
+	stream.CloseSend()
+
+	for i := 0; ; i++ {
+		err := d.DiscoverNew(discoverapi.NodeDiscovery, node)
+		if err == nil {
+			break
+		}
+		if i == 50 {
+			t.Fatalf("Expected the notifications stream to be reopened: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestGRPCAddress(t *testing.T) {
+	p, cleanup := setupGRPCPlugin(t, "test-grpc-address", &grpcDriver{})
+	defer cleanup()
+
+	defer func(dir string) { plugingrpc.SocketsDir = dir }(plugingrpc.SocketsDir)
+	plugingrpc.SocketsDir = filepath.Dir(p.addr.String())
+
+	for _, tc := range []struct {
+		plugin     string
+		implements []string
+		addr       string
+		grpc       bool
+		fails      bool
+	}{
+		// The manifest does not declare the gRPC protocol scheme
+		{plugin: "test-net-grpc-undeclared", addr: p.addr.String()},
+		{plugin: "test-net-grpc-outside", implements: []string{rpc.ProtocolSchemeGRPCV1}, addr: "/var/run/other.sock", fails: true},
+		{plugin: "test-net-grpc-declared", implements: []string{rpc.ProtocolSchemeGRPCV1}, addr: p.addr.String(), grpc: true},
+	} {
+		mux := http.NewServeMux()
+		cleanupPlugin := setupPlugin(t, tc.plugin, mux, tc.implements...)
+		handle(t, mux, "GetCapabilities", func(msg map[string]interface{}) interface{} {
+			return map[string]interface{}{"Scope": "local", "GRPCAddress": tc.addr}
+		})
+
+		pl, err := plugins.Get(tc.plugin, driverapi.NetworkPluginEndpointType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client, err := getPluginClient(pl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := newDriver(tc.plugin, client).(*driver)
+		_, err = d.getCapabilities()
+		cleanupPlugin()
+		if tc.fails {
+			if err == nil {
+				t.Fatalf("%s: expected failure on gRPC socket %s", tc.plugin, tc.addr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", tc.plugin, err)
+		}
+		if _, ok := d.endpoint.(*grpcClient); ok != tc.grpc {
+			t.Fatalf("%s: unexpected plugin client %T", tc.plugin, d.endpoint)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+// Package rpc defines the gRPC protocol of the network driver plugins.
+package rpc
+
+import "github.com/docker/libnetwork/internal/plugingrpc"
+
+// ProtocolSchemeGRPCV1 is the protocol scheme of the managed plugins
+// serving the gRPC NetworkDriver protocol on their socket.
+const ProtocolSchemeGRPCV1 = plugingrpc.ProtocolSchemeV1
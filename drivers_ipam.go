@@ -4,17 +4,24 @@ import (
 	"github.com/docker/libnetwork/drvregistry"
 	"github.com/docker/libnetwork/ipamapi"
 	builtinIpam "github.com/docker/libnetwork/ipams/builtin"
+	dhcpIpam "github.com/docker/libnetwork/ipams/dhcp"
+	dhcppdIpam "github.com/docker/libnetwork/ipams/dhcppd"
 	nullIpam "github.com/docker/libnetwork/ipams/null"
 	remoteIpam "github.com/docker/libnetwork/ipams/remote"
 	"github.com/docker/libnetwork/ipamutils"
 )
 
-func initIPAMDrivers(r *drvregistry.DrvRegistry, lDs, gDs interface{}, addressPool []*ipamutils.NetworkToSplit) error {
+func initIPAMDrivers(r *drvregistry.DrvRegistry, lDs, gDs interface{}, addressPool []*ipamutils.NetworkToSplit, remoteCfg map[string]interface{}) error {
 	builtinIpam.SetDefaultIPAddressPool(addressPool)
+	if err := remoteIpam.SetCallConfig(remoteCfg); err != nil {
+		return err
+	}
 	for _, fn := range [](func(ipamapi.Callback, interface{}, interface{}) error){
 		builtinIpam.Init,
 		remoteIpam.Init,
 		nullIpam.Init,
+		dhcpIpam.Init,
+		dhcppdIpam.Init,
 	} {
 		if err := fn(r, lDs, gDs); err != nil {
 			return err
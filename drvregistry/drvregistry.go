@@ -73,6 +73,7 @@ func (r *DrvRegistry) AddDriver(ntype string, fn InitFunc, config map[string]int
 }
 
 // WalkIPAMs walks the IPAM drivers registered in the registry and invokes the passed walk function and each one of them.
+// The capability of the drivers tracking their health carries their current health.
 func (r *DrvRegistry) WalkIPAMs(ifn IPAMWalkFunc) {
 	type ipamVal struct {
 		name string
@@ -87,13 +88,21 @@ func (r *DrvRegistry) WalkIPAMs(ifn IPAMWalkFunc) {
 	r.Unlock()
 
 	for _, iv := range ivl {
-		if ifn(iv.name, iv.data.driver, iv.data.capability) {
+		capability := iv.data.capability
+		if hc, ok := iv.data.driver.(ipamapi.HealthChecker); ok {
+			c := *capability
+			h := hc.Health()
+			c.Health = &h
+			capability = &c
+		}
+		if ifn(iv.name, iv.data.driver, capability) {
 			break
 		}
 	}
 }
 
 // WalkDrivers walks the network drivers registered in the registry and invokes the passed walk function and each one of them.
+// The capability of the drivers tracking their health carries their current health.
 func (r *DrvRegistry) WalkDrivers(dfn DriverWalkFunc) {
 	type driverVal struct {
 		name string
@@ -108,7 +117,12 @@ func (r *DrvRegistry) WalkDrivers(dfn DriverWalkFunc) {
 	r.Unlock()
 
 	for _, dv := range dvl {
-		if dfn(dv.name, dv.data.driver, dv.data.capability) {
+		capability := dv.data.capability
+		if hc, ok := dv.data.driver.(driverapi.HealthChecker); ok {
+			h := hc.Health()
+			capability.Health = &h
+		}
+		if dfn(dv.name, dv.data.driver, capability) {
 			break
 		}
 	}
@@ -11,6 +11,7 @@ import (
 	builtinIpam "github.com/docker/libnetwork/ipams/builtin"
 	nullIpam "github.com/docker/libnetwork/ipams/null"
 	remoteIpam "github.com/docker/libnetwork/ipams/remote"
+	"github.com/docker/libnetwork/types"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 
@@ -198,3 +199,31 @@ func TestWalkDrivers(t *testing.T) {
 
 	assert.Check(t, is.Equal(driverName, mockDriverName))
 }
+
+type healthyDriver struct {
+	mockDriver
+}
+
+func (h *healthyDriver) Health() types.PluginHealth {
+	return types.PluginHealth{Failures: 3, LastError: "timeout"}
+}
+
+func TestWalkDriversHealth(t *testing.T) {
+	reg := getNew(t)
+
+	err := reg.RegisterDriver("healthy", &healthyDriver{}, driverapi.Capability{DataScope: datastore.LocalScope})
+	assert.NilError(t, err)
+	err = reg.AddDriver(mockDriverName, mockDriverInit, nil)
+	assert.NilError(t, err)
+
+	health := make(map[string]*types.PluginHealth)
+	reg.WalkDrivers(func(name string, driver driverapi.Driver, capability driverapi.Capability) bool {
+		health[name] = capability.Health
+		return false
+	})
+
+	assert.Check(t, is.Nil(health[mockDriverName]))
+	assert.Assert(t, health["healthy"] != nil)
+	assert.Check(t, is.Equal(health["healthy"].Failures, 3))
+	assert.Check(t, is.Equal(health["healthy"].LastError, "timeout"))
+}
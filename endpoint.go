@@ -4,10 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/docker/libnetwork/dad"
 	"github.com/docker/libnetwork/datastore"
+	"github.com/docker/libnetwork/driverapi"
 	"github.com/docker/libnetwork/ipamapi"
 	"github.com/docker/libnetwork/netlabel"
 	"github.com/docker/libnetwork/options"
@@ -41,6 +44,13 @@ type Endpoint interface {
 
 	// Delete and detaches this endpoint from the network.
 	Delete(force bool) error
+
+	// Mirror clones the traffic of the endpoint interface in its sandbox to
+	// the target, until Unmirror is called or the endpoint leaves the sandbox.
+	Mirror(target MirrorTarget) error
+
+	// Unmirror stops the mirroring of the endpoint traffic.
+	Unmirror() error
 }
 
 // EndpointOption is an option setter function type used to pass various options to Network
@@ -75,6 +85,7 @@ type endpoint struct {
 	dbExists          bool
 	serviceEnabled    bool
 	loadBalancer      bool
+	readvertise       bool // driver table entries may be advertised by another node
 	sync.Mutex
 }
 
@@ -439,6 +450,8 @@ func (ep *endpoint) Join(sbox Sandbox, options ...EndpointOption) error {
 	sb.joinLeaveStart()
 	defer sb.joinLeaveEnd()
 
+	defer ep.schedule(jlJoin)()
+
 	return ep.sbJoin(sb, options...)
 }
 
@@ -463,13 +476,14 @@ func (ep *endpoint) sbJoin(sb *sandbox, options ...EndpointOption) (err error) {
 	ep.joinInfo = &endpointJoinInfo{}
 	epid := ep.id
 	ep.Unlock()
-	defer func() {
-		if err != nil {
-			ep.Lock()
-			ep.sandboxID = ""
-			ep.Unlock()
-		}
-	}()
+
+	j := n.getController().newJournal(opJoin, n, epid, sb.ID())
+	defer func() { j.finish(err) }()
+	j.undo(func() {
+		ep.Lock()
+		ep.sandboxID = ""
+		ep.Unlock()
+	})
 
 	nid := n.ID()
 
@@ -480,24 +494,33 @@ func (ep *endpoint) sbJoin(sb *sandbox, options ...EndpointOption) (err error) {
 		return fmt.Errorf("failed to get driver during join: %v", err)
 	}
 
-	err = d.Join(nid, epid, sb.Key(), ep, sb.Labels())
-	if err != nil {
+	if err = j.record(stepDriverJoin, nil); err != nil {
 		return err
 	}
-	defer func() {
-		if err != nil {
+	err = d.Join(nid, epid, sb.Key(), ep, sb.Labels())
+	if err != nil {
+		if _, ok := err.(types.TimeoutError); ok {
+			// The driver may have joined the endpoint before the call timed out
 			if e := d.Leave(nid, epid); e != nil {
-				logrus.Warnf("driver leave failed while rolling back join: %v", e)
+				logrus.Debugf("driver leave failed after join timed out: %v", e)
 			}
 		}
-	}()
+		return err
+	}
+	j.undo(func() {
+		if e := d.Leave(nid, epid); e != nil {
+			logrus.Warnf("driver leave failed while rolling back join: %v", e)
+		}
+	})
 
 	// Watch for service records
 	if !n.getController().isAgent() {
 		n.getController().watchSvcRecord(ep)
 	}
 
-	if doUpdateHostsFile(n, sb) {
+	// The hosts file of a sandbox replacing an endpoint is updated once
+	// the replacement completes
+	if doUpdateHostsFile(n, sb) && sb.replacement(ep) == nil {
 		var addresses []string
 		if ip := ep.getFirstInterfaceIPv4Address(); ip != nil {
 			addresses = append(addresses, ip.String())
@@ -517,40 +540,60 @@ func (ep *endpoint) sbJoin(sb *sandbox, options ...EndpointOption) (err error) {
 	extEp := sb.getGatewayEndpoint()
 
 	sb.addEndpoint(ep)
-	defer func() {
-		if err != nil {
-			sb.removeEndpoint(ep)
+	j.undo(func() {
+		sb.removeEndpoint(ep)
+	})
+
+	// The interfaces and routes of the endpoint are removed from the
+	// sandbox, including the ones left by a partial population
+	j.undo(func() {
+		sb.Lock()
+		osSbox := sb.osSbox
+		delete(sb.populatedEndpoints, ep.ID())
+		sb.Unlock()
+		if osSbox != nil {
+			releaseOSSboxResources(osSbox, ep)
 		}
-	}()
-
+	})
 	if err = sb.populateNetworkResources(ep); err != nil {
 		return err
 	}
 
+	if err = j.record(stepStoreJoin, nil); err != nil {
+		return err
+	}
 	if err = n.getController().updateToStore(ep); err != nil {
 		return err
 	}
+	j.undo(func() {
+		ep.Lock()
+		ep.sandboxID = ""
+		ep.Unlock()
+		if e := n.getController().updateToStore(ep); e != nil {
+			logrus.Warnf("Failed to detach endpoint %s from sandbox %s in store on join failure: %v", ep.Name(), sb.ID(), e)
+		}
+	})
 
 	if err = ep.addDriverInfoToCluster(); err != nil {
 		return err
 	}
-
-	defer func() {
-		if err != nil {
-			if e := ep.deleteDriverInfoFromCluster(); e != nil {
-				logrus.Errorf("Could not delete endpoint state for endpoint %s from cluster on join failure: %v", ep.Name(), e)
-			}
+	j.undo(func() {
+		if e := ep.deleteDriverInfoFromCluster(); e != nil {
+			logrus.Errorf("Could not delete endpoint state for endpoint %s from cluster on join failure: %v", ep.Name(), e)
 		}
-	}()
+	})
 
 	// Load balancing endpoints should never have a default gateway nor
 	// should they alter the status of a network's default gateway
 	if ep.loadBalancer && !sb.ingress {
-		return nil
+		return j.commit()
 	}
 
 	if sb.needDefaultGW() && sb.getEndpointInGWNetwork() == nil {
-		return sb.setupDefaultGW()
+		if err = sb.setupDefaultGW(); err != nil {
+			return err
+		}
+		return j.commit()
 	}
 
 	moveExtConn := sb.getGatewayEndpoint() != extEp
@@ -571,22 +614,29 @@ func (ep *endpoint) sbJoin(sb *sandbox, options ...EndpointOption) (err error) {
 					"driver failed revoking external connectivity on endpoint %s (%s): %v",
 					extEp.Name(), extEp.ID(), err)
 			}
-			defer func() {
-				if err != nil {
-					if e := extD.ProgramExternalConnectivity(extEp.network.ID(), extEp.ID(), sb.Labels()); e != nil {
-						logrus.Warnf("Failed to roll-back external connectivity on endpoint %s (%s): %v",
-							extEp.Name(), extEp.ID(), e)
-					}
+			j.undo(func() {
+				if e := extD.ProgramExternalConnectivity(extEp.network.ID(), extEp.ID(), sb.Labels()); e != nil {
+					logrus.Warnf("Failed to roll-back external connectivity on endpoint %s (%s): %v",
+						extEp.Name(), extEp.ID(), e)
 				}
-			}()
+			})
 		}
 		if !n.internal {
+			if err = j.record(stepExternalConnectivity, nil); err != nil {
+				return err
+			}
 			logrus.Debugf("Programming external connectivity on endpoint %s (%s)", ep.Name(), ep.ID())
 			if err = d.ProgramExternalConnectivity(n.ID(), ep.ID(), sb.Labels()); err != nil {
 				return types.InternalErrorf(
 					"driver failed programming external connectivity on endpoint %s (%s): %v",
 					ep.Name(), ep.ID(), err)
 			}
+			j.undo(func() {
+				if e := d.RevokeExternalConnectivity(n.ID(), ep.ID()); e != nil {
+					logrus.Warnf("Failed to revoke external connectivity on endpoint %s (%s) on join failure: %v",
+						ep.Name(), ep.ID(), e)
+				}
+			})
 		}
 
 	}
@@ -598,7 +648,7 @@ func (ep *endpoint) sbJoin(sb *sandbox, options ...EndpointOption) (err error) {
 		}
 	}
 
-	return nil
+	return j.commit()
 }
 
 func doUpdateHostsFile(n *network, sb *sandbox) bool {
@@ -688,7 +738,17 @@ func (ep *endpoint) hasInterface(iName string) bool {
 	ep.Lock()
 	defer ep.Unlock()
 
-	return ep.iface != nil && ep.iface.srcName == iName
+	if ep.iface != nil && ep.iface.srcName == iName {
+		return true
+	}
+	if ep.joinInfo != nil {
+		for _, i := range ep.joinInfo.extraIfaces {
+			if i.srcName == iName {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func (ep *endpoint) Leave(sbox Sandbox, options ...EndpointOption) error {
@@ -704,6 +764,8 @@ func (ep *endpoint) Leave(sbox Sandbox, options ...EndpointOption) error {
 	sb.joinLeaveStart()
 	defer sb.joinLeaveEnd()
 
+	defer ep.schedule(jlLeave)()
+
 	return ep.sbLeave(sb, false, options...)
 }
 
@@ -1070,11 +1132,28 @@ func JoinOptionPriority(prio int) EndpointOption {
 	}
 }
 
+// endpointOptionID sets the ID of a restored endpoint, for it to keep the
+// identity it had when checkpointed
+func endpointOptionID(id string) EndpointOption {
+	return func(ep *endpoint) {
+		ep.id = id
+	}
+}
+
+// joinOptionReadvertise lets the driver table entries of a restored
+// endpoint take over the ones still advertised by the node it was
+// checkpointed on
+func joinOptionReadvertise() EndpointOption {
+	return func(ep *endpoint) {
+		ep.readvertise = true
+	}
+}
+
 func (ep *endpoint) DataScope() string {
 	return ep.getNetwork().DataScope()
 }
 
-func (ep *endpoint) assignAddress(ipam ipamapi.Ipam, assignIPv4, assignIPv6 bool) error {
+func (ep *endpoint) assignAddress(assignIPv4, assignIPv6 bool) error {
 	var err error
 
 	n := ep.getNetwork()
@@ -1085,19 +1164,29 @@ func (ep *endpoint) assignAddress(ipam ipamapi.Ipam, assignIPv4, assignIPv6 bool
 	logrus.Debugf("Assigning addresses for endpoint %s's interface on network %s", ep.Name(), n.Name())
 
 	if assignIPv4 {
-		if err = ep.assignAddressVersion(4, ipam); err != nil {
+		if err = ep.assignAddressVersion(4); err != nil {
 			return err
 		}
 	}
 
 	if assignIPv6 {
-		err = ep.assignAddressVersion(6, ipam)
+		err = ep.assignAddressVersion(6)
 	}
 
 	return err
 }
 
-func (ep *endpoint) assignAddressVersion(ipVer int, ipam ipamapi.Ipam) error {
+const (
+	// maxAddressConflicts bounds the number of addresses found in use on
+	// the link of a network before giving up on assigning one
+	maxAddressConflicts = 8
+
+	// externalAddressPrefix prefixes the auxiliary address entries of the
+	// addresses found in use on the link of a network
+	externalAddressPrefix = "external-"
+)
+
+func (ep *endpoint) assignAddressVersion(ipVer int) error {
 	var (
 		poolID  *string
 		address **net.IPNet
@@ -1134,11 +1223,21 @@ func (ep *endpoint) assignAddressVersion(ipVer int, ipam ipamapi.Ipam) error {
 		progAdd = (*address).IP
 	}
 
+	// Only the addresses picked by the ipam driver are probed
+	var probeIf string
+	if progAdd == nil {
+		probeIf = n.probeInterface()
+	}
+
 	for _, d := range ipInfo {
 		if progAdd != nil && !d.Pool.Contains(progAdd) {
 			continue
 		}
-		addr, _, err := ipam.RequestAddress(d.PoolID, progAdd, ep.ipamOptions)
+		ipam, _, err := n.getController().getIPAMDriver(n.infoDriverName(d))
+		if err != nil {
+			return err
+		}
+		addr, err := ep.requestAddress(ipam, ipVer, d, progAdd, probeIf)
 		if err == nil {
 			ep.Lock()
 			*address = addr
@@ -1156,6 +1255,202 @@ func (ep *endpoint) assignAddressVersion(ipVer int, ipam ipamapi.Ipam) error {
 	return fmt.Errorf("no available IPv%d addresses on this network's address pools: %s (%s)", ipVer, n.Name(), n.ID())
 }
 
+// requestAddress requests an address from the pool of d. With a probe
+// interface, the address is probed on its link first: the addresses found
+// in use are kept allocated as auxiliary addresses of the network and
+// another one is requested.
+func (ep *endpoint) requestAddress(ipam ipamapi.Ipam, ipVer int, d *IpamInfo, progAdd net.IP, probeIf string) (*net.IPNet, error) {
+	n := ep.getNetwork()
+	for i := 0; ; i++ {
+		addr, _, err := ipam.RequestAddress(d.PoolID, progAdd, ep.ipamOptions)
+		if err != nil || probeIf == "" {
+			return addr, err
+		}
+		inUse, err := dad.Probe(probeIf, addr.IP, dad.DefaultConfig)
+		if err != nil {
+			logrus.Warnf("Failed to probe address %s on %s for endpoint %s: %v", addr.IP, probeIf, ep.Name(), err)
+			return addr, nil
+		}
+		if !inUse {
+			return addr, nil
+		}
+		logrus.Warnf("Address %s of network %s is in use on %s, marking it as externally used", addr.IP, n.Name(), probeIf)
+		n.markExternalAddress(ipVer, d, addr)
+		if i == maxAddressConflicts-1 {
+			return nil, types.ForbiddenErrorf("%d addresses in a row of network %s were found in use on %s", maxAddressConflicts, n.Name(), probeIf)
+		}
+	}
+}
+
+// probeInterface returns the host interface the addresses of the network
+// are probed from before being assigned to the endpoints, or the empty
+// string if duplicate address detection is not enabled on the network or
+// not supported by its driver
+func (n *network) probeInterface() string {
+	n.Lock()
+	enabled, _ := strconv.ParseBool(n.ipamOptions[netlabel.DuplicateAddressDetection])
+	n.Unlock()
+	if !enabled {
+		return ""
+	}
+	d, err := n.driver(true)
+	if err != nil {
+		logrus.Warnf("Failed to get driver of network %s for duplicate address detection: %v", n.Name(), err)
+		return ""
+	}
+	p, ok := d.(driverapi.AddressProber)
+	if !ok {
+		return ""
+	}
+	ifName, err := p.ProbeInterface(n.ID())
+	if err != nil {
+		logrus.Warnf("Failed to get the interface of network %s for duplicate address detection: %v", n.Name(), err)
+		return ""
+	}
+	return ifName
+}
+
+// markExternalAddress records the address, allocated from the pool of d,
+// as an auxiliary address of the network for it to stay allocated until
+// the network is deleted, including across restarts
+func (n *network) markExternalAddress(ipVer int, d *IpamInfo, addr *net.IPNet) {
+	key := externalAddressPrefix + addr.IP.String()
+
+	n.Lock()
+	cfgList, infoList := n.ipamV4Config, n.ipamV4Info
+	if ipVer == 6 {
+		cfgList, infoList = n.ipamV6Config, n.ipamV6Info
+	}
+	if d.IPAMData.AuxAddresses == nil {
+		d.IPAMData.AuxAddresses = make(map[string]*net.IPNet)
+	}
+	d.IPAMData.AuxAddresses[key] = addr
+	for i, info := range infoList {
+		if info.PoolID != d.PoolID || i >= len(cfgList) {
+			continue
+		}
+		if cfgList[i].AuxAddresses == nil {
+			cfgList[i].AuxAddresses = make(map[string]string)
+		}
+		cfgList[i].AuxAddresses[key] = addr.IP.String()
+	}
+	n.Unlock()
+
+	if err := n.getController().updateToStore(n); err != nil {
+		logrus.Warnf("Failed to store externally used address %s of network %s: %v", addr.IP, n.Name(), err)
+	}
+}
+
+// reserveAddresses reserves the current addresses of the endpoints on
+// daemon start replay, from the pools of the IPAM drivers requiring it. The
+// addresses of a driver are reserved in a single batch when it supports it.
+func (n *network) reserveAddresses(epl []*endpoint) {
+	if n.hasSpecialDriver() {
+		return
+	}
+
+	type reservation struct {
+		ep      *endpoint
+		ipVer   int
+		poolID  *string
+		address **net.IPNet
+		prefAdd net.IP
+	}
+	type batch struct {
+		bipam ipamapi.BatchIpam
+		reqs  []ipamapi.AddressRequest
+		rsvs  []reservation
+	}
+	var (
+		batches []*batch
+		byName  = make(map[string]*batch)
+	)
+	for _, ep := range epl {
+		if ep.Iface() == nil {
+			logrus.Warnf("endpoint interface is empty for %q (%s)", ep.Name(), ep.ID())
+			continue
+		}
+		rsvl := []reservation{{ep, 4, &ep.iface.v4PoolID, &ep.iface.addr, ep.prefAddress}}
+		if ep.iface.addrv6 != nil {
+			rsvl = append(rsvl, reservation{ep, 6, &ep.iface.v6PoolID, &ep.iface.addrv6, ep.prefAddressV6})
+		}
+		for _, r := range rsvl {
+			progAdd := r.prefAdd
+			if progAdd == nil && *r.address != nil {
+				progAdd = (*r.address).IP
+			}
+			if progAdd == nil {
+				// No address to replay, let the endpoint get a new one
+				n.reserveAddress(ep, r.ipVer)
+				continue
+			}
+			var pool *IpamInfo
+			for _, d := range n.getIPInfo(r.ipVer) {
+				if d.Pool.Contains(progAdd) {
+					pool = d
+					break
+				}
+			}
+			if pool == nil {
+				logrus.Warnf("Failed to reserve current address %s for endpoint %q (%s): it does not belong to any of the subnets of network %q (%s)",
+					progAdd, ep.Name(), ep.ID(), n.Name(), n.ID())
+				continue
+			}
+
+			name := n.infoDriverName(pool)
+			ipam, caps, err := n.getController().getIPAMDriver(name)
+			if err != nil {
+				logrus.Warnf("Failed to retrieve ipam driver %s to reserve current address %s for endpoint %q (%s): %v",
+					name, progAdd, ep.Name(), ep.ID(), err)
+				continue
+			}
+			// The other drivers still hold the address
+			if !caps.RequiresRequestReplay {
+				continue
+			}
+			bipam, ok := ipam.(ipamapi.BatchIpam)
+			if !ok {
+				n.reserveAddress(ep, r.ipVer)
+				continue
+			}
+			req := ipamapi.AddressRequest{PoolID: pool.PoolID, Address: progAdd, Options: ep.ipamOptions}
+			b, ok := byName[name]
+			if !ok {
+				b = &batch{bipam: bipam}
+				byName[name] = b
+				batches = append(batches, b)
+			}
+			b.reqs = append(b.reqs, req)
+			b.rsvs = append(b.rsvs, r)
+		}
+	}
+
+	for _, b := range batches {
+		for i, res := range b.bipam.RequestAddresses(b.reqs) {
+			r := b.rsvs[i]
+			if res.Err != nil {
+				logrus.Debugf("Batch reservation of the current address of endpoint %q (%s) failed, retrying it alone: %v",
+					r.ep.Name(), r.ep.ID(), res.Err)
+				n.reserveAddress(r.ep, r.ipVer)
+				continue
+			}
+			r.ep.Lock()
+			*r.address = res.Address
+			*r.poolID = b.reqs[i].PoolID
+			r.ep.Unlock()
+		}
+	}
+}
+
+// reserveAddress reserves the current address of the ip version of the
+// endpoint the way it is assigned
+func (n *network) reserveAddress(ep *endpoint, ipVer int) {
+	if err := ep.assignAddressVersion(ipVer); err != nil {
+		logrus.Warnf("Failed to reserve current address for endpoint %q (%s) on network %q (%s): %v",
+			ep.Name(), ep.ID(), n.Name(), n.ID(), err)
+	}
+}
+
 func (ep *endpoint) releaseAddress() {
 	n := ep.getNetwork()
 	if n.hasSpecialDriver() {
@@ -1164,26 +1459,105 @@ func (ep *endpoint) releaseAddress() {
 
 	logrus.Debugf("Releasing addresses for endpoint %s's interface on network %s", ep.Name(), n.Name())
 
-	ipam, _, err := n.getController().getIPAMDriver(n.ipamType)
+	// The addresses handed over to a replacing endpoint are no longer
+	// tied to a pool of this endpoint
+	if ep.iface.addr != nil && ep.iface.v4PoolID != "" {
+		ep.releasePoolAddress(4, ep.iface.v4PoolID, ep.iface.addr.IP)
+	}
+
+	if ep.iface.addrv6 != nil && ep.iface.addrv6.IP.IsGlobalUnicast() && ep.iface.v6PoolID != "" {
+		ep.releasePoolAddress(6, ep.iface.v6PoolID, ep.iface.addrv6.IP)
+	}
+}
+
+// journalAddresses returns the addresses of the endpoint of the requested
+// versions to record in the journal of its creation
+func (ep *endpoint) journalAddresses(ipv4, ipv6 bool) []journalAddress {
+	n := ep.getNetwork()
+	var addrs []journalAddress
+	if ipv4 && ep.iface.addr != nil && ep.iface.v4PoolID != "" {
+		addrs = append(addrs, journalAddress{Driver: n.poolDriverName(4, ep.iface.v4PoolID), PoolID: ep.iface.v4PoolID, Address: ep.iface.addr.IP})
+	}
+	if ipv6 && ep.iface.addrv6 != nil && ep.iface.addrv6.IP.IsGlobalUnicast() && ep.iface.v6PoolID != "" {
+		addrs = append(addrs, journalAddress{Driver: n.poolDriverName(6, ep.iface.v6PoolID), PoolID: ep.iface.v6PoolID, Address: ep.iface.addrv6.IP})
+	}
+	return addrs
+}
+
+// releasePoolAddress releases the address of the endpoint to the IPAM driver
+// its pool was requested from
+func (ep *endpoint) releasePoolAddress(ipVer int, poolID string, ip net.IP) {
+	ipam, err := ep.getNetwork().ipamDriverForPool(ipVer, poolID)
 	if err != nil {
-		logrus.Warnf("Failed to retrieve ipam driver to release interface address on delete of endpoint %s (%s): %v", ep.Name(), ep.ID(), err)
+		logrus.Warnf("Failed to retrieve ipam driver to release ip address %s of endpoint %s (%s): %v", ip, ep.Name(), ep.ID(), err)
 		return
 	}
+	if err := ipam.ReleaseAddress(poolID, ip); err != nil {
+		logrus.Warnf("Failed to release ip address %s of endpoint %s (%s): %v", ip, ep.Name(), ep.ID(), err)
+	}
+}
 
-	if ep.iface.addr != nil {
-		if err := ipam.ReleaseAddress(ep.iface.v4PoolID, ep.iface.addr.IP); err != nil {
-			logrus.Warnf("Failed to release ip address %s on delete of endpoint %s (%s): %v", ep.iface.addr.IP, ep.Name(), ep.ID(), err)
-		}
+// resetAddresses sets the addresses and the MAC address of the endpoint
+// interface, creating the driver endpoint again for the driver to program
+// them. The addresses are not allocated from IPAM.
+func (ep *endpoint) resetAddresses(addr, addrv6 *net.IPNet, mac net.HardwareAddr) error {
+	n := ep.getNetwork()
+	d, err := n.driver(true)
+	if err != nil {
+		return fmt.Errorf("failed to get driver while resetting the addresses of endpoint %s: %v", ep.Name(), err)
+	}
+	if err := d.DeleteEndpoint(n.ID(), ep.ID()); err != nil {
+		return types.InternalErrorf("failed to delete endpoint %s on network %s: %v", ep.Name(), n.Name(), err)
+	}
+
+	ep.Lock()
+	ep.iface.addr = types.GetIPNetCopy(addr)
+	ep.iface.addrv6 = types.GetIPNetCopy(addrv6)
+	ep.iface.mac = types.GetMacCopy(mac)
+	ep.Unlock()
+
+	if err := n.addEndpoint(ep); err != nil {
+		return err
+	}
+	return n.getController().updateToStore(ep)
+}
+
+// handOverAddresses releases the addresses ep held before taking over the
+// ones of the endpoint old it replaced, and unties the latter from their
+// pools so that old does not release them on delete.
+func (ep *endpoint) handOverAddresses(old *endpoint, prev *endpointInterface) {
+	n := ep.getNetwork()
+	old, err := n.getEndpointFromStore(old.ID())
+	if err != nil {
+		logrus.Warnf("Failed to get endpoint %s from store while handing its addresses over: %v", old.ID(), err)
+		return
 	}
 
-	if ep.iface.addrv6 != nil && ep.iface.addrv6.IP.IsGlobalUnicast() {
-		if err := ipam.ReleaseAddress(ep.iface.v6PoolID, ep.iface.addrv6.IP); err != nil {
-			logrus.Warnf("Failed to release ip address %s on delete of endpoint %s (%s): %v", ep.iface.addrv6.IP, ep.Name(), ep.ID(), err)
+	ep.Lock()
+	addr, addrv6 := ep.iface.addr, ep.iface.addrv6
+	ep.Unlock()
+
+	old.Lock()
+	if prev.addr != nil && !types.CompareIPNet(prev.addr, addr) {
+		ep.releasePoolAddress(4, prev.v4PoolID, prev.addr.IP)
+		old.iface.v4PoolID = ""
+	}
+	if prev.addrv6 != nil && !types.CompareIPNet(prev.addrv6, addrv6) {
+		if prev.addrv6.IP.IsGlobalUnicast() {
+			ep.releasePoolAddress(6, prev.v6PoolID, prev.addrv6.IP)
 		}
+		old.iface.v6PoolID = ""
+	}
+	old.Unlock()
+
+	if err := n.getController().updateToStore(old); err != nil {
+		logrus.Warnf("Failed to update endpoint %s (%s) in store after handing its addresses over: %v", old.Name(), old.ID(), err)
 	}
 }
 
 func (c *controller) cleanupLocalEndpoints() {
+	c.replayCreateJournals()
+
 	// Get used endpoints
 	eps := make(map[string]interface{})
 	for _, sb := range c.sandboxes {
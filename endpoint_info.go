@@ -64,6 +64,7 @@ type endpointInterface struct {
 	routes    []*net.IPNet
 	v4PoolID  string
 	v6PoolID  string
+	options   driverapi.InterfaceOptions
 }
 
 func (epi *endpointInterface) MarshalJSON() ([]byte, error) {
@@ -93,6 +94,15 @@ func (epi *endpointInterface) MarshalJSON() ([]byte, error) {
 	epMap["routes"] = routes
 	epMap["v4PoolID"] = epi.v4PoolID
 	epMap["v6PoolID"] = epi.v6PoolID
+	if epi.options.HostDevice {
+		epMap["hostDevice"] = true
+	}
+	if epi.options.KeepName {
+		epMap["keepName"] = true
+	}
+	if epi.options.KeepMAC {
+		epMap["keepMAC"] = true
+	}
 	return json.Marshal(epMap)
 }
 
@@ -146,6 +156,15 @@ func (epi *endpointInterface) UnmarshalJSON(b []byte) error {
 	}
 	epi.v4PoolID = epMap["v4PoolID"].(string)
 	epi.v6PoolID = epMap["v6PoolID"].(string)
+	if v, ok := epMap["hostDevice"]; ok {
+		epi.options.HostDevice = v.(bool)
+	}
+	if v, ok := epMap["keepName"]; ok {
+		epi.options.KeepName = v.(bool)
+	}
+	if v, ok := epMap["keepMAC"]; ok {
+		epi.options.KeepMAC = v.(bool)
+	}
 
 	return nil
 }
@@ -158,6 +177,7 @@ func (epi *endpointInterface) CopyTo(dstEpi *endpointInterface) error {
 	dstEpi.dstPrefix = epi.dstPrefix
 	dstEpi.v4PoolID = epi.v4PoolID
 	dstEpi.v6PoolID = epi.v6PoolID
+	dstEpi.options = epi.options
 	if len(epi.llAddrs) != 0 {
 		dstEpi.llAddrs = make([]*net.IPNet, 0, len(epi.llAddrs))
 		dstEpi.llAddrs = append(dstEpi.llAddrs, epi.llAddrs...)
@@ -176,6 +196,9 @@ type endpointJoinInfo struct {
 	StaticRoutes          []*types.StaticRoute
 	driverTableEntries    []*tableEntry
 	disableGatewayService bool
+	// extraIfaces are the interfaces the driver added to the sandbox in
+	// addition to the endpoint interface
+	extraIfaces []*endpointInterface
 }
 
 type tableEntry struct {
@@ -312,6 +335,35 @@ func (ep *endpoint) AddStaticRoute(destination *net.IPNet, routeType int, nextHo
 	return nil
 }
 
+func (ep *endpoint) SetInterfaceOptions(opts driverapi.InterfaceOptions) error {
+	ep.Lock()
+	defer ep.Unlock()
+
+	ep.iface.options = opts
+	return nil
+}
+
+func (ep *endpoint) AddInterface(srcName, dstPrefix string, opts driverapi.InterfaceOptions) (driverapi.InterfaceInfo, error) {
+	if srcName == "" {
+		return nil, types.BadRequestErrorf("tried to add an interface with no name to endpoint %s", ep.Name())
+	}
+
+	ep.Lock()
+	defer ep.Unlock()
+
+	if ep.iface.srcName == srcName {
+		return nil, types.ForbiddenErrorf("interface %s is already the endpoint interface", srcName)
+	}
+	for _, i := range ep.joinInfo.extraIfaces {
+		if i.srcName == srcName {
+			return nil, types.ForbiddenErrorf("interface %s is already added to endpoint %s", srcName, ep.name)
+		}
+	}
+	i := &endpointInterface{srcName: srcName, dstPrefix: dstPrefix, options: opts}
+	ep.joinInfo.extraIfaces = append(ep.joinInfo.extraIfaces, i)
+	return i, nil
+}
+
 func (ep *endpoint) AddTableEntry(tableName, key string, value []byte) error {
 	ep.Lock()
 	defer ep.Unlock()
@@ -413,6 +465,9 @@ func (epj *endpointJoinInfo) MarshalJSON() ([]byte, error) {
 	}
 	epMap["disableGatewayService"] = epj.disableGatewayService
 	epMap["StaticRoutes"] = epj.StaticRoutes
+	if len(epj.extraIfaces) != 0 {
+		epMap["extraIfaces"] = epj.extraIfaces
+	}
 	return json.Marshal(epMap)
 }
 
@@ -444,6 +499,13 @@ func (epj *endpointJoinInfo) UnmarshalJSON(b []byte) error {
 	}
 	epj.StaticRoutes = StaticRoutes
 
+	if v, ok := epMap["extraIfaces"]; ok {
+		ib, _ := json.Marshal(v)
+		if err := json.Unmarshal(ib, &epj.extraIfaces); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -455,5 +517,13 @@ func (epj *endpointJoinInfo) CopyTo(dstEpj *endpointJoinInfo) error {
 	copy(dstEpj.driverTableEntries, epj.driverTableEntries)
 	dstEpj.gw = types.GetIPCopy(epj.gw)
 	dstEpj.gw6 = types.GetIPCopy(epj.gw6)
+	dstEpj.extraIfaces = make([]*endpointInterface, 0, len(epj.extraIfaces))
+	for _, i := range epj.extraIfaces {
+		dstI := &endpointInterface{}
+		if err := i.CopyTo(dstI); err != nil {
+			return err
+		}
+		dstEpj.extraIfaces = append(dstEpj.extraIfaces, dstI)
+	}
 	return nil
 }
@@ -4,6 +4,7 @@ package idm
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/docker/libnetwork/bitseq"
 	"github.com/docker/libnetwork/datastore"
@@ -29,10 +30,19 @@ func New(ds datastore.DataStore, id string, start, end uint64) (*Idm, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize bit sequence handler: %s", err.Error())
 	}
-
 	return &Idm{start: start, end: end, handle: h}, nil
 }
 
+// EnableWriteCoalescing makes the id set write its changes to its local
+// scope datastore at most once per interval, instead of on every change.
+// The ids reserved within the last interval before a crash are lost.
+func (i *Idm) EnableWriteCoalescing(interval time.Duration) error {
+	if i.handle == nil {
+		return errors.New("ID set is not initialized")
+	}
+	return i.handle.EnableWriteCoalescing(interval)
+}
+
 // GetID returns the first available id in the set
 func (i *Idm) GetID(serial bool) (uint64, error) {
 	if i.handle == nil {
@@ -74,3 +84,11 @@ func (i *Idm) GetIDInRange(start, end uint64, serial bool) (uint64, error) {
 func (i *Idm) Release(id uint64) {
 	i.handle.Unset(id - i.start)
 }
+
+// Flush writes out the id reservations not yet written to the datastore
+func (i *Idm) Flush() error {
+	if i.handle == nil {
+		return errors.New("ID set is not initialized")
+	}
+	return i.handle.Flush()
+}
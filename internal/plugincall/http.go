@@ -0,0 +1,215 @@
+package plugincall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/docker/pkg/plugins/transport"
+	"github.com/docker/go-connections/sockets"
+	"github.com/docker/go-connections/tlsconfig"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+// HTTPClient is the client of the plugins speaking the JSON protocol over
+// HTTP. Unlike the plugins.Client, a call is bound by its deadline: the
+// request is cancelled when the deadline expires, and it is only sent again
+// when the connection to the plugin could not be established, the plugin
+// then never having received it.
+type HTTPClient struct {
+	http    *http.Client
+	factory transport.RequestFactory
+	timeout time.Duration
+}
+
+// NewHTTPClient returns the client of the plugin listening on addr, as in
+// "unix:///run/docker/plugins/foo.sock" or "tcp://127.0.0.1:8080". The calls
+// made without a deadline of their own are bound by timeout, the default
+// one when zero.
+func NewHTTPClient(addr string, tlsConfig *tlsconfig.Options, timeout time.Duration) (*HTTPClient, error) {
+	tr := &http.Transport{}
+	if tlsConfig != nil {
+		c, err := tlsconfig.Client(*tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		tr.TLSClientConfig = c
+	}
+
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+	socket := u.Host
+	if socket == "" {
+		// local socket addresses have the host empty
+		socket = u.Path
+	}
+	if err := sockets.ConfigureTransport(tr, u.Scheme, socket); err != nil {
+		return nil, err
+	}
+	scheme := "http"
+	if u.Scheme == "https" {
+		scheme = "https"
+	}
+
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	return &HTTPClient{
+		http:    &http.Client{Transport: tr},
+		factory: transport.NewHTTPTransport(tr, scheme, socket),
+		timeout: timeout,
+	}, nil
+}
+
+// Call invokes serviceMethod with the client deadline
+func (c *HTTPClient) Call(serviceMethod string, args, ret interface{}) error {
+	return c.CallWithTimeout(serviceMethod, args, ret, c.timeout)
+}
+
+// CallWithTimeout invokes serviceMethod, cancelling the request when it
+// does not complete within timeout. A cancelled request may still have been
+// processed by the plugin: a timed out call which is not idempotent, as Join
+// or CreateEndpoint, leaves the outcome unknown to the caller, which fails
+// the operation and relies on its rollback, or on the cleanup of the next
+// call for the same object, to undo whatever the plugin did.
+func (c *HTTPClient) CallWithTimeout(serviceMethod string, args, ret interface{}, timeout time.Duration) error {
+	var body []byte
+	if args != nil {
+		var err error
+		if body, err = json.Marshal(args); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for backoff := defaultBackoff; ; backoff *= 2 {
+		req, err := c.factory.NewRequest(serviceMethod, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		resp, err := c.http.Do(req.WithContext(ctx))
+		if err == nil {
+			defer resp.Body.Close()
+			return decodeResponse(serviceMethod, resp, ret)
+		}
+		if ctx.Err() != nil {
+			return types.TimeoutErrorf("%s: plugin did not respond within %v", serviceMethod, timeout)
+		}
+		if !isDialError(err) {
+			return err
+		}
+		// The plugin may still be starting, try again until the deadline
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return types.TimeoutErrorf("%s: plugin unreachable within %v: %v", serviceMethod, timeout, err)
+		}
+	}
+}
+
+// decodeResponse decodes the response of the plugin into ret, or returns
+// the error it reports, the way the plugins.Client does
+func decodeResponse(serviceMethod string, resp *http.Response, ret interface{}) error {
+	if resp.StatusCode != http.StatusOK {
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("%s: %v", serviceMethod, err)
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return types.NotImplementedErrorf("%s: %s", serviceMethod, bytes.TrimSpace(b))
+		}
+		var remoteErr struct {
+			Err string
+		}
+		if err := json.Unmarshal(b, &remoteErr); err == nil && remoteErr.Err != "" {
+			return fmt.Errorf("%s: %s", serviceMethod, remoteErr.Err)
+		}
+		return fmt.Errorf("%s: %s", serviceMethod, b)
+	}
+	if ret == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(ret)
+}
+
+// isDialError tells whether err is a failure to connect to the plugin,
+// the request then not having been sent
+func isDialError(err error) bool {
+	if ue, ok := err.(*url.Error); ok {
+		err = ue.Err
+	}
+	op, ok := err.(*net.OpError)
+	return ok && op.Op == "dial"
+}
+
+// HandledClient is the client of the plugins activated through the plugin
+// handlers, which are only given the plugins.Client of the plugin. The
+// plugin cannot be looked up until its activation, and with it the handler,
+// completes: the calls made meanwhile go through the plugins.Client, the
+// later ones through the client resolve returns, an HTTPClient for an HTTP
+// plugin.
+type HandledClient struct {
+	client *plugins.Client
+	sync.Mutex
+	resolved Caller
+}
+
+// NewHandledClient returns the client of the plugin name, given client by
+// the plugin handlers, resolving its bounded client in the background
+func NewHandledClient(name string, client *plugins.Client, resolve func() (Caller, error)) *HandledClient {
+	c := &HandledClient{client: client}
+	go func() {
+		resolved, err := resolve()
+		if err != nil {
+			logrus.Warnf("Failed to resolve the client of plugin %s, its calls are not bound by their deadline: %v", name, err)
+			return
+		}
+		c.Lock()
+		c.resolved = resolved
+		c.Unlock()
+	}()
+	return c
+}
+
+// Call invokes serviceMethod with the client deadline
+func (c *HandledClient) Call(serviceMethod string, args, ret interface{}) error {
+	return c.CallWithTimeout(serviceMethod, args, ret, defaultTimeout)
+}
+
+// CallWithTimeout invokes serviceMethod with the timeout deadline
+func (c *HandledClient) CallWithTimeout(serviceMethod string, args, ret interface{}, timeout time.Duration) error {
+	c.Lock()
+	resolved := c.resolved
+	c.Unlock()
+	if resolved == nil {
+		return callWithRequestTimeout(c.client, serviceMethod, args, ret, timeout)
+	}
+	if t, ok := resolved.(TimeoutCaller); ok {
+		return t.CallWithTimeout(serviceMethod, args, ret, timeout)
+	}
+	return resolved.Call(serviceMethod, args, ret)
+}
+
+// callWithRequestTimeout bounds each attempt of the call through the
+// plugins.Client. The client sends the request again when an attempt fails,
+// a timed out one included, until 30s elapsed: the call is then only bound
+// by the larger of the timeout and those 30s.
+func callWithRequestTimeout(c *plugins.Client, serviceMethod string, args, ret interface{}, timeout time.Duration) error {
+	err := c.CallWithOptions(serviceMethod, args, ret, plugins.WithRequestTimeout(timeout))
+	if ue, ok := err.(*url.Error); ok && ue.Timeout() {
+		return types.TimeoutErrorf("%s: plugin did not respond within %v", serviceMethod, timeout)
+	}
+	return err
+}
@@ -0,0 +1,263 @@
+// Package plugincall bounds the calls to the remote driver plugins: each
+// call gets a deadline, the idempotent ones are retried with backoff and a
+// circuit breaker fails the calls fast once the plugin looks unhealthy.
+package plugincall
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultTimeout   = 30 * time.Second
+	defaultRetries   = 2
+	defaultBackoff   = 100 * time.Millisecond
+	defaultThreshold = 5
+	defaultCooldown  = 30 * time.Second
+)
+
+// Caller is the transport of the plugin calls
+type Caller interface {
+	Call(serviceMethod string, args, ret interface{}) error
+}
+
+// TimeoutCaller is a Caller able to bound the duration of a call
+type TimeoutCaller interface {
+	CallWithTimeout(serviceMethod string, args, ret interface{}, timeout time.Duration) error
+}
+
+// Config holds the settings of the plugin calls. The zero values select
+// the defaults.
+type Config struct {
+	// Timeout is the deadline of the calls
+	Timeout time.Duration
+	// Timeouts overrides the deadline of the calls by method
+	Timeouts map[string]time.Duration
+	// Retries is the number of retries of the idempotent calls, a
+	// negative value disables them
+	Retries int
+	// Backoff is the delay before the first retry, doubled on each retry
+	Backoff time.Duration
+	// Threshold is the number of consecutive failed calls opening the breaker
+	Threshold int
+	// Cooldown is how long the breaker fails the calls fast before letting
+	// one through to probe the plugin
+	Cooldown time.Duration
+}
+
+// ParseConfig reads the calls settings from the driver configuration,
+// where they are set by the netlabel.Remote* labels.
+func ParseConfig(config map[string]interface{}) (Config, error) {
+	var (
+		c   Config
+		err error
+	)
+	for k, v := range config {
+		switch {
+		case k == netlabel.RemoteCallTimeout:
+			c.Timeout, err = parseDuration(v)
+		case strings.HasPrefix(k, netlabel.RemoteCallTimeout+"."):
+			var t time.Duration
+			if t, err = parseDuration(v); err == nil {
+				if c.Timeouts == nil {
+					c.Timeouts = make(map[string]time.Duration)
+				}
+				c.Timeouts[strings.TrimPrefix(k, netlabel.RemoteCallTimeout+".")] = t
+			}
+		case k == netlabel.RemoteCallRetries:
+			c.Retries, err = parseInt(v)
+		case k == netlabel.RemoteBreakerThreshold:
+			c.Threshold, err = parseInt(v)
+		case k == netlabel.RemoteBreakerCooldown:
+			c.Cooldown, err = parseDuration(v)
+		default:
+			continue
+		}
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid value %v for %s: %v", v, k, err)
+		}
+	}
+	return c, nil
+}
+
+func parseDuration(v interface{}) (time.Duration, error) {
+	switch v := v.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		return time.ParseDuration(v)
+	}
+	return 0, fmt.Errorf("unexpected type %T", v)
+}
+
+func parseInt(v interface{}) (int, error) {
+	switch v := v.(type) {
+	case int:
+		return v, nil
+	case string:
+		return strconv.Atoi(v)
+	}
+	return 0, fmt.Errorf("unexpected type %T", v)
+}
+
+// Guard applies the calls settings to the calls to a plugin and tracks
+// its health.
+type Guard struct {
+	name       string
+	cfg        Config
+	idempotent map[string]bool
+	sync.Mutex
+	failures  int
+	lastErr   error
+	open      bool
+	openUntil time.Time
+	probing   bool
+	since     time.Time
+}
+
+// New returns the Guard of the calls to the plugin name, retrying the
+// idempotent serviceMethods on failure
+func New(name string, cfg Config, idempotent ...string) *Guard {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	switch {
+	case cfg.Retries == 0:
+		cfg.Retries = defaultRetries
+	case cfg.Retries < 0:
+		cfg.Retries = 0
+	}
+	if cfg.Backoff == 0 {
+		cfg.Backoff = defaultBackoff
+	}
+	if cfg.Threshold == 0 {
+		cfg.Threshold = defaultThreshold
+	}
+	if cfg.Cooldown == 0 {
+		cfg.Cooldown = defaultCooldown
+	}
+	g := &Guard{name: name, cfg: cfg, idempotent: make(map[string]bool), since: time.Now()}
+	for _, m := range idempotent {
+		g.idempotent[m] = true
+	}
+	return g
+}
+
+// Call invokes serviceMethod through c
+func (g *Guard) Call(c Caller, serviceMethod string, args, ret interface{}) error {
+	attempts := 1
+	if g.idempotent[serviceMethod] {
+		attempts += g.cfg.Retries
+	}
+	backoff := g.cfg.Backoff
+	for i := 1; ; i++ {
+		if err := g.allow(serviceMethod); err != nil {
+			return err
+		}
+		err := g.call(c, serviceMethod, args, ret)
+		if !IsFailure(err) {
+			g.succeeded()
+			return err
+		}
+		g.failed(serviceMethod, err)
+		if i == attempts {
+			return err
+		}
+		logrus.Debugf("%s to plugin %s failed, retrying in %v: %v", serviceMethod, g.name, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (g *Guard) call(c Caller, serviceMethod string, args, ret interface{}) error {
+	timeout, ok := g.cfg.Timeouts[serviceMethod]
+	if !ok {
+		timeout = g.cfg.Timeout
+	}
+	switch t := c.(type) {
+	case TimeoutCaller:
+		return t.CallWithTimeout(serviceMethod, args, ret, timeout)
+	case *plugins.Client:
+		return callWithRequestTimeout(t, serviceMethod, args, ret, timeout)
+	}
+	return c.Call(serviceMethod, args, ret)
+}
+
+// allow fails fast while the breaker is open. Once the cooldown expires,
+// a single call goes through to probe the plugin.
+func (g *Guard) allow(serviceMethod string) error {
+	g.Lock()
+	defer g.Unlock()
+	if !g.open {
+		return nil
+	}
+	if time.Now().Before(g.openUntil) || g.probing {
+		return types.NoServiceErrorf("%s: plugin %s is unhealthy after %d consecutive failed calls, last: %v", serviceMethod, g.name, g.failures, g.lastErr)
+	}
+	g.probing = true
+	return nil
+}
+
+func (g *Guard) succeeded() {
+	g.Lock()
+	defer g.Unlock()
+	g.failures = 0
+	g.probing = false
+	if g.open {
+		logrus.Infof("plugin %s is healthy again", g.name)
+		g.open = false
+		g.since = time.Now()
+	}
+}
+
+func (g *Guard) failed(serviceMethod string, err error) {
+	g.Lock()
+	defer g.Unlock()
+	g.failures++
+	g.lastErr = err
+	if g.probing || (!g.open && g.failures >= g.cfg.Threshold) {
+		if !g.open {
+			logrus.Warnf("plugin %s marked unhealthy after %d consecutive failed calls, last %s: %v", g.name, g.failures, serviceMethod, err)
+			g.open = true
+			g.since = time.Now()
+		}
+		g.probing = false
+		g.openUntil = time.Now().Add(g.cfg.Cooldown)
+	}
+}
+
+// Health returns the health of the plugin
+func (g *Guard) Health() types.PluginHealth {
+	g.Lock()
+	defer g.Unlock()
+	h := types.PluginHealth{
+		Healthy:  !g.open,
+		Failures: g.failures,
+		Since:    g.since,
+	}
+	if g.lastErr != nil {
+		h.LastError = g.lastErr.Error()
+	}
+	return h
+}
+
+// IsFailure tells whether err is a failure to reach the plugin, or to get
+// its response in time, as opposed to an error returned by the plugin.
+func IsFailure(err error) bool {
+	switch err.(type) {
+	case nil:
+		return false
+	case net.Error, types.TimeoutError, types.RetryError:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package plugincall
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/types"
+)
+
+// fakeCaller fails the first fails calls with err
+type fakeCaller struct {
+	calls   int
+	fails   int
+	err     error
+	timeout time.Duration
+}
+
+func (c *fakeCaller) Call(serviceMethod string, args, ret interface{}) error {
+	return c.CallWithTimeout(serviceMethod, args, ret, 0)
+}
+
+func (c *fakeCaller) CallWithTimeout(serviceMethod string, args, ret interface{}, timeout time.Duration) error {
+	c.calls++
+	c.timeout = timeout
+	if c.calls <= c.fails {
+		return c.err
+	}
+	return nil
+}
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig(map[string]interface{}{
+		netlabel.RemoteCallTimeout:                         "10s",
+		netlabel.RemoteCallTimeout + ".NetworkDriver.Join": 2 * time.Second,
+		netlabel.RemoteCallRetries:                         "3",
+		netlabel.RemoteBreakerThreshold:                    4,
+		netlabel.RemoteBreakerCooldown:                     "1m",
+		"com.docker.network.driver.remote.other":           "ignored",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Timeout != 10*time.Second || cfg.Timeouts["NetworkDriver.Join"] != 2*time.Second ||
+		cfg.Retries != 3 || cfg.Threshold != 4 || cfg.Cooldown != time.Minute {
+		t.Fatalf("Unexpected config %+v", cfg)
+	}
+
+	if _, err := ParseConfig(map[string]interface{}{netlabel.RemoteCallRetries: "many"}); err == nil {
+		t.Fatal("Expected failure on an invalid value")
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	g := New("test", Config{Timeouts: map[string]time.Duration{"NetworkDriver.Join": time.Second}})
+	c := &fakeCaller{}
+	if err := g.Call(c, "NetworkDriver.Join", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if c.timeout != time.Second {
+		t.Fatalf("Expected the method timeout, got %v", c.timeout)
+	}
+	if err := g.Call(c, "NetworkDriver.Leave", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if c.timeout != defaultTimeout {
+		t.Fatalf("Expected the default timeout, got %v", c.timeout)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	g := New("test", Config{Backoff: time.Millisecond}, "NetworkDriver.Leave")
+
+	// Idempotent calls are retried
+	c := &fakeCaller{fails: 2, err: types.TimeoutErrorf("timeout")}
+	if err := g.Call(c, "NetworkDriver.Leave", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if c.calls != 3 {
+		t.Fatalf("Expected 3 calls, got %d", c.calls)
+	}
+
+	// Others are not
+	c = &fakeCaller{fails: 1, err: types.TimeoutErrorf("timeout")}
+	if err := g.Call(c, "NetworkDriver.Join", nil, nil); err == nil || c.calls != 1 {
+		t.Fatalf("Expected a single failed call, got %d calls: %v", c.calls, err)
+	}
+
+	// Neither are the errors returned by the plugin
+	c = &fakeCaller{fails: 1, err: errors.New("plugin error")}
+	if err := g.Call(c, "NetworkDriver.Leave", nil, nil); err == nil || c.calls != 1 {
+		t.Fatalf("Expected a single failed call, got %d calls: %v", c.calls, err)
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	g := New("test", Config{Retries: -1, Threshold: 2, Cooldown: 50 * time.Millisecond})
+	c := &fakeCaller{fails: 3, err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}
+
+	for i := 0; i < 2; i++ {
+		if err := g.Call(c, "NetworkDriver.Join", nil, nil); err == nil {
+			t.Fatal("Expected failure")
+		}
+	}
+	if h := g.Health(); h.Healthy || h.Failures != 2 || h.LastError == "" {
+		t.Fatalf("Expected the plugin to be unhealthy, got %+v", h)
+	}
+
+	// Calls fail fast until the cooldown expires
+	err := g.Call(c, "NetworkDriver.Join", nil, nil)
+	if _, ok := err.(types.NoServiceError); !ok || c.calls != 2 {
+		t.Fatalf("Expected the call to fail fast, got %d calls: %v", c.calls, err)
+	}
+
+	// The probe fails, the breaker opens again
+	time.Sleep(60 * time.Millisecond)
+	if err := g.Call(c, "NetworkDriver.Join", nil, nil); err == nil || c.calls != 3 {
+		t.Fatalf("Expected the probe to fail, got %d calls: %v", c.calls, err)
+	}
+	if err := g.Call(c, "NetworkDriver.Join", nil, nil); c.calls != 3 {
+		t.Fatalf("Expected the call to fail fast, got %d calls: %v", c.calls, err)
+	}
+
+	// The probe succeeds, the breaker closes
+	time.Sleep(60 * time.Millisecond)
+	if err := g.Call(c, "NetworkDriver.Join", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if h := g.Health(); !h.Healthy || h.Failures != 0 {
+		t.Fatalf("Expected the plugin to be healthy, got %+v", h)
+	}
+}
+
+func TestHTTPClientDeadline(t *testing.T) {
+	var requests int32
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/NetworkDriver.Join" {
+			<-r.Context().Done()
+			close(cancelled)
+			return
+		}
+		fmt.Fprintln(w, `{"Value": {"id": "dummy"}}`)
+	}))
+	defer server.Close()
+
+	c, err := NewHTTPClient("tcp://"+server.Listener.Addr().String(), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res struct {
+		Value map[string]string
+	}
+	if err := c.Call("NetworkDriver.EndpointOperInfo", nil, &res); err != nil || res.Value["id"] != "dummy" {
+		t.Fatalf("Unexpected response %+v: %v", res, err)
+	}
+
+	err = c.CallWithTimeout("NetworkDriver.Join", nil, nil, 50*time.Millisecond)
+	if _, ok := err.(types.TimeoutError); !ok {
+		t.Fatalf("Expected the call to time out, got %v", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the request to be cancelled")
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Fatalf("Expected the timed out request to be sent once, got %d requests", n)
+	}
+}
@@ -0,0 +1,101 @@
+// Package plugingrpc holds the gRPC transport shared by the remote network
+// driver and IPAM plugins: the validation of the socket a plugin advertises,
+// the connection to it and the conversion of the errors it returns.
+package plugingrpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ProtocolSchemeV1 is the protocol scheme of the managed plugins serving
+// a gRPC protocol on their socket.
+const ProtocolSchemeV1 = "moby.plugins.grpc/v1"
+
+// DefaultTimeout bounds the plugin calls when the plugin does not specify
+// a timeout, as the HTTP plugin client does.
+const DefaultTimeout = 30 * time.Second
+
+// SocketsDir is the directory of the plugin sockets. The gRPC socket a
+// plugin advertises in its capabilities must be in it.
+var SocketsDir = "/run/docker/plugins"
+
+// Address validates the gRPC socket advertised in the capabilities of the
+// legacy plugin name implementing endpointType. It returns an empty address
+// when the plugin manifest does not declare the gRPC protocol scheme among
+// the subsystems it implements, the calls then staying on HTTP.
+func Address(name, endpointType, addr string) (string, error) {
+	p, err := plugins.Get(name, endpointType)
+	if err != nil {
+		return "", err
+	}
+	declared := false
+	if p.Manifest != nil {
+		for _, i := range p.Manifest.Implements {
+			if i == ProtocolSchemeV1 {
+				declared = true
+			}
+		}
+	}
+	if !declared {
+		logrus.Warnf("Ignoring the gRPC socket %s of plugin %s, its manifest does not declare the %s protocol scheme", addr, name, ProtocolSchemeV1)
+		return "", nil
+	}
+
+	addr = filepath.Clean(addr)
+	if !filepath.IsAbs(addr) || !strings.HasPrefix(addr, filepath.Clean(SocketsDir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("gRPC socket %s of plugin %s is not in %s", addr, name, SocketsDir)
+	}
+	return addr, nil
+}
+
+// Dial connects to the gRPC unix socket addr of a plugin
+func Dial(addr string) (*grpc.ClientConn, error) {
+	return grpc.Dial(addr,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", addr)
+		}),
+		// Wait for the plugin to be reachable until the call times out,
+		// as the HTTP client retries
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
+}
+
+// Error converts the errors returned by the plugin into the ones the HTTP
+// client would return in the same situation
+func Error(serviceMethod string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if err == context.DeadlineExceeded {
+		return types.TimeoutErrorf("%s: %v", serviceMethod, err)
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.Unimplemented:
+		return types.NotImplementedErrorf("%s: %s", serviceMethod, st.Message())
+	case codes.Unknown:
+		// error returned by the plugin method handler
+		return fmt.Errorf("remote: %s", st.Message())
+	case codes.DeadlineExceeded:
+		return types.TimeoutErrorf("%s: %s", serviceMethod, st.Message())
+	case codes.Unavailable:
+		return types.RetryErrorf("%s: %s", serviceMethod, st.Message())
+	}
+	return fmt.Errorf("%s: %v", serviceMethod, err)
+}
@@ -0,0 +1,345 @@
+package ipam
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/docker/libnetwork/datastore"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/types"
+)
+
+const (
+	// Number of candidate addresses tried before giving up on a request
+	maxAddrSetProbes = 64
+	// Length of the per pool secret the addresses are generated from
+	addrSetSecretLen = 16
+)
+
+// addrSet keeps track of the allocated addresses of an IPv6 pool too large
+// for a bitmask. Only the allocated addresses are stored. Free addresses
+// are picked in a stable privacy fashion (RFC 7217): the interface
+// identifier is the hash of a per pool secret, the pool and a counter, so
+// that the addresses are neither sequential nor predictable.
+type addrSet struct {
+	app       string
+	id        string
+	pool      *net.IPNet
+	secret    []byte
+	seq       uint64
+	addresses map[string]struct{}
+	dbIndex   uint64
+	dbExists  bool
+	store     datastore.DataStore
+	sync.Mutex
+}
+
+// newAddrSet returns the address set of the pool identified by id, as
+// stored in the datastore if present
+func newAddrSet(app string, ds datastore.DataStore, id string, pool *net.IPNet) (*addrSet, error) {
+	s := &addrSet{
+		app:       app,
+		id:        id,
+		pool:      types.GetIPNetCopy(pool),
+		addresses: make(map[string]struct{}),
+		store:     ds,
+	}
+
+	for {
+		if s.store != nil {
+			if err := s.store.GetObject(datastore.Key(s.Key()...), s); err != nil && err != datastore.ErrKeyNotFound {
+				return nil, err
+			}
+		}
+		if s.Exists() {
+			return s, nil
+		}
+
+		secret := make([]byte, addrSetSecretLen)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate the address set secret: %v", err)
+		}
+		s.Lock()
+		s.secret = secret
+		// Do not let the subnet-router anycast address be allocated
+		s.addresses[s.pool.IP.String()] = struct{}{}
+		s.Unlock()
+
+		if err := s.writeToStore(); err != nil {
+			if _, ok := err.(types.RetryError); !ok {
+				return nil, fmt.Errorf("failed to write address set to store: %v", err)
+			}
+			// Somebody else stored it in the meantime, use theirs
+			continue
+		}
+		return s, nil
+	}
+}
+
+// setAny allocates a free address of the sub network, trying the passed
+// candidates in order before generating one.
+func (s *addrSet) setAny(sub *net.IPNet, candidates []net.IP) (net.IP, error) {
+	var ip net.IP
+	err := s.update(func(ns *addrSet) error {
+		for _, c := range candidates {
+			if _, ok := ns.addresses[c.String()]; !ok {
+				ip = c
+				ns.addresses[ip.String()] = struct{}{}
+				return nil
+			}
+		}
+
+		for i := 0; i < maxAddrSetProbes; i++ {
+			ns.seq++
+			c := ns.stableAddress(sub)
+			if c == nil {
+				continue
+			}
+			if _, ok := ns.addresses[c.String()]; !ok {
+				ip = c
+				ns.addresses[ip.String()] = struct{}{}
+				return nil
+			}
+		}
+		return ipamapi.ErrNoAvailableIPs
+	})
+	return ip, err
+}
+
+// set allocates the passed address
+func (s *addrSet) set(ip net.IP) error {
+	return s.update(func(ns *addrSet) error {
+		if _, ok := ns.addresses[ip.String()]; ok {
+			return ipamapi.ErrIPAlreadyAllocated
+		}
+		ns.addresses[ip.String()] = struct{}{}
+		return nil
+	})
+}
+
+// unset releases the passed address
+func (s *addrSet) unset(ip net.IP) error {
+	return s.update(func(ns *addrSet) error {
+		delete(ns.addresses, ip.String())
+		return nil
+	})
+}
+
+// isSet tells whether the passed address is allocated
+func (s *addrSet) isSet(ip net.IP) bool {
+	s.Lock()
+	defer s.Unlock()
+	_, ok := s.addresses[ip.String()]
+	return ok
+}
+
+// allocated returns the number of allocated addresses
+func (s *addrSet) allocated() int {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.addresses)
+}
+
+// update applies fn to a private copy of the set, after fetching the
+// latest version of it from the store, and atomically writes it back
+func (s *addrSet) update(fn func(ns *addrSet) error) error {
+	for {
+		s.Lock()
+		store := s.store
+		s.Unlock()
+		if store != nil {
+			if err := store.GetObject(datastore.Key(s.Key()...), s); err != nil && err != datastore.ErrKeyNotFound {
+				return err
+			}
+		}
+
+		s.Lock()
+		ns := s.getCopy()
+		s.Unlock()
+
+		if err := fn(ns); err != nil {
+			return err
+		}
+
+		if err := ns.writeToStore(); err != nil {
+			if _, ok := err.(types.RetryError); !ok {
+				return fmt.Errorf("internal failure while updating the address set: %v", err)
+			}
+			continue
+		}
+
+		s.Lock()
+		s.seq = ns.seq
+		s.addresses = ns.addresses
+		s.dbIndex = ns.dbIndex
+		s.dbExists = ns.dbExists
+		s.Unlock()
+		return nil
+	}
+}
+
+// stableAddress derives the current candidate address of the sub network
+// from the secret, the pool and the counter of the set. It returns nil if
+// the interface identifier is all zeros.
+func (s *addrSet) stableAddress(sub *net.IPNet) net.IP {
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], s.seq)
+
+	h := sha256.New()
+	h.Write(s.secret)
+	h.Write(sub.IP.To16())
+	h.Write(sub.Mask)
+	h.Write(seq[:])
+	sum := h.Sum(nil)
+
+	ip := make(net.IP, net.IPv6len)
+	zero := true
+	for i := range ip {
+		host := sum[i] &^ sub.Mask[i]
+		if host != 0 {
+			zero = false
+		}
+		ip[i] = sub.IP.To16()[i]&sub.Mask[i] | host
+	}
+	if zero {
+		return nil
+	}
+	return ip
+}
+
+// destroy removes the set from the datastore
+func (s *addrSet) destroy() error {
+	for {
+		if err := s.deleteFromStore(); err != nil {
+			if _, ok := err.(types.RetryError); !ok {
+				return fmt.Errorf("internal failure while destroying the address set: %v", err)
+			}
+			// Fetch latest
+			if err := s.store.GetObject(datastore.Key(s.Key()...), s); err != nil {
+				if err == datastore.ErrKeyNotFound { // already removed
+					return nil
+				}
+				return fmt.Errorf("failed to fetch from store when destroying the address set: %v", err)
+			}
+			continue
+		}
+		return nil
+	}
+}
+
+func (s *addrSet) getCopy() *addrSet {
+	ns := &addrSet{
+		app:       s.app,
+		id:        s.id,
+		pool:      types.GetIPNetCopy(s.pool),
+		secret:    append([]byte(nil), s.secret...),
+		seq:       s.seq,
+		addresses: make(map[string]struct{}, len(s.addresses)),
+		dbIndex:   s.dbIndex,
+		dbExists:  s.dbExists,
+		store:     s.store,
+	}
+	for a := range s.addresses {
+		ns.addresses[a] = struct{}{}
+	}
+	return ns
+}
+
+// String returns a string representation of the address set
+func (s *addrSet) String() string {
+	s.Lock()
+	defer s.Unlock()
+	return fmt.Sprintf("Pool: %s, Allocated: %d, Seq: %d", s.pool, len(s.addresses), s.seq)
+}
+
+// MarshalJSON encodes the address set as its list of allocated addresses
+func (s *addrSet) MarshalJSON() ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	addresses := make([]string, 0, len(s.addresses))
+	for a := range s.addresses {
+		addresses = append(addresses, a)
+	}
+	sort.Strings(addresses)
+
+	m := map[string]interface{}{
+		"Pool":      s.pool.String(),
+		"Secret":    s.secret,
+		"Seq":       s.seq,
+		"Addresses": addresses,
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON decodes data into the address set
+func (s *addrSet) UnmarshalJSON(data []byte) error {
+	var t struct {
+		Pool      string
+		Secret    []byte
+		Seq       uint64
+		Addresses []string
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	pool, err := types.ParseCIDR(t.Pool)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.pool = pool
+	s.secret = t.Secret
+	s.seq = t.Seq
+	s.addresses = make(map[string]struct{}, len(t.Addresses))
+	for _, a := range t.Addresses {
+		s.addresses[a] = struct{}{}
+	}
+	return nil
+}
+
+// firstAddress returns the first host address of the sub network
+func firstAddress(sub *net.IPNet) net.IP {
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, sub.IP.To16().Mask(sub.Mask))
+	ip[net.IPv6len-1] |= 1
+	return ip
+}
+
+// lastAddress returns the last address of the sub network
+func lastAddress(sub *net.IPNet) net.IP {
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, sub.IP.To16().Mask(sub.Mask))
+	for i := range ip {
+		ip[i] |= ^sub.Mask[i]
+	}
+	return ip
+}
+
+// eui64Address returns the address of the sub network with the modified
+// EUI-64 interface identifier of mac, or nil if the sub network is smaller
+// than a /64 or mac is not a 48 bits MAC address
+func eui64Address(sub *net.IPNet, mac net.HardwareAddr) net.IP {
+	if ones, _ := sub.Mask.Size(); ones > 64 || len(mac) != 6 {
+		return nil
+	}
+	ip := make(net.IP, net.IPv6len)
+	copy(ip, sub.IP.To16().Mask(sub.Mask))
+	ip[8] = mac[0] ^ 0x02
+	ip[9] = mac[1]
+	ip[10] = mac[2]
+	ip[11] = 0xff
+	ip[12] = 0xfe
+	ip[13] = mac[3]
+	ip[14] = mac[4]
+	ip[15] = mac[5]
+	return ip
+}
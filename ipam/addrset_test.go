@@ -0,0 +1,196 @@
+package ipam
+
+import (
+	"math"
+	"net"
+	"testing"
+
+	"github.com/docker/libnetwork/bitseq"
+	"github.com/docker/libnetwork/datastore"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/types"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestRequestReleaseAddressLargeV6Pool(t *testing.T) {
+	for _, store := range []bool{false, true} {
+		a, err := getAllocator(store)
+		assert.NilError(t, err)
+
+		pid, nw, _, err := a.RequestPool(localAddressSpace, "2001:db8::/48", "", nil, true)
+		assert.NilError(t, err)
+
+		gw, _, err := a.RequestAddress(pid, nil, map[string]string{ipamapi.RequestAddressType: netlabel.Gateway})
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(gw.String(), "2001:db8::1/48"))
+
+		seen := map[string]bool{gw.IP.String(): true}
+		for i := 0; i < 100; i++ {
+			ip, _, err := a.RequestAddress(pid, nil, nil)
+			assert.NilError(t, err)
+			assert.Check(t, nw.Contains(ip.IP), ip)
+			assert.Check(t, !seen[ip.IP.String()], "address %s allocated twice", ip.IP)
+			seen[ip.IP.String()] = true
+		}
+
+		// The pool network address is reserved
+		_, _, err = a.RequestAddress(pid, net.ParseIP("2001:db8::"), nil)
+		assert.Check(t, is.Equal(err, ipamapi.ErrIPAlreadyAllocated))
+
+		pref := net.ParseIP("2001:db8:0:ffff:1:2:3:4")
+		ip, _, err := a.RequestAddress(pid, pref, nil)
+		assert.NilError(t, err)
+		assert.Check(t, ip.IP.Equal(pref))
+		_, _, err = a.RequestAddress(pid, pref, nil)
+		assert.Check(t, is.Equal(err, ipamapi.ErrIPAlreadyAllocated))
+
+		assert.NilError(t, a.ReleaseAddress(pid, pref))
+		_, _, err = a.RequestAddress(pid, pref, nil)
+		assert.NilError(t, err)
+
+		_, _, err = a.RequestAddress(pid, net.ParseIP("2001:db9::1"), nil)
+		assert.Check(t, is.Equal(err, ipamapi.ErrIPOutOfRange))
+
+		assert.NilError(t, a.ReleasePool(pid))
+	}
+}
+
+func TestRequestAddressLargeV6SubPool(t *testing.T) {
+	a, err := getAllocator(true)
+	assert.NilError(t, err)
+
+	pid, _, _, err := a.RequestPool(localAddressSpace, "2001:db8::/56", "2001:db8:0:42::/64", nil, true)
+	assert.NilError(t, err)
+
+	_, sub, _ := net.ParseCIDR("2001:db8:0:42::/64")
+	for i := 0; i < 20; i++ {
+		ip, _, err := a.RequestAddress(pid, nil, nil)
+		assert.NilError(t, err)
+		assert.Check(t, sub.Contains(ip.IP), ip)
+		assert.Check(t, is.Equal(ip.Mask.String(), net.CIDRMask(56, 128).String()))
+	}
+
+	assert.NilError(t, a.ReleasePool(pid))
+}
+
+func TestRequestAddressEUI64(t *testing.T) {
+	a, err := getAllocator(false)
+	assert.NilError(t, err)
+
+	pid, _, _, err := a.RequestPool(localAddressSpace, "2001:db8::/56", "", nil, true)
+	assert.NilError(t, err)
+
+	opts := map[string]string{netlabel.MacAddress: "02:42:ac:11:00:02"}
+	ip, _, err := a.RequestAddress(pid, nil, opts)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(ip.IP.String(), "2001:db8::42:acff:fe11:2"))
+
+	// The EUI-64 address is taken, a random one is picked
+	ip2, _, err := a.RequestAddress(pid, nil, opts)
+	assert.NilError(t, err)
+	assert.Check(t, !ip2.IP.Equal(ip.IP))
+
+	_, _, err = a.RequestAddress(pid, nil, map[string]string{netlabel.MacAddress: "bogus"})
+	_, ok := err.(types.BadRequestError)
+	assert.Check(t, ok, err)
+}
+
+func TestSparseV6Pool(t *testing.T) {
+	a, err := getAllocator(false)
+	assert.NilError(t, err)
+
+	_, _, _, err = a.RequestPool(localAddressSpace, "172.28.0.0/16", "", map[string]string{ipamapi.AllocSparse: "true"}, false)
+	_, ok := err.(types.BadRequestError)
+	assert.Check(t, ok, err)
+
+	pid, _, _, err := a.RequestPool(localAddressSpace, "2001:db8:1::/64", "", map[string]string{ipamapi.AllocSparse: "true"}, true)
+	assert.NilError(t, err)
+
+	k := SubnetKey{AddressSpace: localAddressSpace, Subnet: "2001:db8:1::/64"}
+	_, ok = a.sets[k]
+	assert.Check(t, ok)
+	_, ok = a.addresses[k]
+	assert.Check(t, !ok)
+
+	// Random addresses are not handed out serially
+	ip1, _, err := a.RequestAddress(pid, nil, nil)
+	assert.NilError(t, err)
+	ip2, _, err := a.RequestAddress(pid, nil, nil)
+	assert.NilError(t, err)
+	assert.Check(t, ip1.IP.String() != "2001:db8:1::1" || ip2.IP.String() != "2001:db8:1::2")
+}
+
+func TestAddrSetFromStore(t *testing.T) {
+	ds, err := randomLocalStore(true)
+	assert.NilError(t, err)
+
+	a1, err := NewAllocator(ds, nil)
+	assert.NilError(t, err)
+	pid, _, _, err := a1.RequestPool(localAddressSpace, "2001:db8::/48", "", nil, true)
+	assert.NilError(t, err)
+	var allocated []net.IP
+	for i := 0; i < 10; i++ {
+		ip, _, err := a1.RequestAddress(pid, nil, nil)
+		assert.NilError(t, err)
+		allocated = append(allocated, ip.IP)
+	}
+
+	// A new allocator sees the same allocations and keeps generating new
+	// addresses from the stored secret and counter
+	a2, err := NewAllocator(ds, nil)
+	assert.NilError(t, err)
+	for _, ip := range allocated {
+		_, _, err := a2.RequestAddress(pid, ip, nil)
+		assert.Check(t, is.Equal(err, ipamapi.ErrIPAlreadyAllocated), ip)
+	}
+	ip, _, err := a2.RequestAddress(pid, nil, nil)
+	assert.NilError(t, err)
+	for _, aip := range allocated {
+		assert.Check(t, !ip.IP.Equal(aip))
+	}
+
+	k := SubnetKey{AddressSpace: localAddressSpace, Subnet: "2001:db8::/48"}
+	assert.Check(t, is.Equal(a2.sets[k].allocated(), len(allocated)+2))
+
+	assert.NilError(t, a2.ReleasePool(pid))
+}
+
+func TestEUI64Address(t *testing.T) {
+	_, sub, _ := net.ParseCIDR("fd00:1:2:3::/64")
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+	assert.Check(t, is.Equal(eui64Address(sub, mac).String(), "fd00:1:2:3:211:22ff:fe33:4455"))
+
+	_, sub, _ = net.ParseCIDR("fd00:1:2:3::/80")
+	assert.Check(t, is.Nil(eui64Address(sub, mac)))
+}
+
+func TestAddrSetImportBitMask(t *testing.T) {
+	ds, err := randomLocalStore(true)
+	assert.NilError(t, err)
+
+	// Bitmask of the pool as stored before the large pools got address sets
+	k := SubnetKey{AddressSpace: localAddressSpace, Subnet: "2001:db8::/48"}
+	h, err := bitseq.NewHandle(dsDataKey, ds, k.String(), math.MaxUint64)
+	assert.NilError(t, err)
+	for _, o := range []uint64{0, 1, 5} {
+		assert.NilError(t, h.Set(o))
+	}
+
+	a, err := NewAllocator(ds, nil)
+	assert.NilError(t, err)
+	pid, _, _, err := a.RequestPool(localAddressSpace, "2001:db8::/48", "", nil, true)
+	assert.NilError(t, err)
+
+	for _, ip := range []string{"2001:db8::1", "2001:db8::5"} {
+		_, _, err := a.RequestAddress(pid, net.ParseIP(ip), nil)
+		assert.Check(t, is.Equal(err, ipamapi.ErrIPAlreadyAllocated), ip)
+	}
+	_, _, err = a.RequestAddress(pid, net.ParseIP("2001:db8::2"), nil)
+	assert.NilError(t, err)
+
+	exists, err := ds.KVStore().Exists(datastore.Key(dsDataKey, k.String()))
+	assert.NilError(t, err)
+	assert.Check(t, !exists, "bitmask left in the store")
+}
@@ -2,15 +2,18 @@ package ipam
 
 import (
 	"fmt"
+	"math"
 	"net"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/docker/libnetwork/bitseq"
 	"github.com/docker/libnetwork/datastore"
 	"github.com/docker/libnetwork/discoverapi"
 	"github.com/docker/libnetwork/ipamapi"
 	"github.com/docker/libnetwork/ipamutils"
+	"github.com/docker/libnetwork/netlabel"
 	"github.com/docker/libnetwork/types"
 	"github.com/sirupsen/logrus"
 )
@@ -24,6 +27,7 @@ const (
 	// datastore keyes for ipam objects
 	dsConfigKey = "ipam/" + ipamapi.DefaultIPAM + "/config"
 	dsDataKey   = "ipam/" + ipamapi.DefaultIPAM + "/data"
+	dsSetKey    = "ipam/" + ipamapi.DefaultIPAM + "/set"
 )
 
 // Allocator provides per address space ipv4/ipv6 book keeping
@@ -37,6 +41,10 @@ type Allocator struct {
 	// stores        []datastore.Datastore
 	// Allocated addresses in each address space's subnet
 	addresses map[SubnetKey]*bitseq.Handle
+	// Allocated addresses in each address space's sparse IPv6 subnet
+	sets map[SubnetKey]*addrSet
+	// Write coalescing interval of the local scope bitmasks, none if zero
+	coalesce time.Duration
 	sync.Mutex
 }
 
@@ -57,6 +65,9 @@ func NewAllocator(lcDs, glDs datastore.DataStore) (*Allocator, error) {
 	// Initialize bitseq map
 	a.addresses = make(map[SubnetKey]*bitseq.Handle)
 
+	// Initialize address set map
+	a.sets = make(map[SubnetKey]*addrSet)
+
 	// Initialize address spaces
 	a.addrSpaces = make(map[string]*addrSpace)
 	for _, aspc := range []struct {
@@ -72,6 +83,18 @@ func NewAllocator(lcDs, glDs datastore.DataStore) (*Allocator, error) {
 	return a, nil
 }
 
+// EnableWriteCoalescing makes the bitmasks of the local scope address spaces
+// write their changes to the datastore at most once per interval, instead
+// of on every allocation. It applies to the bitmasks loaded afterwards.
+// The allocations made within the last interval before a crash are lost
+// and can be handed out again after the restart, hence it is off unless
+// enabled, as for the builtin IPAM.
+func (a *Allocator) EnableWriteCoalescing(interval time.Duration) {
+	a.Lock()
+	a.coalesce = interval
+	a.Unlock()
+}
+
 func (a *Allocator) refresh(as string) error {
 	aSpace, err := a.getAddressSpaceFromStore(as)
 	if err != nil {
@@ -97,7 +120,7 @@ func (a *Allocator) updateBitMasks(aSpace *addrSpace) error {
 		if v.Range == nil {
 			kk := k
 			vv := v
-			inserterList = append(inserterList, func() error { return a.insertBitMask(kk, vv.Pool) })
+			inserterList = append(inserterList, func() error { return a.insertAddresses(kk, vv) })
 		}
 	}
 	aSpace.Unlock()
@@ -130,7 +153,7 @@ func (a *Allocator) checkConsistency(as string) {
 
 	aSpace.Lock()
 	for sk, pd := range aSpace.subnets {
-		if pd.Range != nil {
+		if pd.Range != nil || usesAddrSet(pd) {
 			continue
 		}
 		sKeyList = append(sKeyList, sk)
@@ -216,8 +239,38 @@ func (a *Allocator) RequestPool(addressSpace, pool, subPool string, options map[
 		return "", nil, nil, types.InternalErrorf("failed to parse pool request for address space %q pool %q subpool %q: %v", addressSpace, pool, subPool, err)
 	}
 
+	// IPv6 pools can request their addresses to be picked at random
+	// rather than serially, as done for the pools larger than a /64
+	sparse := options[ipamapi.AllocSparse] == "true"
+	if sparse && !v6 {
+		return "", nil, nil, types.BadRequestErrorf("sparse allocation is only supported on IPv6 pools")
+	}
+
 	pdf := k == nil
 
+	// Pools can restrict the addresses handed out to a set of ranges
+	// and exclude some more from them
+	var ranges, exclusions []*AddressRange
+	if options[ipamapi.AllocRanges] != "" || options[ipamapi.AllocExclusions] != "" {
+		if pdf {
+			return "", nil, nil, types.BadRequestErrorf("address ranges and exclusions require a pool to be specified")
+		}
+		if usesAddrSet(&PoolData{Pool: nw, Sparse: sparse}) {
+			return "", nil, nil, types.BadRequestErrorf("address ranges and exclusions are not supported on pool %s", nw)
+		}
+		if ranges, err = getAddressRanges(options[ipamapi.AllocRanges], nw); err != nil {
+			return "", nil, nil, err
+		}
+		for i := 1; i < len(ranges); i++ {
+			if ranges[i].Start <= ranges[i-1].End {
+				return "", nil, nil, types.BadRequestErrorf("address ranges %s and %s overlap", ranges[i-1], ranges[i])
+			}
+		}
+		if exclusions, err = getAddressRanges(options[ipamapi.AllocExclusions], nw); err != nil {
+			return "", nil, nil, err
+		}
+	}
+
 retry:
 	if pdf {
 		if nw, err = a.getPredefinedPool(addressSpace, v6); err != nil {
@@ -235,7 +288,7 @@ retry:
 		return "", nil, nil, err
 	}
 
-	insert, err := aSpace.updatePoolDBOnAdd(*k, nw, ipr, pdf)
+	insert, err := aSpace.updatePoolDBOnAdd(*k, nw, ipr, ranges, exclusions, pdf, sparse)
 	if err != nil {
 		if _, ok := err.(types.MaskableError); ok {
 			logrus.Debugf("Retrying predefined pool search: %v", err)
@@ -334,6 +387,101 @@ func (a *Allocator) parsePoolRequest(addressSpace, pool, subPool string, v6 bool
 	return &SubnetKey{AddressSpace: addressSpace, Subnet: nw.String(), ChildSubnet: subPool}, nw, ipr, nil
 }
 
+// usesAddrSet tells whether the allocated addresses of the master pool p are
+// tracked by an address set rather than by a bitmask
+func usesAddrSet(p *PoolData) bool {
+	if p.Sparse {
+		return true
+	}
+	ones, _ := p.Pool.Mask.Size()
+	return getAddressVersion(p.Pool.IP) == v6 && ones < minNetSizeV6
+}
+
+// insertAddresses sets up the book keeping of the allocated addresses of
+// the master pool p
+func (a *Allocator) insertAddresses(key SubnetKey, p *PoolData) error {
+	if usesAddrSet(p) {
+		return a.insertAddrSet(key, p.Pool)
+	}
+	return a.insertBitMask(key, p.Pool)
+}
+
+func (a *Allocator) insertAddrSet(key SubnetKey, pool *net.IPNet) error {
+	store := a.getStore(key.AddressSpace)
+
+	// Address set content may come from datastore
+	s, err := newAddrSet(dsSetKey, store, key.String(), pool)
+	if err != nil {
+		return err
+	}
+	if err := importBitMask(store, key, pool, s); err != nil {
+		return err
+	}
+
+	a.Lock()
+	a.sets[key] = s
+	a.Unlock()
+	return nil
+}
+
+// importBitMask moves the addresses allocated in the bitmask the pool was
+// tracked by, before the large IPv6 pools got address sets, into the
+// address set s, and removes the bitmask from the store
+func importBitMask(store datastore.DataStore, key SubnetKey, pool *net.IPNet, s *addrSet) error {
+	if store == nil {
+		return nil
+	}
+	exists, err := store.KVStore().Exists(datastore.Key(dsDataKey, key.String()))
+	if err != nil && err != datastore.ErrKeyNotFound {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	// The bitmask of such a pool covered the first 2^64-1 addresses
+	h, err := bitseq.NewHandle(dsDataKey, store, key.String(), math.MaxUint64)
+	if err != nil {
+		return err
+	}
+	var ips []net.IP
+	h.WalkSet(func(ordinal uint64) bool {
+		// The subnet-router anycast address is already set aside
+		if ordinal != 0 {
+			ips = append(ips, generateAddress(ordinal, pool))
+		}
+		return true
+	})
+	if len(ips) > 0 {
+		logrus.Infof("Importing %d allocated addresses of pool %s into its address set", len(ips), key.String())
+		if err := s.update(func(ns *addrSet) error {
+			for _, ip := range ips {
+				ns.addresses[ip.String()] = struct{}{}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return h.Destroy()
+}
+
+func (a *Allocator) retrieveAddrSet(k SubnetKey, n *net.IPNet) (*addrSet, error) {
+	a.Lock()
+	s, ok := a.sets[k]
+	a.Unlock()
+	if !ok {
+		logrus.Debugf("Retrieving address set (%s, %s)", k.String(), n.String())
+		if err := a.insertAddrSet(k, n); err != nil {
+			return nil, types.InternalErrorf("could not find address set in datastore for %s", k.String())
+		}
+		a.Lock()
+		s = a.sets[k]
+		a.Unlock()
+	}
+	return s, nil
+}
+
 func (a *Allocator) insertBitMask(key SubnetKey, pool *net.IPNet) error {
 	//logrus.Debugf("Inserting bitmask (%s, %s)", key.String(), pool.String())
 
@@ -347,11 +495,30 @@ func (a *Allocator) insertBitMask(key SubnetKey, pool *net.IPNet) error {
 		numAddresses--
 	}
 
+	// Write out the changes of the bitmask about to be replaced, for the
+	// new one to load them
+	a.Lock()
+	prev := a.addresses[key]
+	a.Unlock()
+	if prev != nil {
+		if err := prev.Flush(); err != nil {
+			return err
+		}
+	}
+
 	// Generate the new address masks. AddressMask content may come from datastore
 	h, err := bitseq.NewHandle(dsDataKey, store, key.String(), numAddresses)
 	if err != nil {
 		return err
 	}
+	a.Lock()
+	coalesce := a.coalesce
+	a.Unlock()
+	if coalesce != 0 && store != nil && store.Scope() == datastore.LocalScope {
+		if err := h.EnableWriteCoalescing(coalesce); err != nil {
+			return err
+		}
+	}
 
 	// Do not let network identifier address be reserved
 	// Do the same for IPv6 so that bridge ip starts with XXXX...::1
@@ -483,6 +650,19 @@ func (a *Allocator) RequestAddress(poolID string, prefAddress net.IP, opts map[s
 	}
 	aSpace.Unlock()
 
+	if usesAddrSet(c) {
+		s, err := a.retrieveAddrSet(k, c.Pool)
+		if err != nil {
+			return nil, nil, types.InternalErrorf("could not find address set in datastore for %s on address %v request from pool %s: %v",
+				k.String(), prefAddress, poolID, err)
+		}
+		ip, err := a.getSetAddress(p.Pool, s, prefAddress, p.Range, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &net.IPNet{IP: ip, Mask: p.Pool.Mask}, nil, nil
+	}
+
 	bm, err := a.retrieveBitmask(k, c.Pool)
 	if err != nil {
 		return nil, nil, types.InternalErrorf("could not find bitmask in datastore for %s on address %v request from pool %s: %v",
@@ -496,7 +676,8 @@ func (a *Allocator) RequestAddress(poolID string, prefAddress net.IP, opts map[s
 			serial = (val == "true")
 		}
 	}
-	ip, err := a.getAddress(p.Pool, bm, prefAddress, p.Range, serial)
+	last := opts[ipamapi.RequestAddressType] == netlabel.Gateway && opts[ipamapi.GatewayPolicy] == ipamapi.GatewayLast
+	ip, err := a.getPoolAddress(p, bm, prefAddress, serial, last)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -545,6 +726,17 @@ func (a *Allocator) ReleaseAddress(poolID string, address net.IP) error {
 	}
 	aSpace.Unlock()
 
+	if usesAddrSet(c) {
+		s, err := a.retrieveAddrSet(k, c.Pool)
+		if err != nil {
+			return types.InternalErrorf("could not find address set in datastore for %s on address %v release from pool %s: %v",
+				k.String(), address, poolID, err)
+		}
+		defer logrus.Debugf("Released address PoolID:%s, Address:%v Set:%s", poolID, address, s.String())
+
+		return s.unset(address)
+	}
+
 	mask := p.Pool.Mask
 
 	h, err := types.GetHostPartIP(address, mask)
@@ -601,6 +793,91 @@ func (a *Allocator) getAddress(nw *net.IPNet, bitmask *bitseq.Handle, prefAddres
 	}
 }
 
+// getPoolAddress allocates the preferred address, or an address of the
+// allocatable ranges of the pool p, from the bitmask. The last free address
+// of the ranges is picked if last is set.
+func (a *Allocator) getPoolAddress(p *PoolData, bitmask *bitseq.Handle, prefAddress net.IP, serial, last bool) (net.IP, error) {
+	rl := p.allocatable()
+	if prefAddress != nil || (rl == nil && !last) {
+		return a.getAddress(p.Pool, bitmask, prefAddress, p.Range, serial)
+	}
+	if rl == nil {
+		rl = []*AddressRange{{Sub: p.Pool, Start: 0, End: lastOrdinal(p.Pool)}}
+	}
+
+	if last {
+		for i := len(rl) - 1; i >= 0; i-- {
+			for o := rl[i].End; ; o-- {
+				if !bitmask.IsSet(o) {
+					switch err := bitmask.Set(o); err {
+					case nil:
+						return generateAddress(o, types.GetIPNetCopy(p.Pool)), nil
+					case bitseq.ErrBitAllocated:
+					default:
+						return nil, err
+					}
+				}
+				if o == rl[i].Start {
+					break
+				}
+			}
+		}
+		return nil, ipamapi.ErrNoAvailableIPs
+	}
+
+	for _, r := range rl {
+		ip, err := a.getAddress(p.Pool, bitmask, nil, r, serial)
+		if err != ipamapi.ErrNoAvailableIPs {
+			return ip, err
+		}
+	}
+	return nil, ipamapi.ErrNoAvailableIPs
+}
+
+// getSetAddress allocates the preferred address, or an address of the range
+// if any, from the address set s of the pool nw
+func (a *Allocator) getSetAddress(nw *net.IPNet, s *addrSet, prefAddress net.IP, ipr *AddressRange, opts map[string]string) (net.IP, error) {
+	logrus.Debugf("Request address PoolID:%v %s PrefAddress:%v ", nw, s.String(), prefAddress)
+
+	if prefAddress != nil {
+		if err := s.set(prefAddress); err != nil {
+			return nil, err
+		}
+		return prefAddress, nil
+	}
+
+	sub := nw
+	if ipr != nil {
+		sub = ipr.Sub
+	}
+	sub = &net.IPNet{IP: sub.IP.Mask(sub.Mask), Mask: sub.Mask}
+
+	var mac net.HardwareAddr
+	if v, ok := opts[netlabel.MacAddress]; ok {
+		var err error
+		if mac, err = net.ParseMAC(v); err != nil {
+			return nil, types.BadRequestErrorf("invalid mac address %q: %v", v, err)
+		}
+	}
+
+	// A gateway gets the first, or last, address of the sub network if
+	// free. With a MAC address, the modified EUI-64 interface identifier
+	// is tried next.
+	var candidates []net.IP
+	if opts[ipamapi.RequestAddressType] == netlabel.Gateway {
+		if opts[ipamapi.GatewayPolicy] == ipamapi.GatewayLast {
+			candidates = append(candidates, lastAddress(sub))
+		} else {
+			candidates = append(candidates, firstAddress(sub))
+		}
+	}
+	if eui := eui64Address(sub, mac); eui != nil {
+		candidates = append(candidates, eui)
+	}
+
+	return s.setAny(sub, candidates)
+}
+
 // DumpDatabase dumps the internal info
 func (a *Allocator) DumpDatabase() string {
 	a.Lock()
@@ -622,7 +899,11 @@ func (a *Allocator) DumpDatabase() string {
 		for k, config := range aSpace.subnets {
 			s += fmt.Sprintf("\n%v: %v", k, config)
 			if config.Range == nil {
-				a.retrieveBitmask(k, config.Pool)
+				if usesAddrSet(config) {
+					a.retrieveAddrSet(k, config.Pool)
+				} else {
+					a.retrieveBitmask(k, config.Pool)
+				}
 			}
 		}
 		aSpace.Unlock()
@@ -633,9 +914,33 @@ func (a *Allocator) DumpDatabase() string {
 		s += fmt.Sprintf("\n%s: %s", k, bm)
 	}
 
+	s = fmt.Sprintf("%s\n\nAddress sets", s)
+	for k, set := range a.sets {
+		s += fmt.Sprintf("\n%s: %s", k, set)
+	}
+
 	return s
 }
 
+// Close writes out the changes of the bitmasks not yet written to the
+// datastore
+func (a *Allocator) Close() error {
+	a.Lock()
+	handles := make([]*bitseq.Handle, 0, len(a.addresses))
+	for _, h := range a.addresses {
+		handles = append(handles, h)
+	}
+	a.Unlock()
+
+	var firstErr error
+	for _, h := range handles {
+		if err := h.Flush(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
 // IsBuiltIn returns true for builtin drivers
 func (a *Allocator) IsBuiltIn() bool {
 	return true
@@ -1305,6 +1305,8 @@ func TestRetrieveFromStore(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	// Close writes out the coalesced changes
+	a.EnableWriteCoalescing(bitseq.DefaultWriteCoalescing)
 	pid, _, _, err := a.RequestPool(localAddressSpace, "172.25.0.0/16", "", nil, false)
 	if err != nil {
 		t.Fatal(err)
@@ -1316,6 +1318,9 @@ func TestRetrieveFromStore(t *testing.T) {
 	}
 
 	// Restore
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
 	a1, err := NewAllocator(ds, nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1338,6 +1343,9 @@ func TestRetrieveFromStore(t *testing.T) {
 	}
 
 	// Restore
+	if err := a1.Close(); err != nil {
+		t.Fatal(err)
+	}
 	a2, err := NewAllocator(ds, nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1355,6 +1363,9 @@ func TestRetrieveFromStore(t *testing.T) {
 	}
 
 	// Restore
+	if err := a2.Close(); err != nil {
+		t.Fatal(err)
+	}
 	a3, err := NewAllocator(ds, nil)
 	if err != nil {
 		t.Fatal(err)
@@ -1372,6 +1383,9 @@ func TestRetrieveFromStore(t *testing.T) {
 	}
 
 	// Restore
+	if err := a3.Close(); err != nil {
+		t.Fatal(err)
+	}
 	a4, err := NewAllocator(ds, nil)
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,176 @@
+package ipam
+
+import (
+	"net"
+	"testing"
+
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/types"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestRequestAddressRanges(t *testing.T) {
+	for _, store := range []bool{false, true} {
+		a, err := getAllocator(store)
+		assert.NilError(t, err)
+
+		opts := map[string]string{
+			ipamapi.AllocRanges:     "192.168.10.1-192.168.10.3, 192.168.10.128/30",
+			ipamapi.AllocExclusions: "192.168.10.2",
+		}
+		pid, _, _, err := a.RequestPool(localAddressSpace, "192.168.10.0/24", "", opts, false)
+		assert.NilError(t, err)
+
+		var got []string
+		for i := 0; i < 5; i++ {
+			ip, _, err := a.RequestAddress(pid, nil, nil)
+			assert.NilError(t, err)
+			got = append(got, ip.String())
+		}
+		assert.Check(t, is.DeepEqual(got, []string{"192.168.10.1/24", "192.168.10.3/24",
+			"192.168.10.128/24", "192.168.10.129/24", "192.168.10.130/24"}))
+
+		// 192.168.10.131 is the broadcast address of the range, not of the pool
+		ip, _, err := a.RequestAddress(pid, nil, nil)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(ip.String(), "192.168.10.131/24"))
+
+		_, _, err = a.RequestAddress(pid, nil, nil)
+		assert.Check(t, is.Equal(err, ipamapi.ErrNoAvailableIPs))
+
+		// Explicit requests are not bound to the ranges
+		ip, _, err = a.RequestAddress(pid, net.ParseIP("192.168.10.2"), nil)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(ip.String(), "192.168.10.2/24"))
+
+		assert.NilError(t, a.ReleaseAddress(pid, net.ParseIP("192.168.10.129")))
+		ip, _, err = a.RequestAddress(pid, nil, nil)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(ip.String(), "192.168.10.129/24"))
+
+		assert.NilError(t, a.ReleasePool(pid))
+	}
+}
+
+func TestRequestAddressExclusions(t *testing.T) {
+	a, err := getAllocator(true)
+	assert.NilError(t, err)
+
+	opts := map[string]string{ipamapi.AllocExclusions: "10.10.0.1-10.10.0.20,10.10.0.200-10.10.0.254"}
+	pid, _, _, err := a.RequestPool(localAddressSpace, "10.10.0.0/24", "", opts, false)
+	assert.NilError(t, err)
+
+	gw, _, err := a.RequestAddress(pid, nil, map[string]string{ipamapi.RequestAddressType: netlabel.Gateway})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(gw.String(), "10.10.0.21/24"))
+
+	for i := 0; i < 178; i++ {
+		ip, _, err := a.RequestAddress(pid, nil, nil)
+		assert.NilError(t, err)
+		assert.Check(t, ip.IP.To4()[3] > 21 && ip.IP.To4()[3] < 200, ip)
+	}
+	_, _, err = a.RequestAddress(pid, nil, nil)
+	assert.Check(t, is.Equal(err, ipamapi.ErrNoAvailableIPs))
+
+	assert.NilError(t, a.ReleasePool(pid))
+}
+
+func TestRequestPoolRangesInvalid(t *testing.T) {
+	a, err := getAllocator(false)
+	assert.NilError(t, err)
+
+	for _, tc := range []struct {
+		pool string
+		opts map[string]string
+		v6   bool
+	}{
+		{"", map[string]string{ipamapi.AllocRanges: "10.0.0.1-10.0.0.10"}, false},
+		{"10.0.0.0/24", map[string]string{ipamapi.AllocRanges: "10.0.1.1-10.0.1.10"}, false},
+		{"10.0.0.0/24", map[string]string{ipamapi.AllocRanges: "10.0.0.10-10.0.0.1"}, false},
+		{"10.0.0.0/24", map[string]string{ipamapi.AllocRanges: "10.0.0.1-10.0.0.10,10.0.0.8/29"}, false},
+		{"10.0.0.0/24", map[string]string{ipamapi.AllocExclusions: "bogus"}, false},
+		{"2001:db8::/48", map[string]string{ipamapi.AllocRanges: "2001:db8::1-2001:db8::10"}, true},
+	} {
+		_, _, _, err := a.RequestPool(localAddressSpace, tc.pool, "", tc.opts, tc.v6)
+		_, ok := err.(types.BadRequestError)
+		assert.Check(t, ok, "%s %v: %v", tc.pool, tc.opts, err)
+	}
+}
+
+func TestRequestSubPoolRangesOverlap(t *testing.T) {
+	a, err := getAllocator(false)
+	assert.NilError(t, err)
+
+	opts := map[string]string{ipamapi.AllocRanges: "172.20.0.200-172.20.0.210"}
+	pid1, _, _, err := a.RequestPool(localAddressSpace, "172.20.0.0/16", "172.20.1.0/24", opts, false)
+	assert.NilError(t, err)
+
+	_, _, _, err = a.RequestPool(localAddressSpace, "172.20.0.0/16", "172.20.0.128/25", nil, false)
+	assert.Check(t, is.Equal(err, ipamapi.ErrPoolOverlap))
+
+	pid2, _, _, err := a.RequestPool(localAddressSpace, "172.20.0.0/16", "172.20.2.0/24", nil, false)
+	assert.NilError(t, err)
+
+	// The sub pool is allocated first, then the ranges
+	for i := 0; i < 256; i++ {
+		_, _, err := a.RequestAddress(pid1, nil, nil)
+		assert.NilError(t, err)
+	}
+	ip, _, err := a.RequestAddress(pid1, nil, nil)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(ip.String(), "172.20.0.200/16"))
+
+	assert.NilError(t, a.ReleasePool(pid1))
+	assert.NilError(t, a.ReleasePool(pid2))
+}
+
+func TestRequestGatewayLast(t *testing.T) {
+	a, err := getAllocator(false)
+	assert.NilError(t, err)
+
+	gwOpts := map[string]string{
+		ipamapi.RequestAddressType: netlabel.Gateway,
+		ipamapi.GatewayPolicy:      ipamapi.GatewayLast,
+	}
+
+	pid, _, _, err := a.RequestPool(localAddressSpace, "172.21.0.0/24", "", nil, false)
+	assert.NilError(t, err)
+	gw, _, err := a.RequestAddress(pid, nil, gwOpts)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(gw.String(), "172.21.0.254/24"))
+	gw, _, err = a.RequestAddress(pid, nil, gwOpts)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(gw.String(), "172.21.0.253/24"))
+
+	opts := map[string]string{ipamapi.AllocExclusions: "172.22.0.200-172.22.0.255"}
+	pid, _, _, err = a.RequestPool(localAddressSpace, "172.22.0.0/24", "", opts, false)
+	assert.NilError(t, err)
+	gw, _, err = a.RequestAddress(pid, nil, gwOpts)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(gw.String(), "172.22.0.199/24"))
+
+	pid, _, _, err = a.RequestPool(localAddressSpace, "2001:db8::/48", "2001:db8:0:1::/64", nil, true)
+	assert.NilError(t, err)
+	gw, _, err = a.RequestAddress(pid, nil, gwOpts)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(gw.String(), "2001:db8:0:1:ffff:ffff:ffff:ffff/48"))
+}
+
+func TestAllocatableRanges(t *testing.T) {
+	_, nw, _ := net.ParseCIDR("10.0.0.0/24")
+	p := &PoolData{Pool: nw}
+	assert.Check(t, is.Nil(p.allocatable()))
+
+	p.Exclusions = []*AddressRange{{Sub: nw, Start: 10, End: 20}, {Sub: nw, Start: 15, End: 30}}
+	rl := p.allocatable()
+	assert.Check(t, is.Len(rl, 2))
+	assert.Check(t, is.Equal(rl[0].Start, uint64(0)))
+	assert.Check(t, is.Equal(rl[0].End, uint64(9)))
+	assert.Check(t, is.Equal(rl[1].Start, uint64(31)))
+	assert.Check(t, is.Equal(rl[1].End, uint64(255)))
+
+	p.Range = &AddressRange{Sub: nw, Start: 10, End: 30}
+	assert.Check(t, is.Len(p.allocatable(), 0))
+}
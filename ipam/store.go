@@ -134,3 +134,140 @@ func (aSpace *addrSpace) DataScope() string {
 
 	return aSpace.scope
 }
+
+// Key provides the Key to be used in KV Store
+func (s *addrSet) Key() []string {
+	s.Lock()
+	defer s.Unlock()
+	return []string{s.app, s.id}
+}
+
+// KeyPrefix returns the immediate parent key that can be used for tree walk
+func (s *addrSet) KeyPrefix() []string {
+	s.Lock()
+	defer s.Unlock()
+	return []string{s.app}
+}
+
+// Value marshals the data to be stored in the KV store
+func (s *addrSet) Value() []byte {
+	b, err := json.Marshal(s)
+	if err != nil {
+		logrus.Warnf("Failed to marshal ipam address set: %v", err)
+		return nil
+	}
+	return b
+}
+
+// SetValue unmarshals the data from the KV store
+func (s *addrSet) SetValue(value []byte) error {
+	return json.Unmarshal(value, s)
+}
+
+// Index returns the latest DB Index as seen by this object
+func (s *addrSet) Index() uint64 {
+	s.Lock()
+	defer s.Unlock()
+	return s.dbIndex
+}
+
+// SetIndex method allows the datastore to store the latest DB Index into this object
+func (s *addrSet) SetIndex(index uint64) {
+	s.Lock()
+	s.dbIndex = index
+	s.dbExists = true
+	s.Unlock()
+}
+
+// Exists method is true if this object has been stored in the DB.
+func (s *addrSet) Exists() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.dbExists
+}
+
+// Skip provides a way for a KV Object to avoid persisting it in the KV Store
+func (s *addrSet) Skip() bool {
+	return false
+}
+
+// New method returns an address set based on the receiver one
+func (s *addrSet) New() datastore.KVObject {
+	s.Lock()
+	defer s.Unlock()
+
+	return &addrSet{
+		app:   s.app,
+		id:    s.id,
+		store: s.store,
+	}
+}
+
+// CopyTo deep copies the address set into the passed destination object
+func (s *addrSet) CopyTo(o datastore.KVObject) error {
+	s.Lock()
+	defer s.Unlock()
+
+	dst := o.(*addrSet)
+	if s == dst {
+		return nil
+	}
+	ns := s.getCopy()
+	dst.Lock()
+	dst.app = ns.app
+	dst.id = ns.id
+	dst.pool = ns.pool
+	dst.secret = ns.secret
+	dst.seq = ns.seq
+	dst.addresses = ns.addresses
+	dst.dbIndex = ns.dbIndex
+	dst.dbExists = ns.dbExists
+	dst.store = ns.store
+	dst.Unlock()
+
+	return nil
+}
+
+// DataScope method returns the storage scope of the datastore
+func (s *addrSet) DataScope() string {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.store.Scope()
+}
+
+func (s *addrSet) writeToStore() error {
+	s.Lock()
+	store := s.store
+	s.Unlock()
+
+	// IPAM may not have a valid store. In such cases it is just in-memory state.
+	if store == nil {
+		return nil
+	}
+
+	err := store.PutObjectAtomic(s)
+	if err == datastore.ErrKeyModified {
+		return types.RetryErrorf("failed to perform atomic write (%v). retry might fix the error", err)
+	}
+
+	return err
+}
+
+func (s *addrSet) deleteFromStore() error {
+	s.Lock()
+	store := s.store
+	s.Unlock()
+
+	// IPAM may not have a valid store. In such cases it is just in-memory state.
+	if store == nil {
+		return nil
+	}
+
+	err := store.DeleteObjectAtomic(s)
+	if err == datastore.ErrKeyModified {
+		return types.RetryErrorf("failed to perform atomic delete (%v). retry might fix the error", err)
+	}
+
+	return err
+}
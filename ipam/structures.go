@@ -25,6 +25,13 @@ type PoolData struct {
 	Pool      *net.IPNet
 	Range     *AddressRange `json:",omitempty"`
 	RefCount  int
+	// Sparse is set on the IPv6 master pools whose addresses are tracked
+	// by an address set even though they fit in a bitmask
+	Sparse bool `json:",omitempty"`
+	// Additional ranges the addresses are allocated from, along with Range
+	Ranges []*AddressRange `json:",omitempty"`
+	// Ranges whose addresses are never allocated, unless requested
+	Exclusions []*AddressRange `json:",omitempty"`
 }
 
 // addrSpace contains the pool configurations for the address space
@@ -106,8 +113,15 @@ func (s *SubnetKey) FromString(str string) error {
 
 // String returns the string form of the PoolData object
 func (p *PoolData) String() string {
-	return fmt.Sprintf("ParentKey: %s, Pool: %s, Range: %s, RefCount: %d",
+	s := fmt.Sprintf("ParentKey: %s, Pool: %s, Range: %s, RefCount: %d",
 		p.ParentKey.String(), p.Pool.String(), p.Range, p.RefCount)
+	if len(p.Ranges) > 0 {
+		s += fmt.Sprintf(", Ranges: %v", p.Ranges)
+	}
+	if len(p.Exclusions) > 0 {
+		s += fmt.Sprintf(", Exclusions: %v", p.Exclusions)
+	}
+	return s
 }
 
 // MarshalJSON returns the JSON encoding of the PoolData object
@@ -122,6 +136,15 @@ func (p *PoolData) MarshalJSON() ([]byte, error) {
 	if p.Range != nil {
 		m["Range"] = p.Range
 	}
+	if p.Sparse {
+		m["Sparse"] = p.Sparse
+	}
+	if len(p.Ranges) > 0 {
+		m["Ranges"] = p.Ranges
+	}
+	if len(p.Exclusions) > 0 {
+		m["Exclusions"] = p.Exclusions
+	}
 	return json.Marshal(m)
 }
 
@@ -130,10 +153,13 @@ func (p *PoolData) UnmarshalJSON(data []byte) error {
 	var (
 		err error
 		t   struct {
-			ParentKey SubnetKey
-			Pool      string
-			Range     *AddressRange `json:",omitempty"`
-			RefCount  int
+			ParentKey  SubnetKey
+			Pool       string
+			Range      *AddressRange `json:",omitempty"`
+			RefCount   int
+			Sparse     bool
+			Ranges     []*AddressRange
+			Exclusions []*AddressRange
 		}
 	)
 
@@ -144,6 +170,9 @@ func (p *PoolData) UnmarshalJSON(data []byte) error {
 	p.ParentKey = t.ParentKey
 	p.Range = t.Range
 	p.RefCount = t.RefCount
+	p.Sparse = t.Sparse
+	p.Ranges = t.Ranges
+	p.Exclusions = t.Exclusions
 	if t.Pool != "" {
 		if p.Pool, err = types.ParseCIDR(t.Pool); err != nil {
 			return err
@@ -220,9 +249,40 @@ func (p *PoolData) CopyTo(dstP *PoolData) error {
 	}
 
 	dstP.RefCount = p.RefCount
+	dstP.Sparse = p.Sparse
+	dstP.Ranges = copyRanges(p.Ranges)
+	dstP.Exclusions = copyRanges(p.Exclusions)
 	return nil
 }
 
+func copyRanges(rl []*AddressRange) []*AddressRange {
+	if rl == nil {
+		return nil
+	}
+	dst := make([]*AddressRange, len(rl))
+	for i, r := range rl {
+		dst[i] = &AddressRange{Sub: types.GetIPNetCopy(r.Sub), Start: r.Start, End: r.End}
+	}
+	return dst
+}
+
+// allocatable returns the ranges the addresses of the pool are allocated
+// from when no specific address is requested, nil meaning the whole pool
+func (p *PoolData) allocatable() []*AddressRange {
+	var rl []*AddressRange
+	if p.Range != nil {
+		rl = append(rl, p.Range)
+	}
+	rl = append(rl, p.Ranges...)
+	if len(p.Exclusions) == 0 {
+		return rl
+	}
+	if len(rl) == 0 {
+		rl = []*AddressRange{{Sub: p.Pool, Start: 0, End: lastOrdinal(p.Pool)}}
+	}
+	return excludeRanges(rl, p.Exclusions)
+}
+
 func (aSpace *addrSpace) CopyTo(o datastore.KVObject) error {
 	aSpace.Lock()
 	defer aSpace.Unlock()
@@ -258,7 +318,10 @@ func (aSpace *addrSpace) New() datastore.KVObject {
 }
 
 // updatePoolDBOnAdd returns a closure which will add the subnet k to the address space when executed.
-func (aSpace *addrSpace) updatePoolDBOnAdd(k SubnetKey, nw *net.IPNet, ipr *AddressRange, pdf bool) (func() error, error) {
+// The sparse master pools track their addresses in an address set. The
+// ranges of a subpool must not overlap the ones of its siblings, if either
+// was requested with additional ranges.
+func (aSpace *addrSpace) updatePoolDBOnAdd(k SubnetKey, nw *net.IPNet, ipr *AddressRange, ranges, exclusions []*AddressRange, pdf, sparse bool) (func() error, error) {
 	aSpace.Lock()
 	defer aSpace.Unlock()
 
@@ -278,16 +341,30 @@ func (aSpace *addrSpace) updatePoolDBOnAdd(k SubnetKey, nw *net.IPNet, ipr *Addr
 			return nil, ipamapi.ErrPoolOverlap
 		}
 		// This is a new master pool, add it along with corresponding bitmask
-		aSpace.subnets[k] = &PoolData{Pool: nw, RefCount: 1}
-		return func() error { return aSpace.alloc.insertBitMask(k, nw) }, nil
+		pd := &PoolData{Pool: nw, RefCount: 1, Sparse: sparse, Ranges: ranges, Exclusions: exclusions}
+		aSpace.subnets[k] = pd
+		return func() error { return aSpace.alloc.insertAddresses(k, pd) }, nil
 	}
 
 	// This is a new non-master pool (subPool)
 	p := &PoolData{
-		ParentKey: SubnetKey{AddressSpace: k.AddressSpace, Subnet: k.Subnet},
-		Pool:      nw,
-		Range:     ipr,
-		RefCount:  1,
+		ParentKey:  SubnetKey{AddressSpace: k.AddressSpace, Subnet: k.Subnet},
+		Pool:       nw,
+		Range:      ipr,
+		RefCount:   1,
+		Ranges:     ranges,
+		Exclusions: exclusions,
+	}
+	for sk, sp := range aSpace.subnets {
+		if sk.AddressSpace != k.AddressSpace || sk.Subnet != k.Subnet || sp.Range == nil {
+			continue
+		}
+		if len(ranges) == 0 && len(sp.Ranges) == 0 {
+			continue
+		}
+		if rangesOverlap(append([]*AddressRange{ipr}, ranges...), append([]*AddressRange{sp.Range}, sp.Ranges...)) {
+			return nil, ipamapi.ErrPoolOverlap
+		}
 	}
 	aSpace.subnets[k] = p
 
@@ -299,8 +376,9 @@ func (aSpace *addrSpace) updatePoolDBOnAdd(k SubnetKey, nw *net.IPNet, ipr *Addr
 	}
 
 	// Parent pool does not exist, add it along with corresponding bitmask
-	aSpace.subnets[p.ParentKey] = &PoolData{Pool: nw, RefCount: 1}
-	return func() error { return aSpace.alloc.insertBitMask(p.ParentKey, nw) }, nil
+	pd := &PoolData{Pool: nw, RefCount: 1, Sparse: sparse}
+	aSpace.subnets[p.ParentKey] = pd
+	return func() error { return aSpace.alloc.insertAddresses(p.ParentKey, pd) }, nil
 }
 
 func (aSpace *addrSpace) updatePoolDBOnRemoval(k SubnetKey) (func() error, error) {
@@ -318,6 +396,15 @@ func (aSpace *addrSpace) updatePoolDBOnRemoval(k SubnetKey) (func() error, error
 	for ok {
 		if c.RefCount == 0 {
 			delete(aSpace.subnets, k)
+			if c.Range == nil && usesAddrSet(c) {
+				return func() error {
+					s, err := aSpace.alloc.retrieveAddrSet(k, c.Pool)
+					if err != nil {
+						return types.InternalErrorf("could not find address set in datastore for pool %s removal: %v", k.String(), err)
+					}
+					return s.destroy()
+				}, nil
+			}
 			if c.Range == nil {
 				return func() error {
 					bm, err := aSpace.alloc.retrieveBitmask(k, c.Pool)
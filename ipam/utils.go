@@ -2,7 +2,10 @@ package ipam
 
 import (
 	"fmt"
+	"math"
 	"net"
+	"sort"
+	"strings"
 
 	"github.com/docker/libnetwork/ipamapi"
 	"github.com/docker/libnetwork/types"
@@ -36,6 +39,99 @@ func getAddressRange(pool string, masterNw *net.IPNet) (*AddressRange, error) {
 	return &AddressRange{nw, ipToUint64(types.GetMinimalIP(lIP)), ipToUint64(types.GetMinimalIP(hIP))}, nil
 }
 
+// getAddressRanges returns the address ranges of the master pool listed,
+// comma separated, in the passed string, sorted by their first address
+func getAddressRanges(list string, masterNw *net.IPNet) ([]*AddressRange, error) {
+	var rl []*AddressRange
+	for _, r := range strings.Split(list, ",") {
+		if r = strings.TrimSpace(r); r == "" {
+			continue
+		}
+		first, last, err := types.ParseAddressRange(r)
+		if err != nil {
+			return nil, types.BadRequestErrorf("%v", err)
+		}
+		if !masterNw.Contains(first) || !masterNw.Contains(last) {
+			return nil, types.BadRequestErrorf("address range %s does not belong to pool %s", r, masterNw)
+		}
+		ar := &AddressRange{Sub: types.GetIPNetCopy(masterNw)}
+		if strings.Contains(r, "/") {
+			if ar.Sub, err = types.ParseCIDR(r); err != nil {
+				return nil, types.BadRequestErrorf("%v", err)
+			}
+		}
+		if ar.Start, err = getOrdinal(first, masterNw); err != nil {
+			return nil, err
+		}
+		if ar.End, err = getOrdinal(last, masterNw); err != nil {
+			return nil, err
+		}
+		rl = append(rl, ar)
+	}
+	sort.Slice(rl, func(i, j int) bool { return rl[i].Start < rl[j].Start })
+	return rl, nil
+}
+
+// getOrdinal returns the ordinal of the address in the master pool
+func getOrdinal(ip net.IP, masterNw *net.IPNet) (uint64, error) {
+	h, err := types.GetHostPartIP(ip, masterNw.Mask)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute the host part of %s: %v", ip, err)
+	}
+	return ipToUint64(types.GetMinimalIP(h)), nil
+}
+
+// lastOrdinal returns the ordinal of the last address of the pool, as
+// tracked by its bitmask
+func lastOrdinal(nw *net.IPNet) uint64 {
+	ones, bits := nw.Mask.Size()
+	if bits-ones >= 64 {
+		// The bitmask of a /64 subnet has 2^64-1 bits
+		return math.MaxUint64 - 1
+	}
+	return 1<<uint(bits-ones) - 1
+}
+
+// rangesOverlap tells whether any of the ranges in a overlaps any in b
+func rangesOverlap(a, b []*AddressRange) bool {
+	for _, ra := range a {
+		for _, rb := range b {
+			if ra.Start <= rb.End && rb.Start <= ra.End {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// excludeRanges returns what is left of the ranges rl once the ranges
+// in excl are taken out of them
+func excludeRanges(rl, excl []*AddressRange) []*AddressRange {
+	out := []*AddressRange{}
+	for _, r := range rl {
+		segs := []*AddressRange{r}
+		for _, e := range excl {
+			var next []*AddressRange
+			for _, s := range segs {
+				if e.End < s.Start || e.Start > s.End {
+					next = append(next, s)
+					continue
+				}
+				if e.Start > s.Start {
+					next = append(next, &AddressRange{Sub: s.Sub, Start: s.Start, End: e.Start - 1})
+				}
+				if e.End < s.End {
+					next = append(next, &AddressRange{Sub: s.Sub, Start: e.End + 1, End: s.End})
+				}
+			}
+			segs = next
+		}
+		out = append(out, segs...)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Start < out[j].Start })
+	return out
+}
+
 // It generates the ip address in the passed subnet specified by
 // the passed host address ordinal
 func generateAddress(ordinal uint64, network *net.IPNet) net.IP {
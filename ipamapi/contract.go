@@ -22,6 +22,19 @@ const (
 	PluginEndpointType = "IpamDriver"
 	// RequestAddressType represents the Address Type used when requesting an address
 	RequestAddressType = "RequestAddressType"
+	// GatewayPolicy represents the policy selecting the gateway address
+	// when requesting one with no preferred address
+	GatewayPolicy = "GatewayPolicy"
+)
+
+// Gateway address selection policies
+const (
+	// GatewayFirst selects the first available address of the pool
+	GatewayFirst = "first"
+	// GatewayLast selects the last available address of the pool
+	GatewayLast = "last"
+	// GatewayNone requests no gateway address at all
+	GatewayNone = "none"
 )
 
 // Callback provides a Callback interface for registering an IPAM instance into LibNetwork
@@ -85,6 +98,31 @@ type Ipam interface {
 	IsBuiltIn() bool
 }
 
+// BatchIpam is implemented by the IPAM drivers able to process several
+// address requests or releases in a single call
+type BatchIpam interface {
+	// RequestAddresses requests the addresses of the batch. The results are
+	// in the order of the requests, a failed request does not fail the others.
+	RequestAddresses(reqs []AddressRequest) []AddressResult
+	// ReleaseAddresses releases the addresses of the batch. The errors are
+	// in the order of the requests.
+	ReleaseAddresses(reqs []AddressRequest) []error
+}
+
+// AddressRequest is an address request or release of a batch
+type AddressRequest struct {
+	PoolID  string
+	Address net.IP
+	Options map[string]string
+}
+
+// AddressResult is the result of an address request of a batch
+type AddressResult struct {
+	Address *net.IPNet
+	Data    map[string]string
+	Err     error
+}
+
 // Capability represents the requirements and capabilities of the IPAM driver
 type Capability struct {
 	// Whether on address request, libnetwork must
@@ -93,4 +131,39 @@ type Capability struct {
 	// Whether of daemon start, libnetwork must replay the pool
 	// request and the address request for current local networks
 	RequiresRequestReplay bool
+	// Health is set by the driver registry walks, for the drivers
+	// implementing HealthChecker
+	Health *types.PluginHealth
+}
+
+// Closer is implemented by the IPAM drivers holding state to write out
+// when the controller stops
+type Closer interface {
+	// Close writes out the state of the driver
+	Close() error
+}
+
+// HealthChecker is implemented by the IPAM drivers tracking their health,
+// as the remote drivers do with the calls to their plugin
+type HealthChecker interface {
+	// Health returns the current health of the driver
+	Health() types.PluginHealth
+}
+
+// PoolEvent notifies that the prefix a pool was carved from changed, so that
+// the network using the pool can be renumbered
+type PoolEvent struct {
+	PoolID string
+	// The pool currently served
+	Pool *net.IPNet
+	// The pool carved from the new prefix in place of the current one, nil
+	// if the new prefix has no room for it
+	Renumbered *net.IPNet
+}
+
+// PoolWatcher is implemented by the IPAM drivers whose pools can change
+// over time, as the ones carved from a delegated prefix
+type PoolWatcher interface {
+	// WatchPools returns a channel the pool events are sent on
+	WatchPools() <-chan PoolEvent
 }
@@ -7,4 +7,16 @@ const (
 	// AllocSerialPrefix constant marks the reserved label space for libnetwork ipam
 	// allocation ordering.(serial/first available)
 	AllocSerialPrefix = Prefix + ".ipam.serial"
+
+	// AllocSparse constant marks the reserved label space for libnetwork ipam
+	// sparse allocation of the IPv6 pool addresses (random/stable privacy)
+	AllocSparse = Prefix + ".ipam.sparse"
+
+	// AllocRanges constant marks the reserved label space for the comma
+	// separated address ranges of a pool the addresses are allocated from
+	AllocRanges = Prefix + ".ipam.ranges"
+
+	// AllocExclusions constant marks the reserved label space for the comma
+	// separated address ranges of a pool which are never allocated
+	AllocExclusions = Prefix + ".ipam.exclusions"
 )
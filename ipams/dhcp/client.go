@@ -0,0 +1,224 @@
+package dhcp
+
+import (
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	serverPort = 67
+	clientPort = 68
+
+	// retransmission schedule of a single request/reply exchange
+	retryIntervals = []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+
+	// infiniteLease is the lease time value for leases which never expire
+	infiniteLease = time.Duration(0xffffffff) * time.Second
+)
+
+// lease represents an address leased by a DHCP server to a client id
+type lease struct {
+	mac      net.HardwareAddr
+	address  *net.IPNet
+	router   net.IP
+	serverID net.IP
+	duration time.Duration
+	renewal  time.Duration
+}
+
+func newLease(mac net.HardwareAddr, ack *message) *lease {
+	l := &lease{
+		mac:      mac,
+		address:  &net.IPNet{IP: ack.yiaddr.To4(), Mask: ack.subnetMask()},
+		router:   ack.ipOption(optRouter),
+		serverID: ack.ipOption(optServerID),
+		duration: ack.durationOption(optLeaseTime),
+		renewal:  ack.durationOption(optRenewalTime),
+	}
+	if l.address.Mask == nil {
+		l.address.Mask = l.address.IP.DefaultMask()
+	}
+	if l.renewal == 0 || l.renewal >= l.duration {
+		l.renewal = l.duration / 2
+	}
+	return l
+}
+
+// client performs DHCP exchanges on behalf of the endpoints attached
+// to a given interface. Replies are dispatched to the pending exchanges
+// by transaction id.
+type client struct {
+	ifName  string
+	conn    *net.UDPConn
+	pending map[uint32]chan *message
+	sync.Mutex
+}
+
+func newClient(ifName string) (*client, error) {
+	conn, err := listen(ifName, clientPort)
+	if err != nil {
+		return nil, err
+	}
+	c := &client{
+		ifName:  ifName,
+		conn:    conn,
+		pending: make(map[uint32]chan *message),
+	}
+	go c.readLoop()
+	return c, nil
+}
+
+func (c *client) close() error {
+	return c.conn.Close()
+}
+
+func (c *client) readLoop() {
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := c.conn.ReadFrom(buf)
+		if err != nil {
+			logrus.Debugf("dhcp client on %s stopped: %v", c.ifName, err)
+			return
+		}
+		m, err := parseMessage(buf[:n])
+		if err != nil {
+			logrus.Debugf("dhcp client on %s discarded invalid message: %v", c.ifName, err)
+			continue
+		}
+		if m.op != opReply {
+			continue
+		}
+		c.Lock()
+		ch, ok := c.pending[m.xid]
+		c.Unlock()
+		if !ok {
+			continue
+		}
+		select {
+		case ch <- m:
+		default:
+		}
+	}
+}
+
+// exchange broadcasts the request until a reply of one of the
+// expected types is received or the retransmissions are exhausted.
+func (c *client) exchange(req *message, expected ...messageType) (*message, error) {
+	ch := make(chan *message, 4)
+
+	c.Lock()
+	c.pending[req.xid] = ch
+	c.Unlock()
+	defer func() {
+		c.Lock()
+		delete(c.pending, req.xid)
+		c.Unlock()
+	}()
+
+	dst := &net.UDPAddr{IP: net.IPv4bcast, Port: serverPort}
+	for _, wait := range retryIntervals {
+		if _, err := c.conn.WriteTo(req.marshal(), dst); err != nil {
+			return nil, err
+		}
+		timer := time.NewTimer(wait)
+	wait:
+		for {
+			select {
+			case m := <-ch:
+				if m.chaddr.String() != req.chaddr.String() {
+					continue
+				}
+				for _, t := range expected {
+					if m.messageType() == t {
+						timer.Stop()
+						return m, nil
+					}
+				}
+			case <-timer.C:
+				break wait
+			}
+		}
+	}
+
+	return nil, types.TimeoutErrorf("no reply from dhcp server on %s to %s", c.ifName, req.messageType())
+}
+
+func (c *client) send(m *message) error {
+	_, err := c.conn.WriteTo(m.marshal(), &net.UDPAddr{IP: net.IPv4bcast, Port: serverPort})
+	return err
+}
+
+func newRequest(t messageType, xid uint32, mac net.HardwareAddr) *message {
+	m := newMessage(t, xid, mac)
+	m.options[optParamRequest] = []byte{optSubnetMask, optRouter, optLeaseTime, optRenewalTime}
+	return m
+}
+
+// probe discovers the network the dhcp server hands out addresses on,
+// without committing to any lease.
+func (c *client) probe(mac net.HardwareAddr) (*lease, error) {
+	offer, err := c.exchange(newRequest(msgDiscover, rand.Uint32(), mac), msgOffer)
+	if err != nil {
+		return nil, err
+	}
+	return newLease(mac, offer), nil
+}
+
+// acquire obtains a lease for the passed mac address, trying to get the
+// preferred address when one is specified.
+func (c *client) acquire(mac net.HardwareAddr, preferred net.IP) (*lease, error) {
+	xid := rand.Uint32()
+
+	discover := newRequest(msgDiscover, xid, mac)
+	if preferred != nil {
+		discover.setIPOption(optRequestedIP, preferred)
+	}
+	offer, err := c.exchange(discover, msgOffer)
+	if err != nil {
+		return nil, err
+	}
+
+	request := newRequest(msgRequest, xid, mac)
+	request.setIPOption(optRequestedIP, offer.yiaddr)
+	request.setIPOption(optServerID, offer.ipOption(optServerID))
+	ack, err := c.exchange(request, msgAck, msgNak)
+	if err != nil {
+		return nil, err
+	}
+	if ack.messageType() == msgNak {
+		return nil, types.ForbiddenErrorf("dhcp server refused address %s for %s: %s", offer.yiaddr, mac, ack.options[optMessage])
+	}
+
+	return newLease(mac, ack), nil
+}
+
+// renew extends the passed lease. The leased address is not configured
+// on the host interface, so the server cannot unicast its reply to it:
+// the request is sent in the INIT-REBOOT form which is answered with a
+// broadcast.
+func (c *client) renew(l *lease) (*lease, error) {
+	request := newRequest(msgRequest, rand.Uint32(), l.mac)
+	request.setIPOption(optRequestedIP, l.address.IP)
+	ack, err := c.exchange(request, msgAck, msgNak)
+	if err != nil {
+		return nil, err
+	}
+	if ack.messageType() == msgNak {
+		return nil, types.ForbiddenErrorf("dhcp server refused to renew address %s for %s: %s", l.address.IP, l.mac, ack.options[optMessage])
+	}
+	return newLease(l.mac, ack), nil
+}
+
+// release gives the lease back to the dhcp server. No reply is expected.
+func (c *client) release(l *lease) error {
+	m := newMessage(msgRelease, rand.Uint32(), l.mac)
+	m.flags = 0
+	m.ciaddr = l.address.IP
+	m.setIPOption(optServerID, l.serverID)
+	return c.send(m)
+}
@@ -0,0 +1,46 @@
+package dhcp
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+// listen returns a broadcast capable UDP socket bound to the passed
+// port and restricted to the named interface. Several sockets may be
+// bound to the same port, each of them receives a copy of the
+// broadcast replies and filters them by transaction id.
+func listen(ifName string, port int) (*net.UDPConn, error) {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, syscall.IPPROTO_UDP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dhcp socket: %v", err)
+	}
+
+	if err := setupSocket(fd, ifName, port); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("failed to setup dhcp socket on %s: %v", ifName, err)
+	}
+
+	f := os.NewFile(uintptr(fd), fmt.Sprintf("dhcp-%s", ifName))
+	defer f.Close()
+
+	c, err := net.FilePacketConn(f)
+	if err != nil {
+		return nil, err
+	}
+	return c.(*net.UDPConn), nil
+}
+
+func setupSocket(fd int, ifName string, port int) error {
+	if err := syscall.SetsockoptInt(fd, syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1); err != nil {
+		return err
+	}
+	if err := syscall.SetsockoptInt(fd, syscall.SOL_SOCKET, syscall.SO_BROADCAST, 1); err != nil {
+		return err
+	}
+	if err := syscall.BindToDevice(fd, ifName); err != nil {
+		return err
+	}
+	return syscall.Bind(fd, &syscall.SockaddrInet4{Port: port})
+}
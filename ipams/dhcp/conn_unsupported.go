@@ -0,0 +1,13 @@
+// +build !linux
+
+package dhcp
+
+import (
+	"net"
+
+	"github.com/docker/libnetwork/types"
+)
+
+func listen(ifName string, port int) (*net.UDPConn, error) {
+	return nil, types.NotImplementedErrorf("dhcp ipam driver is not supported on this platform")
+}
@@ -0,0 +1,299 @@
+// Package dhcp implements an ipam driver which leases the endpoint
+// addresses from the DHCP server of the network the parent interface is
+// attached to. It is meant for macvlan and ipvlan networks bridged to an
+// existing LAN, where addresses must not collide with the ones handed out
+// by the LAN DHCP server.
+package dhcp
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/docker/libnetwork/discoverapi"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/netutils"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DriverName is the name of the dhcp ipam driver
+	DriverName = "dhcp"
+	// ParentInterface is the ipam option naming the host interface
+	// the DHCP requests are sent from
+	ParentInterface = "dhcp_interface"
+
+	localAddressSpace  = "LocalDefault"
+	globalAddressSpace = "GlobalDefault"
+)
+
+// minRenewRetry is the minimum interval between failed renewal attempts
+var minRenewRetry = time.Minute
+
+type allocator struct {
+	pools map[string]*pool
+	sync.Mutex
+}
+
+type pool struct {
+	id       string
+	client   *client
+	subnet   *net.IPNet
+	router   net.IP
+	bindings map[string]*binding
+}
+
+// binding is a lease held for an endpoint, kept alive in background
+type binding struct {
+	lease *lease
+	stop  chan struct{}
+}
+
+// Init registers the dhcp ipam driver with libnetwork
+func Init(ic ipamapi.Callback, l, g interface{}) error {
+	cps := &ipamapi.Capability{RequiresMACAddress: true, RequiresRequestReplay: true}
+	return ic.RegisterIpamDriverWithCapabilities(DriverName, newAllocator(), cps)
+}
+
+func newAllocator() *allocator {
+	return &allocator{pools: make(map[string]*pool)}
+}
+
+func (a *allocator) GetDefaultAddressSpaces() (string, string, error) {
+	return localAddressSpace, globalAddressSpace, nil
+}
+
+// RequestPool discovers the subnet served on the parent interface and
+// verifies it matches the requested pool, if any
+func (a *allocator) RequestPool(addressSpace, requestedPool, subPool string, options map[string]string, v6 bool) (string, *net.IPNet, map[string]string, error) {
+	if addressSpace != localAddressSpace && addressSpace != globalAddressSpace {
+		return "", nil, nil, types.BadRequestErrorf("unknown address space: %s", addressSpace)
+	}
+	if v6 {
+		return "", nil, nil, types.BadRequestErrorf("dhcp ipam driver does not handle IPv6 address pool requests")
+	}
+	if subPool != "" {
+		return "", nil, nil, types.BadRequestErrorf("dhcp ipam driver does not handle specific address subpool requests")
+	}
+	ifName, ok := options[ParentInterface]
+	if !ok || ifName == "" {
+		return "", nil, nil, types.BadRequestErrorf("dhcp ipam driver requires the %q option", ParentInterface)
+	}
+
+	c, err := newClient(ifName)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	offer, err := c.probe(netutils.GenerateRandomMAC())
+	if err != nil {
+		c.close()
+		return "", nil, nil, fmt.Errorf("failed to discover the dhcp served network on %s: %v", ifName, err)
+	}
+	subnet := types.GetIPNetCanonical(offer.address)
+
+	if requestedPool != "" {
+		_, rp, err := net.ParseCIDR(requestedPool)
+		if err != nil {
+			c.close()
+			return "", nil, nil, types.BadRequestErrorf("invalid pool %s: %v", requestedPool, err)
+		}
+		if !types.CompareIPNet(rp, subnet) {
+			c.close()
+			return "", nil, nil, types.BadRequestErrorf("requested pool %s does not match the network %s served by dhcp on %s", requestedPool, subnet, ifName)
+		}
+	}
+
+	p := &pool{
+		id:       fmt.Sprintf("%s/%s/%s", DriverName, ifName, subnet),
+		client:   c,
+		subnet:   subnet,
+		router:   offer.router,
+		bindings: make(map[string]*binding),
+	}
+
+	a.Lock()
+	if _, ok := a.pools[p.id]; ok {
+		a.Unlock()
+		c.close()
+		return "", nil, nil, ipamapi.ErrPoolOverlap
+	}
+	a.pools[p.id] = p
+	a.Unlock()
+
+	var meta map[string]string
+	if p.router != nil {
+		meta = map[string]string{netlabel.Gateway: (&net.IPNet{IP: p.router, Mask: subnet.Mask}).String()}
+	}
+
+	logrus.Debugf("dhcp ipam pool %s registered, gateway %v", p.id, p.router)
+
+	return p.id, subnet, meta, nil
+}
+
+func (a *allocator) ReleasePool(poolID string) error {
+	a.Lock()
+	p, ok := a.pools[poolID]
+	if !ok {
+		a.Unlock()
+		return types.NotFoundErrorf("unknown pool id: %s", poolID)
+	}
+	delete(a.pools, poolID)
+	leases := make([]*lease, 0, len(p.bindings))
+	for _, b := range p.bindings {
+		close(b.stop)
+		leases = append(leases, b.lease)
+	}
+	a.Unlock()
+
+	for _, l := range leases {
+		if err := p.client.release(l); err != nil {
+			logrus.Warnf("failed to release dhcp lease for %s on pool %s: %v", l.address.IP, poolID, err)
+		}
+	}
+
+	return p.client.close()
+}
+
+// RequestAddress leases an address for the endpoint mac address passed in
+// the options. Gateway and auxiliary address requests are not forwarded
+// to the dhcp server.
+func (a *allocator) RequestAddress(poolID string, ip net.IP, opts map[string]string) (*net.IPNet, map[string]string, error) {
+	a.Lock()
+	p, ok := a.pools[poolID]
+	a.Unlock()
+	if !ok {
+		return nil, nil, types.NotFoundErrorf("unknown pool id: %s", poolID)
+	}
+
+	if opts[ipamapi.RequestAddressType] == netlabel.Gateway {
+		if ip == nil {
+			if p.router == nil {
+				return nil, nil, types.BadRequestErrorf("dhcp server on %s did not provide a router for pool %s", p.client.ifName, poolID)
+			}
+			ip = p.router
+		}
+		return &net.IPNet{IP: ip, Mask: p.subnet.Mask}, nil, nil
+	}
+
+	macStr, ok := opts[netlabel.MacAddress]
+	if !ok {
+		if ip != nil {
+			// Auxiliary address, reserved outside of dhcp
+			return &net.IPNet{IP: ip, Mask: p.subnet.Mask}, nil, nil
+		}
+		return nil, nil, types.BadRequestErrorf("dhcp ipam driver requires the endpoint mac address")
+	}
+	mac, err := net.ParseMAC(macStr)
+	if err != nil {
+		return nil, nil, types.BadRequestErrorf("invalid mac address %s: %v", macStr, err)
+	}
+
+	l, err := p.client.acquire(mac, ip)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ip != nil && !ip.Equal(l.address.IP) {
+		if err := p.client.release(l); err != nil {
+			logrus.Warnf("failed to release dhcp lease for %s on pool %s: %v", l.address.IP, poolID, err)
+		}
+		return nil, nil, ipamapi.ErrIPAlreadyAllocated
+	}
+	if !p.subnet.Contains(l.address.IP) {
+		if err := p.client.release(l); err != nil {
+			logrus.Warnf("failed to release dhcp lease for %s on pool %s: %v", l.address.IP, poolID, err)
+		}
+		return nil, nil, types.InternalErrorf("dhcp server leased address %s outside of pool %s", l.address.IP, poolID)
+	}
+
+	b := &binding{lease: l, stop: make(chan struct{})}
+	a.Lock()
+	if old, ok := p.bindings[l.address.IP.String()]; ok {
+		close(old.stop)
+	}
+	p.bindings[l.address.IP.String()] = b
+	a.Unlock()
+
+	go a.keepAlive(p, b)
+
+	return &net.IPNet{IP: l.address.IP, Mask: p.subnet.Mask}, nil, nil
+}
+
+// keepAlive renews the binding lease until it is stopped
+func (a *allocator) keepAlive(p *pool, b *binding) {
+	a.Lock()
+	l := b.lease
+	a.Unlock()
+
+	if l.duration == infiniteLease {
+		return
+	}
+
+	expiry := time.Now().Add(l.duration)
+	next := l.renewal
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-time.After(next):
+		}
+
+		nl, err := p.client.renew(l)
+		if err != nil {
+			next = time.Until(expiry) / 2
+			if next < minRenewRetry {
+				next = minRenewRetry
+			}
+			logrus.Warnf("failed to renew dhcp lease for %s on pool %s, retrying in %s: %v", l.address.IP, p.id, next, err)
+			continue
+		}
+
+		l = nl
+		expiry = time.Now().Add(l.duration)
+		next = l.renewal
+
+		a.Lock()
+		b.lease = l
+		a.Unlock()
+
+		if l.duration == infiniteLease {
+			return
+		}
+	}
+}
+
+func (a *allocator) ReleaseAddress(poolID string, ip net.IP) error {
+	a.Lock()
+	p, ok := a.pools[poolID]
+	if !ok {
+		a.Unlock()
+		return types.NotFoundErrorf("unknown pool id: %s", poolID)
+	}
+	b, ok := p.bindings[ip.String()]
+	if !ok {
+		// Gateway or auxiliary address
+		a.Unlock()
+		return nil
+	}
+	delete(p.bindings, ip.String())
+	close(b.stop)
+	l := b.lease
+	a.Unlock()
+
+	return p.client.release(l)
+}
+
+func (a *allocator) DiscoverNew(dType discoverapi.DiscoveryType, data interface{}) error {
+	return nil
+}
+
+func (a *allocator) DiscoverDelete(dType discoverapi.DiscoveryType, data interface{}) error {
+	return nil
+}
+
+func (a *allocator) IsBuiltIn() bool {
+	return true
+}
@@ -0,0 +1,320 @@
+// +build linux
+
+package dhcp
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/testutils"
+	"github.com/docker/libnetwork/types"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	serverIf = "dhcps0"
+	clientIf = "dhcpc0"
+)
+
+// testServer is a minimal in-process DHCP server handing out
+// addresses from .100 onwards on a /24
+type testServer struct {
+	conn      *net.UDPConn
+	ip        net.IP
+	mask      net.IPMask
+	leaseTime time.Duration
+	leases    map[string]net.IP
+	requests  int
+	releases  int
+	sync.Mutex
+}
+
+// newTestServer starts the server in its own network namespace, on the
+// server end of the veth pair created by setupVethPair.
+func newTestServer(t *testing.T, leaseTime time.Duration) *testServer {
+	origin, err := netns.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer origin.Close()
+
+	link, err := netlink.LinkByName(serverIf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverNs, err := netns.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverNs.Close()
+	defer netns.Set(origin)
+
+	// netns.New switched to the new namespace, the server end of
+	// the pair must be moved from the origin one
+	if err := netns.Set(origin); err != nil {
+		t.Fatal(err)
+	}
+	if err := netlink.LinkSetNsFd(link, int(serverNs)); err != nil {
+		t.Fatal(err)
+	}
+	if err := netns.Set(serverNs); err != nil {
+		t.Fatal(err)
+	}
+
+	if link, err = netlink.LinkByName(serverIf); err != nil {
+		t.Fatal(err)
+	}
+	addr, _ := types.ParseCIDR("192.168.57.1/24")
+	if err := netlink.AddrAdd(link, &netlink.Addr{IPNet: addr}); err != nil {
+		t.Fatal(err)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := listen(serverIf, serverPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &testServer{
+		conn:      conn,
+		ip:        addr.IP.To4(),
+		mask:      addr.Mask,
+		leaseTime: leaseTime,
+		leases:    make(map[string]net.IP),
+	}
+	go s.serve()
+	return s
+}
+
+func (s *testServer) serve() {
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		m, err := parseMessage(buf[:n])
+		if err != nil || m.op != opRequest {
+			continue
+		}
+		if reply := s.handle(m); reply != nil {
+			s.conn.WriteTo(reply.marshal(), &net.UDPAddr{IP: net.IPv4bcast, Port: clientPort})
+		}
+	}
+}
+
+func (s *testServer) owner(ip net.IP) string {
+	for id, lip := range s.leases {
+		if lip.Equal(ip) {
+			return id
+		}
+	}
+	return ""
+}
+
+func (s *testServer) handle(m *message) *message {
+	s.Lock()
+	defer s.Unlock()
+
+	id := string(m.options[optClientID])
+	switch m.messageType() {
+	case msgDiscover:
+		ip, ok := s.leases[id]
+		if !ok {
+			if req := m.ipOption(optRequestedIP); req != nil && s.owner(req) == "" {
+				ip = req
+			} else {
+				for i := 100; i < 200; i++ {
+					ip = net.IPv4(192, 168, 57, byte(i)).To4()
+					if s.owner(ip) == "" {
+						break
+					}
+				}
+			}
+		}
+		return s.reply(m, msgOffer, ip)
+	case msgRequest:
+		if sid := m.ipOption(optServerID); sid != nil && !sid.Equal(s.ip) {
+			return nil
+		}
+		s.requests++
+		ip := m.ipOption(optRequestedIP)
+		if o := s.owner(ip); o != "" && o != id {
+			return s.reply(m, msgNak, nil)
+		}
+		s.leases[id] = ip
+		return s.reply(m, msgAck, ip)
+	case msgRelease:
+		s.releases++
+		if s.leases[id].Equal(m.ciaddr) {
+			delete(s.leases, id)
+		}
+	}
+	return nil
+}
+
+func (s *testServer) reply(m *message, t messageType, ip net.IP) *message {
+	r := newMessage(t, m.xid, m.chaddr)
+	r.op = opReply
+	r.yiaddr = ip
+	delete(r.options, optClientID)
+	r.setIPOption(optServerID, s.ip)
+	if t != msgNak {
+		r.options[optSubnetMask] = []byte(s.mask)
+		r.setIPOption(optRouter, s.ip)
+		r.setDurationOption(optLeaseTime, s.leaseTime)
+	}
+	return r
+}
+
+func (s *testServer) counters() (int, int, int) {
+	s.Lock()
+	defer s.Unlock()
+	return len(s.leases), s.requests, s.releases
+}
+
+// waitForReleases gives the server a chance to process the release
+// messages, which do not get a reply
+func waitForReleases(s *testServer, n int) {
+	for i := 0; i < 20; i++ {
+		if _, _, releases := s.counters(); releases >= n {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func setupVethPair(t *testing.T) {
+	if err := netlink.LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: clientIf},
+		PeerName:  serverIf,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	link, err := netlink.LinkByName(clientIf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := netlink.LinkSetUp(link); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDhcpAllocator(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+	setupVethPair(t)
+
+	s := newTestServer(t, time.Hour)
+	defer s.conn.Close()
+
+	a := newAllocator()
+	opts := map[string]string{ParentInterface: clientIf}
+
+	if _, _, _, err := a.RequestPool(localAddressSpace, "", "", nil, false); err == nil {
+		t.Fatal("expected failure without parent interface")
+	}
+	if _, _, _, err := a.RequestPool(localAddressSpace, "10.10.0.0/16", "", opts, false); err == nil {
+		t.Fatal("expected failure on pool not matching the dhcp served network")
+	}
+
+	pid, pool, meta, err := a.RequestPool(localAddressSpace, "", "", opts, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.String() != "192.168.57.0/24" {
+		t.Fatalf("unexpected pool %s", pool)
+	}
+	if meta[netlabel.Gateway] != "192.168.57.1/24" {
+		t.Fatalf("unexpected gateway %s", meta[netlabel.Gateway])
+	}
+
+	if _, _, err := a.RequestAddress(pid, nil, nil); err == nil {
+		t.Fatal("expected failure without mac address")
+	}
+
+	gw, _, err := a.RequestAddress(pid, nil, map[string]string{ipamapi.RequestAddressType: netlabel.Gateway})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gw.String() != "192.168.57.1/24" {
+		t.Fatalf("unexpected gateway %s", gw)
+	}
+
+	ip1, _, err := a.RequestAddress(pid, nil, map[string]string{netlabel.MacAddress: "02:42:c0:a8:39:01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip1.String() != "192.168.57.100/24" {
+		t.Fatalf("unexpected address %s", ip1)
+	}
+
+	ip2, _, err := a.RequestAddress(pid, net.ParseIP("192.168.57.150"), map[string]string{netlabel.MacAddress: "02:42:c0:a8:39:02"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip2.String() != "192.168.57.150/24" {
+		t.Fatalf("unexpected address %s", ip2)
+	}
+
+	if _, _, err := a.RequestAddress(pid, net.ParseIP("192.168.57.150"), map[string]string{netlabel.MacAddress: "02:42:c0:a8:39:03"}); err == nil {
+		t.Fatal("expected failure on address leased to another client")
+	}
+
+	// The lease offered in place of the unavailable address is given back
+	waitForReleases(s, 1)
+	if leases, _, _ := s.counters(); leases != 2 {
+		t.Fatalf("expected 2 leases on the server, got %d", leases)
+	}
+
+	if err := a.ReleaseAddress(pid, ip1.IP); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ReleaseAddress(pid, gw.IP); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ReleasePool(pid); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForReleases(s, 3)
+	if leases, _, releases := s.counters(); leases != 0 || releases != 3 {
+		t.Fatalf("expected all leases to be released, got %d leases and %d releases", leases, releases)
+	}
+
+	if err := a.ReleasePool(pid); err == nil {
+		t.Fatal("expected failure on unknown pool")
+	}
+}
+
+func TestDhcpLeaseRenewal(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+	setupVethPair(t)
+
+	s := newTestServer(t, 2*time.Second)
+	defer s.conn.Close()
+
+	a := newAllocator()
+	pid, _, _, err := a.RequestPool(localAddressSpace, "192.168.57.0/24", "", map[string]string{ParentInterface: clientIf}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.ReleasePool(pid)
+
+	if _, _, err := a.RequestAddress(pid, nil, map[string]string{netlabel.MacAddress: "02:42:c0:a8:39:01"}); err != nil {
+		t.Fatal(err)
+	}
+	_, requests, _ := s.counters()
+
+	time.Sleep(2500 * time.Millisecond)
+
+	if _, r, _ := s.counters(); r < requests+2 {
+		t.Fatalf("expected the lease to be renewed at least twice, got %d renewals", r-requests)
+	}
+}
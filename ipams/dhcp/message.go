@@ -0,0 +1,234 @@
+package dhcp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// messageType is the value of the DHCP message type option (RFC 2132, 9.6)
+type messageType byte
+
+const (
+	msgDiscover messageType = iota + 1
+	msgOffer
+	msgRequest
+	msgDecline
+	msgAck
+	msgNak
+	msgRelease
+)
+
+func (t messageType) String() string {
+	switch t {
+	case msgDiscover:
+		return "DHCPDISCOVER"
+	case msgOffer:
+		return "DHCPOFFER"
+	case msgRequest:
+		return "DHCPREQUEST"
+	case msgDecline:
+		return "DHCPDECLINE"
+	case msgAck:
+		return "DHCPACK"
+	case msgNak:
+		return "DHCPNAK"
+	case msgRelease:
+		return "DHCPRELEASE"
+	}
+	return fmt.Sprintf("DHCP(%d)", byte(t))
+}
+
+// DHCP option codes used by the driver (RFC 2132)
+const (
+	optPad          byte = 0
+	optSubnetMask   byte = 1
+	optRouter       byte = 3
+	optRequestedIP  byte = 50
+	optLeaseTime    byte = 51
+	optMessageType  byte = 53
+	optServerID     byte = 54
+	optParamRequest byte = 55
+	optMessage      byte = 56
+	optRenewalTime  byte = 58
+	optRebindTime   byte = 59
+	optClientID     byte = 61
+	optEnd          byte = 255
+)
+
+const (
+	opRequest byte = 1
+	opReply   byte = 2
+
+	htypeEthernet byte = 1
+
+	// flagBroadcast asks the server to broadcast its replies, as the
+	// leased address is never configured on the host side interface
+	flagBroadcast uint16 = 0x8000
+
+	// fixed part of the message, up to and including the magic cookie
+	headerLen = 240
+)
+
+var magicCookie = []byte{99, 130, 83, 99}
+
+// message is a DHCPv4 message as defined in RFC 2131
+type message struct {
+	op      byte
+	xid     uint32
+	secs    uint16
+	flags   uint16
+	ciaddr  net.IP
+	yiaddr  net.IP
+	siaddr  net.IP
+	giaddr  net.IP
+	chaddr  net.HardwareAddr
+	options map[byte][]byte
+}
+
+func newMessage(t messageType, xid uint32, mac net.HardwareAddr) *message {
+	m := &message{
+		op:      opRequest,
+		xid:     xid,
+		flags:   flagBroadcast,
+		chaddr:  mac,
+		options: make(map[byte][]byte),
+	}
+	m.options[optMessageType] = []byte{byte(t)}
+	m.options[optClientID] = clientID(mac)
+	return m
+}
+
+// clientID returns the client identifier option for the passed mac
+// address, formatted as hardware type followed by the address.
+func clientID(mac net.HardwareAddr) []byte {
+	return append([]byte{htypeEthernet}, mac...)
+}
+
+func (m *message) marshal() []byte {
+	b := make([]byte, headerLen, headerLen+64)
+	b[0] = m.op
+	b[1] = htypeEthernet
+	b[2] = byte(len(m.chaddr))
+	binary.BigEndian.PutUint32(b[4:8], m.xid)
+	binary.BigEndian.PutUint16(b[8:10], m.secs)
+	binary.BigEndian.PutUint16(b[10:12], m.flags)
+	copy(b[12:16], m.ciaddr.To4())
+	copy(b[16:20], m.yiaddr.To4())
+	copy(b[20:24], m.siaddr.To4())
+	copy(b[24:28], m.giaddr.To4())
+	copy(b[28:44], m.chaddr)
+	copy(b[236:240], magicCookie)
+
+	// Emit the message type first, some servers expect it
+	b = appendOption(b, optMessageType, m.options[optMessageType])
+	for code, val := range m.options {
+		if code == optMessageType {
+			continue
+		}
+		b = appendOption(b, code, val)
+	}
+	b = append(b, optEnd)
+
+	// Pad to the minimum BOOTP message size
+	for len(b) < 300 {
+		b = append(b, optPad)
+	}
+	return b
+}
+
+func appendOption(b []byte, code byte, val []byte) []byte {
+	if val == nil {
+		return b
+	}
+	return append(append(b, code, byte(len(val))), val...)
+}
+
+func parseMessage(b []byte) (*message, error) {
+	if len(b) < headerLen {
+		return nil, fmt.Errorf("dhcp message too short: %d bytes", len(b))
+	}
+	if b[1] != htypeEthernet || b[2] > 16 {
+		return nil, fmt.Errorf("unsupported dhcp hardware type %d (len %d)", b[1], b[2])
+	}
+	for i := range magicCookie {
+		if b[236+i] != magicCookie[i] {
+			return nil, fmt.Errorf("invalid dhcp magic cookie")
+		}
+	}
+
+	m := &message{
+		op:      b[0],
+		xid:     binary.BigEndian.Uint32(b[4:8]),
+		secs:    binary.BigEndian.Uint16(b[8:10]),
+		flags:   binary.BigEndian.Uint16(b[10:12]),
+		ciaddr:  net.IP(append([]byte(nil), b[12:16]...)),
+		yiaddr:  net.IP(append([]byte(nil), b[16:20]...)),
+		siaddr:  net.IP(append([]byte(nil), b[20:24]...)),
+		giaddr:  net.IP(append([]byte(nil), b[24:28]...)),
+		chaddr:  net.HardwareAddr(append([]byte(nil), b[28:28+b[2]]...)),
+		options: make(map[byte][]byte),
+	}
+
+	opts := b[headerLen:]
+	for len(opts) > 0 {
+		code := opts[0]
+		if code == optEnd {
+			break
+		}
+		if code == optPad {
+			opts = opts[1:]
+			continue
+		}
+		if len(opts) < 2 || len(opts) < 2+int(opts[1]) {
+			return nil, fmt.Errorf("truncated dhcp option %d", code)
+		}
+		l := int(opts[1])
+		// Repeated options are concatenated (RFC 3396)
+		m.options[code] = append(m.options[code], opts[2:2+l]...)
+		opts = opts[2+l:]
+	}
+
+	return m, nil
+}
+
+func (m *message) messageType() messageType {
+	if v := m.options[optMessageType]; len(v) == 1 {
+		return messageType(v[0])
+	}
+	return 0
+}
+
+func (m *message) ipOption(code byte) net.IP {
+	if v := m.options[code]; len(v) >= net.IPv4len {
+		return net.IP(v[:net.IPv4len])
+	}
+	return nil
+}
+
+func (m *message) setIPOption(code byte, ip net.IP) {
+	if ip4 := ip.To4(); ip4 != nil {
+		m.options[code] = []byte(ip4)
+	}
+}
+
+func (m *message) durationOption(code byte) time.Duration {
+	if v := m.options[code]; len(v) == 4 {
+		return time.Duration(binary.BigEndian.Uint32(v)) * time.Second
+	}
+	return 0
+}
+
+func (m *message) setDurationOption(code byte, d time.Duration) {
+	v := make([]byte, 4)
+	binary.BigEndian.PutUint32(v, uint32(d/time.Second))
+	m.options[code] = v
+}
+
+func (m *message) subnetMask() net.IPMask {
+	if v := m.options[optSubnetMask]; len(v) == net.IPv4len {
+		return net.IPMask(v)
+	}
+	return nil
+}
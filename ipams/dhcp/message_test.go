@@ -0,0 +1,83 @@
+package dhcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	_ "github.com/docker/libnetwork/testutils"
+)
+
+func TestMessageMarshalParse(t *testing.T) {
+	mac, _ := net.ParseMAC("02:42:ac:11:00:02")
+	m := newRequest(msgRequest, 0xdeadbeef, mac)
+	m.setIPOption(optRequestedIP, net.ParseIP("192.168.1.10"))
+	m.setDurationOption(optLeaseTime, time.Hour)
+
+	p, err := parseMessage(m.marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.op != opRequest || p.xid != 0xdeadbeef || p.flags != flagBroadcast {
+		t.Fatalf("unexpected header: op %d, xid %x, flags %x", p.op, p.xid, p.flags)
+	}
+	if p.chaddr.String() != mac.String() {
+		t.Fatalf("expected chaddr %s, got %s", mac, p.chaddr)
+	}
+	if p.messageType() != msgRequest {
+		t.Fatalf("expected %s, got %s", msgRequest, p.messageType())
+	}
+	if ip := p.ipOption(optRequestedIP); !ip.Equal(net.ParseIP("192.168.1.10")) {
+		t.Fatalf("unexpected requested ip %v", ip)
+	}
+	if d := p.durationOption(optLeaseTime); d != time.Hour {
+		t.Fatalf("unexpected lease time %v", d)
+	}
+	if string(p.options[optClientID]) != string(clientID(mac)) {
+		t.Fatalf("unexpected client id %v", p.options[optClientID])
+	}
+}
+
+func TestParseInvalidMessage(t *testing.T) {
+	if _, err := parseMessage(make([]byte, 100)); err == nil {
+		t.Fatal("expected failure on short message")
+	}
+
+	mac, _ := net.ParseMAC("02:42:ac:11:00:02")
+	b := newMessage(msgDiscover, 1, mac).marshal()
+	b[236] = 0
+	if _, err := parseMessage(b); err == nil {
+		t.Fatal("expected failure on invalid magic cookie")
+	}
+
+	b = newMessage(msgDiscover, 1, mac).marshal()
+	b = append(b[:headerLen], optRouter, 4, 10)
+	if _, err := parseMessage(b); err == nil {
+		t.Fatal("expected failure on truncated option")
+	}
+}
+
+func TestLeaseTimers(t *testing.T) {
+	mac, _ := net.ParseMAC("02:42:ac:11:00:02")
+	ack := newMessage(msgAck, 1, mac)
+	ack.yiaddr = net.ParseIP("10.0.0.5")
+	ack.setDurationOption(optLeaseTime, time.Hour)
+
+	l := newLease(mac, ack)
+	if l.renewal != 30*time.Minute {
+		t.Fatalf("expected default renewal at half the lease time, got %v", l.renewal)
+	}
+	if l.address.String() != "10.0.0.5/8" {
+		t.Fatalf("expected classful mask when none is provided, got %s", l.address)
+	}
+
+	ack.setDurationOption(optRenewalTime, 10*time.Minute)
+	ack.options[optSubnetMask] = []byte{255, 255, 255, 0}
+	l = newLease(mac, ack)
+	if l.renewal != 10*time.Minute {
+		t.Fatalf("expected server provided renewal time, got %v", l.renewal)
+	}
+	if l.address.String() != "10.0.0.5/24" {
+		t.Fatalf("unexpected address %s", l.address)
+	}
+}
@@ -0,0 +1,257 @@
+package dhcppd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	serverPort = 547
+	clientPort = 546
+
+	// allServers is the All_DHCP_Relay_Agents_and_Servers address
+	allServers = net.ParseIP("ff02::1:2")
+
+	// retransmission schedule of a single request/reply exchange
+	retryIntervals = []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+)
+
+// lease is a prefix delegated by a DHCPv6 server
+type lease struct {
+	serverID []byte
+	prefix   *net.IPNet
+	t1       time.Duration
+	t2       time.Duration
+	valid    time.Duration
+	obtained time.Time
+}
+
+// newLease returns the lease of the first valid prefix of the IA_PD of the
+// reply
+func newLease(reply *message, iaid uint32) (*lease, error) {
+	if code, msg := status(reply.options); code != statusSuccess {
+		return nil, types.ForbiddenErrorf("dhcpv6 server refused the prefix delegation (status %d): %s", code, msg)
+	}
+	ia, err := reply.iaPD()
+	if err != nil {
+		return nil, err
+	}
+	if ia == nil || ia.iaid != iaid {
+		return nil, types.InternalErrorf("dhcpv6 server reply carries no prefix delegation")
+	}
+	if ia.status != statusSuccess {
+		return nil, types.ForbiddenErrorf("dhcpv6 server refused the prefix delegation (status %d): %s", ia.status, ia.message)
+	}
+	for _, p := range ia.prefixes {
+		if p.valid == 0 {
+			continue
+		}
+		l := &lease{
+			serverID: reply.options[optServerID],
+			prefix:   p.prefix,
+			t1:       ia.t1,
+			t2:       ia.t2,
+			valid:    p.valid,
+			obtained: time.Now(),
+		}
+		// Timers left to the client choice (RFC 8415, 21.21)
+		if l.t1 == 0 || l.t1 > l.valid {
+			l.t1 = p.preferred / 2
+		}
+		if l.t2 == 0 || l.t2 < l.t1 || l.t2 > l.valid {
+			l.t2 = p.preferred * 4 / 5
+		}
+		return l, nil
+	}
+	return nil, types.InternalErrorf("dhcpv6 server reply carries no valid prefix")
+}
+
+// client performs the prefix delegation exchanges on a host interface.
+// Replies are dispatched to the pending exchanges by transaction id.
+type client struct {
+	ifName  string
+	duid    []byte
+	iaid    uint32
+	conn    net.PacketConn
+	server  net.Addr
+	pending map[uint32]chan *message
+	sync.Mutex
+}
+
+// newClient returns a client sending its requests from the interface
+// ifName. The interface index is used as the identity association id.
+func newClient(ifName string) (*client, error) {
+	iface, err := net.InterfaceByName(ifName)
+	if err != nil {
+		return nil, types.BadRequestErrorf("could not find interface %s: %v", ifName, err)
+	}
+	if len(iface.HardwareAddr) == 0 {
+		return nil, types.BadRequestErrorf("interface %s has no hardware address", ifName)
+	}
+	conn, err := listen(ifName, clientPort)
+	if err != nil {
+		return nil, err
+	}
+	server := &net.UDPAddr{IP: allServers, Port: serverPort, Zone: ifName}
+	return startClient(ifName, duid(iface.HardwareAddr), uint32(iface.Index), conn, server), nil
+}
+
+func startClient(ifName string, duid []byte, iaid uint32, conn net.PacketConn, server net.Addr) *client {
+	c := &client{
+		ifName:  ifName,
+		duid:    duid,
+		iaid:    iaid,
+		conn:    conn,
+		server:  server,
+		pending: make(map[uint32]chan *message),
+	}
+	go c.readLoop()
+	return c
+}
+
+func (c *client) close() error {
+	return c.conn.Close()
+}
+
+func (c *client) readLoop() {
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := c.conn.ReadFrom(buf)
+		if err != nil {
+			logrus.Debugf("dhcpv6 client on %s stopped: %v", c.ifName, err)
+			return
+		}
+		m, err := parseMessage(buf[:n])
+		if err != nil {
+			logrus.Debugf("dhcpv6 client on %s discarded invalid message: %v", c.ifName, err)
+			continue
+		}
+		if m.msgType != msgAdvertise && m.msgType != msgReply {
+			continue
+		}
+		// Several clients may share the socket port, each one only
+		// takes the replies to its own requests
+		if !bytes.Equal(m.options[optClientID], c.duid) {
+			continue
+		}
+		c.Lock()
+		ch, ok := c.pending[m.xid]
+		c.Unlock()
+		if !ok {
+			continue
+		}
+		select {
+		case ch <- m:
+		default:
+		}
+	}
+}
+
+// exchange sends the request until a reply of the expected type is
+// received or the retransmissions are exhausted
+func (c *client) exchange(req *message, expected messageType) (*message, error) {
+	ch := make(chan *message, 4)
+
+	c.Lock()
+	c.pending[req.xid] = ch
+	c.Unlock()
+	defer func() {
+		c.Lock()
+		delete(c.pending, req.xid)
+		c.Unlock()
+	}()
+
+	start := time.Now()
+	for _, wait := range retryIntervals {
+		elapsed := make([]byte, 2)
+		binary.BigEndian.PutUint16(elapsed, uint16(time.Since(start)/(10*time.Millisecond)))
+		req.options[optElapsedTime] = elapsed
+		if _, err := c.conn.WriteTo(req.marshal(), c.server); err != nil {
+			return nil, err
+		}
+		timer := time.NewTimer(wait)
+	wait:
+		for {
+			select {
+			case m := <-ch:
+				if m.msgType == expected {
+					timer.Stop()
+					return m, nil
+				}
+			case <-timer.C:
+				break wait
+			}
+		}
+	}
+
+	return nil, types.TimeoutErrorf("no reply from dhcpv6 server on %s to %s", c.ifName, req.msgType)
+}
+
+func (c *client) newRequest(t messageType, l *lease) *message {
+	m := newMessage(t, rand.Uint32())
+	m.options[optClientID] = c.duid
+	m.options[optORO] = []byte{0, byte(optIAPD)}
+	ia := &iaPD{iaid: c.iaid}
+	if l != nil {
+		ia.prefixes = []iaPrefix{{prefix: l.prefix}}
+	}
+	m.setIAPD(ia)
+	return m
+}
+
+// solicit obtains a prefix delegation from the first server advertising one
+func (c *client) solicit() (*lease, error) {
+	adv, err := c.exchange(c.newRequest(msgSolicit, nil), msgAdvertise)
+	if err != nil {
+		return nil, err
+	}
+	offer, err := newLease(adv, c.iaid)
+	if err != nil {
+		return nil, err
+	}
+
+	req := c.newRequest(msgRequest, offer)
+	req.options[optServerID] = offer.serverID
+	reply, err := c.exchange(req, msgReply)
+	if err != nil {
+		return nil, err
+	}
+	return newLease(reply, c.iaid)
+}
+
+// renew extends the lifetimes of the lease with the server which delegated
+// the prefix. The server may delegate another prefix in its reply.
+func (c *client) renew(l *lease) (*lease, error) {
+	req := c.newRequest(msgRenew, l)
+	req.options[optServerID] = l.serverID
+	reply, err := c.exchange(req, msgReply)
+	if err != nil {
+		return nil, err
+	}
+	return newLease(reply, c.iaid)
+}
+
+// rebind extends the lifetimes of the lease with any server, once the one
+// which delegated the prefix failed to answer
+func (c *client) rebind(l *lease) (*lease, error) {
+	reply, err := c.exchange(c.newRequest(msgRebind, l), msgReply)
+	if err != nil {
+		return nil, err
+	}
+	return newLease(reply, c.iaid)
+}
+
+// release gives the prefix back to the server. The reply is not waited for.
+func (c *client) release(l *lease) error {
+	m := c.newRequest(msgRelease, l)
+	m.options[optServerID] = l.serverID
+	_, err := c.conn.WriteTo(m.marshal(), c.server)
+	return err
+}
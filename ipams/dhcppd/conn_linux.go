@@ -0,0 +1,38 @@
+package dhcppd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+// listen returns a UDP socket bound to the passed port and restricted to
+// the named interface. Several sockets may be bound to the same port, the
+// replies are filtered by client id and transaction id.
+func listen(ifName string, port int) (net.PacketConn, error) {
+	fd, err := syscall.Socket(syscall.AF_INET6, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, syscall.IPPROTO_UDP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dhcpv6 socket: %v", err)
+	}
+
+	if err := setupSocket(fd, ifName, port); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("failed to setup dhcpv6 socket on %s: %v", ifName, err)
+	}
+
+	f := os.NewFile(uintptr(fd), fmt.Sprintf("dhcpv6-%s", ifName))
+	defer f.Close()
+
+	return net.FilePacketConn(f)
+}
+
+func setupSocket(fd int, ifName string, port int) error {
+	if err := syscall.SetsockoptInt(fd, syscall.SOL_SOCKET, syscall.SO_REUSEADDR, 1); err != nil {
+		return err
+	}
+	if err := syscall.BindToDevice(fd, ifName); err != nil {
+		return err
+	}
+	return syscall.Bind(fd, &syscall.SockaddrInet6{Port: port})
+}
@@ -0,0 +1,13 @@
+// +build !linux
+
+package dhcppd
+
+import (
+	"net"
+
+	"github.com/docker/libnetwork/types"
+)
+
+func listen(ifName string, port int) (net.PacketConn, error) {
+	return nil, types.NotImplementedErrorf("dhcp-pd ipam driver is not supported on this platform")
+}
@@ -0,0 +1,420 @@
+// Package dhcppd implements an ipam driver which carves the IPv6 pools of
+// the networks from a prefix delegated by the upstream router with DHCPv6
+// prefix delegation (RFC 8415) on a host interface. Each pool is a /64 of
+// the delegated prefix. The delegation is renewed in background and the
+// users of the pools are notified when the router delegates another prefix,
+// so that their networks can be renumbered.
+package dhcppd
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/docker/libnetwork/discoverapi"
+	"github.com/docker/libnetwork/ipam"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/ipamutils"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DriverName is the name of the dhcp-pd ipam driver
+	DriverName = "dhcp-pd"
+	// ParentInterface is the ipam option naming the host interface the
+	// prefix delegation is requested on
+	ParentInterface = "pd_interface"
+
+	localAddressSpace  = "LocalDefault"
+	globalAddressSpace = "GlobalDefault"
+
+	// poolSize is the prefix length of the pools carved from the delegated
+	// prefix
+	poolSize = 64
+	// carveBase bounds the pools carved from a delegated prefix to the
+	// ones of its first /48
+	carveBase = 48
+)
+
+var (
+	// minRenewRetry is the minimum interval between failed renewal
+	// attempts
+	minRenewRetry = time.Minute
+
+	// dial returns the client of the interface, replaced in tests
+	dial = newClient
+)
+
+type allocator struct {
+	// addresses of the pools, handed out by a builtin allocator
+	addresses   *ipam.Allocator
+	delegations map[string]*delegation
+	pools       map[string]*pool
+	watchers    []chan ipamapi.PoolEvent
+	sync.Mutex
+}
+
+// delegation is the prefix delegated on an interface, shared by the pools
+// carved from it
+type delegation struct {
+	ifName  string
+	client  *client
+	lease   *lease
+	subnets []*net.IPNet
+	used    map[int]*pool
+	refs    int
+	stop    chan struct{}
+}
+
+type pool struct {
+	id         string
+	addrPoolID string
+	index      int
+	subnet     *net.IPNet
+	delegation *delegation
+}
+
+// Init registers the dhcp-pd ipam driver with libnetwork
+func Init(ic ipamapi.Callback, l, g interface{}) error {
+	a, err := newAllocator()
+	if err != nil {
+		return err
+	}
+	cps := &ipamapi.Capability{RequiresRequestReplay: true}
+	return ic.RegisterIpamDriverWithCapabilities(DriverName, a, cps)
+}
+
+func newAllocator() (*allocator, error) {
+	addresses, err := ipam.NewAllocator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &allocator{
+		addresses:   addresses,
+		delegations: make(map[string]*delegation),
+		pools:       make(map[string]*pool),
+	}, nil
+}
+
+func (a *allocator) GetDefaultAddressSpaces() (string, string, error) {
+	return localAddressSpace, globalAddressSpace, nil
+}
+
+// RequestPool carves a /64 from the prefix delegated on the parent
+// interface, the requested one if any
+func (a *allocator) RequestPool(addressSpace, requestedPool, subPool string, options map[string]string, v6 bool) (string, *net.IPNet, map[string]string, error) {
+	if addressSpace != localAddressSpace && addressSpace != globalAddressSpace {
+		return "", nil, nil, types.BadRequestErrorf("unknown address space: %s", addressSpace)
+	}
+	if !v6 {
+		return "", nil, nil, types.BadRequestErrorf("dhcp-pd ipam driver does not handle IPv4 address pool requests")
+	}
+	ifName, ok := options[ParentInterface]
+	if !ok || ifName == "" {
+		return "", nil, nil, types.BadRequestErrorf("dhcp-pd ipam driver requires the %q option", ParentInterface)
+	}
+
+	d, err := a.getDelegation(ifName)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	a.Lock()
+	index, err := d.pick(requestedPool)
+	if err != nil {
+		a.Unlock()
+		a.putDelegation(d)
+		return "", nil, nil, err
+	}
+	subnet := d.subnets[index]
+	addrPoolID, _, _, err := a.addresses.RequestPool(localAddressSpace, subnet.String(), subPool, options, true)
+	if err != nil {
+		a.Unlock()
+		a.putDelegation(d)
+		return "", nil, nil, err
+	}
+	p := &pool{
+		id:         fmt.Sprintf("%s/%s/%s", DriverName, ifName, subnet),
+		addrPoolID: addrPoolID,
+		index:      index,
+		subnet:     subnet,
+		delegation: d,
+	}
+	d.used[index] = p
+	a.pools[p.id] = p
+	a.Unlock()
+
+	logrus.Debugf("dhcp-pd ipam pool %s registered", p.id)
+
+	return p.id, types.GetIPNetCopy(subnet), nil, nil
+}
+
+// getDelegation returns the delegation of the interface, requesting a
+// prefix from the router on first use
+func (a *allocator) getDelegation(ifName string) (*delegation, error) {
+	a.Lock()
+	if d, ok := a.delegations[ifName]; ok {
+		d.refs++
+		a.Unlock()
+		return d, nil
+	}
+	a.Unlock()
+
+	c, err := dial(ifName)
+	if err != nil {
+		return nil, err
+	}
+	l, err := c.solicit()
+	if err != nil {
+		c.close()
+		return nil, fmt.Errorf("failed to obtain a delegated prefix on %s: %v", ifName, err)
+	}
+	subnets, err := carve(l.prefix)
+	if err != nil {
+		if err := c.release(l); err != nil {
+			logrus.Warnf("failed to release prefix %s delegated on %s: %v", l.prefix, ifName, err)
+		}
+		c.close()
+		return nil, types.BadRequestErrorf("prefix %s delegated on %s cannot be split in /%d pools: %v", l.prefix, ifName, poolSize, err)
+	}
+
+	a.Lock()
+	defer a.Unlock()
+	if d, ok := a.delegations[ifName]; ok {
+		// Obtained meanwhile for another pool. The binding is the same
+		// on the server side, so it must not be released.
+		d.refs++
+		c.close()
+		return d, nil
+	}
+	d := &delegation{
+		ifName:  ifName,
+		client:  c,
+		lease:   l,
+		subnets: subnets,
+		used:    make(map[int]*pool),
+		refs:    1,
+		stop:    make(chan struct{}),
+	}
+	a.delegations[ifName] = d
+	go a.keepAlive(d)
+
+	logrus.Infof("dhcp-pd ipam obtained prefix %s on %s", l.prefix, ifName)
+
+	return d, nil
+}
+
+// putDelegation drops a reference to the delegation, giving the prefix back
+// to the router once no pool uses it
+func (a *allocator) putDelegation(d *delegation) {
+	a.Lock()
+	d.refs--
+	if d.refs > 0 {
+		a.Unlock()
+		return
+	}
+	delete(a.delegations, d.ifName)
+	close(d.stop)
+	l := d.lease
+	a.Unlock()
+
+	if err := d.client.release(l); err != nil {
+		logrus.Warnf("failed to release prefix %s delegated on %s: %v", l.prefix, d.ifName, err)
+	}
+	d.client.close()
+}
+
+// carve returns the pools carved from the delegated prefix
+func carve(prefix *net.IPNet) ([]*net.IPNet, error) {
+	base := prefix
+	if ones, _ := prefix.Mask.Size(); ones < carveBase {
+		base = &net.IPNet{IP: prefix.IP, Mask: net.CIDRMask(carveBase, 128)}
+	}
+	return ipamutils.SplitNetworks([]*ipamutils.NetworkToSplit{{Base: base.String(), Size: poolSize}})
+}
+
+// pick returns the index of the requested pool, or of the first one
+// available if none is requested. It must be called with the allocator
+// lock held.
+func (d *delegation) pick(requestedPool string) (int, error) {
+	if requestedPool == "" {
+		for i := range d.subnets {
+			if _, ok := d.used[i]; !ok {
+				return i, nil
+			}
+		}
+		return 0, ipamapi.ErrNoAvailablePool
+	}
+
+	_, rp, err := net.ParseCIDR(requestedPool)
+	if err != nil {
+		return 0, types.BadRequestErrorf("invalid pool %s: %v", requestedPool, err)
+	}
+	for i, s := range d.subnets {
+		if types.CompareIPNet(s, rp) {
+			if _, ok := d.used[i]; ok {
+				return 0, ipamapi.ErrPoolOverlap
+			}
+			return i, nil
+		}
+	}
+	return 0, types.BadRequestErrorf("requested pool %s is not a /%d of the prefix %s delegated on %s", requestedPool, poolSize, d.lease.prefix, d.ifName)
+}
+
+func (a *allocator) ReleasePool(poolID string) error {
+	a.Lock()
+	p, ok := a.pools[poolID]
+	if !ok {
+		a.Unlock()
+		return types.NotFoundErrorf("unknown pool id: %s", poolID)
+	}
+	delete(a.pools, poolID)
+	delete(p.delegation.used, p.index)
+	err := a.addresses.ReleasePool(p.addrPoolID)
+	a.Unlock()
+
+	a.putDelegation(p.delegation)
+
+	return err
+}
+
+func (a *allocator) getPool(poolID string) (*pool, error) {
+	a.Lock()
+	defer a.Unlock()
+	p, ok := a.pools[poolID]
+	if !ok {
+		return nil, types.NotFoundErrorf("unknown pool id: %s", poolID)
+	}
+	return p, nil
+}
+
+func (a *allocator) RequestAddress(poolID string, ip net.IP, opts map[string]string) (*net.IPNet, map[string]string, error) {
+	p, err := a.getPool(poolID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a.addresses.RequestAddress(p.addrPoolID, ip, opts)
+}
+
+func (a *allocator) ReleaseAddress(poolID string, ip net.IP) error {
+	p, err := a.getPool(poolID)
+	if err != nil {
+		return err
+	}
+	return a.addresses.ReleaseAddress(p.addrPoolID, ip)
+}
+
+// keepAlive renews the delegation until it is stopped. Once the lease
+// expired, a new prefix is requested.
+func (a *allocator) keepAlive(d *delegation) {
+	a.Lock()
+	l := d.lease
+	a.Unlock()
+
+	next := l.t1
+	for {
+		if l.valid == infiniteLifetime {
+			return
+		}
+		select {
+		case <-d.stop:
+			return
+		case <-time.After(next):
+		}
+
+		var (
+			nl  *lease
+			err error
+		)
+		switch elapsed := time.Since(l.obtained); {
+		case elapsed < l.t2:
+			nl, err = d.client.renew(l)
+		case elapsed < l.valid:
+			nl, err = d.client.rebind(l)
+		default:
+			nl, err = d.client.solicit()
+		}
+		if err != nil {
+			next = time.Until(l.obtained.Add(l.valid)) / 2
+			if next < minRenewRetry {
+				next = minRenewRetry
+			}
+			logrus.Warnf("failed to renew prefix %s delegated on %s, retrying in %s: %v", l.prefix, d.ifName, next, err)
+			continue
+		}
+
+		a.updateLease(d, nl)
+		l = nl
+		next = l.t1
+	}
+}
+
+// updateLease records the renewed lease of the delegation and notifies the
+// watchers of the pools to renumber if the prefix changed
+func (a *allocator) updateLease(d *delegation, l *lease) {
+	a.Lock()
+	select {
+	case <-d.stop:
+		a.Unlock()
+		return
+	default:
+	}
+	old := d.lease
+	d.lease = l
+	if types.CompareIPNet(old.prefix, l.prefix) {
+		a.Unlock()
+		return
+	}
+
+	subnets, err := carve(l.prefix)
+	if err != nil {
+		logrus.Warnf("prefix %s delegated on %s cannot be split in /%d pools: %v", l.prefix, d.ifName, poolSize, err)
+	}
+	d.subnets = subnets
+	events := make([]ipamapi.PoolEvent, 0, len(d.used))
+	for i, p := range d.used {
+		ev := ipamapi.PoolEvent{PoolID: p.id, Pool: types.GetIPNetCopy(p.subnet)}
+		if i < len(subnets) {
+			ev.Renumbered = types.GetIPNetCopy(subnets[i])
+		}
+		events = append(events, ev)
+	}
+	watchers := a.watchers
+	a.Unlock()
+
+	logrus.Warnf("dhcp-pd ipam prefix delegated on %s changed from %s to %s", d.ifName, old.prefix, l.prefix)
+
+	for _, ev := range events {
+		for _, w := range watchers {
+			select {
+			case w <- ev:
+			default:
+				logrus.Warnf("dhcp-pd ipam dropped the renumbering event of pool %s, the watcher is not keeping up", ev.PoolID)
+			}
+		}
+	}
+}
+
+// WatchPools returns a channel the renumbering events of the pools are sent
+// on
+func (a *allocator) WatchPools() <-chan ipamapi.PoolEvent {
+	ch := make(chan ipamapi.PoolEvent, 64)
+	a.Lock()
+	a.watchers = append(a.watchers, ch)
+	a.Unlock()
+	return ch
+}
+
+func (a *allocator) DiscoverNew(dType discoverapi.DiscoveryType, data interface{}) error {
+	return nil
+}
+
+func (a *allocator) DiscoverDelete(dType discoverapi.DiscoveryType, data interface{}) error {
+	return nil
+}
+
+func (a *allocator) IsBuiltIn() bool {
+	return true
+}
@@ -0,0 +1,238 @@
+package dhcppd
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/types"
+)
+
+// testServer is a minimal in-process DHCPv6 server delegating its current
+// prefix to any client, listening on the loopback address
+type testServer struct {
+	conn     net.PacketConn
+	id       []byte
+	prefix   *net.IPNet
+	lifetime time.Duration
+	renews   int
+	releases int
+	sync.Mutex
+}
+
+func newTestServer(t *testing.T, prefix string, lifetime time.Duration) *testServer {
+	conn, err := net.ListenPacket("udp6", "[::1]:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac, _ := net.ParseMAC("02:42:ac:11:00:01")
+	s := &testServer{conn: conn, id: duid(mac), lifetime: lifetime}
+	s.setPrefix(prefix)
+	go s.serve()
+	return s
+}
+
+func (s *testServer) setPrefix(prefix string) {
+	s.Lock()
+	s.prefix, _ = types.ParseCIDR(prefix)
+	s.Unlock()
+}
+
+func (s *testServer) serve() {
+	buf := make([]byte, 1500)
+	for {
+		n, from, err := s.conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		m, err := parseMessage(buf[:n])
+		if err != nil {
+			continue
+		}
+		if reply := s.handle(m); reply != nil {
+			s.conn.WriteTo(reply.marshal(), from)
+		}
+	}
+}
+
+func (s *testServer) handle(m *message) *message {
+	s.Lock()
+	defer s.Unlock()
+
+	ia, err := m.iaPD()
+	if err != nil || ia == nil {
+		return nil
+	}
+	replyType := msgReply
+	switch m.msgType {
+	case msgSolicit:
+		replyType = msgAdvertise
+	case msgRenew:
+		s.renews++
+	case msgRelease:
+		s.releases++
+		return nil
+	}
+
+	reply := newMessage(replyType, m.xid)
+	reply.options[optClientID] = m.options[optClientID]
+	reply.options[optServerID] = s.id
+	reply.setIAPD(&iaPD{
+		iaid: ia.iaid,
+		t1:   s.lifetime / 4,
+		t2:   s.lifetime / 2,
+		prefixes: []iaPrefix{
+			{preferred: s.lifetime, valid: s.lifetime, prefix: s.prefix},
+		},
+	})
+	return reply
+}
+
+func (s *testServer) counters() (int, int) {
+	s.Lock()
+	defer s.Unlock()
+	return s.renews, s.releases
+}
+
+// setupDial makes the driver reach the test server instead of the router
+// on the parent interface
+func setupDial(t *testing.T, s *testServer) func() {
+	mac, _ := net.ParseMAC("02:42:ac:11:00:02")
+	dial = func(ifName string) (*client, error) {
+		conn, err := net.ListenPacket("udp6", "[::1]:0")
+		if err != nil {
+			return nil, err
+		}
+		return startClient(ifName, duid(mac), 1, conn, s.conn.LocalAddr()), nil
+	}
+	return func() {
+		dial = newClient
+		s.conn.Close()
+	}
+}
+
+func TestPrefixDelegationAllocator(t *testing.T) {
+	s := newTestServer(t, "2001:db8:1:ff00::/56", time.Hour)
+	defer setupDial(t, s)()
+
+	a, err := newAllocator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := map[string]string{ParentInterface: "eth0"}
+
+	if _, _, _, err := a.RequestPool(localAddressSpace, "", "", opts, false); err == nil {
+		t.Fatal("expected failure on IPv4 pool request")
+	}
+	if _, _, _, err := a.RequestPool(localAddressSpace, "", "", nil, true); err == nil {
+		t.Fatal("expected failure on missing parent interface")
+	}
+
+	pid1, pool1, _, err := a.RequestPool(localAddressSpace, "", "", opts, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool1.String() != "2001:db8:1:ff00::/64" {
+		t.Fatalf("unexpected first pool %s", pool1)
+	}
+	pid2, pool2, _, err := a.RequestPool(localAddressSpace, "2001:db8:1:ff42::/64", "", opts, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool2.String() != "2001:db8:1:ff42::/64" {
+		t.Fatalf("unexpected requested pool %s", pool2)
+	}
+	if _, _, _, err := a.RequestPool(localAddressSpace, "2001:db8:1:ff42::/64", "", opts, true); err != ipamapi.ErrPoolOverlap {
+		t.Fatalf("expected pool overlap failure, got %v", err)
+	}
+	if _, _, _, err := a.RequestPool(localAddressSpace, "2001:db8:2::/64", "", opts, true); err == nil {
+		t.Fatal("expected failure on pool outside of the delegated prefix")
+	}
+
+	gw, _, err := a.RequestAddress(pid1, nil, map[string]string{ipamapi.RequestAddressType: netlabel.Gateway})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gw.String() != "2001:db8:1:ff00::1/64" {
+		t.Fatalf("unexpected gateway %s", gw)
+	}
+	addr, _, err := a.RequestAddress(pid1, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pool1.Contains(addr.IP) || addr.IP.Equal(gw.IP) {
+		t.Fatalf("unexpected address %s", addr)
+	}
+	if err := a.ReleaseAddress(pid1, addr.IP); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.ReleasePool(pid1); err != nil {
+		t.Fatal(err)
+	}
+	if _, releases := s.counters(); releases != 0 {
+		t.Fatal("prefix released while still in use")
+	}
+	if err := a.ReleasePool(pid2); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.ReleasePool(pid2); err == nil {
+		t.Fatal("expected failure on unknown pool")
+	}
+
+	// The release is not acknowledged
+	for i := 0; i < 50; i++ {
+		if _, releases := s.counters(); releases == 1 {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("prefix not released to the server")
+}
+
+func TestPrefixDelegationRenumbering(t *testing.T) {
+	s := newTestServer(t, "2001:db8:1:ff00::/56", 4*time.Second)
+	defer setupDial(t, s)()
+
+	a, err := newAllocator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := a.WatchPools()
+	opts := map[string]string{ParentInterface: "eth0"}
+
+	pid, pool, _, err := a.RequestPool(localAddressSpace, "2001:db8:1:ff05::/64", "", opts, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.ReleasePool(pid)
+
+	// The router delegates another prefix on renewal, at a quarter of
+	// the lifetime
+	s.setPrefix("2001:db8:2:ab00::/56")
+
+	select {
+	case ev := <-events:
+		if ev.PoolID != pid || !types.CompareIPNet(ev.Pool, pool) || ev.Renumbered.String() != "2001:db8:2:ab05::/64" {
+			t.Fatalf("unexpected pool event %+v", ev)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no renumbering event")
+	}
+	if renews, _ := s.counters(); renews == 0 {
+		t.Fatal("prefix not renewed")
+	}
+
+	// New pools are carved from the new prefix
+	pid2, pool2, _, err := a.RequestPool(localAddressSpace, "", "", opts, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.ReleasePool(pid2)
+	if pool2.String() != "2001:db8:2:ab00::/64" {
+		t.Fatalf("unexpected pool %s", pool2)
+	}
+}
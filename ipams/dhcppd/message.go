@@ -0,0 +1,238 @@
+package dhcppd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+)
+
+// messageType is the type of a DHCPv6 message (RFC 8415, 7.3)
+type messageType byte
+
+const (
+	msgSolicit messageType = iota + 1
+	msgAdvertise
+	msgRequest
+	msgConfirm
+	msgRenew
+	msgRebind
+	msgReply
+	msgRelease
+)
+
+func (t messageType) String() string {
+	switch t {
+	case msgSolicit:
+		return "SOLICIT"
+	case msgAdvertise:
+		return "ADVERTISE"
+	case msgRequest:
+		return "REQUEST"
+	case msgConfirm:
+		return "CONFIRM"
+	case msgRenew:
+		return "RENEW"
+	case msgRebind:
+		return "REBIND"
+	case msgReply:
+		return "REPLY"
+	case msgRelease:
+		return "RELEASE"
+	}
+	return fmt.Sprintf("DHCPV6(%d)", byte(t))
+}
+
+// DHCPv6 option codes used by the driver (RFC 8415, 21)
+const (
+	optClientID    uint16 = 1
+	optServerID    uint16 = 2
+	optORO         uint16 = 6
+	optElapsedTime uint16 = 8
+	optStatusCode  uint16 = 13
+	optIAPD        uint16 = 25
+	optIAPrefix    uint16 = 26
+)
+
+// DHCPv6 status codes (RFC 8415, 21.13)
+const (
+	statusSuccess       uint16 = 0
+	statusNoBinding     uint16 = 3
+	statusNoPrefixAvail uint16 = 6
+)
+
+const (
+	duidLL        uint16 = 3
+	htypeEthernet uint16 = 1
+
+	// infiniteLifetime is the lifetime value of the prefixes which never
+	// expire
+	infiniteLifetime = time.Duration(0xffffffff) * time.Second
+)
+
+// message is a DHCPv6 client/server message as defined in RFC 8415. Each
+// option is expected at most once at the top level.
+type message struct {
+	msgType messageType
+	xid     uint32
+	options map[uint16][]byte
+}
+
+func newMessage(t messageType, xid uint32) *message {
+	return &message{
+		msgType: t,
+		xid:     xid & 0xffffff,
+		options: make(map[uint16][]byte),
+	}
+}
+
+// duid returns the link-layer DUID of the passed mac address
+func duid(mac net.HardwareAddr) []byte {
+	b := make([]byte, 4, 4+len(mac))
+	binary.BigEndian.PutUint16(b[0:2], duidLL)
+	binary.BigEndian.PutUint16(b[2:4], htypeEthernet)
+	return append(b, mac...)
+}
+
+func (m *message) marshal() []byte {
+	b := make([]byte, 4, 128)
+	binary.BigEndian.PutUint32(b, m.xid)
+	b[0] = byte(m.msgType)
+	for code, val := range m.options {
+		b = appendOption(b, code, val)
+	}
+	return b
+}
+
+func appendOption(b []byte, code uint16, val []byte) []byte {
+	var h [4]byte
+	binary.BigEndian.PutUint16(h[0:2], code)
+	binary.BigEndian.PutUint16(h[2:4], uint16(len(val)))
+	return append(append(b, h[:]...), val...)
+}
+
+// parseOptions walks the options encoded in b, calling fn for each of them
+func parseOptions(b []byte, fn func(code uint16, val []byte)) error {
+	for len(b) > 0 {
+		if len(b) < 4 {
+			return fmt.Errorf("truncated dhcpv6 option header")
+		}
+		code := binary.BigEndian.Uint16(b[0:2])
+		l := int(binary.BigEndian.Uint16(b[2:4]))
+		if len(b) < 4+l {
+			return fmt.Errorf("truncated dhcpv6 option %d", code)
+		}
+		fn(code, b[4:4+l])
+		b = b[4+l:]
+	}
+	return nil
+}
+
+func parseMessage(b []byte) (*message, error) {
+	if len(b) < 4 {
+		return nil, fmt.Errorf("dhcpv6 message too short: %d bytes", len(b))
+	}
+	m := newMessage(messageType(b[0]), binary.BigEndian.Uint32(b[0:4]))
+	err := parseOptions(b[4:], func(code uint16, val []byte) {
+		m.options[code] = append([]byte(nil), val...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// status returns the status code carried by the options, success if none
+func status(options map[uint16][]byte) (uint16, string) {
+	v := options[optStatusCode]
+	if len(v) < 2 {
+		return statusSuccess, ""
+	}
+	return binary.BigEndian.Uint16(v[0:2]), string(v[2:])
+}
+
+// iaPrefix is a delegated prefix with its lifetimes
+type iaPrefix struct {
+	preferred time.Duration
+	valid     time.Duration
+	prefix    *net.IPNet
+}
+
+// iaPD is an identity association for prefix delegation (RFC 8415, 21.21)
+type iaPD struct {
+	iaid     uint32
+	t1       time.Duration
+	t2       time.Duration
+	prefixes []iaPrefix
+	status   uint16
+	message  string
+}
+
+func (ia *iaPD) marshal() []byte {
+	b := make([]byte, 12, 12+29*len(ia.prefixes))
+	binary.BigEndian.PutUint32(b[0:4], ia.iaid)
+	binary.BigEndian.PutUint32(b[4:8], uint32(ia.t1/time.Second))
+	binary.BigEndian.PutUint32(b[8:12], uint32(ia.t2/time.Second))
+	for _, p := range ia.prefixes {
+		v := make([]byte, 25)
+		binary.BigEndian.PutUint32(v[0:4], uint32(p.preferred/time.Second))
+		binary.BigEndian.PutUint32(v[4:8], uint32(p.valid/time.Second))
+		ones, _ := p.prefix.Mask.Size()
+		v[8] = byte(ones)
+		copy(v[9:25], p.prefix.IP.To16())
+		b = appendOption(b, optIAPrefix, v)
+	}
+	if ia.status != statusSuccess {
+		v := make([]byte, 2, 2+len(ia.message))
+		binary.BigEndian.PutUint16(v, ia.status)
+		b = appendOption(b, optStatusCode, append(v, ia.message...))
+	}
+	return b
+}
+
+func parseIAPD(b []byte) (*iaPD, error) {
+	if len(b) < 12 {
+		return nil, fmt.Errorf("dhcpv6 IA_PD option too short: %d bytes", len(b))
+	}
+	ia := &iaPD{
+		iaid: binary.BigEndian.Uint32(b[0:4]),
+		t1:   time.Duration(binary.BigEndian.Uint32(b[4:8])) * time.Second,
+		t2:   time.Duration(binary.BigEndian.Uint32(b[8:12])) * time.Second,
+	}
+	options := make(map[uint16][]byte)
+	err := parseOptions(b[12:], func(code uint16, val []byte) {
+		if code != optIAPrefix {
+			options[code] = val
+			return
+		}
+		if len(val) < 25 || val[8] > 128 {
+			return
+		}
+		ip := net.IP(append([]byte(nil), val[9:25]...))
+		mask := net.CIDRMask(int(val[8]), 128)
+		ia.prefixes = append(ia.prefixes, iaPrefix{
+			preferred: time.Duration(binary.BigEndian.Uint32(val[0:4])) * time.Second,
+			valid:     time.Duration(binary.BigEndian.Uint32(val[4:8])) * time.Second,
+			prefix:    &net.IPNet{IP: ip.Mask(mask), Mask: mask},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	ia.status, ia.message = status(options)
+	return ia, nil
+}
+
+// setIAPD sets the IA_PD option of the message
+func (m *message) setIAPD(ia *iaPD) {
+	m.options[optIAPD] = ia.marshal()
+}
+
+// iaPD returns the IA_PD option of the message, nil if none
+func (m *message) iaPD() (*iaPD, error) {
+	v, ok := m.options[optIAPD]
+	if !ok {
+		return nil, nil
+	}
+	return parseIAPD(v)
+}
@@ -0,0 +1,72 @@
+package dhcppd
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	_ "github.com/docker/libnetwork/testutils"
+	"github.com/docker/libnetwork/types"
+)
+
+func TestMessageMarshalParse(t *testing.T) {
+	mac, _ := net.ParseMAC("02:42:ac:11:00:02")
+	prefix, _ := types.ParseCIDR("2001:db8:1:ff00::/56")
+
+	m := newMessage(msgReply, 0xdeadbeef)
+	m.options[optClientID] = duid(mac)
+	m.setIAPD(&iaPD{
+		iaid: 7,
+		t1:   time.Hour,
+		t2:   2 * time.Hour,
+		prefixes: []iaPrefix{
+			{preferred: 3 * time.Hour, valid: 4 * time.Hour, prefix: prefix},
+		},
+	})
+
+	p, err := parseMessage(m.marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.msgType != msgReply || p.xid != 0xadbeef {
+		t.Fatalf("unexpected header: type %s, xid %x", p.msgType, p.xid)
+	}
+	if string(p.options[optClientID]) != string(duid(mac)) {
+		t.Fatalf("unexpected client id %v", p.options[optClientID])
+	}
+
+	ia, err := p.iaPD()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ia.iaid != 7 || ia.t1 != time.Hour || ia.t2 != 2*time.Hour || ia.status != statusSuccess {
+		t.Fatalf("unexpected IA_PD %+v", ia)
+	}
+	if len(ia.prefixes) != 1 || !types.CompareIPNet(ia.prefixes[0].prefix, prefix) ||
+		ia.prefixes[0].preferred != 3*time.Hour || ia.prefixes[0].valid != 4*time.Hour {
+		t.Fatalf("unexpected prefixes %+v", ia.prefixes)
+	}
+
+	l, err := newLease(p, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !types.CompareIPNet(l.prefix, prefix) || l.t1 != time.Hour || l.valid != 4*time.Hour {
+		t.Fatalf("unexpected lease %+v", l)
+	}
+	if _, err := newLease(p, 8); err == nil {
+		t.Fatal("expected failure on a reply for another IA_PD")
+	}
+
+	m.setIAPD(&iaPD{iaid: 7, status: statusNoPrefixAvail, message: "no prefix"})
+	if p, err = parseMessage(m.marshal()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newLease(p, 7); err == nil {
+		t.Fatal("expected failure on a reply with no prefix available")
+	}
+
+	if _, err := parseMessage([]byte{byte(msgReply), 0, 0, 1, 0, 25, 0, 12}); err == nil {
+		t.Fatal("expected failure on a truncated option")
+	}
+}
@@ -24,6 +24,14 @@ type GetCapabilityResponse struct {
 	Response
 	RequiresMACAddress    bool
 	RequiresRequestReplay bool
+	// GRPCAddress is the unix socket the driver serves the gRPC
+	// IpamDriver protocol on, under the plugin sockets directory. Once set,
+	// the driver calls use gRPC if the plugin manifest declares the gRPC
+	// protocol scheme.
+	GRPCAddress string
+	// BatchSupport tells the driver implements the RequestAddresses
+	// and ReleaseAddresses calls
+	BatchSupport bool
 }
 
 // ToCapability converts the capability response into the internal ipam driver capability structure
@@ -92,3 +100,27 @@ type ReleaseAddressRequest struct {
 type ReleaseAddressResponse struct {
 	Response
 }
+
+// RequestAddressesRequest represents the expected data in a ``request addresses`` batch request message
+type RequestAddressesRequest struct {
+	Requests []RequestAddressRequest
+}
+
+// RequestAddressesResponse represents the response message to a ``request addresses`` batch request.
+// The responses are in the order of the requests, each carrying its own error.
+type RequestAddressesResponse struct {
+	Response
+	Responses []RequestAddressResponse
+}
+
+// ReleaseAddressesRequest represents the expected data in a ``release addresses`` batch request message
+type ReleaseAddressesRequest struct {
+	Requests []ReleaseAddressRequest
+}
+
+// ReleaseAddressesResponse represents the response message to a ``release addresses`` batch request.
+// The responses are in the order of the requests, each carrying its own error.
+type ReleaseAddressesResponse struct {
+	Response
+	Responses []ReleaseAddressResponse
+}
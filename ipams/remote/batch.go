@@ -0,0 +1,227 @@
+package remote
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/ipams/remote/api"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+// maxBatchSize bounds the number of address requests sent in a single
+// batch call to the plugin
+const maxBatchSize = 128
+
+// batcher coalesces the concurrent address requests or releases into
+// batch calls. A call is sent right away when no batch is in flight, the
+// ones issued meanwhile are sent together in the next batch, so that an
+// idle driver does not see its calls delayed.
+type batcher struct {
+	sync.Mutex
+	run     func([]ipamapi.AddressRequest) []ipamapi.AddressResult
+	pending []*batchCall
+	running bool
+}
+
+type batchCall struct {
+	req  ipamapi.AddressRequest
+	res  ipamapi.AddressResult
+	done chan struct{}
+}
+
+func newBatcher(run func([]ipamapi.AddressRequest) []ipamapi.AddressResult) *batcher {
+	return &batcher{run: run}
+}
+
+// do queues the request and waits for its result
+func (b *batcher) do(req ipamapi.AddressRequest) ipamapi.AddressResult {
+	c := &batchCall{req: req, done: make(chan struct{})}
+	b.Lock()
+	b.pending = append(b.pending, c)
+	if !b.running {
+		b.running = true
+		go b.loop()
+	}
+	b.Unlock()
+	<-c.done
+	return c.res
+}
+
+func (b *batcher) loop() {
+	for {
+		b.Lock()
+		if len(b.pending) == 0 {
+			b.running = false
+			b.Unlock()
+			return
+		}
+		n := len(b.pending)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		calls := b.pending[:n:n]
+		b.pending = b.pending[n:]
+		b.Unlock()
+
+		reqs := make([]ipamapi.AddressRequest, 0, len(calls))
+		for _, c := range calls {
+			reqs = append(reqs, c.req)
+		}
+		res := b.run(reqs)
+		for i, c := range calls {
+			c.res = res[i]
+			close(c.done)
+		}
+	}
+}
+
+func (a *allocator) batchSupport() bool {
+	a.Lock()
+	defer a.Unlock()
+	return a.batch
+}
+
+// disableBatch falls back to the single address calls, for the plugins
+// advertising the batch support without implementing the batch calls
+func (a *allocator) disableBatch(err error) {
+	a.Lock()
+	defer a.Unlock()
+	if a.batch {
+		logrus.Warnf("remote ipam driver %s does not support batch calls, falling back to single calls: %v", a.name, err)
+		a.batch = false
+	}
+}
+
+func isNotImplemented(err error) bool {
+	if _, ok := err.(types.NotImplementedError); ok {
+		return true
+	}
+	return plugins.IsNotFound(err)
+}
+
+// RequestAddresses requests the addresses of the batch, in as few plugin
+// calls as possible when the plugin supports batch calls
+func (a *allocator) RequestAddresses(reqs []ipamapi.AddressRequest) []ipamapi.AddressResult {
+	results := make([]ipamapi.AddressResult, 0, len(reqs))
+	for len(reqs) > 0 {
+		n := len(reqs)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		results = append(results, a.requestAddresses(reqs[:n])...)
+		reqs = reqs[n:]
+	}
+	return results
+}
+
+func (a *allocator) requestAddresses(reqs []ipamapi.AddressRequest) []ipamapi.AddressResult {
+	results := make([]ipamapi.AddressResult, len(reqs))
+	if a.batchSupport() {
+		req := &api.RequestAddressesRequest{}
+		for _, r := range reqs {
+			req.Requests = append(req.Requests, api.RequestAddressRequest{PoolID: r.PoolID, Address: ipString(r.Address), Options: r.Options})
+		}
+		res := &api.RequestAddressesResponse{}
+		err := a.call("RequestAddresses", req, res)
+		if err == nil && len(res.Responses) != len(reqs) {
+			err = fmt.Errorf("remote: %d responses to %d address requests", len(res.Responses), len(reqs))
+		}
+		if err == nil {
+			for i := range res.Responses {
+				results[i].Address, results[i].Data, results[i].Err = parseAddressResponse(&res.Responses[i])
+			}
+			return results
+		}
+		if !isNotImplemented(err) {
+			for i := range results {
+				results[i].Err = err
+			}
+			return results
+		}
+		a.disableBatch(err)
+	}
+	for i, r := range reqs {
+		results[i].Address, results[i].Data, results[i].Err = a.requestAddress(r.PoolID, r.Address, r.Options)
+	}
+	return results
+}
+
+// ReleaseAddresses releases the addresses of the batch, in as few plugin
+// calls as possible when the plugin supports batch calls
+func (a *allocator) ReleaseAddresses(reqs []ipamapi.AddressRequest) []error {
+	errs := make([]error, 0, len(reqs))
+	for len(reqs) > 0 {
+		n := len(reqs)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		errs = append(errs, a.releaseAddresses(reqs[:n])...)
+		reqs = reqs[n:]
+	}
+	return errs
+}
+
+func (a *allocator) releaseAddresses(reqs []ipamapi.AddressRequest) []error {
+	errs := make([]error, len(reqs))
+	if a.batchSupport() {
+		req := &api.ReleaseAddressesRequest{}
+		for _, r := range reqs {
+			req.Requests = append(req.Requests, api.ReleaseAddressRequest{PoolID: r.PoolID, Address: ipString(r.Address)})
+		}
+		res := &api.ReleaseAddressesResponse{}
+		err := a.call("ReleaseAddresses", req, res)
+		if err == nil && len(res.Responses) != len(reqs) {
+			err = fmt.Errorf("remote: %d responses to %d address releases", len(res.Responses), len(reqs))
+		}
+		if err == nil {
+			for i, r := range res.Responses {
+				if !r.IsSuccess() {
+					errs[i] = fmt.Errorf("remote: %s", r.GetError())
+				}
+			}
+			return errs
+		}
+		if !isNotImplemented(err) {
+			for i := range errs {
+				errs[i] = err
+			}
+			return errs
+		}
+		a.disableBatch(err)
+	}
+	for i, r := range reqs {
+		errs[i] = a.releaseAddress(r.PoolID, r.Address)
+	}
+	return errs
+}
+
+// releaseAddressBatch is the run function of the releases batcher
+func (a *allocator) releaseAddressBatch(reqs []ipamapi.AddressRequest) []ipamapi.AddressResult {
+	results := make([]ipamapi.AddressResult, len(reqs))
+	for i, err := range a.ReleaseAddresses(reqs) {
+		results[i].Err = err
+	}
+	return results
+}
+
+func parseAddressResponse(res *api.RequestAddressResponse) (*net.IPNet, map[string]string, error) {
+	if !res.IsSuccess() {
+		return nil, nil, fmt.Errorf("remote: %s", res.GetError())
+	}
+	if res.Address == "" {
+		return nil, nil, ipamapi.ErrNoIPReturned
+	}
+	addr, err := types.ParseCIDR(res.Address)
+	return addr, res.Data, err
+}
+
+func ipString(ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
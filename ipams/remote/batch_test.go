@@ -0,0 +1,183 @@
+package remote
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/libnetwork/ipamapi"
+)
+
+func newBatchAllocator(t *testing.T, plugin string, mux *http.ServeMux, batchSupport bool) *allocator {
+	handle(t, mux, "GetCapabilities", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"BatchSupport": batchSupport,
+		}
+	})
+	p, err := plugins.Get(plugin, ipamapi.PluginEndpointType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := newAllocator(plugin, p.Client()).(*allocator)
+	if _, err := a.getCapabilities(); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestBatchCoalescing(t *testing.T) {
+	var plugin = "test-ipam-driver-batch"
+
+	mux := http.NewServeMux()
+	defer setupPlugin(t, plugin, mux)()
+
+	var (
+		mu      sync.Mutex
+		next    int
+		batches []int
+		gate    = make(chan struct{})
+	)
+	handle(t, mux, "RequestAddresses", func(msg map[string]interface{}) interface{} {
+		reqs := msg["Requests"].([]interface{})
+		mu.Lock()
+		batches = append(batches, len(reqs))
+		first := len(batches) == 1
+		mu.Unlock()
+		if first {
+			<-gate
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		var res []map[string]interface{}
+		for _, r := range reqs {
+			if addr := r.(map[string]interface{})["Address"].(string); addr != "" {
+				res = append(res, map[string]interface{}{"Error": fmt.Sprintf("address %s already in use", addr)})
+				continue
+			}
+			next++
+			res = append(res, map[string]interface{}{"Address": fmt.Sprintf("172.20.0.%d/16", next)})
+		}
+		return map[string]interface{}{"Responses": res}
+	})
+	handle(t, mux, "ReleaseAddresses", func(msg map[string]interface{}) interface{} {
+		var res []map[string]interface{}
+		for range msg["Requests"].([]interface{}) {
+			res = append(res, map[string]interface{}{})
+		}
+		return map[string]interface{}{"Responses": res}
+	})
+	handle(t, mux, "RequestAddress", func(msg map[string]interface{}) interface{} {
+		t.Error("Unexpected single address request on a batch driver")
+		return map[string]interface{}{}
+	})
+
+	a := newBatchAllocator(t, plugin, mux, true)
+
+	const n = 8
+	var (
+		wg    sync.WaitGroup
+		addrs = make([]*net.IPNet, n)
+		errs  = make([]error, n)
+	)
+	request := func(i int) {
+		defer wg.Done()
+		addrs[i], _, errs[i] = a.RequestAddress("pool", nil, nil)
+	}
+	wg.Add(1)
+	go request(0)
+	// Wait for the first batch to be in flight before the other requests
+	for {
+		mu.Lock()
+		inFlight := len(batches) == 1
+		mu.Unlock()
+		if inFlight {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i := 1; i < n; i++ {
+		wg.Add(1)
+		go request(i)
+	}
+	for {
+		a.requests.Lock()
+		queued := len(a.requests.pending)
+		a.requests.Unlock()
+		if queued == n-1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(gate)
+	wg.Wait()
+
+	if len(batches) != 2 || batches[0] != 1 || batches[1] != n-1 {
+		t.Fatalf("Expected the requests to be coalesced in two batches, got %v", batches)
+	}
+	seen := make(map[string]bool)
+	for i := range addrs {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if seen[addrs[i].String()] {
+			t.Fatalf("Address %s returned twice", addrs[i])
+		}
+		seen[addrs[i].String()] = true
+	}
+
+	// Failures are per request
+	res := a.RequestAddresses([]ipamapi.AddressRequest{
+		{PoolID: "pool"},
+		{PoolID: "pool", Address: net.ParseIP("172.20.0.1")},
+	})
+	if res[0].Err != nil || res[0].Address.String() != "172.20.0.9/16" {
+		t.Fatalf("Unexpected result %+v", res[0])
+	}
+	if res[1].Err == nil || res[1].Err.Error() != "remote: address 172.20.0.1 already in use" {
+		t.Fatalf("Expected the plugin error, got %v", res[1].Err)
+	}
+
+	if err := a.ReleaseAddress("pool", addrs[0].IP); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBatchFallback(t *testing.T) {
+	var plugin = "test-ipam-driver-batch-fallback"
+
+	mux := http.NewServeMux()
+	defer setupPlugin(t, plugin, mux)()
+
+	var singles int
+	handle(t, mux, "RequestAddress", func(msg map[string]interface{}) interface{} {
+		singles++
+		return map[string]interface{}{"Address": fmt.Sprintf("172.20.0.%d/16", singles)}
+	})
+	handle(t, mux, "ReleaseAddress", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{}
+	})
+
+	// The plugin advertises the batch calls without implementing them
+	a := newBatchAllocator(t, plugin, mux, true)
+	res := a.RequestAddresses([]ipamapi.AddressRequest{{PoolID: "pool"}, {PoolID: "pool"}})
+	for _, r := range res {
+		if r.Err != nil {
+			t.Fatal(r.Err)
+		}
+	}
+	if singles != 2 || a.batchSupport() {
+		t.Fatalf("Expected the fallback to single calls, got %d calls, batch %v", singles, a.batchSupport())
+	}
+	if _, _, err := a.RequestAddress("pool", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, err := range a.ReleaseAddresses([]ipamapi.AddressRequest{{PoolID: "pool", Address: res[0].Address.IP}}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
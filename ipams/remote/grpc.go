@@ -0,0 +1,139 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/libnetwork/internal/plugingrpc"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/ipams/remote/api"
+	"github.com/docker/libnetwork/ipams/remote/rpc"
+	"google.golang.org/grpc"
+)
+
+// grpcClient is the pluginClient of the plugins speaking the gRPC
+// IpamDriver protocol. It translates the calls of the JSON protocol
+// into their gRPC counterpart, so that the allocator logic is shared
+// by both transports.
+type grpcClient struct {
+	conn    *grpc.ClientConn
+	client  rpc.IpamDriverClient
+	timeout time.Duration
+}
+
+func newGRPCClient(addr string, timeout time.Duration) (*grpcClient, error) {
+	if timeout == 0 {
+		timeout = plugingrpc.DefaultTimeout
+	}
+	conn, err := plugingrpc.Dial(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcClient{
+		conn:    conn,
+		client:  rpc.NewIpamDriverClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// Call invokes the gRPC method standing for the JSON protocol serviceMethod.
+func (c *grpcClient) Call(serviceMethod string, args, ret interface{}) error {
+	return c.CallWithTimeout(serviceMethod, args, ret, c.timeout)
+}
+
+// CallWithTimeout is Call with a deadline other than the client one.
+func (c *grpcClient) CallWithTimeout(serviceMethod string, args, ret interface{}, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var err error
+	switch serviceMethod {
+	case ipamapi.PluginEndpointType + ".GetCapabilities":
+		var res *rpc.GetCapabilityResponse
+		if res, err = c.client.GetCapabilities(ctx, &rpc.GetCapabilityRequest{}); err == nil {
+			r := ret.(*api.GetCapabilityResponse)
+			r.RequiresMACAddress = res.RequiresMACAddress
+			r.RequiresRequestReplay = res.RequiresRequestReplay
+			r.BatchSupport = res.BatchSupport
+		}
+	case ipamapi.PluginEndpointType + ".GetDefaultAddressSpaces":
+		var res *rpc.GetAddressSpacesResponse
+		if res, err = c.client.GetDefaultAddressSpaces(ctx, &rpc.GetAddressSpacesRequest{}); err == nil {
+			r := ret.(*api.GetAddressSpacesResponse)
+			r.LocalDefaultAddressSpace = res.LocalDefaultAddressSpace
+			r.GlobalDefaultAddressSpace = res.GlobalDefaultAddressSpace
+		}
+	case ipamapi.PluginEndpointType + ".RequestPool":
+		req := args.(*api.RequestPoolRequest)
+		var res *rpc.RequestPoolResponse
+		if res, err = c.client.RequestPool(ctx, &rpc.RequestPoolRequest{
+			AddressSpace: req.AddressSpace,
+			Pool:         req.Pool,
+			SubPool:      req.SubPool,
+			Options:      req.Options,
+			V6:           req.V6,
+		}); err == nil {
+			r := ret.(*api.RequestPoolResponse)
+			r.PoolID = res.PoolID
+			r.Pool = res.Pool
+			r.Data = res.Data
+		}
+	case ipamapi.PluginEndpointType + ".ReleasePool":
+		_, err = c.client.ReleasePool(ctx, &rpc.ReleasePoolRequest{PoolID: args.(*api.ReleasePoolRequest).PoolID})
+	case ipamapi.PluginEndpointType + ".RequestAddress":
+		req := args.(*api.RequestAddressRequest)
+		var res *rpc.RequestAddressResponse
+		if res, err = c.client.RequestAddress(ctx, &rpc.RequestAddressRequest{
+			PoolID:  req.PoolID,
+			Address: req.Address,
+			Options: req.Options,
+		}); err == nil {
+			r := ret.(*api.RequestAddressResponse)
+			r.Address = res.Address
+			r.Data = res.Data
+			r.Error = res.Err
+		}
+	case ipamapi.PluginEndpointType + ".ReleaseAddress":
+		req := args.(*api.ReleaseAddressRequest)
+		var res *rpc.ReleaseAddressResponse
+		if res, err = c.client.ReleaseAddress(ctx, &rpc.ReleaseAddressRequest{PoolID: req.PoolID, Address: req.Address}); err == nil {
+			ret.(*api.ReleaseAddressResponse).Error = res.Err
+		}
+	case ipamapi.PluginEndpointType + ".RequestAddresses":
+		req := &rpc.RequestAddressesRequest{}
+		for _, r := range args.(*api.RequestAddressesRequest).Requests {
+			req.Requests = append(req.Requests, &rpc.RequestAddressRequest{
+				PoolID:  r.PoolID,
+				Address: r.Address,
+				Options: r.Options,
+			})
+		}
+		var res *rpc.RequestAddressesResponse
+		if res, err = c.client.RequestAddresses(ctx, req); err == nil {
+			r := ret.(*api.RequestAddressesResponse)
+			for _, a := range res.Responses {
+				r.Responses = append(r.Responses, api.RequestAddressResponse{
+					Response: api.Response{Error: a.Err},
+					Address:  a.Address,
+					Data:     a.Data,
+				})
+			}
+		}
+	case ipamapi.PluginEndpointType + ".ReleaseAddresses":
+		req := &rpc.ReleaseAddressesRequest{}
+		for _, r := range args.(*api.ReleaseAddressesRequest).Requests {
+			req.Requests = append(req.Requests, &rpc.ReleaseAddressRequest{PoolID: r.PoolID, Address: r.Address})
+		}
+		var res *rpc.ReleaseAddressesResponse
+		if res, err = c.client.ReleaseAddresses(ctx, req); err == nil {
+			r := ret.(*api.ReleaseAddressesResponse)
+			for _, a := range res.Responses {
+				r.Responses = append(r.Responses, api.ReleaseAddressResponse{Response: api.Response{Error: a.Err}})
+			}
+		}
+	default:
+		return fmt.Errorf("%s: not supported by the gRPC transport", serviceMethod)
+	}
+	return plugingrpc.Error(serviceMethod, err)
+}
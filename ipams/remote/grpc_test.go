@@ -0,0 +1,297 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/pkg/plugins"
+	"github.com/docker/libnetwork/internal/plugingrpc"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/ipams/remote/rpc"
+	"google.golang.org/grpc"
+)
+
+// grpcIpam is an in-process gRPC IPAM plugin handing out the addresses
+// of a single pool in sequence
+type grpcIpam struct {
+	rpc.UnimplementedIpamDriverServer
+	sync.Mutex
+	next      int
+	allocated map[string]bool
+}
+
+func (d *grpcIpam) GetCapabilities(ctx context.Context, req *rpc.GetCapabilityRequest) (*rpc.GetCapabilityResponse, error) {
+	return &rpc.GetCapabilityResponse{RequiresRequestReplay: true, BatchSupport: true}, nil
+}
+
+func (d *grpcIpam) RequestPool(ctx context.Context, req *rpc.RequestPoolRequest) (*rpc.RequestPoolResponse, error) {
+	if req.Pool != "" && req.Pool != "10.20.0.0/24" {
+		return nil, fmt.Errorf("pool %s is not available", req.Pool)
+	}
+	return &rpc.RequestPoolResponse{PoolID: "grpc/10.20.0.0/24", Pool: "10.20.0.0/24", Data: map[string]string{"DNS": "10.20.0.1"}}, nil
+}
+
+func (d *grpcIpam) RequestAddress(ctx context.Context, req *rpc.RequestAddressRequest) (*rpc.RequestAddressResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+	if req.Address != "" {
+		if d.allocated[req.Address] {
+			return &rpc.RequestAddressResponse{Err: fmt.Sprintf("address %s already in use", req.Address)}, nil
+		}
+		d.allocated[req.Address] = true
+		return &rpc.RequestAddressResponse{Address: req.Address + "/24"}, nil
+	}
+	d.next++
+	addr := fmt.Sprintf("10.20.0.%d", d.next)
+	d.allocated[addr] = true
+	return &rpc.RequestAddressResponse{Address: addr + "/24"}, nil
+}
+
+func (d *grpcIpam) ReleaseAddress(ctx context.Context, req *rpc.ReleaseAddressRequest) (*rpc.ReleaseAddressResponse, error) {
+	d.Lock()
+	defer d.Unlock()
+	if !d.allocated[req.Address] {
+		return &rpc.ReleaseAddressResponse{Err: fmt.Sprintf("address %s is not allocated", req.Address)}, nil
+	}
+	delete(d.allocated, req.Address)
+	return &rpc.ReleaseAddressResponse{}, nil
+}
+
+func (d *grpcIpam) RequestAddresses(ctx context.Context, req *rpc.RequestAddressesRequest) (*rpc.RequestAddressesResponse, error) {
+	res := &rpc.RequestAddressesResponse{}
+	for _, r := range req.Requests {
+		a, _ := d.RequestAddress(ctx, r)
+		res.Responses = append(res.Responses, a)
+	}
+	return res, nil
+}
+
+func (d *grpcIpam) ReleaseAddresses(ctx context.Context, req *rpc.ReleaseAddressesRequest) (*rpc.ReleaseAddressesResponse, error) {
+	res := &rpc.ReleaseAddressesResponse{}
+	for _, r := range req.Requests {
+		a, _ := d.ReleaseAddress(ctx, r)
+		res.Responses = append(res.Responses, a)
+	}
+	return res, nil
+}
+
+func setupGRPCPlugin(t *testing.T, srv rpc.IpamDriverServer) (string, func()) {
+	dir, err := ioutil.TempDir("", "grpc-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := filepath.Join(dir, "ipam.sock")
+	l, err := net.Listen("unix", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	rpc.RegisterIpamDriverServer(s, srv)
+	go s.Serve(l)
+
+	return addr, func() {
+		s.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestGRPCDriver(t *testing.T) {
+	var plugin = "test-ipam-driver-grpc"
+
+	srv := &grpcIpam{allocated: make(map[string]bool)}
+	addr, cleanup := setupGRPCPlugin(t, srv)
+	defer cleanup()
+
+	defer func(dir string) { plugingrpc.SocketsDir = dir }(plugingrpc.SocketsDir)
+	plugingrpc.SocketsDir = filepath.Dir(addr)
+
+	// The plugin advertises its gRPC socket in the capabilities
+	mux := http.NewServeMux()
+	defer setupPlugin(t, plugin, mux, rpc.ProtocolSchemeGRPCV1)()
+	handle(t, mux, "GetCapabilities", func(msg map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"GRPCAddress": addr,
+		}
+	})
+
+	p, err := plugins.Get(plugin, ipamapi.PluginEndpointType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := getPluginClient(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := newAllocator(plugin, client)
+	if _, err := d.(*allocator).getCapabilities(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := d.(*allocator).endpoint.(*grpcClient); !ok {
+		t.Fatalf("Expected the calls to switch to gRPC, got %T", d.(*allocator).endpoint)
+	}
+	caps, err := d.(*allocator).getCapabilities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !caps.RequiresRequestReplay || !d.(*allocator).batchSupport() {
+		t.Fatalf("Expected the capabilities of the gRPC plugin, got %+v", caps)
+	}
+
+	poolID, pool, data, err := d.RequestPool("", "10.20.0.0/24", "", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if poolID != "grpc/10.20.0.0/24" || pool.String() != "10.20.0.0/24" || data["DNS"] != "10.20.0.1" {
+		t.Fatalf("Unexpected pool %s %s %v", poolID, pool, data)
+	}
+	if _, _, _, err := d.RequestPool("", "10.30.0.0/24", "", nil, false); err == nil || err.Error() != "remote: pool 10.30.0.0/24 is not available" {
+		t.Fatalf("Expected the plugin error, got %v", err)
+	}
+
+	ip, _, err := d.RequestAddress(poolID, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.20.0.1/24" {
+		t.Fatalf("Unexpected address %s", ip)
+	}
+	if _, _, err := d.RequestAddress(poolID, ip.IP, nil); err == nil || err.Error() != "remote: address 10.20.0.1 already in use" {
+		t.Fatalf("Expected the plugin error, got %v", err)
+	}
+	if err := d.ReleaseAddress(poolID, ip.IP); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ReleaseAddress(poolID, ip.IP); err == nil {
+		t.Fatal("Expected failure releasing an address twice")
+	}
+
+	// Method the plugin does not implement
+	if err := d.ReleasePool(poolID); err == nil {
+		t.Fatal("Expected failure on a method the plugin does not implement")
+	}
+}
+
+func TestGRPCBatch(t *testing.T) {
+	srv := &grpcIpam{allocated: make(map[string]bool)}
+	addr, cleanup := setupGRPCPlugin(t, srv)
+	defer cleanup()
+
+	client, err := newGRPCClient(addr, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &rpc.RequestAddressesRequest{}
+	for i := 0; i < 3; i++ {
+		req.Requests = append(req.Requests, &rpc.RequestAddressRequest{PoolID: "grpc/10.20.0.0/24"})
+	}
+	req.Requests = append(req.Requests, &rpc.RequestAddressRequest{PoolID: "grpc/10.20.0.0/24", Address: "10.20.0.2"})
+	res, err := client.client.RequestAddresses(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Responses) != 4 {
+		t.Fatalf("Expected 4 responses, got %d", len(res.Responses))
+	}
+	for i, r := range res.Responses[:3] {
+		if r.Err != "" || r.Address != fmt.Sprintf("10.20.0.%d/24", i+1) {
+			t.Fatalf("Unexpected response %d: %+v", i, r)
+		}
+	}
+	if res.Responses[3].Err == "" {
+		t.Fatal("Expected the request of an allocated address to fail")
+	}
+
+	rel := &rpc.ReleaseAddressesRequest{Requests: []*rpc.ReleaseAddressRequest{
+		{PoolID: "grpc/10.20.0.0/24", Address: "10.20.0.1"},
+		{PoolID: "grpc/10.20.0.0/24", Address: "10.20.0.9"},
+	}}
+	relRes, err := client.client.ReleaseAddresses(context.Background(), rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(relRes.Responses) != 2 || relRes.Responses[0].Err != "" || relRes.Responses[1].Err == "" {
+		t.Fatalf("Unexpected release responses %+v", relRes.Responses)
+	}
+
+	// Through the allocator
+	a := newAllocator("test-ipam-driver-grpc-batch", client).(*allocator)
+	if _, err := a.getCapabilities(); err != nil {
+		t.Fatal(err)
+	}
+	results := a.RequestAddresses([]ipamapi.AddressRequest{
+		{PoolID: "grpc/10.20.0.0/24"},
+		{PoolID: "grpc/10.20.0.0/24", Address: net.ParseIP("10.20.0.2")},
+	})
+	if results[0].Err != nil || results[0].Address.String() != "10.20.0.4/24" {
+		t.Fatalf("Unexpected result %+v", results[0])
+	}
+	if results[1].Err == nil || results[1].Err.Error() != "remote: address 10.20.0.2 already in use" {
+		t.Fatalf("Expected the plugin error, got %v", results[1].Err)
+	}
+	errs := a.ReleaseAddresses([]ipamapi.AddressRequest{
+		{PoolID: "grpc/10.20.0.0/24", Address: results[0].Address.IP},
+		{PoolID: "grpc/10.20.0.0/24", Address: net.ParseIP("10.20.0.9")},
+	})
+	if errs[0] != nil || errs[1] == nil {
+		t.Fatalf("Unexpected release errors %v", errs)
+	}
+}
+
+func TestGRPCAddress(t *testing.T) {
+	addr, cleanup := setupGRPCPlugin(t, &grpcIpam{allocated: make(map[string]bool)})
+	defer cleanup()
+
+	defer func(dir string) { plugingrpc.SocketsDir = dir }(plugingrpc.SocketsDir)
+	plugingrpc.SocketsDir = filepath.Dir(addr)
+
+	for _, tc := range []struct {
+		plugin     string
+		implements []string
+		addr       string
+		grpc       bool
+		fails      bool
+	}{
+		// The manifest does not declare the gRPC protocol scheme
+		{plugin: "test-ipam-grpc-undeclared", addr: addr},
+		{plugin: "test-ipam-grpc-outside", implements: []string{rpc.ProtocolSchemeGRPCV1}, addr: "/var/run/other.sock", fails: true},
+		{plugin: "test-ipam-grpc-relative", implements: []string{rpc.ProtocolSchemeGRPCV1}, addr: filepath.Join(plugingrpc.SocketsDir, "../other.sock"), fails: true},
+		{plugin: "test-ipam-grpc-declared", implements: []string{rpc.ProtocolSchemeGRPCV1}, addr: addr, grpc: true},
+	} {
+		mux := http.NewServeMux()
+		cleanupPlugin := setupPlugin(t, tc.plugin, mux, tc.implements...)
+		handle(t, mux, "GetCapabilities", func(msg map[string]interface{}) interface{} {
+			return map[string]interface{}{"GRPCAddress": tc.addr}
+		})
+
+		p, err := plugins.Get(tc.plugin, ipamapi.PluginEndpointType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client, err := getPluginClient(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := newAllocator(tc.plugin, client).(*allocator)
+		_, err = a.getCapabilities()
+		cleanupPlugin()
+		if tc.fails {
+			if err == nil {
+				t.Fatalf("%s: expected failure on gRPC socket %s", tc.plugin, tc.addr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", tc.plugin, err)
+		}
+		if _, ok := a.endpoint.(*grpcClient); ok != tc.grpc {
+			t.Fatalf("%s: unexpected plugin client %T", tc.plugin, a.endpoint)
+		}
+	}
+}
@@ -3,20 +3,38 @@ package remote
 import (
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/docker/docker/pkg/plugingetter"
 	"github.com/docker/docker/pkg/plugins"
 	"github.com/docker/libnetwork/discoverapi"
+	"github.com/docker/libnetwork/internal/plugincall"
+	"github.com/docker/libnetwork/internal/plugingrpc"
 	"github.com/docker/libnetwork/ipamapi"
 	"github.com/docker/libnetwork/ipams/remote/api"
+	"github.com/docker/libnetwork/ipams/remote/rpc"
 	"github.com/docker/libnetwork/types"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
 type allocator struct {
-	endpoint *plugins.Client
+	endpoint pluginClient
 	name     string
+	// guard bounds the plugin calls and tracks the plugin health
+	guard *plugincall.Guard
+	sync.Mutex
+	// batch is set when the plugin supports the batch address calls,
+	// the concurrent address requests and releases are then coalesced.
+	batch    bool
+	requests *batcher
+	releases *batcher
+}
+
+// pluginClient is the transport of the plugin calls, the HTTP plugin client
+// or the gRPC one depending on the protocol the plugin speaks.
+type pluginClient interface {
+	Call(serviceMethod string, args, ret interface{}) error
 }
 
 // PluginResponse is the interface for the plugin request responses
@@ -25,15 +43,37 @@ type PluginResponse interface {
 	GetError() string
 }
 
-func newAllocator(name string, client *plugins.Client) ipamapi.Ipam {
-	a := &allocator{name: name, endpoint: client}
+// idempotentCalls are the plugin calls retried on failure
+var idempotentCalls = []string{
+	ipamapi.PluginEndpointType + ".GetCapabilities",
+	ipamapi.PluginEndpointType + ".GetDefaultAddressSpaces",
+}
+
+// callConfig holds the settings of the calls to the IPAM plugins
+var callConfig plugincall.Config
+
+// SetCallConfig sets the settings of the calls to the IPAM plugins from the
+// remote driver configuration, the one of the network plugins calls
+func SetCallConfig(config map[string]interface{}) error {
+	cfg, err := plugincall.ParseConfig(config)
+	if err != nil {
+		return err
+	}
+	callConfig = cfg
+	return nil
+}
+
+func newAllocator(name string, client pluginClient) ipamapi.Ipam {
+	a := &allocator{name: name, endpoint: client, guard: plugincall.New(name, callConfig, idempotentCalls...)}
+	a.requests = newBatcher(a.RequestAddresses)
+	a.releases = newBatcher(a.releaseAddressBatch)
 	return a
 }
 
 // Init registers a remote ipam when its plugin is activated
 func Init(cb ipamapi.Callback, l, g interface{}) error {
 
-	newPluginHandler := func(name string, client *plugins.Client) {
+	newPluginHandler := func(name string, client pluginClient) {
 		a := newAllocator(name, client)
 		if cps, err := a.(*allocator).getCapabilities(); err == nil {
 			if err := cb.RegisterIpamDriverWithCapabilities(name, a, cps); err != nil {
@@ -61,11 +101,53 @@ func Init(cb ipamapi.Callback, l, g interface{}) error {
 			newPluginHandler(ap.Name(), client)
 		}
 	}
-	handleFunc(ipamapi.PluginEndpointType, newPluginHandler)
+	handleFunc(ipamapi.PluginEndpointType, func(name string, client *plugins.Client) {
+		newPluginHandler(name, handledPluginClient(cb.GetPluginGetter(), name, client))
+	})
 	return nil
 }
 
-func getPluginClient(p plugingetter.CompatPlugin) (*plugins.Client, error) {
+// handledPluginClient returns the client of the plugin name activated
+// through the plugin handlers, which give it client
+func handledPluginClient(pg plugingetter.PluginGetter, name string, client *plugins.Client) pluginClient {
+	return plugincall.NewHandledClient(name, client, func() (plugincall.Caller, error) {
+		var (
+			p   plugingetter.CompatPlugin
+			err error
+		)
+		if pg != nil {
+			p, err = pg.Get(name, ipamapi.PluginEndpointType, plugingetter.Lookup)
+		} else {
+			p, err = plugins.Get(name, ipamapi.PluginEndpointType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return getPluginClient(p)
+	})
+}
+
+func getPluginClient(p plugingetter.CompatPlugin) (pluginClient, error) {
+	if pa, ok := p.(plugingetter.PluginAddr); ok && pa.Protocol() == rpc.ProtocolSchemeGRPCV1 {
+		addr := pa.Addr()
+		if addr.Network() != "unix" {
+			return nil, errors.Errorf("unsupported gRPC plugin address %s://%s", addr.Network(), addr)
+		}
+		client, err := newGRPCClient(addr.String(), pa.Timeout())
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating plugin gRPC client")
+		}
+		return client, nil
+	}
+
+	if v1, ok := p.(*plugins.Plugin); ok {
+		client, err := plugincall.NewHTTPClient(v1.Addr, v1.TLSConfig, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating plugin client")
+		}
+		return client, nil
+	}
+
 	if v1, ok := p.(plugingetter.PluginWithV1Client); ok {
 		return v1.Client(), nil
 	}
@@ -80,7 +162,7 @@ func getPluginClient(p plugingetter.CompatPlugin) (*plugins.Client, error) {
 	}
 
 	addr := pa.Addr()
-	client, err := plugins.NewClientWithTimeout(addr.Network()+"://"+addr.String(), nil, pa.Timeout())
+	client, err := plugincall.NewHTTPClient(addr.Network()+"://"+addr.String(), nil, pa.Timeout())
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating plugin client")
 	}
@@ -89,7 +171,7 @@ func getPluginClient(p plugingetter.CompatPlugin) (*plugins.Client, error) {
 
 func (a *allocator) call(methodName string, arg interface{}, retVal PluginResponse) error {
 	method := ipamapi.PluginEndpointType + "." + methodName
-	err := a.endpoint.Call(method, arg, retVal)
+	err := a.guard.Call(a.endpoint, method, arg, retVal)
 	if err != nil {
 		return err
 	}
@@ -104,6 +186,22 @@ func (a *allocator) getCapabilities() (*ipamapi.Capability, error) {
 	if err := a.call("GetCapabilities", nil, &res); err != nil {
 		return nil, err
 	}
+	if _, ok := a.endpoint.(*grpcClient); !ok && res.GRPCAddress != "" {
+		addr, err := plugingrpc.Address(a.name, ipamapi.PluginEndpointType, res.GRPCAddress)
+		if err != nil {
+			return nil, err
+		}
+		if addr != "" {
+			client, err := newGRPCClient(addr, 0)
+			if err != nil {
+				return nil, fmt.Errorf("error creating plugin gRPC client for %s: %v", addr, err)
+			}
+			a.endpoint = client
+		}
+	}
+	a.Lock()
+	a.batch = res.BatchSupport
+	a.Unlock()
 	return res.ToCapability(), nil
 }
 
@@ -136,34 +234,32 @@ func (a *allocator) ReleasePool(poolID string) error {
 
 // RequestAddress requests an address from the address pool
 func (a *allocator) RequestAddress(poolID string, address net.IP, options map[string]string) (*net.IPNet, map[string]string, error) {
-	var (
-		prefAddress string
-		retAddress  *net.IPNet
-		err         error
-	)
-	if address != nil {
-		prefAddress = address.String()
-	}
-	req := &api.RequestAddressRequest{PoolID: poolID, Address: prefAddress, Options: options}
+	if a.batchSupport() {
+		res := a.requests.do(ipamapi.AddressRequest{PoolID: poolID, Address: address, Options: options})
+		return res.Address, res.Data, res.Err
+	}
+	return a.requestAddress(poolID, address, options)
+}
+
+func (a *allocator) requestAddress(poolID string, address net.IP, options map[string]string) (*net.IPNet, map[string]string, error) {
+	req := &api.RequestAddressRequest{PoolID: poolID, Address: ipString(address), Options: options}
 	res := &api.RequestAddressResponse{}
 	if err := a.call("RequestAddress", req, res); err != nil {
 		return nil, nil, err
 	}
-	if res.Address != "" {
-		retAddress, err = types.ParseCIDR(res.Address)
-	} else {
-		return nil, nil, ipamapi.ErrNoIPReturned
-	}
-	return retAddress, res.Data, err
+	return parseAddressResponse(res)
 }
 
 // ReleaseAddress releases the address from the specified address pool
 func (a *allocator) ReleaseAddress(poolID string, address net.IP) error {
-	var relAddress string
-	if address != nil {
-		relAddress = address.String()
+	if a.batchSupport() {
+		return a.releases.do(ipamapi.AddressRequest{PoolID: poolID, Address: address}).Err
 	}
-	req := &api.ReleaseAddressRequest{PoolID: poolID, Address: relAddress}
+	return a.releaseAddress(poolID, address)
+}
+
+func (a *allocator) releaseAddress(poolID string, address net.IP) error {
+	req := &api.ReleaseAddressRequest{PoolID: poolID, Address: ipString(address)}
 	res := &api.ReleaseAddressResponse{}
 	return a.call("ReleaseAddress", req, res)
 }
@@ -178,6 +274,11 @@ func (a *allocator) DiscoverDelete(dType discoverapi.DiscoveryType, data interfa
 	return nil
 }
 
+// Health returns the health of the plugin, as tracked by its calls
+func (a *allocator) Health() types.PluginHealth {
+	return a.guard.Health()
+}
+
 func (a *allocator) IsBuiltIn() bool {
 	return false
 }
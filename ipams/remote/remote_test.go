@@ -35,7 +35,7 @@ func handle(t *testing.T, mux *http.ServeMux, method string, h func(map[string]i
 	})
 }
 
-func setupPlugin(t *testing.T, name string, mux *http.ServeMux) func() {
+func setupPlugin(t *testing.T, name string, mux *http.ServeMux, implements ...string) func() {
 	if err := os.MkdirAll("/etc/docker/plugins", 0755); err != nil {
 		t.Fatal(err)
 	}
@@ -51,7 +51,7 @@ func setupPlugin(t *testing.T, name string, mux *http.ServeMux) func() {
 
 	mux.HandleFunc("/Plugin.Activate", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/vnd.docker.plugins.v1+json")
-		fmt.Fprintf(w, `{"Implements": ["%s"]}`, ipamapi.PluginEndpointType)
+		json.NewEncoder(w).Encode(map[string][]string{"Implements": append([]string{ipamapi.PluginEndpointType}, implements...)})
 	})
 
 	return func() {
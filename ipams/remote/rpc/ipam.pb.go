@@ -0,0 +1,4128 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: ipams/remote/rpc/ipam.proto
+
+package rpc
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetCapabilityRequest struct {
+}
+
+func (m *GetCapabilityRequest) Reset()         { *m = GetCapabilityRequest{} }
+func (m *GetCapabilityRequest) String() string { return proto.CompactTextString(m) }
+func (*GetCapabilityRequest) ProtoMessage()    {}
+func (*GetCapabilityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{0}
+}
+func (m *GetCapabilityRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetCapabilityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetCapabilityRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetCapabilityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCapabilityRequest.Merge(m, src)
+}
+func (m *GetCapabilityRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetCapabilityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCapabilityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCapabilityRequest proto.InternalMessageInfo
+
+type GetCapabilityResponse struct {
+	RequiresMACAddress    bool `protobuf:"varint,1,opt,name=requires_mac_address,json=requiresMacAddress,proto3" json:"requires_mac_address,omitempty"`
+	RequiresRequestReplay bool `protobuf:"varint,2,opt,name=requires_request_replay,json=requiresRequestReplay,proto3" json:"requires_request_replay,omitempty"`
+	BatchSupport          bool `protobuf:"varint,3,opt,name=batch_support,json=batchSupport,proto3" json:"batch_support,omitempty"`
+}
+
+func (m *GetCapabilityResponse) Reset()         { *m = GetCapabilityResponse{} }
+func (m *GetCapabilityResponse) String() string { return proto.CompactTextString(m) }
+func (*GetCapabilityResponse) ProtoMessage()    {}
+func (*GetCapabilityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{1}
+}
+func (m *GetCapabilityResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetCapabilityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetCapabilityResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetCapabilityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetCapabilityResponse.Merge(m, src)
+}
+func (m *GetCapabilityResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetCapabilityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetCapabilityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetCapabilityResponse proto.InternalMessageInfo
+
+type GetAddressSpacesRequest struct {
+}
+
+func (m *GetAddressSpacesRequest) Reset()         { *m = GetAddressSpacesRequest{} }
+func (m *GetAddressSpacesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAddressSpacesRequest) ProtoMessage()    {}
+func (*GetAddressSpacesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{2}
+}
+func (m *GetAddressSpacesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetAddressSpacesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetAddressSpacesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetAddressSpacesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAddressSpacesRequest.Merge(m, src)
+}
+func (m *GetAddressSpacesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetAddressSpacesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAddressSpacesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAddressSpacesRequest proto.InternalMessageInfo
+
+type GetAddressSpacesResponse struct {
+	LocalDefaultAddressSpace  string `protobuf:"bytes,1,opt,name=local_default_address_space,json=localDefaultAddressSpace,proto3" json:"local_default_address_space,omitempty"`
+	GlobalDefaultAddressSpace string `protobuf:"bytes,2,opt,name=global_default_address_space,json=globalDefaultAddressSpace,proto3" json:"global_default_address_space,omitempty"`
+}
+
+func (m *GetAddressSpacesResponse) Reset()         { *m = GetAddressSpacesResponse{} }
+func (m *GetAddressSpacesResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAddressSpacesResponse) ProtoMessage()    {}
+func (*GetAddressSpacesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{3}
+}
+func (m *GetAddressSpacesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetAddressSpacesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetAddressSpacesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetAddressSpacesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAddressSpacesResponse.Merge(m, src)
+}
+func (m *GetAddressSpacesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetAddressSpacesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAddressSpacesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAddressSpacesResponse proto.InternalMessageInfo
+
+type RequestPoolRequest struct {
+	AddressSpace string            `protobuf:"bytes,1,opt,name=address_space,json=addressSpace,proto3" json:"address_space,omitempty"`
+	Pool         string            `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	SubPool      string            `protobuf:"bytes,3,opt,name=sub_pool,json=subPool,proto3" json:"sub_pool,omitempty"`
+	Options      map[string]string `protobuf:"bytes,4,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	V6           bool              `protobuf:"varint,5,opt,name=v6,proto3" json:"v6,omitempty"`
+}
+
+func (m *RequestPoolRequest) Reset()         { *m = RequestPoolRequest{} }
+func (m *RequestPoolRequest) String() string { return proto.CompactTextString(m) }
+func (*RequestPoolRequest) ProtoMessage()    {}
+func (*RequestPoolRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{4}
+}
+func (m *RequestPoolRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestPoolRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestPoolRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestPoolRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestPoolRequest.Merge(m, src)
+}
+func (m *RequestPoolRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestPoolRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestPoolRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestPoolRequest proto.InternalMessageInfo
+
+type RequestPoolResponse struct {
+	PoolID string `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	// CIDR format
+	Pool string            `protobuf:"bytes,2,opt,name=pool,proto3" json:"pool,omitempty"`
+	Data map[string]string `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *RequestPoolResponse) Reset()         { *m = RequestPoolResponse{} }
+func (m *RequestPoolResponse) String() string { return proto.CompactTextString(m) }
+func (*RequestPoolResponse) ProtoMessage()    {}
+func (*RequestPoolResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{5}
+}
+func (m *RequestPoolResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestPoolResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestPoolResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestPoolResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestPoolResponse.Merge(m, src)
+}
+func (m *RequestPoolResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestPoolResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestPoolResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestPoolResponse proto.InternalMessageInfo
+
+type ReleasePoolRequest struct {
+	PoolID string `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+}
+
+func (m *ReleasePoolRequest) Reset()         { *m = ReleasePoolRequest{} }
+func (m *ReleasePoolRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleasePoolRequest) ProtoMessage()    {}
+func (*ReleasePoolRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{6}
+}
+func (m *ReleasePoolRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReleasePoolRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReleasePoolRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReleasePoolRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleasePoolRequest.Merge(m, src)
+}
+func (m *ReleasePoolRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReleasePoolRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleasePoolRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleasePoolRequest proto.InternalMessageInfo
+
+type ReleasePoolResponse struct {
+}
+
+func (m *ReleasePoolResponse) Reset()         { *m = ReleasePoolResponse{} }
+func (m *ReleasePoolResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleasePoolResponse) ProtoMessage()    {}
+func (*ReleasePoolResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{7}
+}
+func (m *ReleasePoolResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReleasePoolResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReleasePoolResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReleasePoolResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleasePoolResponse.Merge(m, src)
+}
+func (m *ReleasePoolResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReleasePoolResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleasePoolResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleasePoolResponse proto.InternalMessageInfo
+
+type RequestAddressRequest struct {
+	PoolID  string            `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Address string            `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Options map[string]string `protobuf:"bytes,3,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *RequestAddressRequest) Reset()         { *m = RequestAddressRequest{} }
+func (m *RequestAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*RequestAddressRequest) ProtoMessage()    {}
+func (*RequestAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{8}
+}
+func (m *RequestAddressRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestAddressRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestAddressRequest.Merge(m, src)
+}
+func (m *RequestAddressRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestAddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestAddressRequest proto.InternalMessageInfo
+
+type RequestAddressResponse struct {
+	// CIDR format
+	Address string            `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Data    map[string]string `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Err is set on a failed request of a batch
+	Err string `protobuf:"bytes,3,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (m *RequestAddressResponse) Reset()         { *m = RequestAddressResponse{} }
+func (m *RequestAddressResponse) String() string { return proto.CompactTextString(m) }
+func (*RequestAddressResponse) ProtoMessage()    {}
+func (*RequestAddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{9}
+}
+func (m *RequestAddressResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestAddressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestAddressResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestAddressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestAddressResponse.Merge(m, src)
+}
+func (m *RequestAddressResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestAddressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestAddressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestAddressResponse proto.InternalMessageInfo
+
+type ReleaseAddressRequest struct {
+	PoolID  string `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *ReleaseAddressRequest) Reset()         { *m = ReleaseAddressRequest{} }
+func (m *ReleaseAddressRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseAddressRequest) ProtoMessage()    {}
+func (*ReleaseAddressRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{10}
+}
+func (m *ReleaseAddressRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReleaseAddressRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReleaseAddressRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReleaseAddressRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseAddressRequest.Merge(m, src)
+}
+func (m *ReleaseAddressRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReleaseAddressRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseAddressRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseAddressRequest proto.InternalMessageInfo
+
+type ReleaseAddressResponse struct {
+	// Err is set on a failed release of a batch
+	Err string `protobuf:"bytes,1,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (m *ReleaseAddressResponse) Reset()         { *m = ReleaseAddressResponse{} }
+func (m *ReleaseAddressResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseAddressResponse) ProtoMessage()    {}
+func (*ReleaseAddressResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{11}
+}
+func (m *ReleaseAddressResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReleaseAddressResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReleaseAddressResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReleaseAddressResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseAddressResponse.Merge(m, src)
+}
+func (m *ReleaseAddressResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReleaseAddressResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseAddressResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseAddressResponse proto.InternalMessageInfo
+
+type RequestAddressesRequest struct {
+	Requests []*RequestAddressRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (m *RequestAddressesRequest) Reset()         { *m = RequestAddressesRequest{} }
+func (m *RequestAddressesRequest) String() string { return proto.CompactTextString(m) }
+func (*RequestAddressesRequest) ProtoMessage()    {}
+func (*RequestAddressesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{12}
+}
+func (m *RequestAddressesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestAddressesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestAddressesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestAddressesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestAddressesRequest.Merge(m, src)
+}
+func (m *RequestAddressesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestAddressesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestAddressesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestAddressesRequest proto.InternalMessageInfo
+
+type RequestAddressesResponse struct {
+	Responses []*RequestAddressResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+}
+
+func (m *RequestAddressesResponse) Reset()         { *m = RequestAddressesResponse{} }
+func (m *RequestAddressesResponse) String() string { return proto.CompactTextString(m) }
+func (*RequestAddressesResponse) ProtoMessage()    {}
+func (*RequestAddressesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{13}
+}
+func (m *RequestAddressesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestAddressesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestAddressesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestAddressesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestAddressesResponse.Merge(m, src)
+}
+func (m *RequestAddressesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestAddressesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestAddressesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestAddressesResponse proto.InternalMessageInfo
+
+type ReleaseAddressesRequest struct {
+	Requests []*ReleaseAddressRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (m *ReleaseAddressesRequest) Reset()         { *m = ReleaseAddressesRequest{} }
+func (m *ReleaseAddressesRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseAddressesRequest) ProtoMessage()    {}
+func (*ReleaseAddressesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{14}
+}
+func (m *ReleaseAddressesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReleaseAddressesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReleaseAddressesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReleaseAddressesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseAddressesRequest.Merge(m, src)
+}
+func (m *ReleaseAddressesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReleaseAddressesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseAddressesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseAddressesRequest proto.InternalMessageInfo
+
+type ReleaseAddressesResponse struct {
+	Responses []*ReleaseAddressResponse `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+}
+
+func (m *ReleaseAddressesResponse) Reset()         { *m = ReleaseAddressesResponse{} }
+func (m *ReleaseAddressesResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseAddressesResponse) ProtoMessage()    {}
+func (*ReleaseAddressesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_98e99cba5fc5be35, []int{15}
+}
+func (m *ReleaseAddressesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReleaseAddressesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReleaseAddressesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReleaseAddressesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseAddressesResponse.Merge(m, src)
+}
+func (m *ReleaseAddressesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReleaseAddressesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseAddressesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseAddressesResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*GetCapabilityRequest)(nil), "remoteipam.GetCapabilityRequest")
+	proto.RegisterType((*GetCapabilityResponse)(nil), "remoteipam.GetCapabilityResponse")
+	proto.RegisterType((*GetAddressSpacesRequest)(nil), "remoteipam.GetAddressSpacesRequest")
+	proto.RegisterType((*GetAddressSpacesResponse)(nil), "remoteipam.GetAddressSpacesResponse")
+	proto.RegisterType((*RequestPoolRequest)(nil), "remoteipam.RequestPoolRequest")
+	proto.RegisterMapType((map[string]string)(nil), "remoteipam.RequestPoolRequest.OptionsEntry")
+	proto.RegisterType((*RequestPoolResponse)(nil), "remoteipam.RequestPoolResponse")
+	proto.RegisterMapType((map[string]string)(nil), "remoteipam.RequestPoolResponse.DataEntry")
+	proto.RegisterType((*ReleasePoolRequest)(nil), "remoteipam.ReleasePoolRequest")
+	proto.RegisterType((*ReleasePoolResponse)(nil), "remoteipam.ReleasePoolResponse")
+	proto.RegisterType((*RequestAddressRequest)(nil), "remoteipam.RequestAddressRequest")
+	proto.RegisterMapType((map[string]string)(nil), "remoteipam.RequestAddressRequest.OptionsEntry")
+	proto.RegisterType((*RequestAddressResponse)(nil), "remoteipam.RequestAddressResponse")
+	proto.RegisterMapType((map[string]string)(nil), "remoteipam.RequestAddressResponse.DataEntry")
+	proto.RegisterType((*ReleaseAddressRequest)(nil), "remoteipam.ReleaseAddressRequest")
+	proto.RegisterType((*ReleaseAddressResponse)(nil), "remoteipam.ReleaseAddressResponse")
+	proto.RegisterType((*RequestAddressesRequest)(nil), "remoteipam.RequestAddressesRequest")
+	proto.RegisterType((*RequestAddressesResponse)(nil), "remoteipam.RequestAddressesResponse")
+	proto.RegisterType((*ReleaseAddressesRequest)(nil), "remoteipam.ReleaseAddressesRequest")
+	proto.RegisterType((*ReleaseAddressesResponse)(nil), "remoteipam.ReleaseAddressesResponse")
+}
+
+func init() { proto.RegisterFile("ipams/remote/rpc/ipam.proto", fileDescriptor_98e99cba5fc5be35) }
+
+var fileDescriptor_98e99cba5fc5be35 = []byte{
+	// 843 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4d, 0x4f, 0xf3, 0x46,
+	0x10, 0x8e, 0x1d, 0x48, 0x60, 0xa0, 0x2d, 0x5a, 0x92, 0x60, 0x4c, 0xe5, 0x80, 0xe1, 0x40, 0x3f,
+	0x94, 0x48, 0x54, 0x4a, 0x5b, 0x24, 0x54, 0x3e, 0x82, 0x80, 0x03, 0x6d, 0x65, 0x24, 0x5a, 0x55,
+	0xad, 0xac, 0x4d, 0xb2, 0xa5, 0x56, 0x1d, 0x6c, 0xd6, 0x4e, 0xa4, 0x9c, 0xfb, 0x07, 0x7a, 0xee,
+	0x8f, 0x69, 0x6f, 0x15, 0x47, 0x8e, 0x55, 0x55, 0xa1, 0xbe, 0xe1, 0x8f, 0xbc, 0xda, 0x0f, 0x07,
+	0xdb, 0x38, 0x09, 0xbc, 0xef, 0x7b, 0x5b, 0xcf, 0xce, 0x3c, 0xf3, 0xcc, 0x3c, 0xb3, 0xbb, 0x86,
+	0x35, 0xc7, 0xc7, 0xdd, 0xa0, 0x4e, 0x49, 0xd7, 0x0b, 0x49, 0x9d, 0xfa, 0xed, 0x3a, 0x33, 0xd4,
+	0x7c, 0xea, 0x85, 0x1e, 0x02, 0x61, 0x66, 0x16, 0xbd, 0x74, 0xe5, 0x5d, 0x79, 0xdc, 0x5c, 0x67,
+	0x2b, 0xe1, 0x61, 0x56, 0xa0, 0x74, 0x42, 0xc2, 0x23, 0xec, 0xe3, 0x96, 0xe3, 0x3a, 0xe1, 0xc0,
+	0x22, 0x37, 0x3d, 0x12, 0x84, 0xe6, 0x9f, 0x0a, 0x94, 0x53, 0x1b, 0x81, 0xef, 0x5d, 0x07, 0x04,
+	0x9d, 0x42, 0x89, 0x92, 0x9b, 0x9e, 0x43, 0x49, 0x60, 0x77, 0x71, 0xdb, 0xc6, 0x9d, 0x0e, 0x25,
+	0x41, 0xa0, 0x29, 0xeb, 0xca, 0xf6, 0xdc, 0x61, 0x65, 0x78, 0x5f, 0x45, 0x96, 0xdc, 0x3f, 0x3f,
+	0x38, 0x3a, 0x10, 0xbb, 0x16, 0x8a, 0x62, 0xce, 0x71, 0x5b, 0xda, 0x50, 0x03, 0x56, 0x46, 0x48,
+	0x54, 0xe4, 0xb5, 0x29, 0xf1, 0x5d, 0x3c, 0xd0, 0x54, 0x06, 0x66, 0x95, 0xa3, 0x6d, 0xc9, 0xca,
+	0xe2, 0x9b, 0x68, 0x13, 0xde, 0x6b, 0xe1, 0xb0, 0xfd, 0x8b, 0x1d, 0xf4, 0x7c, 0xdf, 0xa3, 0xa1,
+	0x96, 0xe7, 0xde, 0x8b, 0xdc, 0x78, 0x21, 0x6c, 0xe6, 0x2a, 0xac, 0x9c, 0x90, 0x50, 0xa6, 0xba,
+	0xf0, 0x71, 0x7b, 0x84, 0x62, 0xfe, 0xa1, 0x80, 0xf6, 0x74, 0x4f, 0x96, 0xb7, 0x07, 0x6b, 0xae,
+	0xd7, 0xc6, 0xae, 0xdd, 0x21, 0x3f, 0xe3, 0x9e, 0x1b, 0x46, 0xf5, 0xd9, 0x01, 0xf3, 0xe3, 0x55,
+	0xce, 0x5b, 0x1a, 0x77, 0x69, 0x0a, 0x8f, 0x38, 0x0e, 0xfa, 0x0a, 0x3e, 0xbc, 0x72, 0xbd, 0xd6,
+	0xd8, 0x78, 0x95, 0xc7, 0xaf, 0x0a, 0x9f, 0x0c, 0x00, 0xf3, 0x37, 0x15, 0x90, 0x24, 0xfa, 0xad,
+	0xe7, 0xb9, 0x72, 0xc9, 0x6a, 0xce, 0x22, 0xb2, 0x88, 0xe3, 0xc9, 0x11, 0xcc, 0xf8, 0x9e, 0xe7,
+	0xca, 0x24, 0x7c, 0x8d, 0x56, 0x61, 0x2e, 0xe8, 0xb5, 0x6c, 0x6e, 0xcf, 0x73, 0x7b, 0x31, 0xe8,
+	0xb5, 0x18, 0x34, 0x3a, 0x86, 0xa2, 0xe7, 0x87, 0x8e, 0x77, 0x1d, 0x68, 0x33, 0xeb, 0xf9, 0xed,
+	0x85, 0x9d, 0x4f, 0x6a, 0x8f, 0xf3, 0x52, 0x7b, 0x4a, 0xa2, 0xf6, 0x8d, 0xf0, 0x3e, 0xbe, 0x0e,
+	0xe9, 0xc0, 0x8a, 0x62, 0x51, 0x05, 0xd4, 0x7e, 0x43, 0x9b, 0xe5, 0xf2, 0x17, 0x86, 0xf7, 0x55,
+	0xf5, 0xb2, 0x61, 0xa9, 0xfd, 0x86, 0xbe, 0x0b, 0x8b, 0xf1, 0x00, 0xb4, 0x04, 0xf9, 0x5f, 0xc9,
+	0x40, 0x12, 0x67, 0x4b, 0x54, 0x82, 0xd9, 0x3e, 0x76, 0x7b, 0x51, 0x57, 0xc4, 0xc7, 0xae, 0xfa,
+	0x85, 0x62, 0xfe, 0xad, 0xc0, 0x72, 0x82, 0x80, 0x54, 0x67, 0x13, 0x8a, 0xac, 0x12, 0xdb, 0xe9,
+	0x08, 0x9c, 0x43, 0x18, 0xde, 0x57, 0x0b, 0xcc, 0xe5, 0xac, 0x69, 0x15, 0xd8, 0xd6, 0x59, 0x27,
+	0xb3, 0x0d, 0x7b, 0x30, 0xd3, 0xc1, 0x21, 0xd6, 0xf2, 0xbc, 0xd0, 0x8f, 0xc6, 0x16, 0x2a, 0xf2,
+	0xd4, 0x9a, 0x38, 0xc4, 0xa2, 0x4c, 0x1e, 0xa6, 0x7f, 0x0e, 0xf3, 0x23, 0xd3, 0x8b, 0x0a, 0xf9,
+	0x92, 0xa9, 0xe9, 0x12, 0x1c, 0x90, 0xa4, 0x9a, 0xd3, 0xcb, 0x30, 0xcb, 0xb0, 0x9c, 0x08, 0x15,
+	0xd4, 0xcc, 0xff, 0x14, 0x28, 0x4b, 0x9c, 0xe8, 0x70, 0xbd, 0x00, 0x15, 0x69, 0x50, 0x8c, 0x4e,
+	0xac, 0x20, 0x1b, 0x7d, 0xa2, 0xd3, 0xc7, 0x71, 0x10, 0x5d, 0xaa, 0x65, 0x74, 0x29, 0x99, 0x32,
+	0x7b, 0x22, 0xde, 0x4a, 0xf9, 0xbf, 0x14, 0xa8, 0xa4, 0x73, 0x49, 0xf1, 0x63, 0xd4, 0x95, 0x24,
+	0xf5, 0x7d, 0xa9, 0xae, 0xca, 0x79, 0x7f, 0x3a, 0x89, 0x77, 0xb6, 0xc0, 0x8c, 0x22, 0xa1, 0x54,
+	0x9e, 0x10, 0xb6, 0x7c, 0x73, 0xc9, 0x2f, 0xa1, 0x2c, 0x75, 0x7b, 0xa7, 0xfa, 0x98, 0x1f, 0x43,
+	0x25, 0x8d, 0x2b, 0x1b, 0x23, 0xc9, 0x2b, 0x23, 0xf2, 0xe6, 0xf7, 0xb0, 0x92, 0x2c, 0x7c, 0x74,
+	0xfb, 0xa1, 0x3d, 0x98, 0x93, 0x97, 0x2d, 0x6b, 0x23, 0xeb, 0xd7, 0xc6, 0x54, 0x9d, 0xad, 0x51,
+	0x88, 0xf9, 0x23, 0x68, 0x4f, 0x91, 0x25, 0x8f, 0x7d, 0x98, 0xa7, 0x72, 0x1d, 0x61, 0x9b, 0xd3,
+	0xb5, 0xb0, 0x1e, 0x83, 0x04, 0xef, 0x78, 0x8d, 0x2f, 0xe0, 0x9d, 0xd1, 0xf2, 0x34, 0xef, 0x34,
+	0xf2, 0xb3, 0x79, 0x67, 0xb5, 0x3d, 0xc6, 0x7b, 0xe7, 0xdf, 0x59, 0x80, 0x33, 0x1f, 0x77, 0x9b,
+	0xd4, 0xe9, 0x13, 0x8a, 0x2e, 0xe1, 0x83, 0xf8, 0xe3, 0xe9, 0x90, 0x00, 0xad, 0xc7, 0x01, 0xb3,
+	0x9e, 0x5c, 0x7d, 0x63, 0x82, 0x87, 0x24, 0xda, 0xe1, 0x8f, 0x5a, 0xc6, 0xb3, 0x11, 0xa0, 0xcd,
+	0x54, 0x74, 0xd6, 0xcb, 0xa7, 0x6f, 0x4d, 0x76, 0x92, 0x59, 0xbe, 0x86, 0x85, 0xd8, 0x9d, 0x88,
+	0x8c, 0xc9, 0xaf, 0x82, 0x5e, 0x9d, 0x72, 0x99, 0x0a, 0xbc, 0xd1, 0x45, 0x96, 0xc6, 0x4b, 0x5f,
+	0x8e, 0x7a, 0x75, 0xec, 0xbe, 0xc4, 0xfb, 0x0e, 0xde, 0x4f, 0x4e, 0x12, 0x9a, 0x3e, 0xc1, 0xfa,
+	0x33, 0x06, 0x51, 0x00, 0xc7, 0xa5, 0x46, 0xd3, 0x47, 0x4c, 0x7f, 0xc6, 0xa4, 0xa0, 0x9f, 0x60,
+	0x29, 0x7d, 0x68, 0x92, 0x82, 0x8d, 0x39, 0xac, 0x49, 0xc1, 0xc6, 0x9e, 0x3b, 0x0e, 0x9f, 0x9c,
+	0xed, 0x34, 0x7c, 0xe6, 0x99, 0xd2, 0xb7, 0x26, 0x3b, 0x09, 0xf8, 0xc3, 0x8d, 0xdb, 0x57, 0x46,
+	0xee, 0x76, 0x68, 0x28, 0x77, 0x43, 0x43, 0xf9, 0x7f, 0x68, 0x28, 0xbf, 0x3f, 0x18, 0xb9, 0xbb,
+	0x07, 0x23, 0xf7, 0xcf, 0x83, 0x91, 0xfb, 0x21, 0x4f, 0xfd, 0x76, 0xab, 0xc0, 0xff, 0x26, 0x3f,
+	0x7b, 0x3d, 0x00, 0xd5, 0x0e, 0x4f, 0x19, 0x8e, 0x0a, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// IpamDriverClient is the client API for IpamDriver service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IpamDriverClient interface {
+	GetCapabilities(ctx context.Context, in *GetCapabilityRequest, opts ...grpc.CallOption) (*GetCapabilityResponse, error)
+	GetDefaultAddressSpaces(ctx context.Context, in *GetAddressSpacesRequest, opts ...grpc.CallOption) (*GetAddressSpacesResponse, error)
+	RequestPool(ctx context.Context, in *RequestPoolRequest, opts ...grpc.CallOption) (*RequestPoolResponse, error)
+	ReleasePool(ctx context.Context, in *ReleasePoolRequest, opts ...grpc.CallOption) (*ReleasePoolResponse, error)
+	RequestAddress(ctx context.Context, in *RequestAddressRequest, opts ...grpc.CallOption) (*RequestAddressResponse, error)
+	ReleaseAddress(ctx context.Context, in *ReleaseAddressRequest, opts ...grpc.CallOption) (*ReleaseAddressResponse, error)
+	// RequestAddresses runs a batch of address requests in a single
+	// round trip. The results are in the order of the requests.
+	RequestAddresses(ctx context.Context, in *RequestAddressesRequest, opts ...grpc.CallOption) (*RequestAddressesResponse, error)
+	// ReleaseAddresses runs a batch of address releases in a single
+	// round trip. The results are in the order of the requests.
+	ReleaseAddresses(ctx context.Context, in *ReleaseAddressesRequest, opts ...grpc.CallOption) (*ReleaseAddressesResponse, error)
+}
+
+type ipamDriverClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewIpamDriverClient(cc *grpc.ClientConn) IpamDriverClient {
+	return &ipamDriverClient{cc}
+}
+
+func (c *ipamDriverClient) GetCapabilities(ctx context.Context, in *GetCapabilityRequest, opts ...grpc.CallOption) (*GetCapabilityResponse, error) {
+	out := new(GetCapabilityResponse)
+	err := c.cc.Invoke(ctx, "/remoteipam.IpamDriver/GetCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ipamDriverClient) GetDefaultAddressSpaces(ctx context.Context, in *GetAddressSpacesRequest, opts ...grpc.CallOption) (*GetAddressSpacesResponse, error) {
+	out := new(GetAddressSpacesResponse)
+	err := c.cc.Invoke(ctx, "/remoteipam.IpamDriver/GetDefaultAddressSpaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ipamDriverClient) RequestPool(ctx context.Context, in *RequestPoolRequest, opts ...grpc.CallOption) (*RequestPoolResponse, error) {
+	out := new(RequestPoolResponse)
+	err := c.cc.Invoke(ctx, "/remoteipam.IpamDriver/RequestPool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ipamDriverClient) ReleasePool(ctx context.Context, in *ReleasePoolRequest, opts ...grpc.CallOption) (*ReleasePoolResponse, error) {
+	out := new(ReleasePoolResponse)
+	err := c.cc.Invoke(ctx, "/remoteipam.IpamDriver/ReleasePool", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ipamDriverClient) RequestAddress(ctx context.Context, in *RequestAddressRequest, opts ...grpc.CallOption) (*RequestAddressResponse, error) {
+	out := new(RequestAddressResponse)
+	err := c.cc.Invoke(ctx, "/remoteipam.IpamDriver/RequestAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ipamDriverClient) ReleaseAddress(ctx context.Context, in *ReleaseAddressRequest, opts ...grpc.CallOption) (*ReleaseAddressResponse, error) {
+	out := new(ReleaseAddressResponse)
+	err := c.cc.Invoke(ctx, "/remoteipam.IpamDriver/ReleaseAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ipamDriverClient) RequestAddresses(ctx context.Context, in *RequestAddressesRequest, opts ...grpc.CallOption) (*RequestAddressesResponse, error) {
+	out := new(RequestAddressesResponse)
+	err := c.cc.Invoke(ctx, "/remoteipam.IpamDriver/RequestAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ipamDriverClient) ReleaseAddresses(ctx context.Context, in *ReleaseAddressesRequest, opts ...grpc.CallOption) (*ReleaseAddressesResponse, error) {
+	out := new(ReleaseAddressesResponse)
+	err := c.cc.Invoke(ctx, "/remoteipam.IpamDriver/ReleaseAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IpamDriverServer is the server API for IpamDriver service.
+type IpamDriverServer interface {
+	GetCapabilities(context.Context, *GetCapabilityRequest) (*GetCapabilityResponse, error)
+	GetDefaultAddressSpaces(context.Context, *GetAddressSpacesRequest) (*GetAddressSpacesResponse, error)
+	RequestPool(context.Context, *RequestPoolRequest) (*RequestPoolResponse, error)
+	ReleasePool(context.Context, *ReleasePoolRequest) (*ReleasePoolResponse, error)
+	RequestAddress(context.Context, *RequestAddressRequest) (*RequestAddressResponse, error)
+	ReleaseAddress(context.Context, *ReleaseAddressRequest) (*ReleaseAddressResponse, error)
+	// RequestAddresses runs a batch of address requests in a single
+	// round trip. The results are in the order of the requests.
+	RequestAddresses(context.Context, *RequestAddressesRequest) (*RequestAddressesResponse, error)
+	// ReleaseAddresses runs a batch of address releases in a single
+	// round trip. The results are in the order of the requests.
+	ReleaseAddresses(context.Context, *ReleaseAddressesRequest) (*ReleaseAddressesResponse, error)
+}
+
+// UnimplementedIpamDriverServer can be embedded to have forward compatible implementations.
+type UnimplementedIpamDriverServer struct {
+}
+
+func (*UnimplementedIpamDriverServer) GetCapabilities(ctx context.Context, req *GetCapabilityRequest) (*GetCapabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (*UnimplementedIpamDriverServer) GetDefaultAddressSpaces(ctx context.Context, req *GetAddressSpacesRequest) (*GetAddressSpacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDefaultAddressSpaces not implemented")
+}
+func (*UnimplementedIpamDriverServer) RequestPool(ctx context.Context, req *RequestPoolRequest) (*RequestPoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPool not implemented")
+}
+func (*UnimplementedIpamDriverServer) ReleasePool(ctx context.Context, req *ReleasePoolRequest) (*ReleasePoolResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleasePool not implemented")
+}
+func (*UnimplementedIpamDriverServer) RequestAddress(ctx context.Context, req *RequestAddressRequest) (*RequestAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAddress not implemented")
+}
+func (*UnimplementedIpamDriverServer) ReleaseAddress(ctx context.Context, req *ReleaseAddressRequest) (*ReleaseAddressResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseAddress not implemented")
+}
+func (*UnimplementedIpamDriverServer) RequestAddresses(ctx context.Context, req *RequestAddressesRequest) (*RequestAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestAddresses not implemented")
+}
+func (*UnimplementedIpamDriverServer) ReleaseAddresses(ctx context.Context, req *ReleaseAddressesRequest) (*ReleaseAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseAddresses not implemented")
+}
+
+func RegisterIpamDriverServer(s *grpc.Server, srv IpamDriverServer) {
+	s.RegisterService(&_IpamDriver_serviceDesc, srv)
+}
+
+func _IpamDriver_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IpamDriverServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remoteipam.IpamDriver/GetCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IpamDriverServer).GetCapabilities(ctx, req.(*GetCapabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IpamDriver_GetDefaultAddressSpaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAddressSpacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IpamDriverServer).GetDefaultAddressSpaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remoteipam.IpamDriver/GetDefaultAddressSpaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IpamDriverServer).GetDefaultAddressSpaces(ctx, req.(*GetAddressSpacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IpamDriver_RequestPool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IpamDriverServer).RequestPool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remoteipam.IpamDriver/RequestPool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IpamDriverServer).RequestPool(ctx, req.(*RequestPoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IpamDriver_ReleasePool_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleasePoolRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IpamDriverServer).ReleasePool(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remoteipam.IpamDriver/ReleasePool",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IpamDriverServer).ReleasePool(ctx, req.(*ReleasePoolRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IpamDriver_RequestAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IpamDriverServer).RequestAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remoteipam.IpamDriver/RequestAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IpamDriverServer).RequestAddress(ctx, req.(*RequestAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IpamDriver_ReleaseAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IpamDriverServer).ReleaseAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remoteipam.IpamDriver/ReleaseAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IpamDriverServer).ReleaseAddress(ctx, req.(*ReleaseAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IpamDriver_RequestAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IpamDriverServer).RequestAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remoteipam.IpamDriver/RequestAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IpamDriverServer).RequestAddresses(ctx, req.(*RequestAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IpamDriver_ReleaseAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IpamDriverServer).ReleaseAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remoteipam.IpamDriver/ReleaseAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IpamDriverServer).ReleaseAddresses(ctx, req.(*ReleaseAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _IpamDriver_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "remoteipam.IpamDriver",
+	HandlerType: (*IpamDriverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _IpamDriver_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "GetDefaultAddressSpaces",
+			Handler:    _IpamDriver_GetDefaultAddressSpaces_Handler,
+		},
+		{
+			MethodName: "RequestPool",
+			Handler:    _IpamDriver_RequestPool_Handler,
+		},
+		{
+			MethodName: "ReleasePool",
+			Handler:    _IpamDriver_ReleasePool_Handler,
+		},
+		{
+			MethodName: "RequestAddress",
+			Handler:    _IpamDriver_RequestAddress_Handler,
+		},
+		{
+			MethodName: "ReleaseAddress",
+			Handler:    _IpamDriver_ReleaseAddress_Handler,
+		},
+		{
+			MethodName: "RequestAddresses",
+			Handler:    _IpamDriver_RequestAddresses_Handler,
+		},
+		{
+			MethodName: "ReleaseAddresses",
+			Handler:    _IpamDriver_ReleaseAddresses_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ipams/remote/rpc/ipam.proto",
+}
+
+func (m *GetCapabilityRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetCapabilityRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetCapabilityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetCapabilityResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetCapabilityResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetCapabilityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BatchSupport {
+		i--
+		if m.BatchSupport {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.RequiresRequestReplay {
+		i--
+		if m.RequiresRequestReplay {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.RequiresMACAddress {
+		i--
+		if m.RequiresMACAddress {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAddressSpacesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAddressSpacesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAddressSpacesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAddressSpacesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAddressSpacesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAddressSpacesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.GlobalDefaultAddressSpace) > 0 {
+		i -= len(m.GlobalDefaultAddressSpace)
+		copy(dAtA[i:], m.GlobalDefaultAddressSpace)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.GlobalDefaultAddressSpace)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.LocalDefaultAddressSpace) > 0 {
+		i -= len(m.LocalDefaultAddressSpace)
+		copy(dAtA[i:], m.LocalDefaultAddressSpace)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.LocalDefaultAddressSpace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestPoolRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestPoolRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestPoolRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.V6 {
+		i--
+		if m.V6 {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Options) > 0 {
+		for k := range m.Options {
+			v := m.Options[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintIpam(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIpam(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIpam(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.SubPool) > 0 {
+		i -= len(m.SubPool)
+		copy(dAtA[i:], m.SubPool)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.SubPool)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Pool) > 0 {
+		i -= len(m.Pool)
+		copy(dAtA[i:], m.Pool)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.Pool)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.AddressSpace) > 0 {
+		i -= len(m.AddressSpace)
+		copy(dAtA[i:], m.AddressSpace)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.AddressSpace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestPoolResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestPoolResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestPoolResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		for k := range m.Data {
+			v := m.Data[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintIpam(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIpam(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIpam(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Pool) > 0 {
+		i -= len(m.Pool)
+		copy(dAtA[i:], m.Pool)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.Pool)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PoolID) > 0 {
+		i -= len(m.PoolID)
+		copy(dAtA[i:], m.PoolID)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.PoolID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleasePoolRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleasePoolRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleasePoolRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.PoolID) > 0 {
+		i -= len(m.PoolID)
+		copy(dAtA[i:], m.PoolID)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.PoolID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleasePoolResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleasePoolResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleasePoolResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestAddressRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestAddressRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestAddressRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Options) > 0 {
+		for k := range m.Options {
+			v := m.Options[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintIpam(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIpam(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIpam(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PoolID) > 0 {
+		i -= len(m.PoolID)
+		copy(dAtA[i:], m.PoolID)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.PoolID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestAddressResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestAddressResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestAddressResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Err) > 0 {
+		i -= len(m.Err)
+		copy(dAtA[i:], m.Err)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.Err)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Data) > 0 {
+		for k := range m.Data {
+			v := m.Data[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintIpam(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIpam(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIpam(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleaseAddressRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseAddressRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleaseAddressRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.PoolID) > 0 {
+		i -= len(m.PoolID)
+		copy(dAtA[i:], m.PoolID)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.PoolID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleaseAddressResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseAddressResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleaseAddressResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Err) > 0 {
+		i -= len(m.Err)
+		copy(dAtA[i:], m.Err)
+		i = encodeVarintIpam(dAtA, i, uint64(len(m.Err)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestAddressesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestAddressesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestAddressesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for iNdEx := len(m.Requests) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Requests[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIpam(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestAddressesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestAddressesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestAddressesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for iNdEx := len(m.Responses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Responses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIpam(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleaseAddressesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseAddressesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleaseAddressesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for iNdEx := len(m.Requests) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Requests[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIpam(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReleaseAddressesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReleaseAddressesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReleaseAddressesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for iNdEx := len(m.Responses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Responses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIpam(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintIpam(dAtA []byte, offset int, v uint64) int {
+	offset -= sovIpam(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GetCapabilityRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetCapabilityResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequiresMACAddress {
+		n += 2
+	}
+	if m.RequiresRequestReplay {
+		n += 2
+	}
+	if m.BatchSupport {
+		n += 2
+	}
+	return n
+}
+
+func (m *GetAddressSpacesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetAddressSpacesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LocalDefaultAddressSpace)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	l = len(m.GlobalDefaultAddressSpace)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	return n
+}
+
+func (m *RequestPoolRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.AddressSpace)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	l = len(m.SubPool)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	if len(m.Options) > 0 {
+		for k, v := range m.Options {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIpam(uint64(len(k))) + 1 + len(v) + sovIpam(uint64(len(v)))
+			n += mapEntrySize + 1 + sovIpam(uint64(mapEntrySize))
+		}
+	}
+	if m.V6 {
+		n += 2
+	}
+	return n
+}
+
+func (m *RequestPoolResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PoolID)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	l = len(m.Pool)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	if len(m.Data) > 0 {
+		for k, v := range m.Data {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIpam(uint64(len(k))) + 1 + len(v) + sovIpam(uint64(len(v)))
+			n += mapEntrySize + 1 + sovIpam(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *ReleasePoolRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PoolID)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	return n
+}
+
+func (m *ReleasePoolResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RequestAddressRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PoolID)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	if len(m.Options) > 0 {
+		for k, v := range m.Options {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIpam(uint64(len(k))) + 1 + len(v) + sovIpam(uint64(len(v)))
+			n += mapEntrySize + 1 + sovIpam(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *RequestAddressResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	if len(m.Data) > 0 {
+		for k, v := range m.Data {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIpam(uint64(len(k))) + 1 + len(v) + sovIpam(uint64(len(v)))
+			n += mapEntrySize + 1 + sovIpam(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.Err)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	return n
+}
+
+func (m *ReleaseAddressRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.PoolID)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	return n
+}
+
+func (m *ReleaseAddressResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Err)
+	if l > 0 {
+		n += 1 + l + sovIpam(uint64(l))
+	}
+	return n
+}
+
+func (m *RequestAddressesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovIpam(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RequestAddressesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovIpam(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ReleaseAddressesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovIpam(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ReleaseAddressesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovIpam(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovIpam(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozIpam(x uint64) (n int) {
+	return sovIpam(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GetCapabilityRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetCapabilityRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetCapabilityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetCapabilityResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetCapabilityResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetCapabilityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequiresMACAddress", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RequiresMACAddress = bool(v != 0)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequiresRequestReplay", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RequiresRequestReplay = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchSupport", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BatchSupport = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAddressSpacesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAddressSpacesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAddressSpacesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAddressSpacesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAddressSpacesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAddressSpacesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalDefaultAddressSpace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LocalDefaultAddressSpace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GlobalDefaultAddressSpace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GlobalDefaultAddressSpace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestPoolRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestPoolRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestPoolRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddressSpace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AddressSpace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SubPool", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SubPool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Options == nil {
+				m.Options = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIpam
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIpam
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIpam
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIpam
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthIpam
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIpam(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Options[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field V6", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.V6 = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestPoolResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestPoolResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestPoolResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pool", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pool = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Data == nil {
+				m.Data = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIpam
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIpam
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIpam
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIpam
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthIpam
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIpam(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Data[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleasePoolRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleasePoolRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleasePoolRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleasePoolResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleasePoolResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleasePoolResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestAddressRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestAddressRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestAddressRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Options == nil {
+				m.Options = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIpam
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIpam
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIpam
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIpam
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthIpam
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIpam(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Options[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestAddressResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestAddressResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestAddressResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Data == nil {
+				m.Data = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIpam
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIpam
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIpam
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIpam
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthIpam
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIpam(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIpam
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Data[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Err = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseAddressRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseAddressRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseAddressRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PoolID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PoolID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseAddressResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseAddressResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseAddressResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Err = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestAddressesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestAddressesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestAddressesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, &RequestAddressRequest{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestAddressesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestAddressesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestAddressesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, &RequestAddressResponse{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseAddressesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseAddressesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseAddressesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, &ReleaseAddressRequest{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReleaseAddressesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReleaseAddressesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReleaseAddressesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIpam
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, &ReleaseAddressResponse{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIpam(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIpam
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipIpam(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowIpam
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowIpam
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthIpam
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupIpam
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthIpam
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthIpam        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowIpam          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupIpam = fmt.Errorf("proto: unexpected end of group")
+)
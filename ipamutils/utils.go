@@ -88,6 +88,12 @@ func ConfigLocalScopeDefaultNetworks(defaultAddressPool []*NetworkToSplit) error
 	return configDefaultNetworks(defaultAddressPool, &PredefinedLocalScopeDefaultNetworks)
 }
 
+// SplitNetworks splits the passed networks in chunks of their size and
+// returns the chunks, in order
+func SplitNetworks(list []*NetworkToSplit) ([]*net.IPNet, error) {
+	return splitNetworks(list)
+}
+
 // splitNetworks takes a slice of networks, split them accordingly and returns them
 func splitNetworks(list []*NetworkToSplit) ([]*net.IPNet, error) {
 	localPools := make([]*net.IPNet, 0, len(list))
@@ -115,7 +121,7 @@ func splitNetwork(size int, base *net.IPNet) []*net.IPNet {
 
 	for i := 0; i < n; i++ {
 		ip := copyIP(base.IP)
-		addIntToIP(ip, uint(i<<s))
+		addIntToIP(ip, uint(i), s)
 		list = append(list, &net.IPNet{IP: ip, Mask: mask})
 	}
 	return list
@@ -127,8 +133,11 @@ func copyIP(from net.IP) net.IP {
 	return ip
 }
 
-func addIntToIP(array net.IP, ordinal uint) {
-	for i := len(array) - 1; i >= 0; i-- {
+// addIntToIP sets the ordinal shifted left by shift bits in the address. The
+// shift may exceed the width of the ordinal, as it does for IPv6 chunks.
+func addIntToIP(array net.IP, ordinal uint, shift uint) {
+	ordinal <<= shift % 8
+	for i := len(array) - 1 - int(shift/8); i >= 0 && ordinal != 0; i-- {
 		array[i] |= (byte)(ordinal & 0xff)
 		ordinal >>= 8
 	}
@@ -116,3 +116,16 @@ func TestInitAddressPools(t *testing.T) {
 	assert.Check(t, is.Equal(PredefinedLocalScopeDefaultNetworks[383].String(), "172.90.127.0/24"))
 	assert.Check(t, is.Equal(PredefinedLocalScopeDefaultNetworks[511].String(), "172.90.255.0/24"))
 }
+
+func TestSplitNetworksIPv6(t *testing.T) {
+	list, err := SplitNetworks([]*NetworkToSplit{{"2001:db8:1:ff00::/56", 64}})
+	assert.NilError(t, err)
+
+	assert.Check(t, is.Len(list, 256))
+	assert.Check(t, is.Equal(list[0].String(), "2001:db8:1:ff00::/64"))
+	assert.Check(t, is.Equal(list[1].String(), "2001:db8:1:ff01::/64"))
+	assert.Check(t, is.Equal(list[255].String(), "2001:db8:1:ffff::/64"))
+
+	_, err = SplitNetworks([]*NetworkToSplit{{"2001:db8:1:ff00::/56", 48}})
+	assert.Check(t, err != nil)
+}
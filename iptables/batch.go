@@ -0,0 +1,348 @@
+package iptables
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	iptablesSavePath     string
+	iptablesRestorePath  string
+	ip6tablesSavePath    string
+	ip6tablesRestorePath string
+	supportsRestoreXlock = false
+)
+
+// detectRestore looks the iptables-save and iptables-restore binaries up,
+// the batches being applied rule by rule without them
+func detectRestore(mj, mn, mc int) {
+	iptablesSavePath, _ = exec.LookPath("iptables-save")
+	iptablesRestorePath, _ = exec.LookPath("iptables-restore")
+	ip6tablesSavePath, _ = exec.LookPath("ip6tables-save")
+	ip6tablesRestorePath, _ = exec.LookPath("ip6tables-restore")
+	supportsRestoreXlock = supportsXlock && supportsRestoreWait(mj, mn, mc)
+}
+
+// iptables-restore takes the xtables lock with --wait since v1.6.2
+// http://ftp.netfilter.org/pub/iptables/changes-iptables-1.6.2.txt
+func supportsRestoreWait(mj, mn, mc int) bool {
+	return mj > 1 || (mj == 1 && (mn > 6 || (mn == 6 && mc >= 2)))
+}
+
+type batchOp struct {
+	table  Table
+	chain  string
+	action Action
+	args   []string
+	// check makes the operation apply only when the rule presence differs
+	// from the one the action leads to, as ProgramRule does
+	check bool
+}
+
+// Batch collects rule changes and applies them at once, with a single
+// iptables-save call for the checks and a single iptables-restore --noflush
+// call for the changes of all the tables. The checked deletions of rules not
+// found in the saved ones are applied one by one, as are all the changes
+// when firewalld is running, or the restore binaries are missing or fail.
+type Batch struct {
+	iptable IPTable
+	ops     []batchOp
+}
+
+// NewBatch returns an empty batch of rule changes for the iptable version
+func (iptable IPTable) NewBatch() *Batch {
+	return &Batch{iptable: iptable}
+}
+
+// ProgramRule adds to the batch the rule change ProgramRule would make: the
+// rule is added only if not already present in the chain and removed only
+// if present.
+func (b *Batch) ProgramRule(table Table, chain string, action Action, args []string) {
+	b.ops = append(b.ops, batchOp{table: table, chain: chain, action: action, args: args, check: true})
+}
+
+// RawRule adds to the batch an unconditional rule change
+func (b *Batch) RawRule(table Table, chain string, action Action, args ...string) {
+	b.ops = append(b.ops, batchOp{table: table, chain: chain, action: action, args: args})
+}
+
+// Len returns the number of rule changes in the batch
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// Apply applies the rule changes of the batch and empties it
+func (b *Batch) Apply() error {
+	ops := b.ops
+	b.ops = nil
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := initCheck(); err != nil {
+		return err
+	}
+
+	restorePath, savePath := iptablesRestorePath, iptablesSavePath
+	if b.iptable.Version == IPv6 {
+		restorePath, savePath = ip6tablesRestorePath, ip6tablesSavePath
+	}
+	if firewalldRunning || restorePath == "" || savePath == "" {
+		return b.iptable.applyEach(ops)
+	}
+
+	present, err := b.iptable.save(savePath)
+	if err != nil {
+		logrus.Debugf("Failed to read the iptables rules, applying the batch rule by rule: %v", err)
+		return b.iptable.applyEach(ops)
+	}
+
+	input, unmatched := restoreInput(ops, present)
+	if input != nil {
+		if err := b.iptable.restore(restorePath, input); err != nil {
+			logrus.Debugf("Failed to apply the iptables batch, applying it rule by rule: %v", err)
+			return b.iptable.applyEach(ops)
+		}
+	}
+	// The rule to delete may only be written differently than the saved one,
+	// let iptables check for it
+	if len(unmatched) > 0 {
+		logrus.Debugf("%d iptables rules to delete not found in the saved rules, deleting them rule by rule", len(unmatched))
+		return b.iptable.applyEach(unmatched)
+	}
+	return nil
+}
+
+// applyEach applies the rule changes one by one
+func (iptable IPTable) applyEach(ops []batchOp) error {
+	for _, op := range ops {
+		if op.check {
+			if err := iptable.ProgramRule(op.table, op.chain, op.action, op.args); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := iptable.RawCombinedOutput(append([]string{"-t", string(op.table), string(op.action), op.chain}, op.args...)...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreInput returns the iptables-restore input applying the rule changes,
+// grouped per table in the order the tables come first, or nil when the
+// checks leave no change. The rules in present are updated as the changes
+// are made. The checked deletions of rules not found in present are left
+// out of the input and returned, as the rules may be there in a form
+// canonicalRule does not match.
+func restoreInput(ops []batchOp, present map[Table]map[string]bool) ([]byte, []batchOp) {
+	var (
+		tables    []Table
+		changes   = make(map[Table][]string)
+		unmatched []batchOp
+	)
+	for _, op := range ops {
+		rules, ok := present[op.table]
+		if !ok {
+			rules = make(map[string]bool)
+			present[op.table] = rules
+		}
+		rule := canonicalRule(op.chain, op.args)
+		if op.check && op.action == Delete && !rules[rule] {
+			unmatched = append(unmatched, op)
+			continue
+		}
+		if op.check && rules[rule] != (op.action == Delete) {
+			continue
+		}
+		rules[rule] = op.action != Delete
+
+		if _, ok := changes[op.table]; !ok {
+			tables = append(tables, op.table)
+		}
+		changes[op.table] = append(changes[op.table], strings.Join(append([]string{string(op.action), op.chain}, quoteArgs(op.args)...), " "))
+	}
+	if len(tables) == 0 {
+		return nil, unmatched
+	}
+
+	var buf bytes.Buffer
+	for _, t := range tables {
+		fmt.Fprintf(&buf, "*%s\n", t)
+		for _, c := range changes[t] {
+			fmt.Fprintln(&buf, c)
+		}
+		fmt.Fprintln(&buf, "COMMIT")
+	}
+	return buf.Bytes(), unmatched
+}
+
+func quoteArgs(args []string) []string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if strings.ContainsAny(a, " \t\"") {
+			a = fmt.Sprintf("%q", a)
+		}
+		quoted[i] = a
+	}
+	return quoted
+}
+
+// save returns the rules of all the tables, in their canonical form
+func (iptable IPTable) save(path string) (map[Table]map[string]bool, error) {
+	out, err := exec.Command(path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %v", path, err)
+	}
+	return parseSave(out), nil
+}
+
+// parseSave parses the iptables-save output into the canonical form of the
+// rules of each table
+func parseSave(out []byte) map[Table]map[string]bool {
+	present := make(map[Table]map[string]bool)
+	var rules map[string]bool
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		switch {
+		case strings.HasPrefix(line, "*"):
+			rules = make(map[string]bool)
+			present[Table(line[1:])] = rules
+		case strings.HasPrefix(line, "-A ") && rules != nil:
+			fields := strings.Fields(line)
+			if len(fields) > 1 {
+				rules[canonicalRule(fields[1], fields[2:])] = true
+			}
+		}
+	}
+	return present
+}
+
+var (
+	// longOptions are the long forms of the options iptables-save shows in
+	// their short form
+	longOptions = map[string]string{
+		"--source":        "-s",
+		"--src":           "-s",
+		"--destination":   "-d",
+		"--dst":           "-d",
+		"--in-interface":  "-i",
+		"--out-interface": "-o",
+		"--protocol":      "-p",
+		"--jump":          "-j",
+		"--match":         "-m",
+	}
+	// baseOptions are the options iptables-save shows first, in this order
+	baseOptions = []string{"-s", "-d", "-i", "-o", "-p"}
+)
+
+// canonicalRule returns the rule of the chain in the form iptables-save
+// shows it: the address, interface and protocol options first, addresses
+// with their prefix length, and the implicit protocol match of the ports.
+func canonicalRule(chain string, args []string) string {
+	var (
+		base    = make(map[string][]string)
+		rest    []string
+		negate  bool
+		hasPort bool
+		proto   string
+		matches = make(map[string]bool)
+	)
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "!" {
+			negate = true
+			continue
+		}
+		if short, ok := longOptions[a]; ok {
+			a = short
+		}
+		if isBaseOption(a) && i+1 < len(args) {
+			val := args[i+1]
+			i++
+			if a == "-s" || a == "-d" {
+				if val == "0/0" || val == "0.0.0.0/0" || val == "::/0" {
+					negate = false
+					continue
+				}
+				if !strings.Contains(val, "/") {
+					if strings.Contains(val, ":") {
+						val += "/128"
+					} else {
+						val += "/32"
+					}
+				}
+			}
+			if a == "-p" {
+				proto = val
+			}
+			opt := []string{a, val}
+			if negate {
+				opt = append([]string{"!"}, opt...)
+			}
+			base[a] = opt
+			negate = false
+			continue
+		}
+		switch a {
+		case "--dport", "--sport", "--destination-port", "--source-port":
+			hasPort = true
+		case "-m":
+			if i+1 < len(args) {
+				matches[args[i+1]] = true
+			}
+		}
+		if negate {
+			rest = append(rest, "!")
+			negate = false
+		}
+		rest = append(rest, a)
+	}
+
+	rule := []string{"-A", chain}
+	for _, o := range baseOptions {
+		rule = append(rule, base[o]...)
+	}
+	if hasPort && proto != "" && !matches[proto] {
+		rule = append(rule, "-m", proto)
+	}
+	return strings.Join(append(rule, rest...), " ")
+}
+
+func isBaseOption(a string) bool {
+	for _, o := range baseOptions {
+		if a == o {
+			return true
+		}
+	}
+	return false
+}
+
+// restore applies the iptables-restore input without flushing the tables
+func (iptable IPTable) restore(path string, input []byte) error {
+	args := []string{"--noflush"}
+	if supportsRestoreXlock {
+		args = append(args, "--wait")
+	} else {
+		bestEffortLock.Lock()
+		defer bestEffortLock.Unlock()
+	}
+
+	logrus.Debugf("%s, %v", path, args)
+
+	startTime := time.Now()
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %s (%v)", path, strings.Join(args, " "), output, err)
+	}
+	filterOutput(startTime, output, args...)
+	return nil
+}
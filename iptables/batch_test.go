@@ -0,0 +1,264 @@
+package iptables
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeIptablesScript logs its calls and the iptables-restore input, and
+// plays the iptables-save output from the rules file. The rule checks of
+// iptables fail, as if no rule existed, unless the check-succeeds file exists.
+const fakeIptablesScript = `#!/bin/sh
+dir=%q
+name=$(basename "$0")
+echo "$name $*" >> "$dir/calls"
+case "$name" in
+iptables-save)
+	cat "$dir/rules"
+	;;
+iptables-restore)
+	cat >> "$dir/restored"
+	[ -e "$dir/restore-fails" ] && exit 1
+	;;
+iptables)
+	case " $* " in *" -C "*) [ -e "$dir/check-succeeds" ] || exit 1;; esac
+	;;
+esac
+exit 0
+`
+
+const fakeRules = `# Generated by iptables-save v1.8.4 on Tue Oct 13 10:00:00 2026
+*nat
+:PREROUTING ACCEPT [0:0]
+:DOCKER - [0:0]
+-A POSTROUTING -s 172.17.0.2/32 -d 172.17.0.2/32 -p tcp -m tcp --dport 80 -j MASQUERADE
+-A DOCKER ! -i docker0 -p tcp -m tcp --dport 8080 -j DNAT --to-destination 172.17.0.2:80
+COMMIT
+*filter
+:FORWARD DROP [0:0]
+:DOCKER - [0:0]
+-A DOCKER -d 172.17.0.3/32 ! -i docker0 -o docker0 -p tcp -m tcp --dport 443 -j ACCEPT
+COMMIT
+`
+
+type fakeIptables struct {
+	dir string
+	t   *testing.T
+}
+
+func setupFakeIptables(t *testing.T) (*fakeIptables, func()) {
+	// Have the dependencies detected before the paths are overridden
+	initCheck()
+
+	dir, err := ioutil.TempDir("", "fake-iptables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "iptables")
+	if err := ioutil.WriteFile(script, []byte(fmt.Sprintf(fakeIptablesScript, dir)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"iptables-save", "iptables-restore"} {
+		if err := os.Symlink(script, filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "rules"), []byte(fakeRules), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := []*string{&iptablesPath, &iptablesSavePath, &iptablesRestorePath}
+	saved := []string{iptablesPath, iptablesSavePath, iptablesRestorePath}
+	savedCOpt, savedXlock, savedRestoreXlock, savedFirewalld := supportsCOpt, supportsXlock, supportsRestoreXlock, firewalldRunning
+	iptablesPath = script
+	iptablesSavePath = filepath.Join(dir, "iptables-save")
+	iptablesRestorePath = filepath.Join(dir, "iptables-restore")
+	supportsCOpt, supportsXlock, supportsRestoreXlock, firewalldRunning = true, false, false, false
+
+	return &fakeIptables{dir: dir, t: t}, func() {
+		for i, p := range paths {
+			*p = saved[i]
+		}
+		supportsCOpt, supportsXlock, supportsRestoreXlock, firewalldRunning = savedCOpt, savedXlock, savedRestoreXlock, savedFirewalld
+		os.RemoveAll(dir)
+	}
+}
+
+func (f *fakeIptables) read(name string) string {
+	b, err := ioutil.ReadFile(filepath.Join(f.dir, name))
+	if err != nil && !os.IsNotExist(err) {
+		f.t.Fatal(err)
+	}
+	return string(b)
+}
+
+func (f *fakeIptables) reset() {
+	os.Remove(filepath.Join(f.dir, "calls"))
+	os.Remove(filepath.Join(f.dir, "restored"))
+}
+
+func TestBatchRestore(t *testing.T) {
+	f, cleanup := setupFakeIptables(t)
+	defer cleanup()
+
+	b := GetIptable(IPv4).NewBatch()
+	// Already present, skipped
+	b.ProgramRule(Nat, "DOCKER", Append, []string{"-p", "tcp", "-d", "0/0", "--dport", "8080", "-j", "DNAT", "--to-destination", "172.17.0.2:80", "!", "-i", "docker0"})
+	// Missing, added
+	b.ProgramRule(Nat, "DOCKER", Append, []string{"-p", "tcp", "-d", "0/0", "--dport", "8081", "-j", "DNAT", "--to-destination", "172.17.0.2:81", "!", "-i", "docker0"})
+	// Added once only
+	b.ProgramRule(Nat, "DOCKER", Append, []string{"-p", "tcp", "-d", "0/0", "--dport", "8081", "-j", "DNAT", "--to-destination", "172.17.0.2:81", "!", "-i", "docker0"})
+	// Present, deleted
+	b.ProgramRule(Filter, "DOCKER", Delete, []string{"!", "-i", "docker0", "-o", "docker0", "-p", "tcp", "-d", "172.17.0.3", "--dport", "443", "-j", "ACCEPT"})
+	// Not in the saved rules, checked with iptables and skipped
+	b.ProgramRule(Filter, "DOCKER", Delete, []string{"!", "-i", "docker0", "-o", "docker0", "-p", "tcp", "-d", "172.17.0.4", "--dport", "443", "-j", "ACCEPT"})
+	// Unconditional
+	b.RawRule(Nat, "POSTROUTING", Append, "-m", "comment", "--comment", "a comment", "-j", "RETURN")
+	if b.Len() != 6 {
+		t.Fatalf("expected 6 rule changes in the batch, got %d", b.Len())
+	}
+
+	if err := b.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if b.Len() != 0 {
+		t.Fatal("batch not emptied by Apply")
+	}
+
+	calls := strings.Split(strings.TrimSpace(f.read("calls")), "\n")
+	if len(calls) != 3 || calls[0] != "iptables-save " || calls[1] != "iptables-restore --noflush" ||
+		calls[2] != "iptables -t filter -C DOCKER ! -i docker0 -o docker0 -p tcp -d 172.17.0.4 --dport 443 -j ACCEPT" {
+		t.Fatalf("unexpected calls %q", calls)
+	}
+	expected := `*nat
+-A DOCKER -p tcp -d 0/0 --dport 8081 -j DNAT --to-destination 172.17.0.2:81 ! -i docker0
+-A POSTROUTING -m comment --comment "a comment" -j RETURN
+COMMIT
+*filter
+-D DOCKER ! -i docker0 -o docker0 -p tcp -d 172.17.0.3 --dport 443 -j ACCEPT
+COMMIT
+`
+	if restored := f.read("restored"); restored != expected {
+		t.Fatalf("unexpected iptables-restore input:\n%s", restored)
+	}
+
+	// Nothing to change, nothing restored
+	f.reset()
+	b.ProgramRule(Nat, "DOCKER", Append, []string{"-p", "tcp", "-d", "0/0", "--dport", "8080", "-j", "DNAT", "--to-destination", "172.17.0.2:80", "!", "-i", "docker0"})
+	if err := b.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if calls := f.read("calls"); calls != "iptables-save \n" {
+		t.Fatalf("unexpected calls %q", calls)
+	}
+}
+
+func TestBatchFallback(t *testing.T) {
+	f, cleanup := setupFakeIptables(t)
+	defer cleanup()
+
+	if err := ioutil.WriteFile(filepath.Join(f.dir, "restore-fails"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := GetIptable(IPv4).NewBatch()
+	b.ProgramRule(Nat, "DOCKER", Append, []string{"-p", "tcp", "--dport", "8081", "-j", "DNAT", "--to-destination", "172.17.0.2:81"})
+	b.RawRule(Filter, "DOCKER", Insert, "-j", "RETURN")
+	if err := b.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := strings.Split(strings.TrimSpace(f.read("calls")), "\n")
+	expected := []string{
+		"iptables-save ",
+		"iptables-restore --noflush",
+		"iptables -t nat -C DOCKER -p tcp --dport 8081 -j DNAT --to-destination 172.17.0.2:81",
+		"iptables -t nat -A DOCKER -p tcp --dport 8081 -j DNAT --to-destination 172.17.0.2:81",
+		"iptables -t filter -I DOCKER -j RETURN",
+	}
+	if strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected calls:\n%s", strings.Join(calls, "\n"))
+	}
+}
+
+func TestBatchUnmatchedDelete(t *testing.T) {
+	f, cleanup := setupFakeIptables(t)
+	defer cleanup()
+
+	if err := ioutil.WriteFile(filepath.Join(f.dir, "check-succeeds"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Present, in a form canonicalRule does not match the saved one with
+	b := GetIptable(IPv4).NewBatch()
+	b.ProgramRule(Filter, "DOCKER", Delete, []string{"-d", "172.17.0.3", "!", "-i", "docker0", "-o", "docker0", "-p", "tcp", "-m", "tcp", "-m", "comment", "--comment", "web", "--dport", "443", "-j", "ACCEPT"})
+	if err := b.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := strings.Split(strings.TrimSpace(f.read("calls")), "\n")
+	expected := []string{
+		"iptables-save ",
+		"iptables -t filter -C DOCKER -d 172.17.0.3 ! -i docker0 -o docker0 -p tcp -m tcp -m comment --comment web --dport 443 -j ACCEPT",
+		"iptables -t filter -D DOCKER -d 172.17.0.3 ! -i docker0 -o docker0 -p tcp -m tcp -m comment --comment web --dport 443 -j ACCEPT",
+	}
+	if strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected calls:\n%s", strings.Join(calls, "\n"))
+	}
+	if restored := f.read("restored"); restored != "" {
+		t.Fatalf("unexpected iptables-restore input:\n%s", restored)
+	}
+}
+
+func TestBatchForward(t *testing.T) {
+	f, cleanup := setupFakeIptables(t)
+	defer cleanup()
+
+	c := &ChainInfo{Name: "DOCKER", Table: Nat, IPTable: IPTable{Version: IPv4}}
+	if err := c.Forward(Append, net.IPv4zero, 8082, "tcp", "172.17.0.2", 82, "docker0"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := strings.Count(f.read("calls"), "\n"); calls != 2 {
+		t.Fatalf("expected a save and a restore call, got %d calls", calls)
+	}
+	if restored := f.read("restored"); strings.Count(restored, "-A ") != 3 || strings.Count(restored, "COMMIT") != 2 {
+		t.Fatalf("unexpected iptables-restore input:\n%s", restored)
+	}
+}
+
+func TestCanonicalRule(t *testing.T) {
+	c := &ChainInfo{Name: "DOCKER", Table: Nat}
+	rules := c.ForwardRules(net.IPv4zero, 8080, "tcp", "172.17.0.2", 80, 1, "docker0")
+	rules = append(rules, c.ForwardRules(net.ParseIP("10.0.0.1"), 5000, "sctp", "172.17.0.2", 5000, 10, "docker0")...)
+	rules = append(rules,
+		Rule{Table: Nat, Chain: "OUTPUT", Args: []string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", "DOCKER", "!", "--dst", "127.0.0.0/8"}},
+		Rule{Table: Filter, Chain: "FORWARD", Args: []string{"-o", "docker0", "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT"}},
+	)
+
+	// As iptables-save v1.8 shows them
+	saved := []string{
+		"-A DOCKER ! -i docker0 -p tcp -m tcp --dport 8080 -j DNAT --to-destination 172.17.0.2:80",
+		"-A DOCKER -d 172.17.0.2/32 ! -i docker0 -o docker0 -p tcp -m tcp --dport 80 -j ACCEPT",
+		"-A POSTROUTING -s 172.17.0.2/32 -d 172.17.0.2/32 -p tcp -m tcp --dport 80 -j MASQUERADE",
+		"-A DOCKER -d 10.0.0.1/32 ! -i docker0 -p sctp -m sctp --dport 5000:5009 -j DNAT --to-destination 172.17.0.2",
+		"-A DOCKER -d 172.17.0.2/32 ! -i docker0 -o docker0 -p sctp -m sctp --dport 5000:5009 -j ACCEPT",
+		"-A POSTROUTING -s 172.17.0.2/32 -d 172.17.0.2/32 -p sctp -m sctp --dport 5000:5009 -j MASQUERADE",
+		"-A POSTROUTING -p sctp -m sctp --sport 5000:5009 -j CHECKSUM --checksum-fill",
+		"-A OUTPUT ! -d 127.0.0.0/8 -m addrtype --dst-type LOCAL -j DOCKER",
+		"-A FORWARD -o docker0 -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT",
+	}
+	if len(rules) != len(saved) {
+		t.Fatalf("expected %d rules, got %d", len(saved), len(rules))
+	}
+	for i, r := range rules {
+		fields := strings.Fields(saved[i])
+		if c, s := canonicalRule(r.Chain, r.Args), canonicalRule(fields[1], fields[2:]); c != s {
+			t.Fatalf("rule %d: canonical form %q differs from the saved one %q", i, c, s)
+		}
+	}
+}
@@ -75,6 +75,13 @@ type ChainInfo struct {
 	IPTable     IPTable
 }
 
+// Rule is a rule of an iptables chain.
+type Rule struct {
+	Table Table
+	Chain string
+	Args  []string
+}
+
 // ChainError is returned to represent errors during ip table operation.
 type ChainError struct {
 	Chain  string
@@ -125,6 +132,7 @@ func detectIptables() {
 		return
 	}
 	supportsCOpt = supportsCOption(mj, mn, mc)
+	detectRestore(mj, mn, mc)
 }
 
 func initDependencies() {
@@ -197,12 +205,10 @@ func (iptable IPTable) ProgramChain(c *ChainInfo, bridgeName string, hairpinMode
 		}
 	}
 
+	rules := iptable.ChainRules(c, bridgeName, hairpinMode)
 	switch c.Table {
 	case Nat:
-		preroute := []string{
-			"-m", "addrtype",
-			"--dst-type", "LOCAL",
-			"-j", c.Name}
+		preroute := rules[0].Args
 		if !iptable.Exists(Nat, "PREROUTING", preroute...) && enable {
 			if err := c.Prerouting(Append, preroute...); err != nil {
 				return fmt.Errorf("Failed to inject %s in PREROUTING chain: %s", c.Name, err)
@@ -212,13 +218,7 @@ func (iptable IPTable) ProgramChain(c *ChainInfo, bridgeName string, hairpinMode
 				return fmt.Errorf("Failed to remove %s in PREROUTING chain: %s", c.Name, err)
 			}
 		}
-		output := []string{
-			"-m", "addrtype",
-			"--dst-type", "LOCAL",
-			"-j", c.Name}
-		if !hairpinMode {
-			output = append(output, "!", "--dst", iptable.LoopbackByVersion())
-		}
+		output := rules[1].Args
 		if !iptable.Exists(Nat, "OUTPUT", output...) && enable {
 			if err := c.Output(Append, output...); err != nil {
 				return fmt.Errorf("Failed to inject %s in OUTPUT chain: %s", c.Name, err)
@@ -233,9 +233,7 @@ func (iptable IPTable) ProgramChain(c *ChainInfo, bridgeName string, hairpinMode
 			return fmt.Errorf("Could not program chain %s/%s, missing bridge name",
 				c.Table, c.Name)
 		}
-		link := []string{
-			"-o", bridgeName,
-			"-j", c.Name}
+		link := rules[0].Args
 		if !iptable.Exists(Filter, "FORWARD", link...) && enable {
 			insert := append([]string{string(Insert), "FORWARD"}, link...)
 			if output, err := iptable.Raw(insert...); err != nil {
@@ -252,11 +250,7 @@ func (iptable IPTable) ProgramChain(c *ChainInfo, bridgeName string, hairpinMode
 			}
 
 		}
-		establish := []string{
-			"-o", bridgeName,
-			"-m", "conntrack",
-			"--ctstate", "RELATED,ESTABLISHED",
-			"-j", "ACCEPT"}
+		establish := rules[1].Args
 		if !iptable.Exists(Filter, "FORWARD", establish...) && enable {
 			insert := append([]string{string(Insert), "FORWARD"}, establish...)
 			if output, err := iptable.Raw(insert...); err != nil {
@@ -276,6 +270,33 @@ func (iptable IPTable) ProgramChain(c *ChainInfo, bridgeName string, hairpinMode
 	return nil
 }
 
+// ChainRules returns the rules ProgramChain programs to link the chain to
+// the bridge: the jumps to a nat chain from PREROUTING and OUTPUT, the jump
+// to a filter chain and the rule accepting the established connections in
+// FORWARD.
+func (iptable IPTable) ChainRules(c *ChainInfo, bridgeName string, hairpinMode bool) []Rule {
+	switch c.Table {
+	case Nat:
+		output := []string{
+			"-m", "addrtype",
+			"--dst-type", "LOCAL",
+			"-j", c.Name}
+		if !hairpinMode {
+			output = append(output, "!", "--dst", iptable.LoopbackByVersion())
+		}
+		return []Rule{
+			{Table: Nat, Chain: "PREROUTING", Args: []string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", c.Name}},
+			{Table: Nat, Chain: "OUTPUT", Args: output},
+		}
+	case Filter:
+		return []Rule{
+			{Table: Filter, Chain: "FORWARD", Args: []string{"-o", bridgeName, "-j", c.Name}},
+			{Table: Filter, Chain: "FORWARD", Args: []string{"-o", bridgeName, "-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "ACCEPT"}},
+		}
+	}
+	return nil
+}
+
 // RemoveExistingChain removes existing chain from the table.
 func (iptable IPTable) RemoveExistingChain(name string, table Table) error {
 	c := &ChainInfo{
@@ -291,8 +312,37 @@ func (iptable IPTable) RemoveExistingChain(name string, table Table) error {
 
 // Forward adds forwarding rule to 'filter' table and corresponding nat rule to 'nat' table.
 func (c *ChainInfo) Forward(action Action, ip net.IP, port int, proto, destAddr string, destPort int, bridgeName string) error {
+	return c.ForwardRange(action, ip, port, proto, destAddr, destPort, 1, bridgeName)
+}
 
-	iptable := GetIptable(c.IPTable.Version)
+// ForwardRange adds forwarding rules to 'filter' table and corresponding nat rule to 'nat' table
+// for a block of size consecutive ports, with a single rule per table using the --dport a:b match.
+// When the block spans more than one port, the host and container ports must be the same, as the
+// DNAT target preserves the destination port.
+func (c *ChainInfo) ForwardRange(action Action, ip net.IP, port int, proto, destAddr string, destPort, size int, bridgeName string) error {
+	b := GetIptable(c.IPTable.Version).NewBatch()
+	if err := c.AddForwardRange(b, action, ip, port, proto, destAddr, destPort, size, bridgeName); err != nil {
+		return err
+	}
+	return b.Apply()
+}
+
+// AddForwardRange adds to the batch the rule changes ForwardRange makes, for
+// the rules of several port blocks to be programmed at once.
+func (c *ChainInfo) AddForwardRange(b *Batch, action Action, ip net.IP, port int, proto, destAddr string, destPort, size int, bridgeName string) error {
+	if size > 1 && port != destPort {
+		return fmt.Errorf("cannot forward port range %s to a different container port range %s",
+			portRange(port, size), portRange(destPort, size))
+	}
+
+	for _, r := range c.ForwardRules(ip, port, proto, destAddr, destPort, size, bridgeName) {
+		b.ProgramRule(r.Table, r.Chain, action, r.Args)
+	}
+	return nil
+}
+
+// ForwardRules returns the rules ForwardRange programs for the block of ports.
+func (c *ChainInfo) ForwardRules(ip net.IP, port int, proto, destAddr string, destPort, size int, bridgeName string) []Rule {
 	daddr := ip.String()
 	if ip.IsUnspecified() {
 		// iptables interprets "0.0.0.0" as "0.0.0.0/32", whereas we
@@ -301,43 +351,42 @@ func (c *ChainInfo) Forward(action Action, ip net.IP, port int, proto, destAddr
 		daddr = "0/0"
 	}
 
+	// Without a port, the DNAT target keeps the original destination port
+	toDest := destAddr
+	if size == 1 {
+		toDest = net.JoinHostPort(destAddr, strconv.Itoa(destPort))
+	}
+
 	args := []string{
 		"-p", proto,
 		"-d", daddr,
-		"--dport", strconv.Itoa(port),
+		"--dport", portRange(port, size),
 		"-j", "DNAT",
-		"--to-destination", net.JoinHostPort(destAddr, strconv.Itoa(destPort))}
+		"--to-destination", toDest}
 
 	if !c.HairpinMode {
 		args = append(args, "!", "-i", bridgeName)
 	}
-	if err := iptable.ProgramRule(Nat, c.Name, action, args); err != nil {
-		return err
-	}
+	rules := []Rule{{Table: Nat, Chain: c.Name, Args: args}}
 
 	args = []string{
 		"!", "-i", bridgeName,
 		"-o", bridgeName,
 		"-p", proto,
 		"-d", destAddr,
-		"--dport", strconv.Itoa(destPort),
+		"--dport", portRange(destPort, size),
 		"-j", "ACCEPT",
 	}
-	if err := iptable.ProgramRule(Filter, c.Name, action, args); err != nil {
-		return err
-	}
+	rules = append(rules, Rule{Table: Filter, Chain: c.Name, Args: args})
 
 	args = []string{
 		"-p", proto,
 		"-s", destAddr,
 		"-d", destAddr,
-		"--dport", strconv.Itoa(destPort),
+		"--dport", portRange(destPort, size),
 		"-j", "MASQUERADE",
 	}
-
-	if err := iptable.ProgramRule(Nat, "POSTROUTING", action, args); err != nil {
-		return err
-	}
+	rules = append(rules, Rule{Table: Nat, Chain: "POSTROUTING", Args: args})
 
 	if proto == "sctp" {
 		// Linux kernel v4.9 and below enables NETIF_F_SCTP_CRC for veth by
@@ -349,22 +398,28 @@ func (c *ChainInfo) Forward(action Action, ip net.IP, port int, proto, destAddr
 		// https://github.com/torvalds/linux/commit/c80fafbbb59ef9924962f83aac85531039395b18
 		args = []string{
 			"-p", proto,
-			"--sport", strconv.Itoa(destPort),
+			"--sport", portRange(destPort, size),
 			"-j", "CHECKSUM",
 			"--checksum-fill",
 		}
-		if err := iptable.ProgramRule(Mangle, "POSTROUTING", action, args); err != nil {
-			return err
-		}
+		rules = append(rules, Rule{Table: Mangle, Chain: "POSTROUTING", Args: args})
 	}
 
-	return nil
+	return rules
+}
+
+// portRange returns the iptables port match for a block of size ports starting at port
+func portRange(port, size int) string {
+	if size > 1 {
+		return fmt.Sprintf("%d:%d", port, port+size-1)
+	}
+	return strconv.Itoa(port)
 }
 
 // Link adds reciprocal ACCEPT rule for two supplied IP addresses.
 // Traffic is allowed from ip1 to ip2 and vice-versa
 func (c *ChainInfo) Link(action Action, ip1, ip2 net.IP, port int, proto string, bridgeName string) error {
-	iptable := GetIptable(c.IPTable.Version)
+	b := GetIptable(c.IPTable.Version).NewBatch()
 	// forward
 	args := []string{
 		"-i", bridgeName, "-o", bridgeName,
@@ -375,13 +430,13 @@ func (c *ChainInfo) Link(action Action, ip1, ip2 net.IP, port int, proto string,
 		"-j", "ACCEPT",
 	}
 
-	if err := iptable.ProgramRule(Filter, c.Name, action, args); err != nil {
-		return err
-	}
+	b.ProgramRule(Filter, c.Name, action, args)
 	// reverse
-	args[7], args[9] = args[9], args[7]
-	args[10] = "--sport"
-	return iptable.ProgramRule(Filter, c.Name, action, args)
+	rargs := append([]string(nil), args...)
+	rargs[7], rargs[9] = rargs[9], rargs[7]
+	rargs[10] = "--sport"
+	b.ProgramRule(Filter, c.Name, action, rargs)
+	return b.Apply()
 }
 
 // ProgramRule adds the rule specified by args only if the
@@ -631,11 +686,16 @@ func (iptable IPTable) AddReturnRule(chain string) error {
 	return nil
 }
 
+// JumpRule returns the rule EnsureJumpRule programs.
+func JumpRule(fromChain, toChain string) Rule {
+	return Rule{Table: Filter, Chain: fromChain, Args: []string{"-j", toChain}}
+}
+
 // EnsureJumpRule ensures the jump rule is on top
 func (iptable IPTable) EnsureJumpRule(fromChain, toChain string) error {
 	var (
 		table = Filter
-		args  = []string{"-j", toChain}
+		args  = JumpRule(fromChain, toChain).Args
 	)
 
 	if iptable.Exists(table, fromChain, args...) {
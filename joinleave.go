@@ -0,0 +1,224 @@
+package libnetwork
+
+import (
+	"sync"
+	"time"
+)
+
+// The joins and leaves of the sandboxes go through the join/leave scheduler
+// of the controller before calling into the drivers. It bounds the number
+// of operations running on the networks of each driver, hands the free
+// slots out to the networks in turn, leaves first, and holds new joins back
+// once too many of them are waiting already. This keeps a mass restart of
+// containers from piling up on the store, IPAM and iptables locks.
+
+const (
+	defaultJoinLeaveConcurrency = 16
+	defaultJoinLeaveQueueLen    = 512
+)
+
+type jlPriority int
+
+const (
+	// jlLeave is the priority of the leaves, the ones of the sandbox
+	// deletions included, which release what the joins wait for
+	jlLeave jlPriority = iota
+	jlJoin
+	jlPriorities
+)
+
+// JoinLeaveStats are the metrics of the join/leave scheduler
+type JoinLeaveStats struct {
+	// Running is the number of operations running per driver
+	Running map[string]int
+	// QueuedJoins and QueuedLeaves are the operations waiting for a slot
+	QueuedJoins  int
+	QueuedLeaves int
+	// Dispatched is the number of operations run so far
+	Dispatched uint64
+	// Throttled is the number of joins held back by a full queue
+	Throttled uint64
+	// WaitTotal and WaitMax are the total and the longest time the
+	// operations waited for their turn
+	WaitTotal time.Duration
+	WaitMax   time.Duration
+}
+
+type jlRequest struct {
+	driver string
+	ready  chan struct{}
+}
+
+// jlQueue holds the requests waiting with a priority, per network, along
+// with the networks in the order their turn comes
+type jlQueue struct {
+	requests map[string][]*jlRequest
+	turns    []string
+}
+
+type joinLeaveScheduler struct {
+	concurrency int
+	queueLen    int
+	running     map[string]int
+	waiting     map[string]int
+	queues      [jlPriorities]jlQueue
+	queued      [jlPriorities]int
+	room        *sync.Cond
+	dispatched  uint64
+	throttled   uint64
+	waitTotal   time.Duration
+	waitMax     time.Duration
+	sync.Mutex
+}
+
+func newJoinLeaveScheduler(concurrency, queueLen int) *joinLeaveScheduler {
+	if concurrency <= 0 {
+		concurrency = defaultJoinLeaveConcurrency
+	}
+	if queueLen <= 0 {
+		queueLen = defaultJoinLeaveQueueLen
+	}
+	s := &joinLeaveScheduler{
+		concurrency: concurrency,
+		queueLen:    queueLen,
+		running:     make(map[string]int),
+		waiting:     make(map[string]int),
+	}
+	for i := range s.queues {
+		s.queues[i].requests = make(map[string][]*jlRequest)
+	}
+	s.room = sync.NewCond(&s.Mutex)
+	return s
+}
+
+// acquire waits for the turn of an operation of the priority on the network
+// nid of the driver and returns the function ending it
+func (s *joinLeaveScheduler) acquire(driver, nid string, prio jlPriority) func() {
+	if s == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	s.Lock()
+	if prio == jlJoin && s.queued[jlJoin] >= s.queueLen {
+		s.throttled++
+		for s.queued[jlJoin] >= s.queueLen {
+			s.room.Wait()
+		}
+	}
+
+	// The requests already waiting on the driver go first
+	if s.waiting[driver] == 0 && s.running[driver] < s.concurrency {
+		s.running[driver]++
+		s.dispatched++
+		s.observeWait(time.Since(start))
+		s.Unlock()
+		return func() { s.release(driver) }
+	}
+
+	req := &jlRequest{driver: driver, ready: make(chan struct{})}
+	q := &s.queues[prio]
+	if len(q.requests[nid]) == 0 {
+		q.turns = append(q.turns, nid)
+	}
+	q.requests[nid] = append(q.requests[nid], req)
+	s.queued[prio]++
+	s.waiting[driver]++
+	s.Unlock()
+
+	<-req.ready
+
+	s.Lock()
+	s.observeWait(time.Since(start))
+	s.Unlock()
+	return func() { s.release(driver) }
+}
+
+func (s *joinLeaveScheduler) observeWait(wait time.Duration) {
+	s.waitTotal += wait
+	if wait > s.waitMax {
+		s.waitMax = wait
+	}
+}
+
+func (s *joinLeaveScheduler) release(driver string) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.running[driver]--; s.running[driver] <= 0 {
+		delete(s.running, driver)
+	}
+	s.dispatch()
+}
+
+// dispatch hands the free slots out to the waiting requests, leaves first,
+// taking the networks in turn. A network served goes back at the end of the
+// line if it has more requests waiting.
+func (s *joinLeaveScheduler) dispatch() {
+	for prio := range s.queues {
+		q := &s.queues[prio]
+		for i := 0; i < len(q.turns); {
+			nid := q.turns[i]
+			reqs := q.requests[nid]
+			req := reqs[0]
+			if s.running[req.driver] >= s.concurrency {
+				i++
+				continue
+			}
+
+			s.running[req.driver]++
+			if s.waiting[req.driver]--; s.waiting[req.driver] <= 0 {
+				delete(s.waiting, req.driver)
+			}
+			s.queued[prio]--
+			s.dispatched++
+			close(req.ready)
+
+			q.turns = append(q.turns[:i], q.turns[i+1:]...)
+			if len(reqs) == 1 {
+				delete(q.requests, nid)
+				continue
+			}
+			q.requests[nid] = reqs[1:]
+			q.turns = append(q.turns, nid)
+		}
+	}
+	s.room.Broadcast()
+}
+
+func (s *joinLeaveScheduler) stats() JoinLeaveStats {
+	if s == nil {
+		return JoinLeaveStats{}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	st := JoinLeaveStats{
+		Running:      make(map[string]int, len(s.running)),
+		QueuedJoins:  s.queued[jlJoin],
+		QueuedLeaves: s.queued[jlLeave],
+		Dispatched:   s.dispatched,
+		Throttled:    s.throttled,
+		WaitTotal:    s.waitTotal,
+		WaitMax:      s.waitMax,
+	}
+	for driver, n := range s.running {
+		st.Running[driver] = n
+	}
+	return st
+}
+
+// schedule waits for the turn of an operation of the priority on the network
+// of the endpoint and returns the function ending it
+func (ep *endpoint) schedule(prio jlPriority) func() {
+	n := ep.getNetwork()
+	if n == nil || n.getController() == nil {
+		return func() {}
+	}
+	return n.getController().joinLeave.acquire(n.Type(), n.ID(), prio)
+}
+
+func (c *controller) JoinLeaveStats() JoinLeaveStats {
+	return c.joinLeave.stats()
+}
@@ -0,0 +1,229 @@
+package libnetwork
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// jlRun runs the operations acquiring a slot of the scheduler, each one
+// reporting its name once started and waiting to be told to end
+type jlRun struct {
+	s       *joinLeaveScheduler
+	started chan string
+	end     map[string]chan struct{}
+}
+
+func newJLRun(s *joinLeaveScheduler) *jlRun {
+	return &jlRun{s: s, started: make(chan string, 100), end: make(map[string]chan struct{})}
+}
+
+func (r *jlRun) start(t *testing.T, name, driver, nid string, prio jlPriority, queued func(JoinLeaveStats) bool) {
+	end := make(chan struct{})
+	r.end[name] = end
+	go func() {
+		release := r.s.acquire(driver, nid, prio)
+		r.started <- name
+		<-end
+		release()
+	}()
+	// Wait for the operation to be queued, for the order to be known
+	r.wait(t, queued)
+}
+
+func (r *jlRun) wait(t *testing.T, cond func(JoinLeaveStats) bool) {
+	for i := 0; !cond(r.s.stats()); i++ {
+		if i == 1000 {
+			t.Fatalf("unexpected scheduler stats %+v", r.s.stats())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (r *jlRun) next(t *testing.T) string {
+	select {
+	case name := <-r.started:
+		return name
+	case <-time.After(5 * time.Second):
+		t.Fatal("no operation started")
+	}
+	return ""
+}
+
+func (r *jlRun) checkOrder(t *testing.T, expected ...string) {
+	for _, e := range expected {
+		name := r.next(t)
+		if name != e {
+			t.Fatalf("expected %s to start, got %s", e, name)
+		}
+		close(r.end[name])
+	}
+}
+
+func running(n int) func(JoinLeaveStats) bool {
+	return func(st JoinLeaveStats) bool {
+		total := 0
+		for _, r := range st.Running {
+			total += r
+		}
+		return total == n
+	}
+}
+
+func queued(joins, leaves int) func(JoinLeaveStats) bool {
+	return func(st JoinLeaveStats) bool {
+		return st.QueuedJoins == joins && st.QueuedLeaves == leaves
+	}
+}
+
+func TestJoinLeaveSchedulerPriority(t *testing.T) {
+	r := newJLRun(newJoinLeaveScheduler(1, 10))
+
+	r.start(t, "first", "bridge", "n1", jlJoin, running(1))
+	r.start(t, "join1", "bridge", "n1", jlJoin, queued(1, 0))
+	r.start(t, "join2", "bridge", "n2", jlJoin, queued(2, 0))
+	r.start(t, "leave", "bridge", "n1", jlLeave, queued(2, 1))
+
+	r.checkOrder(t, "first", "leave", "join1", "join2")
+}
+
+func TestJoinLeaveSchedulerFairness(t *testing.T) {
+	r := newJLRun(newJoinLeaveScheduler(1, 10))
+
+	r.start(t, "first", "bridge", "n1", jlJoin, running(1))
+	for i := 1; i <= 3; i++ {
+		r.start(t, fmt.Sprintf("n1-%d", i), "bridge", "n1", jlJoin, queued(i, 0))
+	}
+	r.start(t, "n2-1", "bridge", "n2", jlJoin, queued(4, 0))
+	r.start(t, "n3-1", "bridge", "n3", jlJoin, queued(5, 0))
+
+	r.checkOrder(t, "first", "n1-1", "n2-1", "n3-1", "n1-2", "n1-3")
+}
+
+func TestJoinLeaveSchedulerDrivers(t *testing.T) {
+	s := newJoinLeaveScheduler(2, 10)
+	r := newJLRun(s)
+
+	r.start(t, "b1", "bridge", "n1", jlJoin, running(1))
+	r.start(t, "b2", "bridge", "n2", jlJoin, running(2))
+	r.start(t, "b3", "bridge", "n1", jlJoin, queued(1, 0))
+	// Another driver does not wait for the slots of the bridge driver
+	r.start(t, "o1", "overlay", "n3", jlJoin, running(3))
+
+	if st := s.stats(); st.Running["bridge"] != 2 || st.Running["overlay"] != 1 {
+		t.Fatalf("unexpected running operations: %v", st.Running)
+	}
+	r.checkOrder(t, "b1", "b2", "o1", "b3")
+}
+
+func TestJoinLeaveSchedulerBackpressure(t *testing.T) {
+	s := newJoinLeaveScheduler(1, 1)
+	r := newJLRun(s)
+
+	r.start(t, "first", "bridge", "n1", jlJoin, running(1))
+	r.start(t, "join1", "bridge", "n1", jlJoin, queued(1, 0))
+	r.start(t, "join2", "bridge", "n2", jlJoin, func(st JoinLeaveStats) bool { return st.Throttled == 1 })
+	// Leaves are never held back
+	r.start(t, "leave", "bridge", "n2", jlLeave, queued(1, 1))
+
+	if st := s.stats(); st.QueuedJoins != 1 {
+		t.Fatalf("expected the throttled join out of the queue, got %d queued joins", st.QueuedJoins)
+	}
+	r.checkOrder(t, "first", "leave", "join1", "join2")
+	r.wait(t, running(0))
+
+	st := s.stats()
+	if st.Dispatched != 4 || st.QueuedJoins != 0 || st.QueuedLeaves != 0 || len(st.Running) != 0 {
+		t.Fatalf("unexpected stats after the operations: %+v", st)
+	}
+	if st.WaitMax == 0 || st.WaitTotal < st.WaitMax {
+		t.Fatalf("unexpected wait times: %+v", st)
+	}
+}
+
+func TestJoinLeaveSchedulerNil(t *testing.T) {
+	var s *joinLeaveScheduler
+	s.acquire("bridge", "n1", jlJoin)()
+	if st := s.stats(); st.Dispatched != 0 {
+		t.Fatalf("unexpected stats: %+v", st)
+	}
+}
+
+// mockStore mimics the local store, serializing its writes, with the
+// endpoint count of the networks updated by compare and swap, retried on
+// conflicts the way atomicIncDecEpCnt does
+type mockStore struct {
+	index map[string]uint64
+	sync.Mutex
+}
+
+func (st *mockStore) get(nid string) uint64 {
+	st.Lock()
+	defer st.Unlock()
+	return st.index[nid]
+}
+
+func (st *mockStore) cas(nid string, index uint64) bool {
+	st.Lock()
+	defer st.Unlock()
+	time.Sleep(50 * time.Microsecond)
+	if st.index[nid] != index {
+		return false
+	}
+	st.index[nid]++
+	return true
+}
+
+// mockJoinDriver joins an endpoint, setting its links up before the
+// endpoint count of the network is updated in the store
+type mockJoinDriver struct {
+	store *mockStore
+}
+
+func (d *mockJoinDriver) join(nid string) {
+	index := d.store.get(nid)
+	time.Sleep(time.Millisecond)
+	for !d.store.cas(nid, index) {
+		index = d.store.get(nid)
+	}
+}
+
+// BenchmarkJoinLeaveScheduler joins the containers of a mass restart spread
+// over a few networks, with the joins run all at once and through the
+// scheduler
+func BenchmarkJoinLeaveScheduler(b *testing.B) {
+	const (
+		containers = 200
+		networks   = 10
+	)
+	for _, bc := range []struct {
+		name      string
+		scheduler func() *joinLeaveScheduler
+	}{
+		{"unscheduled", func() *joinLeaveScheduler { return nil }},
+		{"scheduled", func() *joinLeaveScheduler { return newJoinLeaveScheduler(defaultJoinLeaveConcurrency, defaultJoinLeaveQueueLen) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var joins int
+			start := time.Now()
+			for i := 0; i < b.N; i++ {
+				s := bc.scheduler()
+				d := &mockJoinDriver{store: &mockStore{index: make(map[string]uint64)}}
+				var wg sync.WaitGroup
+				for c := 0; c < containers; c++ {
+					wg.Add(1)
+					go func(c int) {
+						defer wg.Done()
+						nid := fmt.Sprintf("n%d", c%networks)
+						defer s.acquire("bridge", nid, jlJoin)()
+						d.join(nid)
+					}(c)
+				}
+				wg.Wait()
+				joins += containers
+			}
+			b.ReportMetric(float64(joins)/time.Since(start).Seconds(), "joins/s")
+		})
+	}
+}
@@ -0,0 +1,418 @@
+package libnetwork
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/docker/libnetwork/datastore"
+	"github.com/sirupsen/logrus"
+)
+
+const journalPrefix = "journal"
+
+// Operations recorded in a journal
+const (
+	opCreateNetwork  = "create-network"
+	opCreateEndpoint = "create-endpoint"
+	opJoin           = "join"
+)
+
+// Steps of the operations which leave a state behind them, in the store,
+// the drivers or the ipam drivers. Each one is recorded in the journal of
+// its operation before it is applied.
+const (
+	// the pools of the network are requested
+	stepIpamPools = "ipam-pools"
+	// the network is created by its driver
+	stepDriverNetwork = "driver-network"
+	// the network and its endpoint count are stored
+	stepStoreNetwork = "store-network"
+	// addresses are requested for the endpoint
+	stepEndpointAddresses = "endpoint-addresses"
+	// the endpoint is created by its driver
+	stepDriverEndpoint = "driver-endpoint"
+	// the endpoint is stored
+	stepStoreEndpoint = "store-endpoint"
+	// the endpoint joins the sandbox in its driver
+	stepDriverJoin = "driver-join"
+	// the endpoint is stored as attached to the sandbox
+	stepStoreJoin = "store-join"
+	// the driver programs the external connectivity of the endpoint
+	stepExternalConnectivity = "external-connectivity"
+)
+
+var (
+	// journalFault is set by the tests to fail an operation right before
+	// one of its steps, or before its commit for the "commit" step
+	journalFault func(op, step string) error
+	// errJournalCrash is returned by journalFault to leave the operation as
+	// a crash would, for its journal to be replayed
+	errJournalCrash = errors.New("journal crash")
+)
+
+// journal records the steps of an operation on a network, an endpoint or a
+// sandbox. Each step is persisted to the local store before it is applied,
+// along with the state needed to undo it, so that the operations left
+// unfinished by a crash are rolled back on restart. While the operation runs,
+// the steps are undone in the reverse order of the undo actions registered
+// along the way, which include the ones on the state of the process only.
+type journal struct {
+	ID          string
+	Op          string
+	Network     string
+	NetworkType string
+	Endpoint    string `json:",omitempty"`
+	Sandbox     string `json:",omitempty"`
+	Steps       []*journalStep
+	undos       []func()
+	c           *controller
+	dbIndex     uint64
+	dbExists    bool
+	sync.Mutex
+}
+
+// journalStep is a step of an operation, Data holds the state its undo
+// requires once the objects of the operation are lost
+type journalStep struct {
+	Kind string
+	Data json.RawMessage `json:",omitempty"`
+}
+
+// journalPools is the state of stepIpamPools
+type journalPools struct {
+	IpamType string
+	V4Info   []*IpamInfo `json:",omitempty"`
+	V6Info   []*IpamInfo `json:",omitempty"`
+}
+
+// journalAddress is an address requested in stepEndpointAddresses
+type journalAddress struct {
+	Driver  string
+	PoolID  string
+	Address net.IP
+}
+
+func (c *controller) newJournal(op string, n *network, eid, sid string) *journal {
+	return &journal{
+		ID:          stringid.GenerateRandomID(),
+		Op:          op,
+		Network:     n.ID(),
+		NetworkType: n.Type(),
+		Endpoint:    eid,
+		Sandbox:     sid,
+		c:           c,
+	}
+}
+
+// record persists the step before it is applied, along with the state
+// needed to undo it, if known beforehand
+func (j *journal) record(kind string, data interface{}) error {
+	if journalFault != nil {
+		if err := journalFault(j.Op, kind); err != nil {
+			return err
+		}
+	}
+	s := &journalStep{Kind: kind}
+	j.Lock()
+	j.Steps = append(j.Steps, s)
+	j.Unlock()
+	return j.update(data)
+}
+
+// update persists the state needed to undo the last step, once applied
+func (j *journal) update(data interface{}) error {
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		j.Lock()
+		j.Steps[len(j.Steps)-1].Data = b
+		j.Unlock()
+	}
+	if j.c.getStore(datastore.LocalScope) == nil {
+		return nil
+	}
+	return j.c.updateToStore(j)
+}
+
+// undo registers the undo action of the last step applied
+func (j *journal) undo(fn func()) {
+	j.Lock()
+	j.undos = append(j.undos, fn)
+	j.Unlock()
+}
+
+// commit completes the operation once all its steps are applied, the journal
+// is removed
+func (j *journal) commit() error {
+	if journalFault != nil {
+		if err := journalFault(j.Op, "commit"); err != nil {
+			return err
+		}
+	}
+	j.remove()
+	return nil
+}
+
+// finish ends the operation: if it failed, the steps are undone and the
+// journal is removed
+func (j *journal) finish(err error) {
+	if err == nil || err == errJournalCrash {
+		return
+	}
+	j.Lock()
+	undos := j.undos
+	j.undos = nil
+	j.Unlock()
+	for i := len(undos) - 1; i >= 0; i-- {
+		undos[i]()
+	}
+	j.remove()
+}
+
+func (j *journal) remove() {
+	if !j.Exists() {
+		return
+	}
+	if err := j.c.deleteFromStore(j); err != nil {
+		logrus.Warnf("Failed to remove the journal of %s operation on network %s: %v", j.Op, j.Network, err)
+	}
+}
+
+// rollback undoes the steps of an operation left unfinished by a restart,
+// from their persisted state
+func (j *journal) rollback() {
+	logrus.Infof("Rolling back unfinished %s operation on network %s (endpoint %q, sandbox %q)", j.Op, j.Network, j.Endpoint, j.Sandbox)
+	for i := len(j.Steps) - 1; i >= 0; i-- {
+		if err := j.undoStep(j.Steps[i]); err != nil {
+			logrus.Warnf("Failed to undo step %s of %s operation on network %s: %v", j.Steps[i].Kind, j.Op, j.Network, err)
+		}
+	}
+	j.remove()
+}
+
+// network returns the network of the operation, from the store if it made
+// it there
+func (j *journal) network() *network {
+	if n, err := j.c.getNetworkFromStore(j.Network); err == nil {
+		return n
+	}
+	return &network{
+		id:          j.Network,
+		networkType: j.NetworkType,
+		scope:       datastore.LocalScope,
+		ctrlr:       j.c,
+		drvOnce:     &sync.Once{},
+		persist:     true,
+	}
+}
+
+func (j *journal) undoStep(s *journalStep) error {
+	n := j.network()
+	switch s.Kind {
+	case stepIpamPools:
+		var p journalPools
+		if len(s.Data) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(s.Data, &p); err != nil {
+			return err
+		}
+		pn := &network{id: n.id, name: n.name, networkType: j.NetworkType, ipamType: p.IpamType, ctrlr: j.c}
+		pn.ipamV4Info = p.V4Info
+		pn.ipamV6Info = p.V6Info
+		pn.ipamRelease()
+	case stepDriverNetwork:
+		return n.deleteNetwork()
+	case stepStoreNetwork:
+		if err := j.c.deleteStoreKey(n); err != nil {
+			return err
+		}
+		return j.c.deleteStoreKey(&endpointCnt{n: n})
+	case stepEndpointAddresses:
+		var addrs []journalAddress
+		if len(s.Data) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(s.Data, &addrs); err != nil {
+			return err
+		}
+		for _, a := range addrs {
+			ipam, _, err := j.c.getIPAMDriver(a.Driver)
+			if err != nil {
+				return err
+			}
+			if err := ipam.ReleaseAddress(a.PoolID, a.Address); err != nil {
+				logrus.Warnf("Failed to release ip address %s of endpoint %s: %v", a.Address, j.Endpoint, err)
+			}
+		}
+	case stepDriverEndpoint:
+		d, err := n.driver(true)
+		if err != nil {
+			return err
+		}
+		return d.DeleteEndpoint(n.id, j.Endpoint)
+	case stepStoreEndpoint:
+		return j.c.deleteStoreKey(&endpoint{id: j.Endpoint, network: n})
+	case stepDriverJoin:
+		d, err := n.driver(true)
+		if err != nil {
+			return err
+		}
+		return d.Leave(n.id, j.Endpoint)
+	case stepStoreJoin:
+		ep, err := n.getEndpointFromStore(j.Endpoint)
+		if err != nil || ep.sandboxID != j.Sandbox {
+			return nil
+		}
+		ep.sandboxID = ""
+		return j.c.updateToStore(ep)
+	case stepExternalConnectivity:
+		d, err := n.driver(true)
+		if err != nil {
+			return err
+		}
+		return d.RevokeExternalConnectivity(n.id, j.Endpoint)
+	}
+	return nil
+}
+
+// getJournals returns the journals of the unfinished operations matching
+// one of the passed ones
+func (c *controller) getJournals(ops ...string) []*journal {
+	store := c.getStore(datastore.LocalScope)
+	if store == nil {
+		return nil
+	}
+	kvol, err := store.List(datastore.Key(journalPrefix), &journal{c: c})
+	if err != nil {
+		if err != datastore.ErrKeyNotFound {
+			logrus.Warnf("Failed to get the operation journals: %v", err)
+		}
+		return nil
+	}
+	var jl []*journal
+	for _, kvo := range kvol {
+		j := kvo.(*journal)
+		for _, op := range ops {
+			if j.Op == op {
+				jl = append(jl, j)
+				break
+			}
+		}
+	}
+	return jl
+}
+
+// replayJoinJournals completes the joins whose endpoint made it to the
+// persisted state of its sandbox, which is restored or cleaned up as a
+// whole, and rolls back the other ones
+func (c *controller) replayJoinJournals(sandboxes []*sbState) {
+	for _, j := range c.getJournals(opJoin) {
+		attached := false
+		for _, sbs := range sandboxes {
+			if sbs.ID != j.Sandbox {
+				continue
+			}
+			for _, eps := range sbs.Eps {
+				if eps.Eid == j.Endpoint {
+					attached = true
+				}
+			}
+		}
+		if attached {
+			logrus.Infof("Completing unfinished join of endpoint %s to sandbox %s", j.Endpoint, j.Sandbox)
+			j.remove()
+			continue
+		}
+		j.rollback()
+	}
+}
+
+// replayCreateJournals rolls back the creations of endpoints and networks
+// left unfinished
+func (c *controller) replayCreateJournals() {
+	for _, j := range c.getJournals(opCreateEndpoint) {
+		j.rollback()
+	}
+	for _, j := range c.getJournals(opCreateNetwork) {
+		j.rollback()
+	}
+}
+
+func (j *journal) Key() []string {
+	return []string{journalPrefix, j.ID}
+}
+
+func (j *journal) KeyPrefix() []string {
+	return []string{journalPrefix}
+}
+
+func (j *journal) Value() []byte {
+	j.Lock()
+	defer j.Unlock()
+	b, err := json.Marshal(j)
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func (j *journal) SetValue(value []byte) error {
+	return json.Unmarshal(value, j)
+}
+
+func (j *journal) Index() uint64 {
+	j.Lock()
+	defer j.Unlock()
+	return j.dbIndex
+}
+
+func (j *journal) SetIndex(index uint64) {
+	j.Lock()
+	j.dbIndex = index
+	j.dbExists = true
+	j.Unlock()
+}
+
+func (j *journal) Exists() bool {
+	j.Lock()
+	defer j.Unlock()
+	return j.dbExists
+}
+
+func (j *journal) Skip() bool {
+	return false
+}
+
+func (j *journal) New() datastore.KVObject {
+	return &journal{c: j.c}
+}
+
+func (j *journal) CopyTo(o datastore.KVObject) error {
+	j.Lock()
+	defer j.Unlock()
+	dstJ := o.(*journal)
+	dstJ.ID = j.ID
+	dstJ.Op = j.Op
+	dstJ.Network = j.Network
+	dstJ.NetworkType = j.NetworkType
+	dstJ.Endpoint = j.Endpoint
+	dstJ.Sandbox = j.Sandbox
+	dstJ.Steps = make([]*journalStep, 0, len(j.Steps))
+	for _, s := range j.Steps {
+		dstJ.Steps = append(dstJ.Steps, &journalStep{Kind: s.Kind, Data: append(json.RawMessage(nil), s.Data...)})
+	}
+	dstJ.c = j.c
+	dstJ.dbIndex = j.dbIndex
+	dstJ.dbExists = j.dbExists
+	return nil
+}
+
+func (j *journal) DataScope() string {
+	return datastore.LocalScope
+}
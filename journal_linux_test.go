@@ -0,0 +1,407 @@
+package libnetwork
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/docker/libnetwork/datastore"
+	"github.com/docker/libnetwork/discoverapi"
+	"github.com/docker/libnetwork/driverapi"
+	"github.com/docker/libnetwork/ipamapi"
+	"github.com/docker/libnetwork/testutils"
+	"github.com/docker/libnetwork/types"
+	"github.com/vishvananda/netlink"
+)
+
+const journalDriverName = "journal-test"
+
+// journalDriver keeps track of the state the operations leave in a driver.
+// A veth link is handed to each join for the gateway to be programmed in
+// the sandbox.
+type journalDriver struct {
+	networks  map[string]bool
+	endpoints map[string]bool
+	joins     map[string]bool
+	extConns  map[string]bool
+	links     map[string]string
+	seq       int
+	sync.Mutex
+}
+
+var jd = &journalDriver{}
+
+func journalDriverInit(reg driverapi.DriverCallback, opt map[string]interface{}) error {
+	jd.reset()
+	return reg.RegisterDriver(journalDriverName, jd, driverapi.Capability{DataScope: datastore.LocalScope})
+}
+
+func (d *journalDriver) reset() {
+	d.Lock()
+	d.networks = map[string]bool{}
+	d.endpoints = map[string]bool{}
+	d.joins = map[string]bool{}
+	d.extConns = map[string]bool{}
+	d.links = map[string]string{}
+	d.Unlock()
+}
+
+func (d *journalDriver) state() (int, int, int, int) {
+	d.Lock()
+	defer d.Unlock()
+	return len(d.networks), len(d.endpoints), len(d.joins), len(d.extConns)
+}
+
+func (d *journalDriver) set(m map[string]bool, key string, val bool) {
+	d.Lock()
+	if val {
+		m[key] = true
+	} else {
+		delete(m, key)
+	}
+	d.Unlock()
+}
+
+func (d *journalDriver) CreateNetwork(nid string, options map[string]interface{}, nInfo driverapi.NetworkInfo, ipV4Data, ipV6Data []driverapi.IPAMData) error {
+	d.set(d.networks, nid, true)
+	return nil
+}
+func (d *journalDriver) DeleteNetwork(nid string) error {
+	d.set(d.networks, nid, false)
+	return nil
+}
+func (d *journalDriver) CreateEndpoint(nid, eid string, ifInfo driverapi.InterfaceInfo, options map[string]interface{}) error {
+	d.set(d.endpoints, eid, true)
+	return nil
+}
+func (d *journalDriver) DeleteEndpoint(nid, eid string) error {
+	d.set(d.endpoints, eid, false)
+	return nil
+}
+func (d *journalDriver) EndpointOperInfo(nid, eid string) (map[string]interface{}, error) {
+	return nil, nil
+}
+func (d *journalDriver) Join(nid, eid string, sboxKey string, jinfo driverapi.JoinInfo, options map[string]interface{}) error {
+	d.Lock()
+	d.seq++
+	name := fmt.Sprintf("jveth%d", d.seq)
+	d.links[eid] = name
+	d.Unlock()
+	if err := netlink.LinkAdd(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: name}, PeerName: name + "p"}); err != nil {
+		return err
+	}
+	if err := jinfo.InterfaceName().SetNames(name, "eth"); err != nil {
+		return err
+	}
+	d.set(d.joins, eid, true)
+	return jinfo.SetGateway(net.ParseIP("10.40.255.254"))
+}
+func (d *journalDriver) Leave(nid, eid string) error {
+	d.Lock()
+	name := d.links[eid]
+	delete(d.links, eid)
+	d.Unlock()
+	if link, err := netlink.LinkByName(name); err == nil {
+		netlink.LinkDel(link)
+	}
+	d.set(d.joins, eid, false)
+	return nil
+}
+func (d *journalDriver) DiscoverNew(dType discoverapi.DiscoveryType, data interface{}) error {
+	return nil
+}
+func (d *journalDriver) DiscoverDelete(dType discoverapi.DiscoveryType, data interface{}) error {
+	return nil
+}
+func (d *journalDriver) Type() string {
+	return journalDriverName
+}
+func (d *journalDriver) IsBuiltIn() bool {
+	return false
+}
+func (d *journalDriver) ProgramExternalConnectivity(nid, eid string, options map[string]interface{}) error {
+	d.set(d.extConns, eid, true)
+	return nil
+}
+func (d *journalDriver) RevokeExternalConnectivity(nid, eid string) error {
+	d.set(d.extConns, eid, false)
+	return nil
+}
+func (d *journalDriver) NetworkAllocate(id string, option map[string]string, ipV4Data, ipV6Data []driverapi.IPAMData) (map[string]string, error) {
+	return nil, types.NotImplementedErrorf("not implemented")
+}
+func (d *journalDriver) NetworkFree(id string) error {
+	return types.NotImplementedErrorf("not implemented")
+}
+func (d *journalDriver) EventNotify(etype driverapi.EventType, nid, tableName, key string, value []byte) {
+}
+func (d *journalDriver) DecodeTableEntry(tablename string, key string, value []byte) (string, map[string]string) {
+	return "", nil
+}
+
+func newJournalTestController(t *testing.T) *controller {
+	cfgOptions, err := OptionBoltdbWithRandomDBFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(cfgOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc := c.(*controller)
+	if err := cc.drvRegistry.AddDriver(journalDriverName, journalDriverInit, nil); err != nil {
+		t.Fatal(err)
+	}
+	return cc
+}
+
+// The same pool, gateway and endpoint address are requested each time, they
+// can only be obtained again if the rolled back operations released them
+func journalTestNetwork(c *controller) (Network, error) {
+	ipamOpt := NetworkOptionIpam(ipamapi.DefaultIPAM, "", []*IpamConf{{PreferredPool: "10.40.0.0/16", Gateway: "10.40.255.254"}}, nil, nil)
+	return c.NewNetwork(journalDriverName, "jnet", "", ipamOpt)
+}
+
+func journalTestEndpoint(n Network) (Endpoint, error) {
+	return n.CreateEndpoint("jep", CreateOptionIpam(net.ParseIP("10.40.0.10"), nil, nil, nil))
+}
+
+func checkJournalState(t *testing.T, c *controller, networks, endpoints, joins, extConns int) {
+	if jl := c.getJournals(opCreateNetwork, opCreateEndpoint, opJoin); len(jl) != 0 {
+		t.Fatalf("expected no journal left, got %d", len(jl))
+	}
+	nw, ep, jn, ext := jd.state()
+	if nw != networks || ep != endpoints || jn != joins || ext != extConns {
+		t.Fatalf("unexpected driver state: networks %d/%d, endpoints %d/%d, joins %d/%d, external connectivity %d/%d",
+			nw, networks, ep, endpoints, jn, joins, ext, extConns)
+	}
+	_, err := c.NetworkByName("jnet")
+	if networks == 0 && err == nil {
+		t.Fatal("network left in store")
+	}
+	if networks == 1 && err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJournalRollback(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+	defer func() { journalFault = nil }()
+
+	c := newJournalTestController(t)
+	defer c.Stop()
+
+	errFault := errors.New("injected fault")
+	for _, tc := range []struct {
+		op   string
+		step string
+	}{
+		{opCreateNetwork, stepIpamPools},
+		{opCreateNetwork, stepDriverNetwork},
+		{opCreateNetwork, stepStoreNetwork},
+		{opCreateNetwork, "commit"},
+		{opCreateEndpoint, stepEndpointAddresses},
+		{opCreateEndpoint, stepDriverEndpoint},
+		{opCreateEndpoint, stepStoreEndpoint},
+		{opCreateEndpoint, "commit"},
+		{opJoin, stepDriverJoin},
+		{opJoin, stepStoreJoin},
+		{opJoin, stepExternalConnectivity},
+		{opJoin, "commit"},
+	} {
+		tc := tc
+		journalFault = func(op, step string) error {
+			if op == tc.op && step == tc.step {
+				return errFault
+			}
+			return nil
+		}
+
+		n, err := journalTestNetwork(c)
+		if tc.op == opCreateNetwork {
+			if err != errFault {
+				t.Fatalf("%s/%s: expected injected fault, got %v", tc.op, tc.step, err)
+			}
+			checkJournalState(t, c, 0, 0, 0, 0)
+			journalFault = nil
+			n, err = journalTestNetwork(c)
+		}
+		if err != nil {
+			t.Fatalf("%s/%s: %v", tc.op, tc.step, err)
+		}
+
+		ep, err := journalTestEndpoint(n)
+		if tc.op == opCreateEndpoint {
+			if err != errFault {
+				t.Fatalf("%s/%s: expected injected fault, got %v", tc.op, tc.step, err)
+			}
+			checkJournalState(t, c, 1, 0, 0, 0)
+			if _, err := n.EndpointByName("jep"); err == nil {
+				t.Fatalf("%s/%s: endpoint left in store", tc.op, tc.step)
+			}
+			journalFault = nil
+			ep, err = journalTestEndpoint(n)
+		}
+		if err != nil {
+			t.Fatalf("%s/%s: %v", tc.op, tc.step, err)
+		}
+
+		sb, err := c.NewSandbox("jcontainer")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ep.Join(sb)
+		if tc.op == opJoin {
+			if err != errFault {
+				t.Fatalf("%s/%s: expected injected fault, got %v", tc.op, tc.step, err)
+			}
+			checkJournalState(t, c, 1, 1, 0, 0)
+			sep, e := n.(*network).getEndpointFromStore(ep.ID())
+			if e != nil {
+				t.Fatal(e)
+			}
+			if sep.sandboxID != "" {
+				t.Fatalf("%s/%s: endpoint left attached to the sandbox in store", tc.op, tc.step)
+			}
+			journalFault = nil
+			err = ep.Join(sb)
+		}
+		if err != nil {
+			t.Fatalf("%s/%s: %v", tc.op, tc.step, err)
+		}
+		checkJournalState(t, c, 1, 1, 1, 1)
+
+		if err := ep.Leave(sb); err != nil {
+			t.Fatal(err)
+		}
+		if err := sb.Delete(); err != nil {
+			t.Fatal(err)
+		}
+		if err := ep.Delete(false); err != nil {
+			t.Fatal(err)
+		}
+		if err := n.Delete(); err != nil {
+			t.Fatal(err)
+		}
+		checkJournalState(t, c, 0, 0, 0, 0)
+	}
+}
+
+func TestJournalReplay(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+	defer func() { journalFault = nil }()
+
+	c := newJournalTestController(t)
+	defer c.Stop()
+
+	crashAt := func(op, step string) {
+		journalFault = func(o, s string) error {
+			if o == op && s == step {
+				return errJournalCrash
+			}
+			return nil
+		}
+	}
+
+	// The operations left unfinished by a crash are rolled back on restart
+	for _, step := range []string{stepDriverNetwork, stepStoreNetwork, "commit"} {
+		crashAt(opCreateNetwork, step)
+		if _, err := journalTestNetwork(c); err != errJournalCrash {
+			t.Fatalf("%s: expected crash, got %v", step, err)
+		}
+		if jl := c.getJournals(opCreateNetwork); len(jl) != 1 {
+			t.Fatalf("%s: expected the journal of the crashed operation, got %d", step, len(jl))
+		}
+		c.cleanupLocalEndpoints()
+		checkJournalState(t, c, 0, 0, 0, 0)
+	}
+
+	journalFault = nil
+	n, err := journalTestNetwork(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, step := range []string{stepDriverEndpoint, stepStoreEndpoint, "commit"} {
+		crashAt(opCreateEndpoint, step)
+		if _, err := journalTestEndpoint(n); err != errJournalCrash {
+			t.Fatalf("%s: expected crash, got %v", step, err)
+		}
+		c.cleanupLocalEndpoints()
+		checkJournalState(t, c, 1, 0, 0, 0)
+		if _, err := n.EndpointByName("jep"); err == nil {
+			t.Fatalf("%s: endpoint left in store", step)
+		}
+	}
+
+	journalFault = nil
+	ep, err := journalTestEndpoint(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb, err := c.NewSandbox("jcontainer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sb.Delete()
+
+	// A join missing from the persisted sandbox state is rolled back
+	crashAt(opJoin, stepExternalConnectivity)
+	if err := ep.Join(sb); err != errJournalCrash {
+		t.Fatalf("expected crash, got %v", err)
+	}
+	c.replayJoinJournals([]*sbState{{ID: sb.ID()}})
+	checkJournalState(t, c, 1, 1, 0, 0)
+	sep, err := n.(*network).getEndpointFromStore(ep.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sep.sandboxID != "" {
+		t.Fatal("endpoint left attached to the sandbox in store")
+	}
+
+	// A join which made it to the persisted sandbox state is completed
+	sb2, err := c.NewSandbox("jcontainer2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sb2.Delete()
+	crashAt(opJoin, "commit")
+	if err := ep.Join(sb2); err != errJournalCrash {
+		t.Fatalf("expected crash, got %v", err)
+	}
+	c.replayJoinJournals([]*sbState{{ID: sb2.ID(), Eps: []epState{{Eid: ep.ID(), Nid: n.ID()}}}})
+	checkJournalState(t, c, 1, 1, 1, 1)
+	journalFault = nil
+}
+
+func TestJournalAddresses(t *testing.T) {
+	n := &network{ipamType: "default"}
+	ep := &endpoint{network: n, iface: &endpointInterface{
+		addr:     &net.IPNet{IP: net.ParseIP("172.20.0.2"), Mask: net.CIDRMask(16, 32)},
+		addrv6:   &net.IPNet{IP: net.ParseIP("2001:db8::2"), Mask: net.CIDRMask(64, 128)},
+		v4PoolID: "pool4",
+		v6PoolID: "pool6",
+	}}
+
+	for _, tc := range []struct {
+		ipv4, ipv6 bool
+		pools      []string
+	}{
+		{true, true, []string{"pool4", "pool6"}},
+		{true, false, []string{"pool4"}},
+		{false, true, []string{"pool6"}},
+	} {
+		addrs := ep.journalAddresses(tc.ipv4, tc.ipv6)
+		if len(addrs) != len(tc.pools) {
+			t.Fatalf("expected %d addresses for (%t, %t), got %v", len(tc.pools), tc.ipv4, tc.ipv6, addrs)
+		}
+		for i, a := range addrs {
+			if a.PoolID != tc.pools[i] || a.Driver != "default" {
+				t.Fatalf("unexpected address %v for (%t, %t)", a, tc.ipv4, tc.ipv6)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package libnetwork
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -52,11 +53,13 @@ func TestNetworkMarshalling(t *testing.T) {
 				SubPool:       "abcd:abcd:abcd:abcd:abcd::/80",
 				Gateway:       "abcd::29/64",
 				AuxAddresses:  nil,
+				Driver:        "pd",
 			},
 		},
 		ipamV4Info: []*IpamInfo{
 			{
 				PoolID: "ipoolverde123",
+				Driver: "default",
 				Meta: map[string]string{
 					netlabel.Gateway: "10.2.1.255/16",
 				},
@@ -72,6 +75,7 @@ func TestNetworkMarshalling(t *testing.T) {
 			},
 			{
 				PoolID: "ipoolblue345",
+				Driver: "default",
 				Meta: map[string]string{
 					netlabel.Gateway: "10.2.1.255/16",
 				},
@@ -90,6 +94,7 @@ func TestNetworkMarshalling(t *testing.T) {
 			},
 			{
 				PoolID: "weirdinfo",
+				Driver: "default",
 				IPAMData: driverapi.IPAMData{
 					Gateway: &net.IPNet{
 						IP:   net.IP{11, 2, 1, 255},
@@ -101,6 +106,7 @@ func TestNetworkMarshalling(t *testing.T) {
 		ipamV6Info: []*IpamInfo{
 			{
 				PoolID: "ipoolv6",
+				Driver: "pd",
 				IPAMData: driverapi.IPAMData{
 					AddressSpace: "viola",
 					Pool: &net.IPNet{
@@ -203,6 +209,12 @@ func TestEndpointMarshalling(t *testing.T) {
 			v4PoolID:  "poolpool",
 			v6PoolID:  "poolv6",
 			llAddrs:   lla,
+			options:   driverapi.InterfaceOptions{HostDevice: true, KeepMAC: true},
+		},
+		joinInfo: &endpointJoinInfo{
+			extraIfaces: []*endpointInterface{
+				{srcName: "mgmt0", options: driverapi.InterfaceOptions{HostDevice: true, KeepName: true}},
+			},
 		},
 	}
 
@@ -220,6 +232,9 @@ func TestEndpointMarshalling(t *testing.T) {
 	if e.name != ee.name || e.id != ee.id || e.sandboxID != ee.sandboxID || !compareEndpointInterface(e.iface, ee.iface) || e.anonymous != ee.anonymous {
 		t.Fatalf("JSON marsh/unmarsh failed.\nOriginal:\n%#v\nDecoded:\n%#v\nOriginal iface: %#v\nDecodediface:\n%#v", e, ee, e.iface, ee.iface)
 	}
+	if ee.joinInfo == nil || len(ee.joinInfo.extraIfaces) != 1 || !compareEndpointInterface(e.joinInfo.extraIfaces[0], ee.joinInfo.extraIfaces[0]) {
+		t.Fatalf("JSON marsh/unmarsh of the extra interfaces failed.\nOriginal:\n%#v\nDecoded:\n%#v", e.joinInfo, ee.joinInfo)
+	}
 }
 
 func compareEndpointInterface(a, b *endpointInterface) bool {
@@ -230,7 +245,46 @@ func compareEndpointInterface(a, b *endpointInterface) bool {
 		return false
 	}
 	return a.srcName == b.srcName && a.dstPrefix == b.dstPrefix && a.v4PoolID == b.v4PoolID && a.v6PoolID == b.v6PoolID &&
-		types.CompareIPNet(a.addr, b.addr) && types.CompareIPNet(a.addrv6, b.addrv6) && compareNwLists(a.llAddrs, b.llAddrs)
+		types.CompareIPNet(a.addr, b.addr) && types.CompareIPNet(a.addrv6, b.addrv6) && compareNwLists(a.llAddrs, b.llAddrs) &&
+		a.options == b.options
+}
+
+func TestIpamInfoDriverMigration(t *testing.T) {
+	// A network stored before its pools recorded their IPAM driver
+	n := &network{
+		name:        "old",
+		id:          "oldid",
+		ipamType:    "myipam",
+		networkType: "bridge",
+		ipamV4Info: []*IpamInfo{
+			{
+				PoolID: "oldpool",
+				IPAMData: driverapi.IPAMData{
+					AddressSpace: "local",
+					Pool:         &net.IPNet{IP: net.IP{10, 3, 0, 0}, Mask: net.IPMask{255, 255, 0, 0}},
+				},
+			},
+		},
+	}
+
+	b, err := json.Marshal(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(b, []byte(`\"Driver\"`)) {
+		t.Fatalf("Unexpected driver in marshalled pool: %s", b)
+	}
+
+	nn := &network{}
+	if err := json.Unmarshal(b, nn); err != nil {
+		t.Fatal(err)
+	}
+	if d := nn.ipamV4Info[0].Driver; d != "myipam" {
+		t.Fatalf("Expected the pool driver to be migrated to the network one, got %q", d)
+	}
+	if l := nn.ipamDriverNames(); len(l) != 1 || l[0] != "myipam" {
+		t.Fatalf("Unexpected ipam drivers %v", l)
+	}
 }
 
 func compareIpamConfList(listA, listB []*IpamConf) bool {
@@ -243,7 +297,8 @@ func compareIpamConfList(listA, listB []*IpamConf) bool {
 		b = listB[i]
 		if a.PreferredPool != b.PreferredPool ||
 			a.SubPool != b.SubPool ||
-			a.Gateway != b.Gateway || !compareStringMaps(a.AuxAddresses, b.AuxAddresses) {
+			a.Gateway != b.Gateway || !compareStringMaps(a.AuxAddresses, b.AuxAddresses) ||
+			a.Driver != b.Driver {
 			return false
 		}
 	}
@@ -258,7 +313,7 @@ func compareIpamInfoList(listA, listB []*IpamInfo) bool {
 	for i := 0; i < len(listA); i++ {
 		a = listA[i]
 		b = listB[i]
-		if a.PoolID != b.PoolID || !compareStringMaps(a.Meta, b.Meta) ||
+		if a.PoolID != b.PoolID || a.Driver != b.Driver || !compareStringMaps(a.Meta, b.Meta) ||
 			!types.CompareIPNet(a.Gateway, b.Gateway) ||
 			a.AddressSpace != b.AddressSpace ||
 			!types.CompareIPNet(a.Pool, b.Pool) ||
@@ -345,6 +400,112 @@ func TestAuxAddresses(t *testing.T) {
 	}
 }
 
+func TestIpamConfRanges(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+
+	n := &network{ipamType: ipamapi.DefaultIPAM, networkType: "bridge", ctrlr: c.(*controller)}
+	nn := &network{ipamType: ipamapi.DefaultIPAM, networkType: "macvlan", ctrlr: c.(*controller)}
+
+	input := []struct {
+		conf    IpamConf
+		gateway string
+		good    bool
+	}{
+		{IpamConf{PreferredPool: "192.168.0.0/24", Exclusions: []string{"192.168.0.1-192.168.0.20"}}, "192.168.0.21/24", true},
+		{IpamConf{PreferredPool: "192.168.0.0/24", Ranges: []string{"192.168.0.128/25"}}, "192.168.0.128/24", true},
+		{IpamConf{PreferredPool: "192.168.0.0/24", GatewayPolicy: ipamapi.GatewayLast}, "192.168.0.254/24", true},
+		// The bridge driver needs a gateway
+		{IpamConf{PreferredPool: "192.168.0.0/24", GatewayPolicy: ipamapi.GatewayNone}, "", false},
+		{IpamConf{PreferredPool: "192.168.0.0/24", Gateway: "192.168.0.1", GatewayPolicy: ipamapi.GatewayNone}, "", false},
+		{IpamConf{PreferredPool: "192.168.0.0/24", GatewayPolicy: "middle"}, "", false},
+		{IpamConf{PreferredPool: "192.168.0.0/24", Ranges: []string{"192.168.0.1-"}}, "", false},
+		{IpamConf{PreferredPool: "192.168.0.0/24", Ranges: []string{"192.168.1.0/25"}}, "", false},
+		{IpamConf{PreferredPool: "192.168.0.0/24", Exclusions: []string{"192.168.0.250-192.168.1.5"}}, "", false},
+		{IpamConf{Ranges: []string{"192.168.0.128/25"}}, "", false},
+		{IpamConf{PreferredPool: "192.168.0.0/24", Ranges: []string{"192.168.0.128/25"}, AuxAddresses: map[string]string{"host": "192.168.0.200"}}, "", false},
+		{IpamConf{PreferredPool: "192.168.0.0/24", Ranges: []string{"192.168.0.128/25"}, Exclusions: []string{"192.168.0.200"}, AuxAddresses: map[string]string{"host": "192.168.0.200"}}, "192.168.0.128/24", true},
+		{IpamConf{PreferredPool: "192.168.0.0/24", Ranges: []string{"192.168.0.128/25"}, AuxAddresses: map[string]string{"host": "192.168.0.2"}}, "192.168.0.128/24", true},
+	}
+
+	for _, i := range input {
+		conf := i.conf
+		n.ipamV4Config = []*IpamConf{&conf}
+
+		err = n.ipamAllocate()
+
+		if i.good != (err == nil) {
+			t.Fatalf("Unexpected result for %v: %v", i, err)
+		}
+		if err == nil {
+			var gw string
+			if n.ipamV4Info[0].Gateway != nil {
+				gw = n.ipamV4Info[0].Gateway.String()
+			}
+			if gw != i.gateway {
+				t.Fatalf("Unexpected gateway for %v: %s", i, gw)
+			}
+			n.ipamRelease()
+		}
+	}
+
+	// Drivers not needing a gateway can do without
+	nn.ipamV4Config = []*IpamConf{{PreferredPool: "192.168.0.0/24", GatewayPolicy: ipamapi.GatewayNone}}
+	if err := nn.ipamAllocate(); err != nil {
+		t.Fatal(err)
+	}
+	if nn.ipamV4Info[0].Gateway != nil {
+		t.Fatalf("Unexpected gateway %s", nn.ipamV4Info[0].Gateway)
+	}
+	nn.ipamRelease()
+}
+
+func TestIpamConfDriver(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+
+	n := &network{ipamType: ipamapi.NullIPAM, networkType: "bridge", ctrlr: c.(*controller)}
+	n.ipamV4Config = []*IpamConf{
+		{PreferredPool: "192.168.10.0/24", Driver: ipamapi.DefaultIPAM},
+		{},
+	}
+
+	for i := 0; i < 2; i++ {
+		// The builtin pool must have been released for the second run
+		if err := n.ipamAllocate(); err != nil {
+			t.Fatal(err)
+		}
+		builtin, null := n.ipamV4Info[0], n.ipamV4Info[1]
+		if builtin.Driver != ipamapi.DefaultIPAM || builtin.AddressSpace != "LocalDefault" ||
+			builtin.Pool.String() != "192.168.10.0/24" {
+			t.Fatalf("Unexpected builtin pool %s", builtin)
+		}
+		if null.Driver != ipamapi.NullIPAM || null.AddressSpace != "null" {
+			t.Fatalf("Unexpected null pool %s", null)
+		}
+		if l := n.ipamDriverNames(); len(l) != 2 {
+			t.Fatalf("Unexpected ipam drivers %v", l)
+		}
+		ipam, err := n.ipamDriverForPool(4, builtin.PoolID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := ipam.RequestAddress(builtin.PoolID, net.ParseIP("192.168.10.5"), nil); err != nil {
+			t.Fatal(err)
+		}
+		if err := ipam.ReleaseAddress(builtin.PoolID, net.ParseIP("192.168.10.5")); err != nil {
+			t.Fatal(err)
+		}
+		n.ipamRelease()
+	}
+}
+
 func TestSRVServiceQuery(t *testing.T) {
 	c, err := New()
 	if err != nil {
@@ -689,3 +850,111 @@ func (b *badDriver) EventNotify(etype driverapi.EventType, nid, tableName, key s
 func (b *badDriver) DecodeTableEntry(tablename string, key string, value []byte) (string, map[string]string) {
 	return "", nil
 }
+
+// replayIpam is an IPAM driver requiring the replay of the addresses,
+// recording the addresses requested, alone or in a batch
+type replayIpam struct {
+	failBatch map[string]bool
+	fail      map[string]bool
+	requests  []string
+}
+
+func (r *replayIpam) GetDefaultAddressSpaces() (string, string, error) { return "local", "global", nil }
+func (r *replayIpam) RequestPool(addressSpace, pool, subPool string, options map[string]string, v6 bool) (string, *net.IPNet, map[string]string, error) {
+	return "", nil, nil, nil
+}
+func (r *replayIpam) ReleasePool(poolID string) error                                     { return nil }
+func (r *replayIpam) ReleaseAddress(poolID string, ip net.IP) error                       { return nil }
+func (r *replayIpam) DiscoverNew(dType discoverapi.DiscoveryType, data interface{}) error { return nil }
+func (r *replayIpam) DiscoverDelete(dType discoverapi.DiscoveryType, data interface{}) error {
+	return nil
+}
+func (r *replayIpam) IsBuiltIn() bool { return false }
+
+func (r *replayIpam) RequestAddress(poolID string, ip net.IP, opts map[string]string) (*net.IPNet, map[string]string, error) {
+	r.requests = append(r.requests, "single "+ip.String())
+	if r.fail[ip.String()] {
+		return nil, nil, ipamapi.ErrIPAlreadyAllocated
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(24, 32)}, nil, nil
+}
+
+type batchReplayIpam struct {
+	replayIpam
+}
+
+func (r *batchReplayIpam) RequestAddresses(reqs []ipamapi.AddressRequest) []ipamapi.AddressResult {
+	res := make([]ipamapi.AddressResult, len(reqs))
+	for i, req := range reqs {
+		r.requests = append(r.requests, "batch "+req.Address.String())
+		if r.failBatch[req.Address.String()] {
+			res[i].Err = ipamapi.ErrIpamInternalError
+			continue
+		}
+		res[i].Address = &net.IPNet{IP: req.Address, Mask: net.CIDRMask(24, 32)}
+	}
+	return res
+}
+
+func (r *batchReplayIpam) ReleaseAddresses(reqs []ipamapi.AddressRequest) []error {
+	return make([]error, len(reqs))
+}
+
+func TestReserveAddresses(t *testing.T) {
+	c, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+
+	_, pool, _ := net.ParseCIDR("10.50.0.0/24")
+	for _, tc := range []struct {
+		name     string
+		ipam     ipamapi.Ipam
+		requests []string
+		kept     []string
+	}{
+		{
+			name:     "replay-single",
+			ipam:     &replayIpam{fail: map[string]bool{"10.50.0.3": true}},
+			requests: []string{"single 10.50.0.2", "single 10.50.0.3"},
+			kept:     []string{"10.50.0.2/24", "10.50.0.3/16"},
+		},
+		{
+			// A failed batch request is retried alone
+			name:     "replay-batch",
+			ipam:     &batchReplayIpam{replayIpam{failBatch: map[string]bool{"10.50.0.3": true}}},
+			requests: []string{"batch 10.50.0.2", "batch 10.50.0.3", "single 10.50.0.3"},
+			kept:     []string{"10.50.0.2/24", "10.50.0.3/24"},
+		},
+	} {
+		if err := c.(*controller).drvRegistry.RegisterIpamDriverWithCapabilities(tc.name, tc.ipam, &ipamapi.Capability{RequiresRequestReplay: true}); err != nil {
+			t.Fatal(err)
+		}
+		n := &network{id: tc.name, name: tc.name, ipamType: tc.name, networkType: "bridge", ctrlr: c.(*controller),
+			ipamV4Info: []*IpamInfo{{PoolID: "pool", IPAMData: driverapi.IPAMData{Pool: pool}}}}
+		var epl []*endpoint
+		for _, a := range []string{"10.50.0.2/16", "10.50.0.3/16"} {
+			addr, _ := types.ParseCIDR(a)
+			epl = append(epl, &endpoint{name: a, network: n, iface: &endpointInterface{addr: addr}})
+		}
+
+		n.reserveAddresses(epl)
+
+		var requests []string
+		switch ipam := tc.ipam.(type) {
+		case *replayIpam:
+			requests = ipam.requests
+		case *batchReplayIpam:
+			requests = ipam.requests
+		}
+		if fmt.Sprint(requests) != fmt.Sprint(tc.requests) {
+			t.Fatalf("%s: expected requests %v, got %v", tc.name, tc.requests, requests)
+		}
+		for i, ep := range epl {
+			if ep.iface.addr.String() != tc.kept[i] {
+				t.Fatalf("%s: expected address %s for endpoint %d, got %s", tc.name, tc.kept[i], i, ep.iface.addr)
+			}
+		}
+	}
+}
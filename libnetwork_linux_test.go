@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -377,6 +378,404 @@ func TestEndpointJoin(t *testing.T) {
 	checkSandbox(t, info)
 }
 
+func TestReplaceEndpoint(t *testing.T) {
+	if !testutils.IsRunningInContainer() {
+		defer testutils.SetupTestOSContext(t)()
+	}
+
+	for _, name := range []string{"rpl0", "rpl1", "rpl2"} {
+		if err := netlink.LinkAdd(&netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: name},
+			PeerName:  name + "p",
+		}); err != nil {
+			t.Fatal(err)
+		}
+		defer netlink.LinkDel(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: name}})
+	}
+
+	n, err := createTestNetwork("hostdev", "testreplace",
+		options.Generic{netlabel.GenericData: map[string]string{"devices": "rpl0,rpl1,rpl2"}},
+		[]*libnetwork.IpamConf{{PreferredPool: "172.31.10.0/24", Gateway: "172.31.10.1"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := n.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	ep1, err := n.CreateEndpoint("ep1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ep2 is deleted along with the sandbox
+	ep2, err := n.CreateEndpoint("ep2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ep1.Info().Iface().Address()
+
+	hostsFile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(hostsFile.Name())
+	hostsFile.Close()
+
+	sb, err := controller.NewSandbox("replace-container",
+		libnetwork.OptionHostname("test"),
+		libnetwork.OptionHostsPath(hostsFile.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := sb.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	if err := sb.ReplaceEndpoint(ep1, ep2); err == nil {
+		t.Fatal("Expected failure replacing an endpoint not connected to the sandbox")
+	}
+	if err := ep1.Join(sb); err != nil {
+		t.Fatal(err)
+	}
+	if err := sb.ReplaceEndpoint(ep1, ep2); err != nil {
+		t.Fatal(err)
+	}
+
+	// ep2 took over the address and the interface name of ep1
+	if eps := sb.Endpoints(); len(eps) != 1 || eps[0].ID() != ep2.ID() {
+		t.Fatalf("Expected ep2 to be the only endpoint of the sandbox, got %v", eps)
+	}
+	if a := ep2.Info().Iface().Address(); !types.CompareIPNet(a, addr) {
+		t.Fatalf("Expected ep2 to take over address %s, got %s", addr, a)
+	}
+	stats, err := sb.Statistics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := stats["eth0"]; !ok || len(stats) != 1 {
+		t.Fatalf("Expected eth0 as the only sandbox interface, got %v", stats)
+	}
+	content, err := ioutil.ReadFile(hostsFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), addr.IP.String()+"\ttest") {
+		t.Fatalf("Expected a hosts record for %s, got:\n%s", addr.IP, content)
+	}
+
+	// The address stays allocated to ep2 once ep1 is gone
+	if err := ep1.Delete(false); err != nil {
+		t.Fatal(err)
+	}
+	ep3, err := n.CreateEndpoint("ep3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ep3.Delete(false)
+	if a := ep3.Info().Iface().Address(); a.IP.Equal(addr.IP) {
+		t.Fatalf("Address %s of ep2 was handed out again", a)
+	}
+}
+
+func TestCheckpointRestoreSandbox(t *testing.T) {
+	if !testutils.IsRunningInContainer() {
+		defer testutils.SetupTestOSContext(t)()
+	}
+
+	for _, name := range []string{"ckp0", "ckp1"} {
+		if err := netlink.LinkAdd(&netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: name},
+			PeerName:  name + "p",
+		}); err != nil {
+			t.Fatal(err)
+		}
+		defer netlink.LinkDel(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: name}})
+	}
+
+	n, err := createTestNetwork("hostdev", "testcheckpoint",
+		options.Generic{netlabel.GenericData: map[string]string{"devices": "ckp0,ckp1"}},
+		[]*libnetwork.IpamConf{{PreferredPool: "172.31.11.0/24", Gateway: "172.31.11.1"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := n.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// The endpoint is deleted along with the sandbox
+	ep, err := n.CreateEndpoint("ep1", libnetwork.CreateOptionMyAlias("web"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ep.Info().Iface().Address()
+
+	hostsFile, err := ioutil.TempFile("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(hostsFile.Name())
+	hostsFile.Close()
+
+	sb, err := controller.NewSandbox("checkpoint-container",
+		libnetwork.OptionHostname("test"),
+		libnetwork.OptionHostsPath(hostsFile.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ep.Join(sb); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := sb.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := controller.RestoreSandbox(data); err == nil {
+		t.Fatal("Expected failure restoring a sandbox which still exists")
+	}
+	sbID, epID := sb.ID(), ep.ID()
+	if err := sb.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := controller.RestoreSandbox([]byte("{")); err == nil {
+		t.Fatal("Expected failure restoring an invalid checkpoint")
+	}
+	rsb, err := controller.RestoreSandbox(data, libnetwork.OptionHostsPath(hostsFile.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := rsb.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	if rsb.ID() != sbID || rsb.ContainerID() != "checkpoint-container" {
+		t.Fatalf("Restored sandbox %s (%s) does not match %s", rsb.ID(), rsb.ContainerID(), sbID)
+	}
+	eps := rsb.Endpoints()
+	if len(eps) != 1 || eps[0].ID() != epID || eps[0].Name() != "ep1" {
+		t.Fatalf("Expected the restored sandbox to be connected to endpoint %s, got %v", epID, eps)
+	}
+	if a := eps[0].Info().Iface().Address(); !types.CompareIPNet(a, addr) {
+		t.Fatalf("Expected the restored endpoint to keep address %s, got %s", addr, a)
+	}
+	if _, err := n.EndpointByID(epID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEndpointMirror(t *testing.T) {
+	if !testutils.IsRunningInContainer() {
+		defer testutils.SetupTestOSContext(t)()
+	}
+
+	for _, name := range []string{"mrr0", "mrr1", "mrrtgt"} {
+		if err := netlink.LinkAdd(&netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: name},
+			PeerName:  name + "p",
+		}); err != nil {
+			t.Fatal(err)
+		}
+		defer netlink.LinkDel(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: name}})
+	}
+
+	n, err := createTestNetwork("hostdev", "testmirror",
+		options.Generic{netlabel.GenericData: map[string]string{"devices": "mrr0,mrr1"}},
+		[]*libnetwork.IpamConf{{PreferredPool: "172.31.12.0/24", Gateway: "172.31.12.1"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := n.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// The endpoints are deleted along with the sandboxes
+	ep1, err := n.CreateEndpoint("ep1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ep2, err := n.CreateEndpoint("ep2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ep1.Mirror(libnetwork.MirrorTarget{HostInterface: "mrrtgt"}); err == nil {
+		t.Fatal("Expected failure mirroring an endpoint not connected to a sandbox")
+	}
+
+	sb1, err := controller.NewSandbox("mirror-container1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sb1.Delete()
+	sb2, err := controller.NewSandbox("mirror-container2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ep1.Join(sb1); err != nil {
+		t.Fatal(err)
+	}
+	if err := ep2.Join(sb2); err != nil {
+		t.Fatal(err)
+	}
+
+	linkName := "mir" + ep1.ID()[:7]
+	if err := ep1.Mirror(libnetwork.MirrorTarget{HostInterface: "mrrtgt", File: "/tmp/x"}); err == nil {
+		t.Fatal("Expected failure mirroring to more than one target")
+	}
+	if err := ep1.Mirror(libnetwork.MirrorTarget{HostInterface: "mrrtgt"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ep1.Mirror(libnetwork.MirrorTarget{HostInterface: "mrrtgt"}); err == nil {
+		t.Fatal("Expected failure mirroring an endpoint twice")
+	}
+	if _, err := netlink.LinkByName(linkName); err != nil {
+		t.Fatalf("Mirror link not found: %v", err)
+	}
+	if err := ep1.Unmirror(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := netlink.LinkByName(linkName); err == nil {
+		t.Fatal("Mirror link left after unmirroring the endpoint")
+	}
+	if err := ep1.Unmirror(); err == nil {
+		t.Fatal("Expected failure unmirroring an endpoint not mirrored")
+	}
+
+	// Mirror to a pcapng file of the exec root
+	for _, file := range []string{"/tmp/mirror.pcapng", "../mirror.pcapng", ".."} {
+		if err := ep1.Mirror(libnetwork.MirrorTarget{File: file}); err == nil {
+			t.Fatalf("Expected failure mirroring to file %s", file)
+		}
+	}
+	execRoot := controller.Config().Daemon.ExecRoot
+	if execRoot == "" {
+		execRoot = "/run/docker"
+	}
+	path := filepath.Join(execRoot, "libnetwork", "mirrors", "mirror.pcapng")
+	defer os.Remove(path)
+	if err := ep1.Mirror(libnetwork.MirrorTarget{File: "mirror.pcapng"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ep1.Unmirror(); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Size() == 0 {
+		t.Fatalf("Expected the pcapng headers in the mirror file: %v", err)
+	}
+	if err := ep1.Mirror(libnetwork.MirrorTarget{File: "mirror.pcapng"}); err == nil {
+		t.Fatal("Expected failure mirroring to an existing file")
+	}
+
+	// Mirroring to an endpoint stops as the target endpoint leaves
+	if err := ep1.Mirror(libnetwork.MirrorTarget{Endpoint: ep2.ID()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sb2.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ep1.Unmirror(); err == nil {
+		t.Fatal("Expected the mirroring to stop as the target endpoint left")
+	}
+	if _, err := netlink.LinkByName(linkName); err == nil {
+		t.Fatal("Mirror link left after the target endpoint left")
+	}
+}
+
+func TestDuplicateAddressDetection(t *testing.T) {
+	if !testutils.IsRunningInContainer() {
+		defer testutils.SetupTestOSContext(t)()
+	}
+
+	// The responder end of the veth pair owns 172.31.13.2 in a network
+	// namespace of its own
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "dadp0"}, PeerName: "dadr0"}
+	if err := netlink.LinkAdd(veth); err != nil {
+		t.Fatal(err)
+	}
+	defer netlink.LinkDel(veth)
+	parent, err := netlink.LinkByName("dadp0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := netlink.LinkSetUp(parent); err != nil {
+		t.Fatal(err)
+	}
+	origin, err := netns.Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer origin.Close()
+	responderNs, err := netns.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer responderNs.Close()
+	if err := netns.Set(origin); err != nil {
+		t.Fatal(err)
+	}
+	peer, err := netlink.LinkByName("dadr0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := netlink.LinkSetNsFd(peer, int(responderNs)); err != nil {
+		t.Fatal(err)
+	}
+	nh, err := netlink.NewHandleAt(responderNs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nh.Delete()
+	if peer, err = nh.LinkByName("dadr0"); err != nil {
+		t.Fatal(err)
+	}
+	addr, _ := types.ParseCIDR("172.31.13.2/24")
+	if err := nh.AddrAdd(peer, &netlink.Addr{IPNet: addr}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nh.LinkSetUp(peer); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := controller.NewNetwork("macvlan", "testdad", "",
+		libnetwork.NetworkOptionDriverOpts(map[string]string{"parent": "dadp0"}),
+		libnetwork.NetworkOptionIpam(ipamapi.DefaultIPAM, "",
+			[]*libnetwork.IpamConf{{PreferredPool: "172.31.13.0/24", Gateway: "172.31.13.1"}}, nil,
+			map[string]string{netlabel.DuplicateAddressDetection: "true"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := n.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	ep, err := n.CreateEndpoint("ep1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ep.Delete(false)
+
+	if ip := ep.Info().Iface().Address().IP; !ip.Equal(net.ParseIP("172.31.13.3")) {
+		t.Fatalf("Expected the address in use to be skipped, got %s", ip)
+	}
+	v4Info, _ := n.Info().IpamInfo()
+	if ext, ok := v4Info[0].AuxAddresses["external-172.31.13.2"]; !ok || ext.String() != addr.String() {
+		t.Fatalf("Expected the address in use to be recorded as externally used: %v", v4Info[0].AuxAddresses)
+	}
+}
+
 func TestExternalKey(t *testing.T) {
 	externalKeyTest(t, false)
 }
@@ -944,6 +944,14 @@ func (f *fakeSandbox) Rename(name string) error {
 	return nil
 }
 
+func (f *fakeSandbox) ReplaceEndpoint(oldEp, newEp libnetwork.Endpoint) error {
+	return nil
+}
+
+func (f *fakeSandbox) Checkpoint() ([]byte, error) {
+	return nil, nil
+}
+
 func (f *fakeSandbox) SetKey(key string) error {
 	return nil
 }
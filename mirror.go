@@ -0,0 +1,153 @@
+package libnetwork
+
+import (
+	"path/filepath"
+
+	"github.com/docker/libnetwork/osl"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+// MirrorTarget is where the traffic of a mirrored endpoint is cloned to.
+// Exactly one of its fields is set.
+type MirrorTarget struct {
+	// HostInterface is the name of a host interface, e.g. a dummy one,
+	// the traffic is sent out of
+	HostInterface string `json:"host_interface,omitempty"`
+	// Endpoint is the ID of an endpoint, the traffic is injected into
+	// its sandbox as received by the endpoint interface
+	Endpoint string `json:"endpoint,omitempty"`
+	// File is the name of a pcapng file the traffic is written to. It is
+	// created in the mirrors directory of the daemon exec root, and must
+	// not exist yet.
+	File string `json:"file,omitempty"`
+}
+
+func (t MirrorTarget) validate() error {
+	var set int
+	for _, v := range []string{t.HostInterface, t.Endpoint, t.File} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return types.BadRequestErrorf("exactly one mirror target is expected, got %d", set)
+	}
+	if t.File != "" && (filepath.Base(t.File) != t.File || t.File == "." || t.File == "..") {
+		return types.BadRequestErrorf("mirror file %s is not a bare file name", t.File)
+	}
+	return nil
+}
+
+// endpointMirror is the runtime state of the mirroring of an endpoint.
+// Mirrors are not persisted, they end with the daemon or when the endpoint
+// leaves its sandbox.
+type endpointMirror struct {
+	eid      string
+	target   MirrorTarget
+	osSbox   osl.Sandbox
+	ifName   string
+	linkName string
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func (ep *endpoint) Mirror(target MirrorTarget) error {
+	if err := target.validate(); err != nil {
+		return err
+	}
+	if target.Endpoint == ep.ID() {
+		return types.BadRequestErrorf("endpoint %s cannot be mirrored to itself", ep.Name())
+	}
+
+	c := ep.getNetwork().getController()
+	sb, sep := c.sandboxEndpoint(ep.ID())
+	if sep == nil {
+		return types.ForbiddenErrorf("endpoint %s is not connected to a sandbox", ep.Name())
+	}
+
+	c.Lock()
+	if _, ok := c.mirrors[ep.ID()]; ok {
+		c.Unlock()
+		return types.ForbiddenErrorf("endpoint %s is already mirrored", ep.Name())
+	}
+	// Reserve the slot while the mirror is set up
+	c.mirrors[ep.ID()] = nil
+	c.Unlock()
+
+	m, err := sep.startMirror(sb, target)
+
+	c.Lock()
+	if err != nil {
+		delete(c.mirrors, ep.ID())
+	} else {
+		c.mirrors[ep.ID()] = m
+	}
+	c.Unlock()
+
+	if err == nil {
+		logrus.Infof("Mirroring endpoint %s to %+v", ep.Name(), target)
+	}
+	return err
+}
+
+func (ep *endpoint) Unmirror() error {
+	c := ep.getNetwork().getController()
+	c.Lock()
+	m, ok := c.mirrors[ep.ID()]
+	if ok && m != nil {
+		delete(c.mirrors, ep.ID())
+	}
+	c.Unlock()
+
+	if !ok {
+		return types.NotFoundErrorf("endpoint %s is not mirrored", ep.Name())
+	}
+	if m == nil {
+		return types.ForbiddenErrorf("mirroring of endpoint %s is being set up", ep.Name())
+	}
+
+	return m.teardown()
+}
+
+// stopMirrors tears down the mirroring of the endpoint and the mirroring
+// of other endpoints targeting it, as it leaves its sandbox
+func (c *controller) stopMirrors(eid string) {
+	var stale []*endpointMirror
+
+	c.Lock()
+	for id, m := range c.mirrors {
+		if m == nil {
+			continue
+		}
+		if id == eid || m.target.Endpoint == eid {
+			stale = append(stale, m)
+			delete(c.mirrors, id)
+		}
+	}
+	c.Unlock()
+
+	for _, m := range stale {
+		if err := m.teardown(); err != nil {
+			logrus.Warnf("Failed to stop mirroring endpoint %s: %v", m.eid, err)
+		}
+	}
+}
+
+// sandboxEndpoint returns the endpoint with the passed ID among the ones
+// connected to the sandboxes, along with its sandbox
+func (c *controller) sandboxEndpoint(eid string) (*sandbox, *endpoint) {
+	c.Lock()
+	sandboxes := make([]*sandbox, 0, len(c.sandboxes))
+	for _, sb := range c.sandboxes {
+		sandboxes = append(sandboxes, sb)
+	}
+	c.Unlock()
+
+	for _, sb := range sandboxes {
+		if ep := sb.getEndpoint(eid); ep != nil {
+			return sb, ep
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,133 @@
+package libnetwork
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/docker/libnetwork/capture"
+	"github.com/docker/libnetwork/osl"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+const mirrorSubdir = "mirrors"
+
+// startMirror clones the traffic of the endpoint interface in the sandbox
+// to a veth pair, and hands the host end of the pair over to the target
+func (ep *endpoint) startMirror(sb *sandbox, target MirrorTarget) (m *endpointMirror, err error) {
+	if sb.osSbox == nil {
+		return nil, types.ForbiddenErrorf("sandbox %s of endpoint %s is not set up", sb.ID(), ep.Name())
+	}
+	ifName := findIfaceDstName(sb, ep)
+	if ifName == "" {
+		return nil, fmt.Errorf("could not find the interface of endpoint %s in sandbox %s", ep.Name(), sb.ID())
+	}
+
+	m = &endpointMirror{
+		eid:      ep.ID(),
+		target:   target,
+		osSbox:   sb.osSbox,
+		ifName:   ifName,
+		linkName: "mir" + ep.ID()[:7],
+	}
+	if err := sb.osSbox.MirrorInterface(ifName, m.linkName); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if err := sb.osSbox.UnmirrorInterface(ifName); err != nil {
+				logrus.Warnf("Failed to clean up the mirroring of endpoint %s: %v", ep.Name(), err)
+			}
+		}
+	}()
+
+	switch {
+	case target.HostInterface != "":
+		if err := osl.RedirectHostLink(m.linkName, target.HostInterface); err != nil {
+			return nil, err
+		}
+	case target.Endpoint != "":
+		tsb, tep := ep.getNetwork().getController().sandboxEndpoint(target.Endpoint)
+		if tep == nil {
+			return nil, types.NotFoundErrorf("target endpoint %s is not connected to a sandbox", target.Endpoint)
+		}
+		if tsb.osSbox == nil {
+			return nil, types.ForbiddenErrorf("sandbox %s of target endpoint %s is not set up", tsb.ID(), tep.Name())
+		}
+		tifName := findIfaceDstName(tsb, tep)
+		if tifName == "" {
+			return nil, fmt.Errorf("could not find the interface of endpoint %s in sandbox %s", tep.Name(), tsb.ID())
+		}
+		if err := tsb.osSbox.RedirectLink(m.linkName, tifName); err != nil {
+			return nil, err
+		}
+	case target.File != "":
+		if err := m.captureToFile(ep.getNetwork().getController().mirrorDir(), target.File); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// mirrorDir returns the directory the mirror files are created in, which
+// only the daemon can write to
+func (c *controller) mirrorDir() string {
+	execRoot := defaultExecRoot
+	if v := c.Config().Daemon.ExecRoot; v != "" {
+		execRoot = v
+	}
+	return filepath.Join(execRoot, execSubdir, mirrorSubdir)
+}
+
+// captureToFile writes the packets coming out of the host end of the veth
+// pair to the new pcapng file name in dir, from a goroutine running until
+// the mirror is torn down
+func (m *endpointMirror) captureToFile(dir, name string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, name)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL|syscall.O_NOFOLLOW, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open mirror file %s: %v", path, err)
+	}
+	w, err := capture.NewWriter(f, m.linkName)
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write mirror file %s: %v", path, err)
+	}
+	s, err := capture.Open(m.linkName, nil)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	go func() {
+		defer close(m.done)
+		if err := s.Capture(w, m.stop); err != nil {
+			logrus.Warnf("Mirroring of endpoint %s to %s stopped: %v", m.eid, path, err)
+		}
+		s.Close()
+		if err := f.Close(); err != nil {
+			logrus.Warnf("Failed to close mirror file %s: %v", path, err)
+		}
+	}()
+
+	return nil
+}
+
+// teardown stops the capture to file, if any, and deletes the veth pair
+// the traffic is mirrored to
+func (m *endpointMirror) teardown() error {
+	if m.stop != nil {
+		close(m.stop)
+		<-m.done
+	}
+
+	return m.osSbox.UnmirrorInterface(m.ifName)
+}
@@ -0,0 +1,13 @@
+// +build !linux
+
+package libnetwork
+
+import "github.com/docker/libnetwork/types"
+
+func (ep *endpoint) startMirror(sb *sandbox, target MirrorTarget) (*endpointMirror, error) {
+	return nil, types.NotImplementedErrorf("endpoint mirroring is not supported on this platform")
+}
+
+func (m *endpointMirror) teardown() error {
+	return nil
+}
@@ -45,6 +45,19 @@ const (
 	// OverlayVxlanIDList constant represents a list of VXLAN Ids as csv
 	OverlayVxlanIDList = DriverPrefix + ".overlay.vxlanid_list"
 
+	// RemoteCallTimeout constant represents the deadline of the remote plugin calls.
+	// Suffixed with "." and a method, as in "NetworkDriver.Join", it is the one of that method.
+	RemoteCallTimeout = DriverPrefix + ".remote.call_timeout"
+
+	// RemoteCallRetries constant represents the number of retries of the idempotent remote plugin calls
+	RemoteCallRetries = DriverPrefix + ".remote.call_retries"
+
+	// RemoteBreakerThreshold constant represents the number of consecutive failed calls marking a remote plugin unhealthy
+	RemoteBreakerThreshold = DriverPrefix + ".remote.breaker_threshold"
+
+	// RemoteBreakerCooldown constant represents how long the calls to an unhealthy remote plugin fail fast
+	RemoteBreakerCooldown = DriverPrefix + ".remote.breaker_cooldown"
+
 	// Gateway represents the gateway for the network
 	Gateway = Prefix + ".gateway"
 
@@ -56,6 +69,10 @@ const (
 
 	// HostIP is the Source-IP Address used to SNAT container traffic
 	HostIP = Prefix + ".host_ipv4"
+
+	// DuplicateAddressDetection constant represents the ipam option enabling the probing of the
+	// endpoint addresses on the link of the network before assigning them
+	DuplicateAddressDetection = Prefix + ".ipam.dad"
 )
 
 var (
@@ -1,6 +1,7 @@
 package libnetwork
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -140,6 +141,17 @@ type IpamConf struct {
 	// Auxiliary addresses for network driver. Must be within the master pool.
 	// libnetwork will reserve them if they fall into the container pool
 	AuxAddresses map[string]string
+	// Address ranges of the master pool, in CIDR or first-last notation,
+	// container addresses are allocated from along with the SubPool (optional)
+	Ranges []string `json:",omitempty"`
+	// Address ranges of the master pool never allocated to containers (optional)
+	Exclusions []string `json:",omitempty"`
+	// Which address the gateway gets when none is specified: the first
+	// (default) or the last available one, or none at all (optional)
+	GatewayPolicy string `json:",omitempty"`
+	// IPAM driver the pool is requested from, if other than the network
+	// one (optional)
+	Driver string `json:",omitempty"`
 }
 
 // Validate checks whether the configuration is valid
@@ -147,13 +159,87 @@ func (c *IpamConf) Validate() error {
 	if c.Gateway != "" && nil == net.ParseIP(c.Gateway) {
 		return types.BadRequestErrorf("invalid gateway address %s in Ipam configuration", c.Gateway)
 	}
+	var pool *net.IPNet
+	if c.PreferredPool != "" {
+		var err error
+		if _, pool, err = net.ParseCIDR(c.PreferredPool); err != nil {
+			return types.BadRequestErrorf("invalid pool %s in Ipam configuration: %v", c.PreferredPool, err)
+		}
+	}
+	var ranges, exclusions [][2]net.IP
+	for i, r := range append(append([]string{}, c.Ranges...), c.Exclusions...) {
+		first, last, err := types.ParseAddressRange(r)
+		if err != nil {
+			return types.BadRequestErrorf("invalid address range %q in Ipam configuration: %v", r, err)
+		}
+		if pool == nil {
+			return types.BadRequestErrorf("address range %q requires a pool in Ipam configuration", r)
+		}
+		if !pool.Contains(first) || !pool.Contains(last) {
+			return types.BadRequestErrorf("address range %q does not belong to pool %s in Ipam configuration", r, pool)
+		}
+		if i < len(c.Ranges) {
+			ranges = append(ranges, [2]net.IP{first, last})
+		} else {
+			exclusions = append(exclusions, [2]net.IP{first, last})
+		}
+	}
+	// The auxiliary addresses are reserved for the driver, they must not be
+	// part of the addresses allocated to containers
+	for k, v := range c.AuxAddresses {
+		ip := net.ParseIP(v)
+		if ip == nil || !inAddressRanges(ip, ranges) || inAddressRanges(ip, exclusions) {
+			continue
+		}
+		return types.BadRequestErrorf("auxiliary address (%s:%s) falls in an allocatable address range in Ipam configuration", k, v)
+	}
+	switch c.GatewayPolicy {
+	case "", ipamapi.GatewayFirst, ipamapi.GatewayLast:
+	case ipamapi.GatewayNone:
+		if c.Gateway != "" {
+			return types.BadRequestErrorf("gateway address %s conflicts with gateway policy %q in Ipam configuration", c.Gateway, c.GatewayPolicy)
+		}
+	default:
+		return types.BadRequestErrorf("invalid gateway policy %q in Ipam configuration", c.GatewayPolicy)
+	}
 	return nil
 }
 
+// inAddressRanges tells whether the address belongs to any of the ranges
+func inAddressRanges(ip net.IP, ranges [][2]net.IP) bool {
+	ip = ip.To16()
+	for _, r := range ranges {
+		if bytes.Compare(ip, r[0].To16()) >= 0 && bytes.Compare(ip, r[1].To16()) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// poolOptions returns the options of the pool request for the configuration
+func (c *IpamConf) poolOptions(opts map[string]string) map[string]string {
+	if len(c.Ranges) == 0 && len(c.Exclusions) == 0 {
+		return opts
+	}
+	po := make(map[string]string, len(opts)+2)
+	for k, v := range opts {
+		po[k] = v
+	}
+	if len(c.Ranges) > 0 {
+		po[ipamapi.AllocRanges] = strings.Join(c.Ranges, ",")
+	}
+	if len(c.Exclusions) > 0 {
+		po[ipamapi.AllocExclusions] = strings.Join(c.Exclusions, ",")
+	}
+	return po
+}
+
 // IpamInfo contains all the ipam related operational info for a network
 type IpamInfo struct {
 	PoolID string
 	Meta   map[string]string
+	// IPAM driver the pool was requested from
+	Driver string
 	driverapi.IPAMData
 }
 
@@ -171,6 +257,9 @@ func (i *IpamInfo) MarshalJSON() ([]byte, error) {
 	if i.Meta != nil {
 		m["Meta"] = i.Meta
 	}
+	if i.Driver != "" {
+		m["Driver"] = i.Driver
+	}
 	return json.Marshal(m)
 }
 
@@ -195,6 +284,9 @@ func (i *IpamInfo) UnmarshalJSON(data []byte) error {
 			return err
 		}
 	}
+	if v, ok := m["Driver"]; ok {
+		i.Driver = v.(string)
+	}
 	return nil
 }
 
@@ -342,12 +434,21 @@ func (c *IpamConf) CopyTo(dstC *IpamConf) error {
 			dstC.AuxAddresses[k] = v
 		}
 	}
+	if c.Ranges != nil {
+		dstC.Ranges = append([]string{}, c.Ranges...)
+	}
+	if c.Exclusions != nil {
+		dstC.Exclusions = append([]string{}, c.Exclusions...)
+	}
+	dstC.GatewayPolicy = c.GatewayPolicy
+	dstC.Driver = c.Driver
 	return nil
 }
 
 // CopyTo deep copies to the destination IpamInfo
 func (i *IpamInfo) CopyTo(dstI *IpamInfo) error {
 	dstI.PoolID = i.PoolID
+	dstI.Driver = i.Driver
 	if i.Meta != nil {
 		dstI.Meta = make(map[string]string)
 		for k, v := range i.Meta {
@@ -688,6 +789,18 @@ func (n *network) UnmarshalJSON(b []byte) (err error) {
 			return err
 		}
 	}
+	// The pools of the networks stored before the subnets could have an
+	// IPAM driver of their own were all requested from the network one
+	for _, d := range n.ipamV4Info {
+		if d.Driver == "" {
+			d.Driver = n.ipamType
+		}
+	}
+	for _, d := range n.ipamV6Info {
+		if d.Driver == "" {
+			d.Driver = n.ipamType
+		}
+	}
 	if v, ok := netMap["internal"]; ok {
 		n.internal = v.(bool)
 	}
@@ -1141,6 +1254,12 @@ func (n *network) addEndpoint(ep *endpoint) error {
 
 	err = d.CreateEndpoint(n.id, ep.id, ep.Interface(), ep.generic)
 	if err != nil {
+		if _, ok := err.(types.TimeoutError); ok {
+			// The driver may have created the endpoint before the call timed out
+			if e := d.DeleteEndpoint(n.id, ep.id); e != nil {
+				logrus.Debugf("driver delete endpoint failed after create endpoint timed out: %v", e)
+			}
+		}
 		return types.InternalErrorf("failed to create endpoint %s on network %s: %v",
 			ep.Name(), n.Name(), err)
 	}
@@ -1200,71 +1319,92 @@ func (n *network) createEndpoint(name string, options ...EndpointOption) (Endpoi
 		}
 	}
 
-	ipam, cap, err := n.getController().getIPAMDriver(n.ipamType)
+	requiresMAC, err := n.ipamRequiresMACAddress()
 	if err != nil {
 		return nil, err
 	}
 
-	if cap.RequiresMACAddress {
-		if ep.iface.mac == nil {
-			ep.iface.mac = netutils.GenerateRandomMAC()
-		}
+	if requiresMAC && ep.iface.mac == nil {
+		ep.iface.mac = netutils.GenerateRandomMAC()
+	}
+
+	// A configured MAC address also lets the ipam driver derive the
+	// interface identifier of the IPv6 address from it
+	if ep.iface.mac != nil {
 		if ep.ipamOptions == nil {
 			ep.ipamOptions = make(map[string]string)
 		}
 		ep.ipamOptions[netlabel.MacAddress] = ep.iface.mac.String()
 	}
 
-	if err = ep.assignAddress(ipam, true, n.enableIPv6 && !n.postIPv6); err != nil {
+	j := n.getController().newJournal(opCreateEndpoint, n, ep.id, "")
+	defer func() { j.finish(err) }()
+
+	if err = j.record(stepEndpointAddresses, nil); err != nil {
+		return nil, err
+	}
+	if err = ep.assignAddress(true, n.enableIPv6 && !n.postIPv6); err != nil {
+		return nil, err
+	}
+	j.undo(ep.releaseAddress)
+	if err = j.update(ep.journalAddresses(true, n.enableIPv6 && !n.postIPv6)); err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err != nil {
-			ep.releaseAddress()
-		}
-	}()
 
+	if err = j.record(stepDriverEndpoint, nil); err != nil {
+		return nil, err
+	}
 	if err = n.addEndpoint(ep); err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err != nil {
-			if e := ep.deleteEndpoint(false); e != nil {
-				logrus.Warnf("cleaning up endpoint failed %s : %v", name, e)
-			}
+	j.undo(func() {
+		if e := ep.deleteEndpoint(false); e != nil {
+			logrus.Warnf("cleaning up endpoint failed %s : %v", name, e)
 		}
-	}()
+	})
 
 	// We should perform updateToStore call right after addEndpoint
 	// in order to have iface properly configured
+	if err = j.record(stepStoreEndpoint, nil); err != nil {
+		return nil, err
+	}
 	if err = n.getController().updateToStore(ep); err != nil {
 		return nil, err
 	}
-	defer func() {
-		if err != nil {
-			if e := n.getController().deleteFromStore(ep); e != nil {
-				logrus.Warnf("error rolling back endpoint %s from store: %v", name, e)
-			}
+	j.undo(func() {
+		if e := n.getController().deleteFromStore(ep); e != nil {
+			logrus.Warnf("error rolling back endpoint %s from store: %v", name, e)
 		}
-	}()
+	})
 
-	if err = ep.assignAddress(ipam, false, n.enableIPv6 && n.postIPv6); err != nil {
-		return nil, err
+	if n.enableIPv6 && n.postIPv6 {
+		if err = j.record(stepEndpointAddresses, nil); err != nil {
+			return nil, err
+		}
+		if err = ep.assignAddress(false, true); err != nil {
+			return nil, err
+		}
+		// The IPv4 address is already recorded by the first step
+		if err = j.update(ep.journalAddresses(false, true)); err != nil {
+			return nil, err
+		}
 	}
 
 	// Watch for service records
 	n.getController().watchSvcRecord(ep)
-	defer func() {
-		if err != nil {
-			n.getController().unWatchSvcRecord(ep)
-		}
-	}()
+	j.undo(func() {
+		n.getController().unWatchSvcRecord(ep)
+	})
 
 	// Increment endpoint count to indicate completion of endpoint addition
 	if err = n.getEpCnt().IncEndpointCnt(); err != nil {
 		return nil, err
 	}
 
+	if err = j.commit(); err != nil {
+		n.getEpCnt().DecEndpointCnt()
+		return nil, err
+	}
 	return ep, nil
 }
 
@@ -1524,29 +1664,39 @@ func (n *network) getController() *controller {
 }
 
 func (n *network) ipamAllocate() error {
+	return n.ipamAllocatePools(false)
+}
+
+// ipamReplay requests the pools of the network again from the IPAM drivers
+// requiring it on start, the pools of the other drivers are kept as is
+func (n *network) ipamReplay() error {
+	return n.ipamAllocatePools(true)
+}
+
+func (n *network) ipamAllocatePools(replay bool) error {
 	if n.hasSpecialDriver() {
 		return nil
 	}
 
-	ipam, _, err := n.getController().getIPAMDriver(n.ipamType)
+	_, _, err := n.getController().getIPAMDriver(n.ipamType)
 	if err != nil {
 		return err
 	}
 
 	if n.addrSpace == "" {
-		if n.addrSpace, err = n.deriveAddressSpace(); err != nil {
+		if n.addrSpace, err = n.deriveAddressSpace(n.ipamType); err != nil {
 			return err
 		}
 	}
 
-	err = n.ipamAllocateVersion(4, ipam)
+	err = n.ipamAllocateVersion(4, replay)
 	if err != nil {
 		return err
 	}
 
 	defer func() {
 		if err != nil {
-			n.ipamReleaseVersion(4, ipam)
+			n.ipamReleaseVersion(4, replay)
 		}
 	}()
 
@@ -1554,10 +1704,84 @@ func (n *network) ipamAllocate() error {
 		return nil
 	}
 
-	err = n.ipamAllocateVersion(6, ipam)
+	err = n.ipamAllocateVersion(6, replay)
 	return err
 }
 
+// ipamDriverName returns the name of the IPAM driver the subnets configured
+// with the passed one are allocated from, the network one if empty
+func (n *network) ipamDriverName(driver string) string {
+	switch driver {
+	case "":
+		return n.ipamType
+	case ipamapi.DefaultIPAM:
+		return defaultIpamForNetworkType(n.Type())
+	}
+	return driver
+}
+
+// infoDriverName returns the name of the IPAM driver the pool of d was
+// requested from
+func (n *network) infoDriverName(d *IpamInfo) string {
+	if d.Driver != "" {
+		return d.Driver
+	}
+	return n.ipamType
+}
+
+// ipamDriverNames returns the names of the IPAM drivers of the network, the
+// network one first
+func (n *network) ipamDriverNames() []string {
+	names := []string{n.ipamType}
+	for _, d := range append(n.getIPInfo(4), n.getIPInfo(6)...) {
+		name := n.infoDriverName(d)
+		found := false
+		for _, dn := range names {
+			if dn == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ipamRequiresMACAddress tells whether any of the IPAM drivers of the network
+// requires the MAC address of the endpoints
+func (n *network) ipamRequiresMACAddress() (bool, error) {
+	for _, name := range n.ipamDriverNames() {
+		_, caps, err := n.getController().getIPAMDriver(name)
+		if err != nil {
+			return false, err
+		}
+		if caps.RequiresMACAddress {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ipamDriverForPool returns the IPAM driver the IPv4 or IPv6 pool poolID of
+// the network was requested from
+func (n *network) ipamDriverForPool(ipVer int, poolID string) (ipamapi.Ipam, error) {
+	ipam, _, err := n.getController().getIPAMDriver(n.poolDriverName(ipVer, poolID))
+	return ipam, err
+}
+
+// poolDriverName returns the name of the IPAM driver the IPv4 or IPv6 pool
+// poolID of the network was requested from
+func (n *network) poolDriverName(ipVer int, poolID string) string {
+	for _, d := range n.getIPInfo(ipVer) {
+		if d.PoolID == poolID {
+			return n.infoDriverName(d)
+		}
+	}
+	return n.ipamType
+}
+
 func (n *network) requestPoolHelper(ipam ipamapi.Ipam, addressSpace, preferredPool, subPool string, options map[string]string, v6 bool) (string, *net.IPNet, map[string]string, error) {
 	for {
 		poolID, pool, meta, err := ipam.RequestPool(addressSpace, preferredPool, subPool, options, v6)
@@ -1599,7 +1823,10 @@ func (n *network) requestPoolHelper(ipam ipamapi.Ipam, addressSpace, preferredPo
 	}
 }
 
-func (n *network) ipamAllocateVersion(ipVer int, ipam ipamapi.Ipam) error {
+// ipamAllocateVersion requests the IPv4 or IPv6 pools of the network, each
+// from its IPAM driver. On replay, only the drivers requiring it are asked
+// for their pools again.
+func (n *network) ipamAllocateVersion(ipVer int, replay bool) error {
 	var (
 		cfgList  *[]*IpamConf
 		infoList *[]*IpamInfo
@@ -1621,6 +1848,7 @@ func (n *network) ipamAllocateVersion(ipVer int, ipam ipamapi.Ipam) error {
 		*cfgList = []*IpamConf{{}}
 	}
 
+	old := *infoList
 	*infoList = make([]*IpamInfo, len(*cfgList))
 
 	logrus.Debugf("Allocating IPv%d pools for network %s (%s)", ipVer, n.Name(), n.ID())
@@ -1629,11 +1857,39 @@ func (n *network) ipamAllocateVersion(ipVer int, ipam ipamapi.Ipam) error {
 		if err = cfg.Validate(); err != nil {
 			return err
 		}
-		d := &IpamInfo{}
+		if cfg.GatewayPolicy == ipamapi.GatewayNone {
+			if _, cap, _ := n.resolveDriver(n.networkType, false); cap != nil && cap.RequiresGateway {
+				return types.BadRequestErrorf("gateway policy %q is not supported by network driver %s", cfg.GatewayPolicy, n.networkType)
+			}
+		}
+
+		var (
+			ipam ipamapi.Ipam
+			caps *ipamapi.Capability
+		)
+		name := n.ipamDriverName(cfg.Driver)
+		if ipam, caps, err = n.getController().getIPAMDriver(name); err != nil {
+			return err
+		}
+		if replay && !caps.RequiresRequestReplay && i < len(old) {
+			(*infoList)[i] = old[i]
+			continue
+		}
+
+		// The pools of another driver are requested from its default
+		// address space
+		addrSpace := n.addrSpace
+		if name != n.ipamType {
+			if addrSpace, err = n.deriveAddressSpace(name); err != nil {
+				return err
+			}
+		}
+
+		d := &IpamInfo{Driver: name}
 		(*infoList)[i] = d
 
-		d.AddressSpace = n.addrSpace
-		d.PoolID, d.Pool, d.Meta, err = n.requestPoolHelper(ipam, n.addrSpace, cfg.PreferredPool, cfg.SubPool, n.ipamOptions, ipVer == 6)
+		d.AddressSpace = addrSpace
+		d.PoolID, d.Pool, d.Meta, err = n.requestPoolHelper(ipam, addrSpace, cfg.PreferredPool, cfg.SubPool, cfg.poolOptions(n.ipamOptions), ipVer == 6)
 		if err != nil {
 			return err
 		}
@@ -1654,11 +1910,17 @@ func (n *network) ipamAllocateVersion(ipVer int, ipam ipamapi.Ipam) error {
 
 		// If user requested a specific gateway, libnetwork will allocate it
 		// irrespective of whether ipam driver returned a gateway already.
-		// If none of the above is true, libnetwork will allocate one.
-		if cfg.Gateway != "" || d.Gateway == nil {
+		// If none of the above is true, libnetwork will allocate one, unless
+		// the gateway policy says otherwise.
+		if cfg.GatewayPolicy == ipamapi.GatewayNone {
+			d.Gateway = nil
+		} else if cfg.Gateway != "" || d.Gateway == nil {
 			var gatewayOpts = map[string]string{
 				ipamapi.RequestAddressType: netlabel.Gateway,
 			}
+			if cfg.GatewayPolicy != "" {
+				gatewayOpts[ipamapi.GatewayPolicy] = cfg.GatewayPolicy
+			}
 			if d.Gateway, _, err = ipam.RequestAddress(d.PoolID, net.ParseIP(cfg.Gateway), gatewayOpts); err != nil {
 				return types.InternalErrorf("failed to allocate gateway (%v): %v", cfg.Gateway, err)
 			}
@@ -1691,16 +1953,14 @@ func (n *network) ipamRelease() {
 	if n.hasSpecialDriver() {
 		return
 	}
-	ipam, _, err := n.getController().getIPAMDriver(n.ipamType)
-	if err != nil {
-		logrus.Warnf("Failed to retrieve ipam driver to release address pool(s) on delete of network %s (%s): %v", n.Name(), n.ID(), err)
-		return
-	}
-	n.ipamReleaseVersion(4, ipam)
-	n.ipamReleaseVersion(6, ipam)
+	n.ipamReleaseVersion(4, false)
+	n.ipamReleaseVersion(6, false)
 }
 
-func (n *network) ipamReleaseVersion(ipVer int, ipam ipamapi.Ipam) {
+// ipamReleaseVersion releases the IPv4 or IPv6 pools of the network, each to
+// its IPAM driver. On replay, only the pools of the drivers requiring it are
+// released.
+func (n *network) ipamReleaseVersion(ipVer int, replay bool) {
 	var infoList *[]*IpamInfo
 
 	switch ipVer {
@@ -1720,6 +1980,14 @@ func (n *network) ipamReleaseVersion(ipVer int, ipam ipamapi.Ipam) {
 	logrus.Debugf("releasing IPv%d pools from network %s (%s)", ipVer, n.Name(), n.ID())
 
 	for _, d := range *infoList {
+		ipam, caps, err := n.getController().getIPAMDriver(n.infoDriverName(d))
+		if err != nil {
+			logrus.Warnf("Failed to retrieve ipam driver to release address pool %s on delete of network %s (%s): %v", d.PoolID, n.Name(), n.ID(), err)
+			continue
+		}
+		if replay && !caps.RequiresRequestReplay {
+			continue
+		}
 		if d.Gateway != nil {
 			if err := ipam.ReleaseAddress(d.PoolID, d.Gateway.IP); err != nil {
 				logrus.Warnf("Failed to release gateway ip address %s on delete of network %s (%s): %v", d.Gateway.IP, n.Name(), n.ID(), err)
@@ -1778,8 +2046,10 @@ func (n *network) getIPData(ipVer int) []driverapi.IPAMData {
 	return l
 }
 
-func (n *network) deriveAddressSpace() (string, error) {
-	local, global, err := n.getController().drvRegistry.IPAMDefaultAddressSpaces(n.ipamType)
+// deriveAddressSpace returns the default address space of the IPAM driver
+// name for the network
+func (n *network) deriveAddressSpace(name string) (string, error) {
+	local, global, err := n.getController().drvRegistry.IPAMDefaultAddressSpaces(name)
 	if err != nil {
 		return "", types.NotFoundErrorf("failed to get default address space: %v", err)
 	}
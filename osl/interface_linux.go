@@ -29,6 +29,13 @@ type nwIface struct {
 	llAddrs     []*net.IPNet
 	routes      []*net.IPNet
 	bridge      bool
+	hostDevice  bool
+	hostMAC     net.HardwareAddr
+	skipRename  bool
+	keepMAC     bool
+	policy      bool
+	policyGw    net.IP
+	policyGw6   net.IP
 	ns          *networkNamespace
 	sync.Mutex
 }
@@ -61,6 +68,27 @@ func (i *nwIface) Bridge() bool {
 	return i.bridge
 }
 
+func (i *nwIface) HostDevice() bool {
+	i.Lock()
+	defer i.Unlock()
+
+	return i.hostDevice
+}
+
+func (i *nwIface) KeepMAC() bool {
+	i.Lock()
+	defer i.Unlock()
+
+	return i.keepMAC
+}
+
+func (i *nwIface) PolicyRouting() bool {
+	i.Lock()
+	defer i.Unlock()
+
+	return i.policy
+}
+
 func (i *nwIface) Master() string {
 	i.Lock()
 	defer i.Unlock()
@@ -138,6 +166,13 @@ func (i *nwIface) Remove() error {
 		return err
 	}
 
+	// Tear down its policy routing while the link is still in the sandbox
+	if i.PolicyRouting() {
+		if err := n.programPolicyRoutes(iface, i, false); err != nil {
+			logrus.Warnf("failed to remove the policy routes of interface %s: %v", i.DstName(), err)
+		}
+	}
+
 	// Down the interface before configuring
 	if err := nlh.LinkSetDown(iface); err != nil {
 		return err
@@ -149,6 +184,8 @@ func (i *nwIface) Remove() error {
 		return err
 	}
 
+	i.restoreHostMAC(nlh, iface)
+
 	// if it is a bridge just delete it.
 	if i.Bridge() {
 		if err := nlh.LinkDel(iface); err != nil {
@@ -230,7 +267,7 @@ func (n *networkNamespace) AddInterface(srcName, dstPrefix string, options ...If
 	}
 
 	n.Lock()
-	if n.isDefault {
+	if n.isDefault || i.skipRename {
 		i.dstName = i.srcName
 	} else {
 		i.dstName = fmt.Sprintf("%s%d", dstPrefix, n.nextIfIndex[dstPrefix])
@@ -287,30 +324,24 @@ func (n *networkNamespace) AddInterface(srcName, dstPrefix string, options ...If
 		return fmt.Errorf("failed to set link down: %v", err)
 	}
 
+	// Remember the MAC address of a host device, to give it back as it was
+	if i.hostDevice {
+		i.hostMAC = types.GetMacCopy(iface.Attrs().HardwareAddr)
+	}
+
 	// Configure the interface now this is moved in the proper namespace.
 	if err := configureInterface(nlh, iface, i); err != nil {
 		// If configuring the device fails move it back to the host namespace
 		// and change the name back to the source name. This allows the caller
 		// to properly cleanup the interface. Its important especially for
 		// interfaces with global attributes, ex: vni id for vxlan interfaces.
-		if nerr := nlh.LinkSetName(iface, i.SrcName()); nerr != nil {
-			logrus.Errorf("renaming interface (%s->%s) failed, %v after config error %v", i.DstName(), i.SrcName(), nerr, err)
-		}
-		if nerr := nlh.LinkSetNsFd(iface, ns.ParseHandlerInt()); nerr != nil {
-			logrus.Errorf("moving interface %s to host ns failed, %v, after config error %v", i.SrcName(), nerr, err)
-		}
+		i.moveBackToHost(nlh, iface, err)
 		return err
 	}
 
 	// Up the interface.
-	cnt := 0
-	for err = nlh.LinkSetUp(iface); err != nil && cnt < 3; cnt++ {
-		logrus.Debugf("retrying link setup because of: %v", err)
-		time.Sleep(10 * time.Millisecond)
-		err = nlh.LinkSetUp(iface)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to set link up: %v", err)
+	if err := linkSetUp(nlh, iface); err != nil {
+		return err
 	}
 
 	// Set the routes on the interface. This can only be done when the interface is up.
@@ -318,6 +349,12 @@ func (n *networkNamespace) AddInterface(srcName, dstPrefix string, options ...If
 		return fmt.Errorf("error setting interface %q routes to %q: %v", iface.Attrs().Name, i.Routes(), err)
 	}
 
+	if i.PolicyRouting() {
+		if err := n.programPolicyRoutes(iface, i, true); err != nil {
+			return fmt.Errorf("error setting interface %q policy routes: %v", iface.Attrs().Name, err)
+		}
+	}
+
 	n.Lock()
 	n.iFaces = append(n.iFaces, i)
 	n.Unlock()
@@ -327,6 +364,207 @@ func (n *networkNamespace) AddInterface(srcName, dstPrefix string, options ...If
 	return nil
 }
 
+// ReplaceInterface moves the interface srcName into the sandbox in place of
+// the interface old. The old interface is taken down and gives its name up
+// to the new one, then moved back to the host once the new one is
+// configured, so that the sandbox is left with a single interface carrying
+// the addresses and routes at any time.
+func (n *networkNamespace) ReplaceInterface(old Interface, srcName string, options ...IfaceOption) error {
+	oi, ok := old.(*nwIface)
+	if !ok || oi.ns != n {
+		return fmt.Errorf("interface %s does not belong to the sandbox", old.SrcName())
+	}
+
+	i := &nwIface{srcName: srcName, dstName: oi.DstName(), ns: n}
+	i.processInterfaceOptions(options...)
+
+	if i.bridge || oi.Bridge() {
+		return fmt.Errorf("cannot replace interface %s: bridges cannot be replaced", oi.DstName())
+	}
+	if i.skipRename {
+		i.dstName = i.srcName
+	}
+	if i.master != "" {
+		i.dstMaster = n.findDst(i.master, true)
+		if i.dstMaster == "" {
+			return fmt.Errorf("could not find an appropriate master %q for %q",
+				i.master, i.srcName)
+		}
+	}
+
+	n.Lock()
+	path := n.path
+	isDefault := n.isDefault
+	nlh := n.nlHandle
+	nlhHost := ns.NlHandle()
+	n.Unlock()
+
+	if isDefault {
+		return fmt.Errorf("cannot replace interface %s of the default sandbox", oi.DstName())
+	}
+
+	// Move the new interface into the sandbox, down, ahead of touching the
+	// old one.
+	iface, err := nlhHost.LinkByName(i.srcName)
+	if err != nil {
+		return fmt.Errorf("failed to get link by name %q: %v", i.srcName, err)
+	}
+	newNs, err := netns.GetFromPath(path)
+	if err != nil {
+		return fmt.Errorf("failed get network namespace %q: %v", path, err)
+	}
+	defer newNs.Close()
+	if err := nlhHost.LinkSetNsFd(iface, int(newNs)); err != nil {
+		return fmt.Errorf("failed to set namespace on link %q: %v", i.srcName, err)
+	}
+	if iface, err = nlh.LinkByName(i.srcName); err != nil {
+		return fmt.Errorf("failed to get link by name %q: %v", i.srcName, err)
+	}
+	if err := nlh.LinkSetDown(iface); err != nil {
+		err = fmt.Errorf("failed to set link down: %v", err)
+		i.moveBackToHost(nlh, iface, err)
+		return err
+	}
+	if i.hostDevice {
+		i.hostMAC = types.GetMacCopy(iface.Attrs().HardwareAddr)
+	}
+
+	// Take the old interface out of the way. Its connected routes and the
+	// routes through it go away as it goes down.
+	oldLink, err := nlh.LinkByName(oi.DstName())
+	if err != nil {
+		err = fmt.Errorf("failed to get link by name %q: %v", oi.DstName(), err)
+		i.moveBackToHost(nlh, iface, err)
+		return err
+	}
+	if oi.PolicyRouting() {
+		if err := n.programPolicyRoutes(oldLink, oi, false); err != nil {
+			logrus.Warnf("failed to remove the policy routes of interface %s: %v", oi.DstName(), err)
+		}
+	}
+	if err := nlh.LinkSetDown(oldLink); err != nil {
+		err = fmt.Errorf("failed to set link %q down: %v", oi.DstName(), err)
+		i.moveBackToHost(nlh, iface, err)
+		n.reinstate(nlh, oldLink, oi)
+		return err
+	}
+	if err := nlh.LinkSetName(oldLink, oi.SrcName()); err != nil {
+		err = fmt.Errorf("failed to rename link %q to %q: %v", oi.DstName(), oi.SrcName(), err)
+		i.moveBackToHost(nlh, iface, err)
+		n.reinstate(nlh, oldLink, oi)
+		return err
+	}
+
+	// Configure the new interface as AddInterface does, giving the sandbox
+	// its old interface back on failure.
+	if err = configureInterface(nlh, iface, i); err == nil {
+		err = linkSetUp(nlh, iface)
+	}
+	if err == nil {
+		if err = setInterfaceRoutes(nlh, iface, i); err != nil {
+			err = fmt.Errorf("error setting interface %q routes to %q: %v", iface.Attrs().Name, i.Routes(), err)
+		}
+	}
+	if err != nil {
+		i.moveBackToHost(nlh, iface, err)
+		n.reinstate(nlh, oldLink, oi)
+		return err
+	}
+	if i.PolicyRouting() {
+		if err := n.programPolicyRoutes(iface, i, true); err != nil {
+			logrus.Warnf("error setting interface %q policy routes: %v", iface.Attrs().Name, err)
+		}
+	}
+
+	// The old interface leaves the sandbox as Remove does it
+	oi.restoreHostMAC(nlh, oldLink)
+	if err := nlh.LinkSetNsFd(oldLink, ns.ParseHandlerInt()); err != nil {
+		logrus.Warnf("failed to move replaced interface %s to host ns: %v", oi.SrcName(), err)
+	}
+
+	n.Lock()
+	for index, intf := range n.iFaces {
+		if intf == oi {
+			n.iFaces[index] = i
+			break
+		}
+	}
+	n.Unlock()
+
+	n.checkLoV6()
+
+	return nil
+}
+
+// reinstate brings back up the interface taken down to be replaced, after
+// its replacement failed
+func (n *networkNamespace) reinstate(nlh *netlink.Handle, link netlink.Link, i *nwIface) {
+	if err := nlh.LinkSetName(link, i.DstName()); err != nil {
+		logrus.Errorf("renaming interface (%s->%s) failed while reinstating it: %v", i.SrcName(), i.DstName(), err)
+	}
+	if err := linkSetUp(nlh, link); err != nil {
+		logrus.Errorf("failed to reinstate interface %s: %v", i.DstName(), err)
+		return
+	}
+	// The IPv6 addresses are flushed as the link goes down
+	if i.AddressIPv6() != nil {
+		ipAddr := &netlink.Addr{IPNet: i.AddressIPv6(), Label: "", Flags: syscall.IFA_F_NODAD}
+		if err := nlh.AddrReplace(link, ipAddr); err != nil {
+			logrus.Errorf("failed to restore address %v on interface %s: %v", i.AddressIPv6(), i.DstName(), err)
+		}
+	}
+	if err := setInterfaceRoutes(nlh, link, i); err != nil {
+		logrus.Errorf("failed to restore the routes of interface %s: %v", i.DstName(), err)
+	}
+	if i.PolicyRouting() {
+		if err := n.programPolicyRoutes(link, i, true); err != nil {
+			logrus.Errorf("failed to restore the policy routes of interface %s: %v", i.DstName(), err)
+		}
+	}
+}
+
+// moveBackToHost renames an interface back to its source name and moves it
+// to the host namespace after a configuration error
+func (i *nwIface) moveBackToHost(nlh *netlink.Handle, iface netlink.Link, err error) {
+	if nerr := nlh.LinkSetName(iface, i.SrcName()); nerr != nil {
+		logrus.Errorf("renaming interface (%s->%s) failed, %v after config error %v", i.DstName(), i.SrcName(), nerr, err)
+	}
+	i.restoreHostMAC(nlh, iface)
+	if nerr := nlh.LinkSetNsFd(iface, ns.ParseHandlerInt()); nerr != nil {
+		logrus.Errorf("moving interface %s to host ns failed, %v, after config error %v", i.SrcName(), nerr, err)
+	}
+}
+
+// linkSetUp brings the link up, retrying a few times on failure
+func linkSetUp(nlh *netlink.Handle, iface netlink.Link) error {
+	cnt := 0
+	err := nlh.LinkSetUp(iface)
+	for ; err != nil && cnt < 3; cnt++ {
+		logrus.Debugf("retrying link setup because of: %v", err)
+		time.Sleep(10 * time.Millisecond)
+		err = nlh.LinkSetUp(iface)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set link up: %v", err)
+	}
+	return nil
+}
+
+// restoreHostMAC gives a host device its MAC address back before it leaves
+// the sandbox
+func (i *nwIface) restoreHostMAC(nlh *netlink.Handle, iface netlink.Link) {
+	i.Lock()
+	hostMAC := i.hostMAC
+	i.Unlock()
+
+	if hostMAC == nil {
+		return
+	}
+	if err := nlh.LinkSetHardwareAddr(iface, hostMAC); err != nil {
+		logrus.Warnf("failed to restore the MAC address %s of host device %s: %v", hostMAC, i.SrcName(), err)
+	}
+}
+
 func configureInterface(nlh *netlink.Handle, iface netlink.Link, i *nwIface) error {
 	ifaceName := iface.Attrs().Name
 	ifaceConfigurators := []struct {
@@ -359,7 +597,7 @@ func setInterfaceMaster(nlh *netlink.Handle, iface netlink.Link, i *nwIface) err
 }
 
 func setInterfaceMAC(nlh *netlink.Handle, iface netlink.Link, i *nwIface) error {
-	if i.MacAddress() == nil {
+	if i.MacAddress() == nil || i.KeepMAC() {
 		return nil
 	}
 	return nlh.LinkSetHardwareAddr(iface, i.MacAddress())
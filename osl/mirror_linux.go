@@ -0,0 +1,245 @@
+package osl
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/docker/libnetwork/ns"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// mirrorFilterPrio is the priority of the tc filters mirroring or
+// redirecting the traffic of an interface, for them to be removed alone
+const mirrorFilterPrio = 0xc000
+
+// MirrorInterface clones the packets received and sent by the sandbox
+// interface ifName to a new veth pair. The veth end named linkName is left
+// in the host namespace, where the mirrored traffic comes out.
+func (n *networkNamespace) MirrorInterface(ifName, linkName string) (err error) {
+	peerName := linkName + "p"
+	if len(peerName) > 15 {
+		return fmt.Errorf("mirror link name %q is too long", linkName)
+	}
+
+	n.Lock()
+	if _, ok := n.mirrors[ifName]; ok {
+		n.Unlock()
+		return fmt.Errorf("interface %s is already mirrored", ifName)
+	}
+	path := n.path
+	isDefault := n.isDefault
+	nlh := n.nlHandle
+	nlhHost := ns.NlHandle()
+	n.Unlock()
+
+	iface, err := nlh.LinkByName(ifName)
+	if err != nil {
+		return fmt.Errorf("failed to get link by name %q: %v", ifName, err)
+	}
+
+	veth := &netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: linkName}, PeerName: peerName}
+	if err := nlhHost.LinkAdd(veth); err != nil {
+		return fmt.Errorf("failed to create mirror veth pair %s: %v", linkName, err)
+	}
+	defer func() {
+		if err != nil {
+			if err := nlhHost.LinkDel(veth); err != nil {
+				logrus.Debugf("Failed to delete mirror veth pair %s: %v", linkName, err)
+			}
+		}
+	}()
+
+	peer, err := nlhHost.LinkByName(peerName)
+	if err != nil {
+		return fmt.Errorf("failed to get link by name %q: %v", peerName, err)
+	}
+	if !isDefault {
+		newNs, err := netns.GetFromPath(path)
+		if err != nil {
+			return fmt.Errorf("failed get network namespace %q: %v", path, err)
+		}
+		defer newNs.Close()
+		if err := nlhHost.LinkSetNsFd(peer, int(newNs)); err != nil {
+			return fmt.Errorf("failed to set namespace on link %q: %v", peerName, err)
+		}
+		if peer, err = nlh.LinkByName(peerName); err != nil {
+			return fmt.Errorf("failed to get link by name %q: %v", peerName, err)
+		}
+	}
+	if err = nlh.LinkSetUp(peer); err != nil {
+		return fmt.Errorf("failed to set link %s up: %v", peerName, err)
+	}
+	if err = nlhHost.LinkSetUp(veth); err != nil {
+		return fmt.Errorf("failed to set link %s up: %v", linkName, err)
+	}
+
+	for _, parent := range []uint32{netlink.HANDLE_MIN_INGRESS, netlink.HANDLE_MIN_EGRESS} {
+		if err = addMirredFilter(nlh, iface, parent, netlink.TCA_EGRESS_MIRROR, peer.Attrs().Index); err != nil {
+			delMirredFilters(nlh, iface)
+			return fmt.Errorf("failed to mirror interface %s: %v", ifName, err)
+		}
+	}
+
+	n.Lock()
+	if n.mirrors == nil {
+		n.mirrors = make(map[string]string)
+	}
+	n.mirrors[ifName] = peerName
+	n.Unlock()
+
+	return nil
+}
+
+// UnmirrorInterface stops the mirroring of the sandbox interface ifName
+// and deletes the veth pair the traffic was cloned to.
+func (n *networkNamespace) UnmirrorInterface(ifName string) error {
+	n.Lock()
+	peerName, ok := n.mirrors[ifName]
+	delete(n.mirrors, ifName)
+	nlh := n.nlHandle
+	n.Unlock()
+
+	if !ok {
+		return fmt.Errorf("interface %s is not mirrored", ifName)
+	}
+
+	if iface, err := nlh.LinkByName(ifName); err == nil {
+		delMirredFilters(nlh, iface)
+	}
+
+	peer, err := nlh.LinkByName(peerName)
+	if err != nil {
+		// The veth pair is gone along with the namespace its other end
+		// was redirected to
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil
+		}
+		return fmt.Errorf("failed to get link by name %q: %v", peerName, err)
+	}
+	if err := nlh.LinkDel(peer); err != nil {
+		return fmt.Errorf("failed to delete mirror link %s: %v", peerName, err)
+	}
+
+	return nil
+}
+
+// RedirectLink moves the host link linkName into the sandbox, and injects
+// the packets it receives as received by the sandbox interface ifName.
+func (n *networkNamespace) RedirectLink(linkName, ifName string) error {
+	n.Lock()
+	path := n.path
+	isDefault := n.isDefault
+	nlh := n.nlHandle
+	nlhHost := ns.NlHandle()
+	n.Unlock()
+
+	iface, err := nlh.LinkByName(ifName)
+	if err != nil {
+		return fmt.Errorf("failed to get link by name %q: %v", ifName, err)
+	}
+	link, err := nlhHost.LinkByName(linkName)
+	if err != nil {
+		return fmt.Errorf("failed to get link by name %q: %v", linkName, err)
+	}
+	if !isDefault {
+		newNs, err := netns.GetFromPath(path)
+		if err != nil {
+			return fmt.Errorf("failed get network namespace %q: %v", path, err)
+		}
+		defer newNs.Close()
+		if err := nlhHost.LinkSetNsFd(link, int(newNs)); err != nil {
+			return fmt.Errorf("failed to set namespace on link %q: %v", linkName, err)
+		}
+		if link, err = nlh.LinkByName(linkName); err != nil {
+			return fmt.Errorf("failed to get link by name %q: %v", linkName, err)
+		}
+	}
+	if err := nlh.LinkSetUp(link); err != nil {
+		return fmt.Errorf("failed to set link %s up: %v", linkName, err)
+	}
+
+	if err := addMirredFilter(nlh, link, netlink.HANDLE_MIN_INGRESS, netlink.TCA_INGRESS_REDIR, iface.Attrs().Index); err != nil {
+		return fmt.Errorf("failed to redirect link %s to interface %s: %v", linkName, ifName, err)
+	}
+
+	return nil
+}
+
+// RedirectHostLink sends the packets received by the host link linkName
+// out of the host link target.
+func RedirectHostLink(linkName, target string) error {
+	nlh := ns.NlHandle()
+
+	link, err := nlh.LinkByName(linkName)
+	if err != nil {
+		return fmt.Errorf("failed to get link by name %q: %v", linkName, err)
+	}
+	tlink, err := nlh.LinkByName(target)
+	if err != nil {
+		return fmt.Errorf("failed to get link by name %q: %v", target, err)
+	}
+
+	if err := addMirredFilter(nlh, link, netlink.HANDLE_MIN_INGRESS, netlink.TCA_EGRESS_REDIR, tlink.Attrs().Index); err != nil {
+		return fmt.Errorf("failed to redirect link %s to %s: %v", linkName, target, err)
+	}
+
+	return nil
+}
+
+// addMirredFilter sets up a clsact qdisc on link, and a filter on the
+// parent hook of it handing all the packets to the mirred action on the
+// link with index redirIndex
+func addMirredFilter(nlh *netlink.Handle, link netlink.Link, parent uint32, action netlink.MirredAct, redirIndex int) error {
+	qdisc := &netlink.GenericQdisc{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+			Parent:    netlink.HANDLE_CLSACT,
+		},
+		QdiscType: "clsact",
+	}
+	if err := nlh.QdiscAdd(qdisc); err != nil && err != syscall.EEXIST {
+		return fmt.Errorf("failed to add clsact qdisc: %v", err)
+	}
+
+	mirred := netlink.NewMirredAction(redirIndex)
+	mirred.MirredAction = action
+	if action == netlink.TCA_EGRESS_MIRROR || action == netlink.TCA_INGRESS_MIRROR {
+		mirred.Attrs().Action = netlink.TC_ACT_PIPE
+	}
+	filter := &netlink.U32{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    parent,
+			Priority:  mirrorFilterPrio,
+			Protocol:  syscall.ETH_P_ALL,
+		},
+		Actions: []netlink.Action{mirred},
+	}
+	if err := nlh.FilterAdd(filter); err != nil {
+		return fmt.Errorf("failed to add mirred filter: %v", err)
+	}
+
+	return nil
+}
+
+// delMirredFilters removes the filters added by addMirredFilter from link.
+// The clsact qdisc is left in place, as other filters may be attached to it.
+func delMirredFilters(nlh *netlink.Handle, link netlink.Link) {
+	for _, parent := range []uint32{netlink.HANDLE_MIN_INGRESS, netlink.HANDLE_MIN_EGRESS} {
+		filters, err := nlh.FilterList(link, parent)
+		if err != nil {
+			continue
+		}
+		for _, f := range filters {
+			if f.Attrs().Priority != mirrorFilterPrio {
+				continue
+			}
+			if err := nlh.FilterDel(f); err != nil {
+				logrus.Debugf("Failed to delete mirred filter %v from %s: %v", f.Attrs(), link.Attrs().Name, err)
+			}
+		}
+	}
+}
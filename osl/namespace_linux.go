@@ -64,6 +64,7 @@ type networkNamespace struct {
 	isDefault    bool
 	nlHandle     *netlink.Handle
 	loV6Enabled  bool
+	mirrors      map[string]string
 	sync.Mutex
 }
 
@@ -511,6 +512,11 @@ func (n *networkNamespace) Restore(ifsopt map[string][]IfaceOption, routes []*ty
 		}
 		if n.isDefault {
 			i.dstName = i.srcName
+		} else if i.skipRename {
+			i.dstName = i.srcName
+			n.Lock()
+			n.iFaces = append(n.iFaces, i)
+			n.Unlock()
 		} else {
 			links, err := n.nlHandle.LinkList()
 			if err != nil {
@@ -192,3 +192,60 @@ func (n *networkNamespace) AddNeighbor(dstIP net.IP, dstMac net.HardwareAddr, fo
 
 	return nil
 }
+
+func (n *networkNamespace) Neighbors() ([]NeighborEntry, error) {
+	n.Lock()
+	nlh := n.nlHandle
+	n.Unlock()
+
+	var entries []NeighborEntry
+	for _, i := range n.Interfaces() {
+		link, err := nlh.LinkByName(i.DstName())
+		if err != nil {
+			return nil, fmt.Errorf("could not find interface with destination name %s: %v", i.DstName(), err)
+		}
+		neighs, err := nlh.NeighList(link.Attrs().Index, netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, fmt.Errorf("could not list the neighbors of interface %s: %v", i.DstName(), err)
+		}
+		for _, nh := range neighs {
+			// Skip the incomplete and failed resolutions
+			if len(nh.HardwareAddr) == 0 || nh.State&(netlink.NUD_INCOMPLETE|netlink.NUD_FAILED|netlink.NUD_NOARP) != 0 {
+				continue
+			}
+			entries = append(entries, NeighborEntry{IP: nh.IP, MAC: nh.HardwareAddr, LinkName: i.DstName()})
+		}
+	}
+
+	return entries, nil
+}
+
+func (n *networkNamespace) RestoreNeighbors(entries []NeighborEntry) error {
+	n.Lock()
+	nlh := n.nlHandle
+	n.Unlock()
+
+	for _, e := range entries {
+		link, err := nlh.LinkByName(e.LinkName)
+		if err != nil {
+			logrus.Debugf("Skipping neighbor entry for IP:%v, mac:%v: could not find interface %s: %v", e.IP, e.MAC, e.LinkName, err)
+			continue
+		}
+		family := netlink.FAMILY_V4
+		if e.IP.To4() == nil {
+			family = netlink.FAMILY_V6
+		}
+		nlnh := &netlink.Neigh{
+			LinkIndex:    link.Attrs().Index,
+			Family:       family,
+			IP:           e.IP,
+			HardwareAddr: e.MAC,
+			State:        netlink.NUD_STALE,
+		}
+		if err := nlh.NeighSet(nlnh); err != nil {
+			return fmt.Errorf("could not restore neighbor entry:%+v error:%v", nlnh, err)
+		}
+	}
+
+	return nil
+}
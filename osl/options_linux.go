@@ -36,6 +36,32 @@ func (n *networkNamespace) Bridge(isBridge bool) IfaceOption {
 	}
 }
 
+func (n *networkNamespace) HostDevice(isHostDevice bool) IfaceOption {
+	return func(i *nwIface) {
+		i.hostDevice = isHostDevice
+	}
+}
+
+func (n *networkNamespace) SkipRename(skip bool) IfaceOption {
+	return func(i *nwIface) {
+		i.skipRename = skip
+	}
+}
+
+func (n *networkNamespace) KeepMAC(keep bool) IfaceOption {
+	return func(i *nwIface) {
+		i.keepMAC = keep
+	}
+}
+
+func (n *networkNamespace) PolicyRouting(gw, gw6 net.IP) IfaceOption {
+	return func(i *nwIface) {
+		i.policy = true
+		i.policyGw = gw
+		i.policyGw6 = gw6
+	}
+}
+
 func (n *networkNamespace) Master(name string) IfaceOption {
 	return func(i *nwIface) {
 		i.master = name
@@ -0,0 +1,215 @@
+package osl
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+
+	"github.com/docker/libnetwork/types"
+	"github.com/vishvananda/netlink"
+)
+
+// Reconcile verifies the interfaces of the sandbox, their addresses and
+// routes, along with the default gateways and the static routes, against
+// the state the sandbox was configured, or restored, with. Missing addresses
+// and routes are added back and default routes through another gateway than
+// the expected one are removed. A missing interface is only reported, it is
+// up to its driver to recreate it.
+func (n *networkNamespace) Reconcile(dryRun bool) ([]types.Drift, error) {
+	n.Lock()
+	if n.isDefault {
+		n.Unlock()
+		return nil, nil
+	}
+	ifaces := make([]*nwIface, len(n.iFaces))
+	copy(ifaces, n.iFaces)
+	gateways := []net.IP{n.gw, n.gwv6}
+	routes := make([]*types.StaticRoute, len(n.staticRoutes))
+	copy(routes, n.staticRoutes)
+	nlh := n.nlHandle
+	n.Unlock()
+
+	r := &types.DriftReport{DryRun: dryRun}
+
+	for _, i := range ifaces {
+		if err := n.reconcileInterface(r, nlh, i); err != nil {
+			return r.Drifts, err
+		}
+	}
+
+	for _, gw := range gateways {
+		if len(gw) == 0 {
+			continue
+		}
+		if err := n.reconcileGateway(r, nlh, gw); err != nil {
+			return r.Drifts, err
+		}
+	}
+
+	for _, sr := range routes {
+		found, err := hasRoute(nlh, nil, sr.Destination, sr.NextHop)
+		if err != nil {
+			return r.Drifts, err
+		}
+		if !found {
+			sr := sr
+			r.Add(types.DriftMissing, "route", fmt.Sprintf("%s via %s", sr.Destination, sr.NextHop), func() error {
+				return n.programRoute(n.nsPath(), sr.Destination, sr.NextHop)
+			})
+		}
+	}
+
+	return r.Drifts, nil
+}
+
+func (n *networkNamespace) reconcileInterface(r *types.DriftReport, nlh *netlink.Handle, i *nwIface) error {
+	name := i.DstName()
+	link, err := nlh.LinkByName(name)
+	if err != nil {
+		r.Add(types.DriftMissing, "interface", name, func() error {
+			return errors.New("the interface must be recreated by its driver")
+		})
+		return nil
+	}
+
+	if link.Attrs().Flags&net.FlagUp == 0 {
+		r.Add(types.DriftChanged, "link state", name+" down", func() error {
+			return nlh.LinkSetUp(link)
+		})
+	}
+
+	if master := i.DstMaster(); master != "" {
+		ml, err := nlh.LinkByName(master)
+		if err != nil || link.Attrs().MasterIndex != ml.Attrs().Index {
+			r.Add(types.DriftChanged, "master", fmt.Sprintf("%s of %s", master, name), func() error {
+				return setInterfaceMaster(nlh, link, i)
+			})
+		}
+	}
+
+	addrs, err := nlh.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("failed to list the addresses of %s: %v", name, err)
+	}
+	expected := append([]*net.IPNet{i.Address(), i.AddressIPv6()}, i.LinkLocalAddresses()...)
+	for _, a := range expected {
+		if a == nil || hasAddress(addrs, a) {
+			continue
+		}
+		a := a
+		r.Add(types.DriftMissing, "address", fmt.Sprintf("%s on %s", a, name), func() error {
+			addr := &netlink.Addr{IPNet: a}
+			if a.IP.To4() == nil {
+				addr.Flags = syscall.IFA_F_NODAD
+			}
+			return nlh.AddrAdd(link, addr)
+		})
+	}
+
+	for _, route := range i.Routes() {
+		found, err := hasRoute(nlh, link, route, nil)
+		if err != nil {
+			return err
+		}
+		if found {
+			continue
+		}
+		route := route
+		r.Add(types.DriftMissing, "route", fmt.Sprintf("%s dev %s", route, name), func() error {
+			return nlh.RouteAdd(&netlink.Route{
+				Scope:     netlink.SCOPE_LINK,
+				LinkIndex: link.Attrs().Index,
+				Dst:       route,
+			})
+		})
+	}
+
+	if i.PolicyRouting() {
+		table := policyTable(link)
+		policyRoutes, err := nlh.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+		if err != nil {
+			return fmt.Errorf("failed to list the policy routes of %s: %v", name, err)
+		}
+		if len(policyRoutes) == 0 {
+			r.Add(types.DriftMissing, "policy routes", fmt.Sprintf("table %d of %s", table, name), func() error {
+				return n.programPolicyRoutes(link, i, true)
+			})
+		}
+	}
+
+	return nil
+}
+
+// reconcileGateway makes sure the default route of the gateway family goes
+// through the gateway
+func (n *networkNamespace) reconcileGateway(r *types.DriftReport, nlh *netlink.Handle, gw net.IP) error {
+	family := netlink.FAMILY_V4
+	if gw.To4() == nil {
+		family = netlink.FAMILY_V6
+	}
+	routes, err := nlh.RouteList(nil, family)
+	if err != nil {
+		return fmt.Errorf("failed to list the routes of the sandbox: %v", err)
+	}
+
+	var found bool
+	for _, route := range routes {
+		if !isDefaultRoute(route) || route.Gw == nil {
+			continue
+		}
+		if route.Gw.Equal(gw) {
+			found = true
+			continue
+		}
+		route := route
+		r.Add(types.DriftOrphan, "default route", fmt.Sprintf("via %s", route.Gw), func() error {
+			return nlh.RouteDel(&route)
+		})
+	}
+	if !found {
+		r.Add(types.DriftMissing, "default route", fmt.Sprintf("via %s", gw), func() error {
+			return n.programGateway(gw, true)
+		})
+	}
+	return nil
+}
+
+func isDefaultRoute(route netlink.Route) bool {
+	if route.Dst == nil {
+		return true
+	}
+	ones, _ := route.Dst.Mask.Size()
+	return ones == 0
+}
+
+func hasAddress(addrs []netlink.Addr, a *net.IPNet) bool {
+	for _, addr := range addrs {
+		if types.CompareIPNet(addr.IPNet, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRoute tells whether the main table holds a route to dst, through the
+// link and the gateway gw when set
+func hasRoute(nlh *netlink.Handle, link netlink.Link, dst *net.IPNet, gw net.IP) (bool, error) {
+	family := netlink.FAMILY_V4
+	if dst.IP.To4() == nil {
+		family = netlink.FAMILY_V6
+	}
+	routes, err := nlh.RouteList(link, family)
+	if err != nil {
+		return false, fmt.Errorf("failed to list the routes of the sandbox: %v", err)
+	}
+	for _, route := range routes {
+		if route.Dst == nil || !types.CompareIPNet(route.Dst, dst) {
+			continue
+		}
+		if gw == nil || route.Gw.Equal(gw) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -201,3 +201,86 @@ func (n *networkNamespace) RemoveStaticRoute(r *types.StaticRoute) error {
 	}
 	return err
 }
+
+const (
+	// policyTableBase is added to the index of an interface to get the
+	// routing table holding its policy routes
+	policyTableBase = 1000
+	// policyRulePriority is the priority of the rules selecting the policy
+	// routing tables, ahead of the main table
+	policyRulePriority = 1000
+)
+
+// policyTable returns the routing table holding the policy routes of link
+func policyTable(link netlink.Link) int {
+	return policyTableBase + link.Attrs().Index
+}
+
+// programPolicyRoutes sets up, or tears down, the source based routing of
+// the interface: the traffic sourced from its addresses is routed through a
+// table of its own, holding its connected routes and its gateway as the
+// default route, so that it leaves through the interface it belongs to
+// whatever the default gateway of the sandbox.
+func (n *networkNamespace) programPolicyRoutes(link netlink.Link, i *nwIface, isAdd bool) error {
+	i.Lock()
+	policies := []struct {
+		addr *net.IPNet
+		gw   net.IP
+	}{{i.address, i.policyGw}, {i.addressIPv6, i.policyGw6}}
+	i.Unlock()
+
+	table := policyTable(link)
+	for _, p := range policies {
+		if p.addr == nil {
+			continue
+		}
+		family, bits := netlink.FAMILY_V4, 32
+		if p.addr.IP.To4() == nil {
+			family, bits = netlink.FAMILY_V6, 128
+		}
+
+		routes := []*netlink.Route{{
+			LinkIndex: link.Attrs().Index,
+			Scope:     netlink.SCOPE_LINK,
+			Dst:       &net.IPNet{IP: p.addr.IP.Mask(p.addr.Mask), Mask: p.addr.Mask},
+			Src:       p.addr.IP,
+			Table:     table,
+		}}
+		if p.gw != nil {
+			routes = append(routes, &netlink.Route{
+				LinkIndex: link.Attrs().Index,
+				Scope:     netlink.SCOPE_UNIVERSE,
+				Gw:        p.gw,
+				Table:     table,
+			})
+		}
+		rule := netlink.NewRule()
+		rule.Family = family
+		rule.Priority = policyRulePriority
+		rule.Table = table
+		rule.Src = &net.IPNet{IP: p.addr.IP, Mask: net.CIDRMask(bits, bits)}
+
+		if !isAdd {
+			if err := n.nlHandle.RuleDel(rule); err != nil {
+				return fmt.Errorf("failed to delete policy rule from %s: %v", p.addr.IP, err)
+			}
+			// The routes of the table go along with the interface, do
+			// not fail on the ones already gone
+			for _, r := range routes {
+				n.nlHandle.RouteDel(r)
+			}
+			continue
+		}
+
+		for _, r := range routes {
+			if err := n.nlHandle.RouteReplace(r); err != nil {
+				return fmt.Errorf("failed to add policy route %s via %s in table %d: %v", r.Dst, r.Gw, table, err)
+			}
+		}
+		if err := n.nlHandle.RuleAdd(rule); err != nil {
+			return fmt.Errorf("failed to add policy rule from %s to table %d: %v", p.addr.IP, table, err)
+		}
+	}
+
+	return nil
+}
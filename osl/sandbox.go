@@ -30,6 +30,11 @@ type Sandbox interface {
 	// an appropriate suffix for the DstName to disambiguate.
 	AddInterface(SrcName string, DstPrefix string, options ...IfaceOption) error
 
+	// Replace an Interface of this sandbox with an existing one. The new
+	// Interface takes over the DstName of the old one, which is moved back
+	// out of the sandbox once the new one is configured.
+	ReplaceInterface(old Interface, SrcName string, options ...IfaceOption) error
+
 	// Set default IPv4 gateway for the sandbox
 	SetGateway(gw net.IP) error
 
@@ -70,6 +75,25 @@ type Sandbox interface {
 	// Returns an interface with methods to set neighbor options.
 	NeighborOptions() NeighborOptionSetter
 
+	// Neighbors returns the neighbor entries resolved on the sandbox
+	// interfaces.
+	Neighbors() ([]NeighborEntry, error)
+
+	// RestoreNeighbors adds the neighbor entries to the sandbox interfaces
+	// as stale entries, for the kernel to confirm them on use.
+	RestoreNeighbors(entries []NeighborEntry) error
+
+	// MirrorInterface clones the traffic of the named interface to a new
+	// veth pair, of which the end named linkName is left on the host.
+	MirrorInterface(ifName, linkName string) error
+
+	// UnmirrorInterface stops the mirroring of the named interface.
+	UnmirrorInterface(ifName string) error
+
+	// RedirectLink moves the host link linkName into the sandbox, for the
+	// packets it receives to be injected as received by the named interface.
+	RedirectLink(linkName, ifName string) error
+
 	// Returns an interface with methods to set interface options.
 	InterfaceOptions() IfaceOptionSetter
 
@@ -85,6 +109,11 @@ type Sandbox interface {
 	// restore sandbox
 	Restore(ifsopt map[string][]IfaceOption, routes []*types.StaticRoute, gw net.IP, gw6 net.IP) error
 
+	// Reconcile compares the links, addresses and routes of the sandbox
+	// in the kernel with the ones it was configured with. The drift found
+	// is repaired, unless dryRun is set, and returned.
+	Reconcile(dryRun bool) ([]types.Drift, error)
+
 	// ApplyOSTweaks applies operating system specific knobs on the sandbox
 	ApplyOSTweaks([]SandboxType)
 }
@@ -105,6 +134,19 @@ type IfaceOptionSetter interface {
 	// Bridge returns an option setter to set if the interface is a bridge.
 	Bridge(bool) IfaceOption
 
+	// HostDevice returns an option setter to set if the interface is an
+	// existing host device, moved into the sandbox by name. Its MAC address
+	// is restored when it is moved back to the host.
+	HostDevice(bool) IfaceOption
+
+	// SkipRename returns an option setter to keep the interface name in the
+	// sandbox instead of naming it after the destination prefix.
+	SkipRename(bool) IfaceOption
+
+	// KeepMAC returns an option setter to leave the MAC address of the
+	// interface untouched.
+	KeepMAC(bool) IfaceOption
+
 	// MacAddress returns an option setter to set the MAC address.
 	MacAddress(net.HardwareAddr) IfaceOption
 
@@ -124,6 +166,19 @@ type IfaceOptionSetter interface {
 
 	// Address returns an option setter to set interface routes.
 	Routes([]*net.IPNet) IfaceOption
+
+	// PolicyRouting returns an option setter to route the traffic sourced
+	// from the interface addresses through a routing table of its own,
+	// with the given gateways as default routes.
+	PolicyRouting(gw, gw6 net.IP) IfaceOption
+}
+
+// NeighborEntry is a neighbor entry of a sandbox interface
+type NeighborEntry struct {
+	IP  net.IP
+	MAC net.HardwareAddr
+	// LinkName is the name of the interface in the sandbox
+	LinkName string
 }
 
 // Info represents all possible information that
@@ -182,6 +237,16 @@ type Interface interface {
 	// Master returns the srcname of the master interface for this interface.
 	Master() string
 
+	// HostDevice returns true if the interface is an existing host device
+	HostDevice() bool
+
+	// KeepMAC returns true if the MAC address of the interface is left untouched
+	KeepMAC() bool
+
+	// PolicyRouting returns true if the traffic sourced from the interface
+	// addresses is routed through a table of its own
+	PolicyRouting() bool
+
 	// Remove an interface from the sandbox by renaming to original name
 	// and moving it out of the sandbox.
 	Remove() error
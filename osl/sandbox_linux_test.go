@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/libnetwork/ns"
 	"github.com/docker/libnetwork/testutils"
 	"github.com/docker/libnetwork/types"
 	"github.com/vishvananda/netlink"
@@ -407,3 +408,494 @@ func TestLiveRestore(t *testing.T) {
 		t.Fatalf("Expected route conflict error, but succeeded for IPV4 ")
 	}
 }
+
+func TestAddRemoveHostDevice(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	key, err := newKey(t)
+	if err != nil {
+		t.Fatalf("Failed to obtain a key: %v", err)
+	}
+
+	s, err := NewSandbox(key, true, false)
+	if err != nil {
+		t.Fatalf("Failed to create a new sandbox: %v", err)
+	}
+	runtime.LockOSThread()
+	defer s.Destroy()
+
+	n := s.(*networkNamespace)
+	hostMAC, _ := net.ParseMAC("02:42:ac:11:00:10")
+	mac, _ := net.ParseMAC("02:42:ac:11:00:20")
+	ipv4, _ := types.ParseCIDR("172.30.0.33/24")
+	for _, name := range []string{"hostdev0", "hostdev1"} {
+		if err := ns.NlHandle().LinkAdd(&netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: name, HardwareAddr: hostMAC},
+			PeerName:  name + "p",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A host device moved by name, keeping its name and given a MAC address
+	if err := s.AddInterface("hostdev0", "eth",
+		s.InterfaceOptions().HostDevice(true),
+		s.InterfaceOptions().SkipRename(true),
+		s.InterfaceOptions().MacAddress(mac),
+		s.InterfaceOptions().Address(ipv4)); err != nil {
+		t.Fatal(err)
+	}
+	// A host device keeping its MAC address, renamed after the prefix
+	if err := s.AddInterface("hostdev1", "eth",
+		s.InterfaceOptions().HostDevice(true),
+		s.InterfaceOptions().KeepMAC(true),
+		s.InterfaceOptions().MacAddress(mac)); err != nil {
+		t.Fatal(err)
+	}
+
+	link, err := n.nlHandle.LinkByName("hostdev0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Attrs().HardwareAddr.String() != mac.String() {
+		t.Fatalf("Expected MAC %s, got %s", mac, link.Attrs().HardwareAddr)
+	}
+	link, err = n.nlHandle.LinkByName("eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Attrs().HardwareAddr.String() != hostMAC.String() {
+		t.Fatalf("Expected the MAC %s to be kept, got %s", hostMAC, link.Attrs().HardwareAddr)
+	}
+
+	for _, i := range s.Info().Interfaces() {
+		if !i.HostDevice() {
+			t.Fatalf("Expected %s to be a host device", i.SrcName())
+		}
+		if err := i.Remove(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The devices are back on the host with their MAC address
+	for _, name := range []string{"hostdev0", "hostdev1"} {
+		link, err := ns.NlHandle().LinkByName(name)
+		if err != nil {
+			t.Fatalf("Expected %s back on the host: %v", name, err)
+		}
+		if link.Attrs().HardwareAddr.String() != hostMAC.String() {
+			t.Fatalf("Expected %s MAC %s to be restored, got %s", name, hostMAC, link.Attrs().HardwareAddr)
+		}
+	}
+}
+
+func TestPolicyRouting(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	key, err := newKey(t)
+	if err != nil {
+		t.Fatalf("Failed to obtain a key: %v", err)
+	}
+
+	s, err := NewSandbox(key, true, false)
+	if err != nil {
+		t.Fatalf("Failed to create a new sandbox: %v", err)
+	}
+	runtime.LockOSThread()
+	defer s.Destroy()
+
+	n := s.(*networkNamespace)
+	for _, name := range []string{"polA", "polB"} {
+		if err := ns.NlHandle().LinkAdd(&netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: name},
+			PeerName:  name + "p",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	addrA, _ := types.ParseCIDR("172.30.1.10/24")
+	addrB, _ := types.ParseCIDR("172.30.2.10/24")
+	gwB := net.ParseIP("172.30.2.1")
+	if err := s.AddInterface("polA", "eth",
+		s.InterfaceOptions().Address(addrA)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddInterface("polB", "eth",
+		s.InterfaceOptions().Address(addrB),
+		s.InterfaceOptions().PolicyRouting(gwB, nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	link, err := n.nlHandle.LinkByName("eth1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := policyTable(link)
+
+	rules, err := n.nlHandle.RuleList(netlink.FAMILY_V4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found int
+	for _, r := range rules {
+		if r.Table == table {
+			found++
+			if r.Src == nil || r.Src.String() != "172.30.2.10/32" || r.Priority != policyRulePriority {
+				t.Fatalf("Unexpected policy rule %v", r)
+			}
+		}
+	}
+	if found != 1 {
+		t.Fatalf("Expected a single policy rule for table %d, got %d", table, found)
+	}
+
+	routes, err := n.nlHandle.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var connected, deflt bool
+	for _, r := range routes {
+		switch {
+		case r.Dst != nil && r.Dst.String() == "172.30.2.0/24":
+			connected = true
+		case r.Dst == nil && r.Gw.Equal(gwB):
+			deflt = true
+		}
+	}
+	if !connected || !deflt {
+		t.Fatalf("Expected the connected and default routes in table %d, got %v", table, routes)
+	}
+
+	// The rule goes away along with the interface
+	for _, i := range s.Info().Interfaces() {
+		if i.SrcName() != "polB" {
+			continue
+		}
+		if !i.PolicyRouting() {
+			t.Fatal("Expected policy routing on polB")
+		}
+		if err := i.Remove(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rules, err = n.nlHandle.RuleList(netlink.FAMILY_V4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range rules {
+		if r.Table == table {
+			t.Fatalf("Unexpected policy rule left %v", r)
+		}
+	}
+}
+
+func TestReplaceInterface(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	key, err := newKey(t)
+	if err != nil {
+		t.Fatalf("Failed to obtain a key: %v", err)
+	}
+
+	s, err := NewSandbox(key, true, false)
+	if err != nil {
+		t.Fatalf("Failed to create a new sandbox: %v", err)
+	}
+	runtime.LockOSThread()
+	defer s.Destroy()
+
+	n := s.(*networkNamespace)
+	for _, name := range []string{"repA", "repB", "repC"} {
+		if err := ns.NlHandle().LinkAdd(&netlink.Veth{
+			LinkAttrs: netlink.LinkAttrs{Name: name},
+			PeerName:  name + "p",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	addr, _ := types.ParseCIDR("172.30.3.10/24")
+	other, _ := types.ParseCIDR("172.30.4.10/24")
+	if err := s.AddInterface("repA", "eth", s.InterfaceOptions().Address(addr)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddInterface("repC", "eth", s.InterfaceOptions().Address(other)); err != nil {
+		t.Fatal(err)
+	}
+	sboxIface := func(srcName string) Interface {
+		for _, i := range s.Info().Interfaces() {
+			if i.SrcName() == srcName {
+				return i
+			}
+		}
+		t.Fatalf("Interface %s not found in the sandbox", srcName)
+		return nil
+	}
+	checkIface := func(srcName string) {
+		i := sboxIface(srcName)
+		if i.DstName() != "eth0" {
+			t.Fatalf("Expected %s to be eth0, got %s", srcName, i.DstName())
+		}
+		link, err := n.nlHandle.LinkByName("eth0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if link.Attrs().Flags&net.FlagUp == 0 {
+			t.Fatal("Expected eth0 to be up")
+		}
+		addrs, err := n.nlHandle.AddrList(link, netlink.FAMILY_V4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(addrs) != 1 || addrs[0].IPNet.String() != addr.String() {
+			t.Fatalf("Expected address %s on eth0, got %v", addr, addrs)
+		}
+		if _, err := ns.NlHandle().LinkByName(srcName); err == nil {
+			t.Fatalf("Expected %s to be out of the host namespace", srcName)
+		}
+	}
+
+	// A failed replacement gives the sandbox its interface back
+	if err := s.ReplaceInterface(sboxIface("repA"), "repB",
+		s.InterfaceOptions().Address(&net.IPNet{IP: net.ParseIP("172.30.4.20"), Mask: other.Mask})); err == nil {
+		t.Fatal("Expected failure replacing an interface with a conflicting address")
+	}
+	checkIface("repA")
+	if _, err := ns.NlHandle().LinkByName("repB"); err != nil {
+		t.Fatalf("Expected repB to be back in the host namespace: %v", err)
+	}
+
+	if err := s.ReplaceInterface(sboxIface("repA"), "repB", s.InterfaceOptions().Address(addr)); err != nil {
+		t.Fatal(err)
+	}
+	checkIface("repB")
+	if _, err := ns.NlHandle().LinkByName("repA"); err != nil {
+		t.Fatalf("Expected repA to be back in the host namespace: %v", err)
+	}
+	if len(s.Info().Interfaces()) != 2 {
+		t.Fatalf("Expected 2 interfaces in the sandbox, got %d", len(s.Info().Interfaces()))
+	}
+}
+
+func TestRestoreNeighbors(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	key, err := newKey(t)
+	if err != nil {
+		t.Fatalf("Failed to obtain a key: %v", err)
+	}
+
+	s, err := NewSandbox(key, true, false)
+	if err != nil {
+		t.Fatalf("Failed to create a new sandbox: %v", err)
+	}
+	runtime.LockOSThread()
+	defer s.Destroy()
+
+	if err := ns.NlHandle().LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: "nghA"},
+		PeerName:  "nghAp",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	addr, _ := types.ParseCIDR("172.30.5.10/24")
+	if err := s.AddInterface("nghA", "eth", s.InterfaceOptions().Address(addr)); err != nil {
+		t.Fatal(err)
+	}
+
+	mac, _ := net.ParseMAC("02:42:ac:1e:05:01")
+	entries := []NeighborEntry{
+		{IP: net.ParseIP("172.30.5.1"), MAC: mac, LinkName: "eth0"},
+		// Entries of interfaces gone from the sandbox are skipped
+		{IP: net.ParseIP("172.30.6.1"), MAC: mac, LinkName: "eth5"},
+	}
+	if err := s.RestoreNeighbors(entries); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.Neighbors()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !got[0].IP.Equal(entries[0].IP) || got[0].MAC.String() != mac.String() || got[0].LinkName != "eth0" {
+		t.Fatalf("Unexpected neighbor entries %v", got)
+	}
+}
+
+func TestMirrorInterface(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	key, err := newKey(t)
+	if err != nil {
+		t.Fatalf("Failed to obtain a key: %v", err)
+	}
+
+	s, err := NewSandbox(key, true, false)
+	if err != nil {
+		t.Fatalf("Failed to create a new sandbox: %v", err)
+	}
+	runtime.LockOSThread()
+	defer s.Destroy()
+
+	nlh := ns.NlHandle()
+	if err := nlh.LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: "mirA"},
+		PeerName:  "mirAp",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer nlh.LinkDel(&netlink.Veth{LinkAttrs: netlink.LinkAttrs{Name: "mirAp"}})
+	if err := s.AddInterface("mirA", "eth"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.MirrorInterface("eth0", "mirtest"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.MirrorInterface("eth0", "mirtest2"); err == nil {
+		t.Fatal("Expected failure mirroring an interface twice")
+	}
+	if _, err := nlh.LinkByName("mirtest"); err != nil {
+		t.Fatalf("Mirror link not found on the host: %v", err)
+	}
+
+	sbNs := s.(*networkNamespace)
+	iface, err := sbNs.nlHandle.LinkByName("eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mirrorFilters := func() int {
+		var count int
+		for _, parent := range []uint32{netlink.HANDLE_MIN_INGRESS, netlink.HANDLE_MIN_EGRESS} {
+			filters, err := sbNs.nlHandle.FilterList(iface, parent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range filters {
+				if f.Attrs().Priority == mirrorFilterPrio {
+					count++
+				}
+			}
+		}
+		return count
+	}
+	if mirrorFilters() == 0 {
+		t.Fatal("Mirror filters not found on the sandbox interface")
+	}
+
+	if err := s.UnmirrorInterface("eth0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nlh.LinkByName("mirtest"); err == nil {
+		t.Fatal("Mirror link still present on the host")
+	}
+	if n := mirrorFilters(); n != 0 {
+		t.Fatalf("%d mirror filters left on the sandbox interface", n)
+	}
+	if err := s.UnmirrorInterface("eth0"); err == nil {
+		t.Fatal("Expected failure unmirroring an interface not mirrored")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	key, err := newKey(t)
+	if err != nil {
+		t.Fatalf("Failed to obtain a key: %v", err)
+	}
+
+	s, err := NewSandbox(key, true, false)
+	if err != nil {
+		t.Fatalf("Failed to create a new sandbox: %v", err)
+	}
+	runtime.LockOSThread()
+	defer s.Destroy()
+
+	n := s.(*networkNamespace)
+	if err := ns.NlHandle().LinkAdd(&netlink.Veth{
+		LinkAttrs: netlink.LinkAttrs{Name: "recA"},
+		PeerName:  "recAp",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	addr, _ := types.ParseCIDR("172.31.1.10/24")
+	llAddr, _ := types.ParseCIDR("169.254.10.10/16")
+	ifRoute, _ := types.ParseCIDR("172.31.9.0/24")
+	gw := net.ParseIP("172.31.1.1")
+	if err := s.AddInterface("recA", "eth",
+		s.InterfaceOptions().Address(addr),
+		s.InterfaceOptions().LinkLocalAddresses([]*net.IPNet{llAddr}),
+		s.InterfaceOptions().Routes([]*net.IPNet{ifRoute})); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetGateway(gw); err != nil {
+		t.Fatal(err)
+	}
+	staticDst, _ := types.ParseCIDR("172.31.5.0/24")
+	if err := s.AddStaticRoute(&types.StaticRoute{Destination: staticDst, NextHop: net.ParseIP("172.31.1.254")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if drifts, err := s.Reconcile(true); err != nil || len(drifts) != 0 {
+		t.Fatalf("Expected no drift on a fresh sandbox, got %v (err: %v)", drifts, err)
+	}
+
+	// Drift away from the configured state
+	nlh := n.nlHandle
+	link, err := nlh.LinkByName("eth0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nlh.AddrDel(link, &netlink.Addr{IPNet: llAddr}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nlh.RouteDel(&netlink.Route{LinkIndex: link.Attrs().Index, Scope: netlink.SCOPE_LINK, Dst: ifRoute}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nlh.RouteDel(&netlink.Route{LinkIndex: link.Attrs().Index, Dst: staticDst, Gw: net.ParseIP("172.31.1.254")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nlh.RouteDel(&netlink.Route{LinkIndex: link.Attrs().Index, Gw: gw}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nlh.RouteAdd(&netlink.Route{LinkIndex: link.Attrs().Index, Gw: net.ParseIP("172.31.1.2")}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]types.DriftKind{
+		"address 169.254.10.10/16 on eth0":     types.DriftMissing,
+		"route 172.31.9.0/24 dev eth0":         types.DriftMissing,
+		"route 172.31.5.0/24 via 172.31.1.254": types.DriftMissing,
+		"default route via 172.31.1.1":         types.DriftMissing,
+		"default route via 172.31.1.2":         types.DriftOrphan,
+	}
+	check := func(dryRun bool) {
+		drifts, err := s.Reconcile(dryRun)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(drifts) != len(expected) {
+			t.Fatalf("Expected %d drifts, got %v", len(expected), drifts)
+		}
+		for _, d := range drifts {
+			kind, ok := expected[d.Object+" "+d.Name]
+			if !ok || kind != d.Kind {
+				t.Fatalf("Unexpected drift %s", d)
+			}
+			if d.Repaired == dryRun {
+				t.Fatalf("Unexpected repair state for %s in dry run %v", d, dryRun)
+			}
+		}
+	}
+
+	// A dry run leaves the drift in place
+	check(true)
+	check(false)
+
+	if drifts, err := s.Reconcile(true); err != nil || len(drifts) != 0 {
+		t.Fatalf("Expected no drift left after the repair, got %v (err: %v)", drifts, err)
+	}
+}
@@ -151,21 +151,7 @@ func (p *PortAllocator) RequestPortInRange(ip net.IP, proto string, portStart, p
 		return 0, ErrUnknownProtocol
 	}
 
-	if ip == nil {
-		ip = defaultIP
-	}
-	ipstr := ip.String()
-	protomap, ok := p.ipMap[ipstr]
-	if !ok {
-		protomap = protoMap{
-			"tcp":  p.newPortMap(),
-			"udp":  p.newPortMap(),
-			"sctp": p.newPortMap(),
-		}
-
-		p.ipMap[ipstr] = protomap
-	}
-	mapping := protomap[proto]
+	ipstr, mapping := p.getPortMap(ip, proto)
 	if portStart > 0 && portStart == portEnd {
 		if _, ok := mapping.p[portStart]; !ok {
 			mapping.p[portStart] = struct{}{}
@@ -181,6 +167,35 @@ func (p *PortAllocator) RequestPortInRange(ip net.IP, proto string, portStart, p
 	return port, nil
 }
 
+// RequestPortBlock requests a block of size contiguous ports from global ports pool
+// for specified ip and proto, and returns the first port of the block.
+// If portStart and portEnd are 0 the block is searched in the default ephemeral range.
+// If the requested range is as large as the block, it checks the availability of all
+// the ports in the range. Otherwise the block is searched in the requested range.
+// Either all the ports of the block are allocated or none is.
+func (p *PortAllocator) RequestPortBlock(ip net.IP, proto string, portStart, portEnd, size int) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if proto != "tcp" && proto != "udp" && proto != "sctp" {
+		return 0, ErrUnknownProtocol
+	}
+	if size < 1 {
+		return 0, fmt.Errorf("invalid port block size: %d", size)
+	}
+
+	ipstr, mapping := p.getPortMap(ip, proto)
+	if portStart > 0 && portEnd-portStart+1 == size {
+		if used := mapping.firstUsed(portStart, size); used != 0 {
+			return 0, newErrPortAlreadyAllocated(ipstr, used)
+		}
+		mapping.allocate(portStart, size)
+		return portStart, nil
+	}
+
+	return mapping.findBlock(portStart, portEnd, size)
+}
+
 // ReleasePort releases port from global ports pool for specified ip and proto.
 func (p *PortAllocator) ReleasePort(ip net.IP, proto string, port int) error {
 	p.mutex.Lock()
@@ -197,6 +212,25 @@ func (p *PortAllocator) ReleasePort(ip net.IP, proto string, port int) error {
 	return nil
 }
 
+// ReleasePortBlock releases the block of size contiguous ports starting at port
+// from global ports pool for specified ip and proto.
+func (p *PortAllocator) ReleasePortBlock(ip net.IP, proto string, port, size int) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if ip == nil {
+		ip = defaultIP
+	}
+	protomap, ok := p.ipMap[ip.String()]
+	if !ok {
+		return nil
+	}
+	for i := 0; i < size; i++ {
+		delete(protomap[proto].p, port+i)
+	}
+	return nil
+}
+
 // SetPortRange sets dynamic port allocation range.
 // if both portBegin and portEnd are 0, the port range reverts to default
 // value. Otherwise they are sanitized against the default values to
@@ -226,6 +260,26 @@ func (p *PortAllocator) SetPortRange(portBegin, portEnd int) error {
 	return nil
 }
 
+// getPortMap returns the ports pool for specified ip and proto, creating it
+// if needed. Must be called with the allocator lock held.
+func (p *PortAllocator) getPortMap(ip net.IP, proto string) (string, *portMap) {
+	if ip == nil {
+		ip = defaultIP
+	}
+	ipstr := ip.String()
+	protomap, ok := p.ipMap[ipstr]
+	if !ok {
+		protomap = protoMap{
+			"tcp":  p.newPortMap(),
+			"udp":  p.newPortMap(),
+			"sctp": p.newPortMap(),
+		}
+
+		p.ipMap[ipstr] = protomap
+	}
+	return ipstr, protomap[proto]
+}
+
 func (p *PortAllocator) newPortMap() *portMap {
 	defaultKey := getRangeKey(p.Begin, p.End)
 	pm := &portMap{
@@ -303,3 +357,44 @@ func (pm *portMap) findPort(portStart, portEnd int) (int, error) {
 	}
 	return 0, ErrAllPortsAllocated
 }
+
+// findBlock looks for size contiguous free ports in the requested range,
+// starting after the last allocated block.
+func (pm *portMap) findBlock(portStart, portEnd, size int) (int, error) {
+	pr, err := pm.getPortRange(portStart, portEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	start := pr.last + 1
+	for i := 0; i <= pr.end-pr.begin+1-size; i++ {
+		if start+size-1 > pr.end {
+			start = pr.begin
+		}
+		used := pm.firstUsed(start, size)
+		if used == 0 {
+			pm.allocate(start, size)
+			pr.last = start + size - 1
+			return start, nil
+		}
+		start++
+	}
+	return 0, ErrAllPortsAllocated
+}
+
+// firstUsed returns the first allocated port of the block of size ports
+// starting at port, or 0 if all the ports are free
+func (pm *portMap) firstUsed(port, size int) int {
+	for i := port; i < port+size; i++ {
+		if _, ok := pm.p[i]; ok {
+			return i
+		}
+	}
+	return 0
+}
+
+func (pm *portMap) allocate(port, size int) {
+	for i := port; i < port+size; i++ {
+		pm.p[i] = struct{}{}
+	}
+}
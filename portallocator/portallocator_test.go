@@ -366,3 +366,81 @@ func TestChangePortRange(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestPortBlock(t *testing.T) {
+	p := Get()
+	defer resetPortAllocator()
+
+	for _, proto := range []string{"tcp", "udp", "sctp"} {
+		port, err := p.RequestPortBlock(defaultIP, proto, 8000, 8099, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if port != 8000 {
+			t.Fatalf("Expected port 8000 got %d", port)
+		}
+
+		// Overlapping block fails without allocating any port
+		if _, err := p.RequestPortBlock(defaultIP, proto, 8099, 8100, 2); err == nil {
+			t.Fatalf("Expected %s block allocation to fail", proto)
+		} else if _, ok := err.(ErrPortAlreadyAllocated); !ok {
+			t.Fatalf("Expected port allocation error got %s", err)
+		}
+		if _, err := p.RequestPort(defaultIP, proto, 8100); err != nil {
+			t.Fatalf("Port 8100 should have been left free: %v", err)
+		}
+
+		if err := p.ReleasePortBlock(defaultIP, proto, 8000, 100); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.RequestPortBlock(defaultIP, proto, 8000, 8099, 100); err != nil {
+			t.Fatalf("Block should have been released: %v", err)
+		}
+	}
+}
+
+func TestRequestPortBlockInRange(t *testing.T) {
+	p := Get()
+	defer resetPortAllocator()
+
+	if _, err := p.RequestPort(defaultIP, "tcp", 9002); err != nil {
+		t.Fatal(err)
+	}
+
+	port, err := p.RequestPortBlock(defaultIP, "tcp", 9000, 9009, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port != 9003 {
+		t.Fatalf("Expected port 9003 got %d", port)
+	}
+
+	port, err = p.RequestPortBlock(defaultIP, "tcp", 9000, 9009, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port != 9006 {
+		t.Fatalf("Expected port 9006 got %d", port)
+	}
+
+	// Only 9000-9001 are left, the search wraps around the range
+	port, err = p.RequestPortBlock(defaultIP, "tcp", 9000, 9009, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port != 9000 {
+		t.Fatalf("Expected port 9000 got %d", port)
+	}
+
+	if _, err := p.RequestPortBlock(defaultIP, "tcp", 9000, 9009, 1); err != ErrAllPortsAllocated {
+		t.Fatalf("Expected error %s got %v", ErrAllPortsAllocated, err)
+	}
+
+	port, err = p.RequestPortBlock(defaultIP, "udp", 0, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if port != p.Begin {
+		t.Fatalf("Expected port %d got %d", p.Begin, port)
+	}
+}
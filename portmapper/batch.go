@@ -0,0 +1,51 @@
+package portmapper
+
+import (
+	"net"
+)
+
+// forwardOp is a change of the forwarding table of a port mapping
+type forwardOp struct {
+	add           bool
+	proto         string
+	sourceIP      net.IP
+	sourcePort    int
+	containerIP   string
+	containerPort int
+	size          int
+}
+
+// Batch defers the forwarding table changes of the port mappings made and
+// removed through it, for Apply to program them at once. It is meant for
+// the port bindings of an endpoint, whose rules then cost a single update
+// of the forwarding table.
+type Batch struct {
+	pm  *PortMapper
+	ops []forwardOp
+}
+
+// NewBatch returns an empty batch of port mapping changes
+func (pm *PortMapper) NewBatch() *Batch {
+	return &Batch{pm: pm}
+}
+
+// MapPortRange maps the ports as PortMapper.MapPortRange does, except that
+// the forwarding rules are only programmed by Apply
+func (b *Batch) MapPortRange(container net.Addr, size int, hostIP net.IP, hostPortStart, hostPortEnd int, useProxy bool, proxyProtocol int) (net.Addr, error) {
+	return b.pm.mapPortRange(b, container, size, hostIP, hostPortStart, hostPortEnd, useProxy, proxyProtocol)
+}
+
+// Unmap removes the mapping as PortMapper.Unmap does, except that the
+// forwarding rules are only removed by Apply
+func (b *Batch) Unmap(host net.Addr) error {
+	return b.pm.unmap(b, host)
+}
+
+// Apply programs the forwarding table changes of the batch and empties it.
+// On failure, the mappings made through the batch are left in place for the
+// caller to unmap them.
+func (b *Batch) Apply() error {
+	ops := b.ops
+	b.ops = nil
+	return b.pm.applyForwards(ops)
+}
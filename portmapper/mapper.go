@@ -15,6 +15,10 @@ type mapping struct {
 	userlandProxy userlandProxy
 	host          net.Addr
 	container     net.Addr
+	// size is the number of consecutive ports mapped
+	size int
+	// proxyOnly is set when the traffic is not forwarded by iptables
+	proxyOnly bool
 }
 
 var newProxy = newProxyCommand
@@ -28,6 +32,8 @@ var (
 	ErrPortNotMapped = errors.New("port is not mapped")
 	// ErrSCTPAddrNoIP refers to a SCTP address without IP address.
 	ErrSCTPAddrNoIP = errors.New("sctp address does not contain any IP address")
+	// ErrProxyProtocol refers to a PROXY protocol request on a mapping not going through a tcp userland proxy
+	ErrProxyProtocol = errors.New("PROXY protocol requires a tcp mapping through the userland proxy")
 )
 
 // New returns a new instance of PortMapper
@@ -44,131 +50,125 @@ func NewWithPortAllocator(allocator *portallocator.PortAllocator, proxyPath stri
 	}
 }
 
-// Map maps the specified container transport address to the host's network address and transport port
-func (pm *PortMapper) Map(container net.Addr, hostIP net.IP, hostPort int, useProxy bool) (host net.Addr, err error) {
-	return pm.MapRange(container, hostIP, hostPort, hostPort, useProxy)
+// SetProxyManager sets the manager running the userland proxies of the port
+// mapper in process. When unset, a docker-proxy process is run per mapped port.
+func (pm *PortMapper) SetProxyManager(m *ProxyManager) {
+	pm.lock.Lock()
+	pm.proxyManager = m
+	pm.lock.Unlock()
+}
+
+// Map maps the specified container transport address to the host's network address and transport port.
+// A non zero proxyProtocol is the PROXY protocol version of the userland proxy, as for MapPortRange.
+func (pm *PortMapper) Map(container net.Addr, hostIP net.IP, hostPort int, useProxy bool, proxyProtocol int) (host net.Addr, err error) {
+	return pm.MapRange(container, hostIP, hostPort, hostPort, useProxy, proxyProtocol)
 }
 
 // MapRange maps the specified container transport address to the host's network address and transport port range
-func (pm *PortMapper) MapRange(container net.Addr, hostIP net.IP, hostPortStart, hostPortEnd int, useProxy bool) (host net.Addr, err error) {
+func (pm *PortMapper) MapRange(container net.Addr, hostIP net.IP, hostPortStart, hostPortEnd int, useProxy bool, proxyProtocol int) (host net.Addr, err error) {
+	return pm.MapPortRange(container, 1, hostIP, hostPortStart, hostPortEnd, useProxy, proxyProtocol)
+}
+
+// MapPortRange maps size consecutive container transport ports, starting at the specified
+// container transport address, to a contiguous block of host transport ports in the host's
+// port range. The block is allocated atomically: either all the ports are mapped or none is.
+// It returns the host address of the first port of the block.
+// A non zero proxyProtocol makes the userland proxy prepend a PROXY protocol header
+// of that version to the container connections, so that the container gets to know
+// the client address. The traffic is then not forwarded to the container by iptables,
+// so that it all goes through the userland proxy.
+func (pm *PortMapper) MapPortRange(container net.Addr, size int, hostIP net.IP, hostPortStart, hostPortEnd int, useProxy bool, proxyProtocol int) (host net.Addr, err error) {
+	return pm.mapPortRange(nil, container, size, hostIP, hostPortStart, hostPortEnd, useProxy, proxyProtocol)
+}
+
+// mapPortRange maps the ports, the forwarding rules being deferred to the
+// batch when one is passed
+func (pm *PortMapper) mapPortRange(b *Batch, container net.Addr, size int, hostIP net.IP, hostPortStart, hostPortEnd int, useProxy bool, proxyProtocol int) (host net.Addr, err error) {
 	pm.lock.Lock()
 	defer pm.lock.Unlock()
 
-	var (
-		m                 *mapping
-		proto             string
-		allocatedHostPort int
-	)
-
-	switch container.(type) {
+	var proto string
+	switch a := container.(type) {
 	case *net.TCPAddr:
 		proto = "tcp"
-		if allocatedHostPort, err = pm.Allocator.RequestPortInRange(hostIP, proto, hostPortStart, hostPortEnd); err != nil {
-			return nil, err
-		}
-
-		m = &mapping{
-			proto:     proto,
-			host:      &net.TCPAddr{IP: hostIP, Port: allocatedHostPort},
-			container: container,
-		}
-
-		if useProxy {
-			m.userlandProxy, err = newProxy(proto, hostIP, allocatedHostPort, container.(*net.TCPAddr).IP, container.(*net.TCPAddr).Port, pm.proxyPath)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			m.userlandProxy, err = newDummyProxy(proto, hostIP, allocatedHostPort)
-			if err != nil {
-				return nil, err
-			}
-		}
 	case *net.UDPAddr:
 		proto = "udp"
-		if allocatedHostPort, err = pm.Allocator.RequestPortInRange(hostIP, proto, hostPortStart, hostPortEnd); err != nil {
-			return nil, err
-		}
-
-		m = &mapping{
-			proto:     proto,
-			host:      &net.UDPAddr{IP: hostIP, Port: allocatedHostPort},
-			container: container,
-		}
-
-		if useProxy {
-			m.userlandProxy, err = newProxy(proto, hostIP, allocatedHostPort, container.(*net.UDPAddr).IP, container.(*net.UDPAddr).Port, pm.proxyPath)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			m.userlandProxy, err = newDummyProxy(proto, hostIP, allocatedHostPort)
-			if err != nil {
-				return nil, err
-			}
-		}
 	case *sctp.SCTPAddr:
 		proto = "sctp"
-		if allocatedHostPort, err = pm.Allocator.RequestPortInRange(hostIP, proto, hostPortStart, hostPortEnd); err != nil {
-			return nil, err
-		}
-
-		m = &mapping{
-			proto:     proto,
-			host:      &sctp.SCTPAddr{IPAddrs: []net.IPAddr{{IP: hostIP}}, Port: allocatedHostPort},
-			container: container,
-		}
-
-		if useProxy {
-			sctpAddr := container.(*sctp.SCTPAddr)
-			if len(sctpAddr.IPAddrs) == 0 {
-				return nil, ErrSCTPAddrNoIP
-			}
-			m.userlandProxy, err = newProxy(proto, hostIP, allocatedHostPort, sctpAddr.IPAddrs[0].IP, sctpAddr.Port, pm.proxyPath)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			m.userlandProxy, err = newDummyProxy(proto, hostIP, allocatedHostPort)
-			if err != nil {
-				return nil, err
-			}
+		if useProxy && len(a.IPAddrs) == 0 {
+			return nil, ErrSCTPAddrNoIP
 		}
 	default:
 		return nil, ErrUnknownBackendAddressType
 	}
+	if proxyProtocol != 0 && (proto != "tcp" || !useProxy) {
+		return nil, ErrProxyProtocol
+	}
 
-	// release the allocated port on any further error during return.
+	allocatedHostPort, err := pm.Allocator.RequestPortBlock(hostIP, proto, hostPortStart, hostPortEnd, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// release the allocated ports on any further error during return.
 	defer func() {
 		if err != nil {
-			pm.Allocator.ReleasePort(hostIP, proto, allocatedHostPort)
+			pm.Allocator.ReleasePortBlock(hostIP, proto, allocatedHostPort, size)
 		}
 	}()
 
+	m := &mapping{
+		proto:     proto,
+		host:      newAddr(proto, hostIP, allocatedHostPort),
+		container: container,
+		size:      size,
+		proxyOnly: proxyProtocol != 0,
+	}
+
 	key := getKey(m.host)
 	if _, exists := pm.currentMappings[key]; exists {
 		return nil, ErrPortMappedForIP
 	}
 
 	containerIP, containerPort := getIPAndPort(m.container)
-	if err := pm.AppendForwardingTableEntry(m.proto, hostIP, allocatedHostPort, containerIP.String(), containerPort); err != nil {
-		return nil, err
+	proxies := make(proxyGroup, 0, size)
+	for i := 0; i < size; i++ {
+		var p userlandProxy
+		if useProxy && pm.proxyManager != nil {
+			p, err = pm.proxyManager.newProxy(proto, hostIP, allocatedHostPort+i, containerIP, containerPort+i, proxyProtocol)
+		} else if useProxy {
+			p, err = newProxy(proto, hostIP, allocatedHostPort+i, containerIP, containerPort+i, proxyProtocol, pm.proxyPath)
+		} else {
+			p, err = newDummyProxy(proto, hostIP, allocatedHostPort+i)
+		}
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, p)
+	}
+	m.userlandProxy = proxies
+	if size == 1 {
+		m.userlandProxy = proxies[0]
 	}
 
-	cleanup := func() error {
-		// need to undo the iptables rules before we return
-		m.userlandProxy.Stop()
-		pm.DeleteForwardingTableEntry(m.proto, hostIP, allocatedHostPort, containerIP.String(), containerPort)
-		if err := pm.Allocator.ReleasePort(hostIP, m.proto, allocatedHostPort); err != nil {
-			return err
+	if !m.proxyOnly {
+		if b != nil {
+			b.ops = append(b.ops, forwardOp{add: true, proto: m.proto, sourceIP: hostIP, sourcePort: allocatedHostPort,
+				containerIP: containerIP.String(), containerPort: containerPort, size: size})
+		} else if err := pm.AppendForwardingTableRange(m.proto, hostIP, allocatedHostPort, containerIP.String(), containerPort, size); err != nil {
+			return nil, err
 		}
-
-		return nil
 	}
 
 	if err := m.userlandProxy.Start(); err != nil {
-		if err := cleanup(); err != nil {
-			return nil, fmt.Errorf("Error during port allocation cleanup: %v", err)
+		// need to undo the iptables rules before we return
+		m.userlandProxy.Stop()
+		if !m.proxyOnly {
+			if b != nil {
+				b.ops = b.ops[:len(b.ops)-1]
+			} else {
+				pm.DeleteForwardingTableRange(m.proto, hostIP, allocatedHostPort, containerIP.String(), containerPort, size)
+			}
 		}
 		return nil, err
 	}
@@ -177,8 +177,16 @@ func (pm *PortMapper) MapRange(container net.Addr, hostIP net.IP, hostPortStart,
 	return m.host, nil
 }
 
-// Unmap removes stored mapping for the specified host transport address
+// Unmap removes stored mapping for the specified host transport address.
+// For a port range mapping, the address of the first port of the range
+// must be passed and the whole range is unmapped.
 func (pm *PortMapper) Unmap(host net.Addr) error {
+	return pm.unmap(nil, host)
+}
+
+// unmap removes the mapping, the removal of the forwarding rules being
+// deferred to the batch when one is passed
+func (pm *PortMapper) unmap(b *Batch, host net.Addr) error {
 	pm.lock.Lock()
 	defer pm.lock.Unlock()
 
@@ -196,20 +204,25 @@ func (pm *PortMapper) Unmap(host net.Addr) error {
 
 	containerIP, containerPort := getIPAndPort(data.container)
 	hostIP, hostPort := getIPAndPort(data.host)
-	if err := pm.DeleteForwardingTableEntry(data.proto, hostIP, hostPort, containerIP.String(), containerPort); err != nil {
-		logrus.Errorf("Error on iptables delete: %s", err)
+	if !data.proxyOnly {
+		if b != nil {
+			b.ops = append(b.ops, forwardOp{proto: data.proto, sourceIP: hostIP, sourcePort: hostPort,
+				containerIP: containerIP.String(), containerPort: containerPort, size: data.size})
+		} else if err := pm.DeleteForwardingTableRange(data.proto, hostIP, hostPort, containerIP.String(), containerPort, data.size); err != nil {
+			logrus.Errorf("Error on iptables delete: %s", err)
+		}
 	}
 
 	switch a := host.(type) {
 	case *net.TCPAddr:
-		return pm.Allocator.ReleasePort(a.IP, "tcp", a.Port)
+		return pm.Allocator.ReleasePortBlock(a.IP, "tcp", a.Port, data.size)
 	case *net.UDPAddr:
-		return pm.Allocator.ReleasePort(a.IP, "udp", a.Port)
+		return pm.Allocator.ReleasePortBlock(a.IP, "udp", a.Port, data.size)
 	case *sctp.SCTPAddr:
 		if len(a.IPAddrs) == 0 {
 			return ErrSCTPAddrNoIP
 		}
-		return pm.Allocator.ReleasePort(a.IPAddrs[0].IP, "sctp", a.Port)
+		return pm.Allocator.ReleasePortBlock(a.IPAddrs[0].IP, "sctp", a.Port, data.size)
 	}
 	return ErrUnknownBackendAddressType
 }
@@ -219,15 +232,37 @@ func (pm *PortMapper) ReMapAll() {
 	pm.lock.Lock()
 	defer pm.lock.Unlock()
 	logrus.Debugln("Re-applying all port mappings.")
+	var ops []forwardOp
 	for _, data := range pm.currentMappings {
+		if data.proxyOnly {
+			continue
+		}
 		containerIP, containerPort := getIPAndPort(data.container)
 		hostIP, hostPort := getIPAndPort(data.host)
-		if err := pm.AppendForwardingTableEntry(data.proto, hostIP, hostPort, containerIP.String(), containerPort); err != nil {
+		ops = append(ops, forwardOp{add: true, proto: data.proto, sourceIP: hostIP, sourcePort: hostPort,
+			containerIP: containerIP.String(), containerPort: containerPort, size: data.size})
+	}
+	if err := pm.applyForwards(ops); err == nil {
+		return
+	}
+	// Apply them one by one, for a failing mapping not to hold the others back
+	for _, op := range ops {
+		if err := pm.applyForwards([]forwardOp{op}); err != nil {
 			logrus.Errorf("Error on iptables add: %s", err)
 		}
 	}
 }
 
+func newAddr(proto string, ip net.IP, port int) net.Addr {
+	switch proto {
+	case "udp":
+		return &net.UDPAddr{IP: ip, Port: port}
+	case "sctp":
+		return &sctp.SCTPAddr{IPAddrs: []net.IPAddr{{IP: ip}}, Port: port}
+	}
+	return &net.TCPAddr{IP: ip, Port: port}
+}
+
 func getKey(a net.Addr) string {
 	switch t := a.(type) {
 	case *net.TCPAddr:
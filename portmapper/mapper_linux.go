@@ -2,6 +2,7 @@ package portmapper
 
 import (
 	"net"
+	"sort"
 	"sync"
 
 	"github.com/docker/libnetwork/iptables"
@@ -17,6 +18,8 @@ type PortMapper struct {
 	lock            sync.Mutex
 
 	proxyPath string
+	// proxyManager runs the userland proxies in process when set
+	proxyManager *ProxyManager
 
 	Allocator *portallocator.PortAllocator
 	chain     *iptables.ChainInfo
@@ -30,17 +33,99 @@ func (pm *PortMapper) SetIptablesChain(c *iptables.ChainInfo, bridgeName string)
 
 // AppendForwardingTableEntry adds a port mapping to the forwarding table
 func (pm *PortMapper) AppendForwardingTableEntry(proto string, sourceIP net.IP, sourcePort int, containerIP string, containerPort int) error {
-	return pm.forward(iptables.Append, proto, sourceIP, sourcePort, containerIP, containerPort)
+	return pm.forward(iptables.Append, proto, sourceIP, sourcePort, containerIP, containerPort, 1)
 }
 
 // DeleteForwardingTableEntry removes a port mapping from the forwarding table
 func (pm *PortMapper) DeleteForwardingTableEntry(proto string, sourceIP net.IP, sourcePort int, containerIP string, containerPort int) error {
-	return pm.forward(iptables.Delete, proto, sourceIP, sourcePort, containerIP, containerPort)
+	return pm.forward(iptables.Delete, proto, sourceIP, sourcePort, containerIP, containerPort, 1)
 }
 
-func (pm *PortMapper) forward(action iptables.Action, proto string, sourceIP net.IP, sourcePort int, containerIP string, containerPort int) error {
+// AppendForwardingTableRange adds a port range mapping to the forwarding table
+func (pm *PortMapper) AppendForwardingTableRange(proto string, sourceIP net.IP, sourcePort int, containerIP string, containerPort, size int) error {
+	return pm.forward(iptables.Append, proto, sourceIP, sourcePort, containerIP, containerPort, size)
+}
+
+// DeleteForwardingTableRange removes a port range mapping from the forwarding table
+func (pm *PortMapper) DeleteForwardingTableRange(proto string, sourceIP net.IP, sourcePort int, containerIP string, containerPort, size int) error {
+	return pm.forward(iptables.Delete, proto, sourceIP, sourcePort, containerIP, containerPort, size)
+}
+
+func (pm *PortMapper) forward(action iptables.Action, proto string, sourceIP net.IP, sourcePort int, containerIP string, containerPort, size int) error {
+	op := forwardOp{add: action == iptables.Append, proto: proto, sourceIP: sourceIP, sourcePort: sourcePort,
+		containerIP: containerIP, containerPort: containerPort, size: size}
+	err := pm.applyForwards([]forwardOp{op})
+	if err != nil && op.add {
+		// Remove the rules which made it
+		op.add = false
+		pm.applyForwards([]forwardOp{op})
+	}
+	return err
+}
+
+// applyForwards programs the forwarding table changes with a single
+// iptables batch
+func (pm *PortMapper) applyForwards(ops []forwardOp) error {
+	if pm.chain == nil || len(ops) == 0 {
+		return nil
+	}
+	b := iptables.GetIptable(pm.chain.IPTable.Version).NewBatch()
+	for _, op := range ops {
+		action := iptables.Delete
+		if op.add {
+			action = iptables.Append
+		}
+		if op.size <= 1 || op.sourcePort == op.containerPort {
+			if err := pm.chain.AddForwardRange(b, action, op.sourceIP, op.sourcePort, op.proto, op.containerIP, op.containerPort, op.size, pm.bridgeName); err != nil {
+				return err
+			}
+			continue
+		}
+		// The DNAT target cannot shift a port range, program one rule per port
+		for i := 0; i < op.size; i++ {
+			if err := pm.chain.AddForwardRange(b, action, op.sourceIP, op.sourcePort+i, op.proto, op.containerIP, op.containerPort+i, 1, pm.bridgeName); err != nil {
+				return err
+			}
+		}
+	}
+	return b.Apply()
+}
+
+// MissingMappings returns the host addresses of the port mappings whose
+// forwarding rules are missing from the iptables chains, as after a firewall
+// flush. ReMapAll programs them back.
+func (pm *PortMapper) MissingMappings() []string {
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+
 	if pm.chain == nil {
 		return nil
 	}
-	return pm.chain.Forward(action, sourceIP, sourcePort, proto, containerIP, containerPort, pm.bridgeName)
+	iptable := iptables.GetIptable(pm.chain.IPTable.Version)
+
+	var missing []string
+	for key, data := range pm.currentMappings {
+		if data.proxyOnly {
+			continue
+		}
+		containerIP, containerPort := getIPAndPort(data.container)
+		hostIP, hostPort := getIPAndPort(data.host)
+
+		var rules []iptables.Rule
+		if data.size <= 1 || hostPort == containerPort {
+			rules = pm.chain.ForwardRules(hostIP, hostPort, data.proto, containerIP.String(), containerPort, data.size, pm.bridgeName)
+		} else {
+			for i := 0; i < data.size; i++ {
+				rules = append(rules, pm.chain.ForwardRules(hostIP, hostPort+i, data.proto, containerIP.String(), containerPort+i, 1, pm.bridgeName)...)
+			}
+		}
+		for _, r := range rules {
+			if !iptable.Exists(r.Table, r.Chain, r.Args...) {
+				missing = append(missing, key)
+				break
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
 }
@@ -1,12 +1,15 @@
 package portmapper
 
 import (
+	"io"
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/libnetwork/iptables"
 	_ "github.com/docker/libnetwork/testutils"
+	"github.com/ishidawataru/sctp"
 )
 
 func init() {
@@ -45,22 +48,22 @@ func TestMapTCPPorts(t *testing.T) {
 		return (addr1.Network() == addr2.Network()) && (addr1.String() == addr2.String())
 	}
 
-	if host, err := pm.Map(srcAddr1, dstIP1, 80, true); err != nil {
+	if host, err := pm.Map(srcAddr1, dstIP1, 80, true, 0); err != nil {
 		t.Fatalf("Failed to allocate port: %s", err)
 	} else if !addrEqual(dstAddr1, host) {
 		t.Fatalf("Incorrect mapping result: expected %s:%s, got %s:%s",
 			dstAddr1.String(), dstAddr1.Network(), host.String(), host.Network())
 	}
 
-	if _, err := pm.Map(srcAddr1, dstIP1, 80, true); err == nil {
+	if _, err := pm.Map(srcAddr1, dstIP1, 80, true, 0); err == nil {
 		t.Fatalf("Port is in use - mapping should have failed")
 	}
 
-	if _, err := pm.Map(srcAddr2, dstIP1, 80, true); err == nil {
+	if _, err := pm.Map(srcAddr2, dstIP1, 80, true, 0); err == nil {
 		t.Fatalf("Port is in use - mapping should have failed")
 	}
 
-	if _, err := pm.Map(srcAddr2, dstIP2, 80, true); err != nil {
+	if _, err := pm.Map(srcAddr2, dstIP2, 80, true, 0); err != nil {
 		t.Fatalf("Failed to allocate port: %s", err)
 	}
 
@@ -124,22 +127,22 @@ func TestMapUDPPorts(t *testing.T) {
 		return (addr1.Network() == addr2.Network()) && (addr1.String() == addr2.String())
 	}
 
-	if host, err := pm.Map(srcAddr1, dstIP1, 80, true); err != nil {
+	if host, err := pm.Map(srcAddr1, dstIP1, 80, true, 0); err != nil {
 		t.Fatalf("Failed to allocate port: %s", err)
 	} else if !addrEqual(dstAddr1, host) {
 		t.Fatalf("Incorrect mapping result: expected %s:%s, got %s:%s",
 			dstAddr1.String(), dstAddr1.Network(), host.String(), host.Network())
 	}
 
-	if _, err := pm.Map(srcAddr1, dstIP1, 80, true); err == nil {
+	if _, err := pm.Map(srcAddr1, dstIP1, 80, true, 0); err == nil {
 		t.Fatalf("Port is in use - mapping should have failed")
 	}
 
-	if _, err := pm.Map(srcAddr2, dstIP1, 80, true); err == nil {
+	if _, err := pm.Map(srcAddr2, dstIP1, 80, true, 0); err == nil {
 		t.Fatalf("Port is in use - mapping should have failed")
 	}
 
-	if _, err := pm.Map(srcAddr2, dstIP2, 80, true); err != nil {
+	if _, err := pm.Map(srcAddr2, dstIP2, 80, true, 0); err != nil {
 		t.Fatalf("Failed to allocate port: %s", err)
 	}
 
@@ -174,14 +177,14 @@ func TestMapAllPortsSingleInterface(t *testing.T) {
 	for i := 0; i < 10; i++ {
 		start, end := pm.Allocator.Begin, pm.Allocator.End
 		for i := start; i < end; i++ {
-			if host, err = pm.Map(srcAddr1, dstIP1, 0, true); err != nil {
+			if host, err = pm.Map(srcAddr1, dstIP1, 0, true, 0); err != nil {
 				t.Fatal(err)
 			}
 
 			hosts = append(hosts, host)
 		}
 
-		if _, err := pm.Map(srcAddr1, dstIP1, start, true); err == nil {
+		if _, err := pm.Map(srcAddr1, dstIP1, start, true, 0); err == nil {
 			t.Fatalf("Port %d should be bound but is not", start)
 		}
 
@@ -207,7 +210,7 @@ func TestMapTCPDummyListen(t *testing.T) {
 		return (addr1.Network() == addr2.Network()) && (addr1.String() == addr2.String())
 	}
 
-	if host, err := pm.Map(srcAddr, dstIP, 80, false); err != nil {
+	if host, err := pm.Map(srcAddr, dstIP, 80, false, 0); err != nil {
 		t.Fatalf("Failed to allocate port: %s", err)
 	} else if !addrEqual(dstAddr, host) {
 		t.Fatalf("Incorrect mapping result: expected %s:%s, got %s:%s",
@@ -223,7 +226,7 @@ func TestMapTCPDummyListen(t *testing.T) {
 	if _, err := net.Listen("tcp", "0.0.0.0:81"); err != nil {
 		t.Fatal(err)
 	}
-	if host, err := pm.Map(srcAddr, dstIP, 81, false); err == nil {
+	if host, err := pm.Map(srcAddr, dstIP, 81, false, 0); err == nil {
 		t.Fatalf("Bound port shouldn't be allocated, but it was on: %v", host)
 	} else {
 		if !strings.Contains(err.Error(), "address already in use") {
@@ -244,7 +247,7 @@ func TestMapUDPDummyListen(t *testing.T) {
 		return (addr1.Network() == addr2.Network()) && (addr1.String() == addr2.String())
 	}
 
-	if host, err := pm.Map(srcAddr, dstIP, 80, false); err != nil {
+	if host, err := pm.Map(srcAddr, dstIP, 80, false, 0); err != nil {
 		t.Fatalf("Failed to allocate port: %s", err)
 	} else if !addrEqual(dstAddr, host) {
 		t.Fatalf("Incorrect mapping result: expected %s:%s, got %s:%s",
@@ -260,7 +263,7 @@ func TestMapUDPDummyListen(t *testing.T) {
 	if _, err := net.ListenUDP("udp", &net.UDPAddr{IP: dstIP, Port: 81}); err != nil {
 		t.Fatal(err)
 	}
-	if host, err := pm.Map(srcAddr, dstIP, 81, false); err == nil {
+	if host, err := pm.Map(srcAddr, dstIP, 81, false, 0); err == nil {
 		t.Fatalf("Bound port shouldn't be allocated, but it was on: %v", host)
 	} else {
 		if !strings.Contains(err.Error(), "address already in use") {
@@ -268,3 +271,203 @@ func TestMapUDPDummyListen(t *testing.T) {
 		}
 	}
 }
+
+func TestMapPortRange(t *testing.T) {
+	pm := New("")
+	hostIP := net.ParseIP("192.168.0.1")
+
+	for _, container := range []net.Addr{
+		&net.TCPAddr{IP: net.ParseIP("172.16.0.1"), Port: 8000},
+		&net.UDPAddr{IP: net.ParseIP("172.16.0.1"), Port: 8000},
+		&sctp.SCTPAddr{IPAddrs: []net.IPAddr{{IP: net.ParseIP("172.16.0.1")}}, Port: 8000},
+	} {
+		host, err := pm.MapPortRange(container, 10, hostIP, 8000, 8009, true, 0)
+		if err != nil {
+			t.Fatalf("Failed to map %s port range: %v", container.Network(), err)
+		}
+		if _, port := getIPAndPort(host); port != 8000 {
+			t.Fatalf("Expected %s port range to start at 8000, got %d", container.Network(), port)
+		}
+
+		// Overlapping ranges must fail without allocating any port
+		if _, err := pm.MapPortRange(container, 10, hostIP, 8005, 8014, true, 0); err == nil {
+			t.Fatalf("Port range is in use - %s mapping should have failed", container.Network())
+		}
+		if _, err := pm.Map(container, hostIP, 8010, true, 0); err != nil {
+			t.Fatalf("Port 8010 should have been left free: %v", err)
+		}
+		if err := pm.Unmap(newAddr(host.Network(), hostIP, 8010)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := pm.Unmap(host); err != nil {
+			t.Fatalf("Failed to unmap %s port range: %v", container.Network(), err)
+		}
+		if _, err := pm.Map(container, hostIP, 8009, true, 0); err != nil {
+			t.Fatalf("Last port of the range should have been released: %v", err)
+		}
+		if err := pm.Unmap(newAddr(host.Network(), hostIP, 8009)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMapPortRangeInLargerRange(t *testing.T) {
+	pm := New("")
+	hostIP := net.ParseIP("192.168.0.1")
+	container := &net.TCPAddr{IP: net.ParseIP("172.16.0.1"), Port: 80}
+
+	if _, err := pm.Map(container, hostIP, 9002, true, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// The block of 3 ports must skip the port already in use
+	host, err := pm.MapPortRange(container, 3, hostIP, 9000, 9010, true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, port := getIPAndPort(host); port != 9003 {
+		t.Fatalf("Expected the block to start at 9003, got %d", port)
+	}
+
+	if _, err := pm.MapPortRange(container, 10, hostIP, 9000, 9010, true, 0); err == nil {
+		t.Fatal("No contiguous block of 10 ports is available - mapping should have failed")
+	}
+}
+
+func TestMapInProcessProxy(t *testing.T) {
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+	go func() {
+		for {
+			c, err := backend.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				io.Copy(c, c)
+				c.Close()
+			}()
+		}
+	}()
+
+	pm := New("")
+	m := NewProxyManager(0, time.Second)
+	defer m.Close()
+	pm.SetProxyManager(m)
+
+	host, err := pm.Map(backend.Addr(), net.ParseIP("127.0.0.1"), 0, true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Len() != 1 {
+		t.Fatalf("Expected 1 running proxy, got %d", m.Len())
+	}
+
+	c, err := net.Dial("tcp", host.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	c.SetDeadline(time.Now().Add(10 * time.Second))
+	msg := []byte("ping")
+	if _, err := c.Write(msg); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(c, buf); err != nil || string(buf) != "ping" {
+		t.Fatalf("Unexpected reply %q: %v", buf, err)
+	}
+
+	if err := pm.Unmap(host); err != nil {
+		t.Fatal(err)
+	}
+	if m.Len() != 0 {
+		t.Fatalf("Expected no running proxy, got %d", m.Len())
+	}
+	if _, err := net.Dial("tcp", host.String()); err == nil {
+		t.Fatal("Expected the proxy to stop listening on unmap")
+	}
+}
+
+func TestMapProxyProtocol(t *testing.T) {
+	pm := New("")
+	hostIP := net.ParseIP("192.168.0.1")
+
+	udp := &net.UDPAddr{IP: net.ParseIP("172.16.0.1"), Port: 53}
+	if _, err := pm.MapPortRange(udp, 1, hostIP, 53, 53, true, 1); err != ErrProxyProtocol {
+		t.Fatalf("Expected error %v on udp mapping, got %v", ErrProxyProtocol, err)
+	}
+	tcp := &net.TCPAddr{IP: net.ParseIP("172.16.0.1"), Port: 80}
+	if _, err := pm.MapPortRange(tcp, 1, hostIP, 8080, 8080, false, 1); err != ErrProxyProtocol {
+		t.Fatalf("Expected error %v without userland proxy, got %v", ErrProxyProtocol, err)
+	}
+
+	if _, err := pm.Map(tcp, hostIP, 8080, false, 1); err != ErrProxyProtocol {
+		t.Fatalf("Expected error %v on a single port mapping without userland proxy, got %v", ErrProxyProtocol, err)
+	}
+
+	host, err := pm.Map(tcp, hostIP, 8080, true, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := pm.currentMappings[getKey(host)]; !m.proxyOnly {
+		t.Fatal("Expected the mapping to go through the userland proxy only")
+	}
+	if err := pm.Unmap(host); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMapBatch(t *testing.T) {
+	pm := New("")
+	hostIP := net.ParseIP("127.0.0.1")
+
+	b := pm.NewBatch()
+	host1, err := b.MapPortRange(&net.TCPAddr{IP: net.ParseIP("172.16.0.1"), Port: 80}, 1, hostIP, 8080, 8080, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Proxied only, no forwarding rule
+	if _, err := b.MapPortRange(&net.TCPAddr{IP: net.ParseIP("172.16.0.1"), Port: 81}, 1, hostIP, 8081, 8081, true, 1); err != nil {
+		t.Fatal(err)
+	}
+	host3, err := b.MapPortRange(&net.UDPAddr{IP: net.ParseIP("172.16.0.1"), Port: 5000}, 4, hostIP, 6000, 6010, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.ops) != 2 || !b.ops[0].add || b.ops[1].size != 4 {
+		t.Fatalf("unexpected forwarding table changes %+v", b.ops)
+	}
+	if len(pm.currentMappings) != 3 {
+		t.Fatalf("expected 3 mappings, got %d", len(pm.currentMappings))
+	}
+	if err := b.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	if len(b.ops) != 0 {
+		t.Fatal("batch not emptied by Apply")
+	}
+
+	if err := b.Unmap(host1); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Unmap(host3); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Unmap(host3); err != ErrPortNotMapped {
+		t.Fatalf("expected %v, got %v", ErrPortNotMapped, err)
+	}
+	if len(b.ops) != 2 || b.ops[0].add || b.ops[1].add {
+		t.Fatalf("unexpected forwarding table changes %+v", b.ops)
+	}
+	if len(pm.currentMappings) != 1 {
+		t.Fatalf("expected 1 mapping, got %d", len(pm.currentMappings))
+	}
+	if err := b.Apply(); err != nil {
+		t.Fatal(err)
+	}
+}
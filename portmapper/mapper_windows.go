@@ -16,6 +16,8 @@ type PortMapper struct {
 	lock            sync.Mutex
 
 	proxyPath string
+	// proxyManager runs the userland proxies in process when set
+	proxyManager *ProxyManager
 
 	Allocator *portallocator.PortAllocator
 }
@@ -30,6 +32,26 @@ func (pm *PortMapper) DeleteForwardingTableEntry(proto string, sourceIP net.IP,
 	return nil
 }
 
+// AppendForwardingTableRange adds a port range mapping to the forwarding table
+func (pm *PortMapper) AppendForwardingTableRange(proto string, sourceIP net.IP, sourcePort int, containerIP string, containerPort, size int) error {
+	return nil
+}
+
+// DeleteForwardingTableRange removes a port range mapping from the forwarding table
+func (pm *PortMapper) DeleteForwardingTableRange(proto string, sourceIP net.IP, sourcePort int, containerIP string, containerPort, size int) error {
+	return nil
+}
+
+func (pm *PortMapper) applyForwards(ops []forwardOp) error {
+	return nil
+}
+
+// MissingMappings returns the host addresses of the port mappings whose
+// forwarding rules are missing
+func (pm *PortMapper) MissingMappings() []string {
+	return nil
+}
+
 // checkIP checks if IP is valid and matching to chain version
 func (pm *PortMapper) checkIP(ip net.IP) bool {
 	// no IPv6 for port mapper on windows -> only IPv4 valid
@@ -19,6 +19,33 @@ type userlandProxy interface {
 	Stop() error
 }
 
+// proxyGroup runs the userland proxies of a port range mapping
+type proxyGroup []userlandProxy
+
+// Start starts all the proxies of the group. If one of them fails,
+// the ones already started are stopped.
+func (g proxyGroup) Start() error {
+	for i, p := range g {
+		if err := p.Start(); err != nil {
+			for _, sp := range g[:i] {
+				sp.Stop()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (g proxyGroup) Stop() error {
+	var err error
+	for _, p := range g {
+		if e := p.Stop(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
 // ipVersion refers to IP version - v4 or v6
 type ipVersion string
 
@@ -1,4 +1,4 @@
-package main
+package proxy
 
 import (
 	"bytes"
@@ -305,3 +305,178 @@ func TestSCTP6Proxy(t *testing.T) {
 	}
 	testProxy(t, "sctp", proxy, false)
 }
+
+func TestTCPProxyMaxConns(t *testing.T) {
+	backend := NewEchoServer(t, "tcp", "127.0.0.1:0", EchoServerOptions{})
+	defer backend.Close()
+	backend.Run()
+	frontendAddr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0}
+	proxy, err := NewProxyWithConfig(frontendAddr, backend.LocalAddr(), Config{MaxConns: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxy.Close()
+	go proxy.Run()
+
+	first, err := net.Dial("tcp", proxy.FrontendAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Close()
+	first.SetDeadline(time.Now().Add(10 * time.Second))
+	echo(t, first)
+
+	// The second connection is over the limit and gets closed by the proxy
+	second, err := net.Dial("tcp", proxy.FrontendAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Close()
+	second.SetDeadline(time.Now().Add(10 * time.Second))
+	second.Write(testBuf)
+	if _, err := second.Read(make([]byte, testBufSize)); err == nil {
+		t.Fatal("Expected the connection over the limit to be closed")
+	}
+
+	// The first one keeps working, and a new one is accepted once it is gone
+	echo(t, first)
+	first.Close()
+	for i := 0; i < 20; i++ {
+		if c, err := net.Dial("tcp", proxy.FrontendAddr().String()); err == nil {
+			c.SetDeadline(time.Now().Add(time.Second))
+			_, werr := c.Write(testBuf)
+			_, rerr := c.Read(make([]byte, testBufSize))
+			c.Close()
+			if werr == nil && rerr == nil {
+				return
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("Expected a new connection to be accepted after the first one is closed")
+}
+
+func TestTCPProxyDrain(t *testing.T) {
+	backend := NewEchoServer(t, "tcp", "127.0.0.1:0", EchoServerOptions{})
+	defer backend.Close()
+	backend.Run()
+	frontendAddr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0}
+	proxy, err := NewProxy(frontendAddr, backend.LocalAddr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	go proxy.Run()
+
+	client, err := net.Dial("tcp", proxy.FrontendAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	client.SetDeadline(time.Now().Add(10 * time.Second))
+	echo(t, client)
+
+	proxy.Drain(time.Second)
+
+	if _, err := net.Dial("tcp", proxy.FrontendAddr().String()); err == nil {
+		t.Fatal("Expected new connections to be refused while draining")
+	}
+	// The ongoing connection is still served until the drain timeout
+	echo(t, client)
+
+	time.Sleep(1500 * time.Millisecond)
+	if _, err := client.Read(make([]byte, 1)); err == nil {
+		t.Fatal("Expected the connection to be closed after the drain timeout")
+	}
+}
+
+func echo(t *testing.T, c net.Conn) {
+	if _, err := c.Write(testBuf); err != nil {
+		t.Fatal(err)
+	}
+	recvBuf := make([]byte, testBufSize)
+	if _, err := io.ReadFull(c, recvBuf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(testBuf, recvBuf) {
+		t.Fatalf("Expected [%v] but got [%v]", testBuf, recvBuf)
+	}
+}
+
+func TestProxyHeader(t *testing.T) {
+	src4 := &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 56324}
+	dst4 := &net.TCPAddr{IP: net.ParseIP("198.51.100.1"), Port: 443}
+	if h := string(proxyHeader(1, src4, dst4)); h != "PROXY TCP4 192.0.2.10 198.51.100.1 56324 443\r\n" {
+		t.Fatalf("Unexpected v1 header %q", h)
+	}
+
+	src6 := &net.TCPAddr{IP: net.ParseIP("2001:db8::10"), Port: 56324}
+	dst6 := &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 443}
+	if h := string(proxyHeader(1, src6, dst6)); h != "PROXY TCP6 2001:db8::10 2001:db8::1 56324 443\r\n" {
+		t.Fatalf("Unexpected v1 header %q", h)
+	}
+
+	h := proxyHeader(2, src4, dst4)
+	expected := append(append([]byte{}, proxyV2Signature...),
+		0x21, 0x11, 0, 12,
+		192, 0, 2, 10,
+		198, 51, 100, 1,
+		0xdc, 0x04, 0x01, 0xbb)
+	if !bytes.Equal(h, expected) {
+		t.Fatalf("Unexpected v2 header %x", h)
+	}
+
+	h = proxyHeader(2, src6, dst6)
+	if len(h) != 16+36 || h[13] != 0x21 || h[15] != 36 {
+		t.Fatalf("Unexpected v2 header %x", h)
+	}
+}
+
+func TestTCPProxyProtocol(t *testing.T) {
+	backend, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.Close()
+
+	frontendAddr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0}
+	proxy, err := NewProxyWithConfig(frontendAddr, backend.Addr(), Config{ProxyProtocol: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer proxy.Close()
+	go proxy.Run()
+
+	client, err := net.Dial("tcp", proxy.FrontendAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if _, err := client.Write(testBuf); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := backend.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	local := client.LocalAddr().(*net.TCPAddr)
+	header := fmt.Sprintf("PROXY TCP4 127.0.0.1 127.0.0.1 %d %d\r\n", local.Port, proxy.FrontendAddr().(*net.TCPAddr).Port)
+	recvBuf := make([]byte, len(header)+testBufSize)
+	if _, err := io.ReadFull(conn, recvBuf); err != nil {
+		t.Fatal(err)
+	}
+	if string(recvBuf[:len(header)]) != header {
+		t.Fatalf("Expected header %q, got %q", header, recvBuf[:len(header)])
+	}
+	if !bytes.Equal(recvBuf[len(header):], testBuf) {
+		t.Fatalf("Expected [%v] but got [%v]", testBuf, recvBuf[len(header):])
+	}
+
+	udpAddr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0}
+	if _, err := NewProxyWithConfig(udpAddr, udpAddr, Config{ProxyProtocol: 2}); err == nil {
+		t.Fatal("Expected failure on PROXY protocol for udp")
+	}
+}
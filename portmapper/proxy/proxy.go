@@ -0,0 +1,124 @@
+// Package proxy provides a network Proxy interface and implementations for TCP,
+// UDP and SCTP. It is used by the docker-proxy binary and by the in-process
+// userland proxy of the portmapper.
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ishidawataru/sctp"
+)
+
+// ipVersion refers to IP version - v4 or v6
+type ipVersion string
+
+const (
+	// IPv4 is version 4
+	ipv4 ipVersion = "4"
+	// IPv4 is version 6
+	ipv6 ipVersion = "6"
+)
+
+// Proxy defines the behavior of a proxy. It forwards traffic back and forth
+// between two endpoints : the frontend and the backend.
+// It can be used to do software port-mapping between two addresses.
+// e.g. forward all traffic between the frontend (host) 127.0.0.1:3000
+// to the backend (container) at 172.17.42.108:4000.
+type Proxy interface {
+	// Run starts forwarding traffic back and forth between the front
+	// and back-end addresses.
+	Run()
+	// Close stops forwarding traffic and close both ends of the Proxy.
+	Close()
+	// Drain stops accepting new connections and lets the ongoing ones
+	// complete for up to timeout, after which they are closed. It returns
+	// as soon as the frontend is closed.
+	Drain(timeout time.Duration)
+	// FrontendAddr returns the address on which the proxy is listening.
+	FrontendAddr() net.Addr
+	// BackendAddr returns the proxied address.
+	BackendAddr() net.Addr
+}
+
+// NewProxy creates a Proxy according to the specified frontendAddr and backendAddr.
+func NewProxy(frontendAddr, backendAddr net.Addr) (Proxy, error) {
+	return NewProxyWithConfig(frontendAddr, backendAddr, Config{})
+}
+
+// Config holds the optional settings of a Proxy
+type Config struct {
+	// MaxConns bounds the connections (TCP, SCTP) or flows (UDP)
+	// tracked at once, 0 means no limit
+	MaxConns int
+	// ProxyProtocol is the version of the PROXY protocol header prepended
+	// to the backend connections, 0 for none. Only TCP supports it.
+	ProxyProtocol int
+}
+
+// NewProxyWithConfig creates a Proxy according to the specified frontendAddr, backendAddr and config.
+func NewProxyWithConfig(frontendAddr, backendAddr net.Addr, config Config) (Proxy, error) {
+	if config.ProxyProtocol != 0 {
+		if _, ok := frontendAddr.(*net.TCPAddr); !ok {
+			return nil, fmt.Errorf("PROXY protocol is not supported for %s", frontendAddr.Network())
+		}
+		if config.ProxyProtocol != 1 && config.ProxyProtocol != 2 {
+			return nil, fmt.Errorf("unsupported PROXY protocol version %d", config.ProxyProtocol)
+		}
+	}
+	switch frontendAddr.(type) {
+	case *net.UDPAddr:
+		return newUDPProxy(frontendAddr.(*net.UDPAddr), backendAddr.(*net.UDPAddr), config.MaxConns)
+	case *net.TCPAddr:
+		return newTCPProxy(frontendAddr.(*net.TCPAddr), backendAddr.(*net.TCPAddr), config)
+	case *sctp.SCTPAddr:
+		return newSCTPProxy(frontendAddr.(*sctp.SCTPAddr), backendAddr.(*sctp.SCTPAddr), config.MaxConns)
+	default:
+		panic("Unsupported protocol")
+	}
+}
+
+// connTracker bounds and tracks the connections handled by a stream proxy
+type connTracker struct {
+	max   int
+	count int
+	wg    sync.WaitGroup
+	sync.Mutex
+}
+
+// add registers a new connection, it returns false if the limit is reached
+func (t *connTracker) add() bool {
+	t.Lock()
+	defer t.Unlock()
+	if t.max > 0 && t.count >= t.max {
+		return false
+	}
+	t.count++
+	t.wg.Add(1)
+	return true
+}
+
+func (t *connTracker) done() {
+	t.Lock()
+	t.count--
+	t.Unlock()
+	t.wg.Done()
+}
+
+// wait waits for all the connections to complete, up to timeout.
+// It returns false on timeout.
+func (t *connTracker) wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
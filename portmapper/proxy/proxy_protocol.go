@@ -0,0 +1,50 @@
+package proxy
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+// proxyV2Signature starts every PROXY protocol version 2 header
+var proxyV2Signature = []byte{0x0D, 0x0A, 0x0D, 0x0A, 0x00, 0x0D, 0x0A, 0x51, 0x55, 0x49, 0x54, 0x0A}
+
+const (
+	proxyV2Command = 0x21 // version 2, PROXY command
+	proxyV2TCP4    = 0x11 // AF_INET, STREAM
+	proxyV2TCP6    = 0x21 // AF_INET6, STREAM
+)
+
+// proxyHeader returns the PROXY protocol header of the specified version
+// describing a connection from src to dst, as seen by the proxy frontend.
+// See https://www.haproxy.org/download/2.0/doc/proxy-protocol.txt
+func proxyHeader(version int, src, dst *net.TCPAddr) []byte {
+	src4, dst4 := src.IP.To4(), dst.IP.To4()
+	v4 := src4 != nil && dst4 != nil
+
+	if version == 1 {
+		if v4 {
+			return []byte(fmt.Sprintf("PROXY TCP4 %s %s %d %d\r\n", src4, dst4, src.Port, dst.Port))
+		}
+		return []byte(fmt.Sprintf("PROXY TCP6 %s %s %d %d\r\n", src.IP.To16(), dst.IP.To16(), src.Port, dst.Port))
+	}
+
+	var family byte
+	var addrs []byte
+	if v4 {
+		family = proxyV2TCP4
+		addrs = append(append(addrs, src4...), dst4...)
+	} else {
+		family = proxyV2TCP6
+		addrs = append(append(addrs, src.IP.To16()...), dst.IP.To16()...)
+	}
+	addrs = append(addrs, 0, 0, 0, 0)
+	binary.BigEndian.PutUint16(addrs[len(addrs)-4:], uint16(src.Port))
+	binary.BigEndian.PutUint16(addrs[len(addrs)-2:], uint16(dst.Port))
+
+	h := make([]byte, 0, len(proxyV2Signature)+4+len(addrs))
+	h = append(h, proxyV2Signature...)
+	h = append(h, proxyV2Command, family, 0, 0)
+	binary.BigEndian.PutUint16(h[len(h)-2:], uint16(len(addrs)))
+	return append(h, addrs...)
+}
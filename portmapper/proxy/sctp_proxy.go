@@ -1,12 +1,13 @@
-package main
+package proxy
 
 import (
 	"io"
-	"log"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/ishidawataru/sctp"
+	"github.com/sirupsen/logrus"
 )
 
 // SCTPProxy is a proxy for SCTP connections. It implements the Proxy interface to
@@ -15,10 +16,17 @@ type SCTPProxy struct {
 	listener     *sctp.SCTPListener
 	frontendAddr *sctp.SCTPAddr
 	backendAddr  *sctp.SCTPAddr
+	conns        connTracker
+	quit         chan struct{}
+	quitOnce     sync.Once
 }
 
 // NewSCTPProxy creates a new SCTPProxy.
 func NewSCTPProxy(frontendAddr, backendAddr *sctp.SCTPAddr) (*SCTPProxy, error) {
+	return newSCTPProxy(frontendAddr, backendAddr, 0)
+}
+
+func newSCTPProxy(frontendAddr, backendAddr *sctp.SCTPAddr, maxConns int) (*SCTPProxy, error) {
 	// detect version of hostIP to bind only to correct version
 	ipVersion := ipv4
 	if frontendAddr.IPAddrs[0].IP.To4() == nil {
@@ -34,13 +42,17 @@ func NewSCTPProxy(frontendAddr, backendAddr *sctp.SCTPAddr) (*SCTPProxy, error)
 		listener:     listener,
 		frontendAddr: listener.Addr().(*sctp.SCTPAddr),
 		backendAddr:  backendAddr,
+		conns:        connTracker{max: maxConns},
+		quit:         make(chan struct{}),
 	}, nil
 }
 
-func (proxy *SCTPProxy) clientLoop(client *sctp.SCTPConn, quit chan bool) {
+func (proxy *SCTPProxy) clientLoop(client *sctp.SCTPConn, quit chan struct{}) {
+	defer proxy.conns.done()
+
 	backend, err := sctp.DialSCTP("sctp", nil, proxy.backendAddr)
 	if err != nil {
-		log.Printf("Can't forward traffic to backend sctp/%v: %s\n", proxy.backendAddr, err)
+		logrus.Errorf("Can't forward traffic to backend sctp/%v: %s", proxy.backendAddr, err)
 		client.Close()
 		return
 	}
@@ -76,20 +88,38 @@ func (proxy *SCTPProxy) clientLoop(client *sctp.SCTPConn, quit chan bool) {
 
 // Run starts forwarding the traffic using SCTP.
 func (proxy *SCTPProxy) Run() {
-	quit := make(chan bool)
-	defer close(quit)
 	for {
 		client, err := proxy.listener.Accept()
 		if err != nil {
-			log.Printf("Stopping proxy on sctp/%v for sctp/%v (%s)", proxy.frontendAddr, proxy.backendAddr, err)
+			logrus.Debugf("Stopping proxy on sctp/%v for sctp/%v (%s)", proxy.frontendAddr, proxy.backendAddr, err)
 			return
 		}
-		go proxy.clientLoop(client.(*sctp.SCTPConn), quit)
+		if !proxy.conns.add() {
+			logrus.Warnf("Dropping connection from %v to sctp/%v: too many connections", client.RemoteAddr(), proxy.frontendAddr)
+			client.Close()
+			continue
+		}
+		go proxy.clientLoop(client.(*sctp.SCTPConn), proxy.quit)
 	}
 }
 
 // Close stops forwarding the traffic.
-func (proxy *SCTPProxy) Close() { proxy.listener.Close() }
+func (proxy *SCTPProxy) Close() {
+	proxy.listener.Close()
+	proxy.quitOnce.Do(func() { close(proxy.quit) })
+}
+
+// Drain stops accepting new connections and closes the ongoing ones
+// after they complete or timeout expires.
+func (proxy *SCTPProxy) Drain(timeout time.Duration) {
+	proxy.listener.Close()
+	go func() {
+		if !proxy.conns.wait(timeout) {
+			logrus.Debugf("Closing remaining connections of proxy on sctp/%v after %s", proxy.frontendAddr, timeout)
+		}
+		proxy.quitOnce.Do(func() { close(proxy.quit) })
+	}()
+}
 
 // FrontendAddr returns the SCTP address on which the proxy is listening.
 func (proxy *SCTPProxy) FrontendAddr() net.Addr { return proxy.frontendAddr }
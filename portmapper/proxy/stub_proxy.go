@@ -1,7 +1,8 @@
-package main
+package proxy
 
 import (
 	"net"
+	"time"
 )
 
 // StubProxy is a proxy that is a stub (does nothing).
@@ -16,6 +17,9 @@ func (p *StubProxy) Run() {}
 // Close does nothing.
 func (p *StubProxy) Close() {}
 
+// Drain does nothing.
+func (p *StubProxy) Drain(timeout time.Duration) {}
+
 // FrontendAddr returns the frontend address.
 func (p *StubProxy) FrontendAddr() net.Addr { return p.frontendAddr }
 
@@ -0,0 +1,137 @@
+package proxy
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TCPProxy is a proxy for TCP connections. It implements the Proxy interface to
+// handle TCP traffic forwarding between the frontend and backend addresses.
+type TCPProxy struct {
+	listener      *net.TCPListener
+	frontendAddr  *net.TCPAddr
+	backendAddr   *net.TCPAddr
+	conns         connTracker
+	proxyProtocol int
+	quit          chan struct{}
+	quitOnce      sync.Once
+}
+
+// NewTCPProxy creates a new TCPProxy.
+func NewTCPProxy(frontendAddr, backendAddr *net.TCPAddr) (*TCPProxy, error) {
+	return newTCPProxy(frontendAddr, backendAddr, Config{})
+}
+
+func newTCPProxy(frontendAddr, backendAddr *net.TCPAddr, config Config) (*TCPProxy, error) {
+	// detect version of hostIP to bind only to correct version
+	ipVersion := ipv4
+	if frontendAddr.IP.To4() == nil {
+		ipVersion = ipv6
+	}
+	listener, err := net.ListenTCP("tcp"+string(ipVersion), frontendAddr)
+	if err != nil {
+		return nil, err
+	}
+	// If the port in frontendAddr was 0 then ListenTCP will have a picked
+	// a port to listen on, hence the call to Addr to get that actual port:
+	return &TCPProxy{
+		listener:      listener,
+		frontendAddr:  listener.Addr().(*net.TCPAddr),
+		backendAddr:   backendAddr,
+		conns:         connTracker{max: config.MaxConns},
+		proxyProtocol: config.ProxyProtocol,
+		quit:          make(chan struct{}),
+	}, nil
+}
+
+func (proxy *TCPProxy) clientLoop(client *net.TCPConn, quit chan struct{}) {
+	defer proxy.conns.done()
+
+	backend, err := net.DialTCP("tcp", nil, proxy.backendAddr)
+	if err != nil {
+		logrus.Errorf("Can't forward traffic to backend tcp/%v: %s", proxy.backendAddr, err)
+		client.Close()
+		return
+	}
+
+	if proxy.proxyProtocol != 0 {
+		header := proxyHeader(proxy.proxyProtocol, client.RemoteAddr().(*net.TCPAddr), client.LocalAddr().(*net.TCPAddr))
+		if _, err := backend.Write(header); err != nil {
+			logrus.Errorf("Can't send PROXY protocol header to backend tcp/%v: %s", proxy.backendAddr, err)
+			client.Close()
+			backend.Close()
+			return
+		}
+	}
+
+	var wg sync.WaitGroup
+	var broker = func(to, from *net.TCPConn) {
+		io.Copy(to, from)
+		from.CloseRead()
+		to.CloseWrite()
+		wg.Done()
+	}
+
+	wg.Add(2)
+	go broker(client, backend)
+	go broker(backend, client)
+
+	finish := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finish)
+	}()
+
+	select {
+	case <-quit:
+	case <-finish:
+	}
+	client.Close()
+	backend.Close()
+	<-finish
+}
+
+// Run starts forwarding the traffic using TCP.
+func (proxy *TCPProxy) Run() {
+	for {
+		client, err := proxy.listener.Accept()
+		if err != nil {
+			logrus.Debugf("Stopping proxy on tcp/%v for tcp/%v (%s)", proxy.frontendAddr, proxy.backendAddr, err)
+			return
+		}
+		if !proxy.conns.add() {
+			logrus.Warnf("Dropping connection from %v to tcp/%v: too many connections", client.RemoteAddr(), proxy.frontendAddr)
+			client.Close()
+			continue
+		}
+		go proxy.clientLoop(client.(*net.TCPConn), proxy.quit)
+	}
+}
+
+// Close stops forwarding the traffic.
+func (proxy *TCPProxy) Close() {
+	proxy.listener.Close()
+	proxy.quitOnce.Do(func() { close(proxy.quit) })
+}
+
+// Drain stops accepting new connections and closes the ongoing ones
+// after they complete or timeout expires.
+func (proxy *TCPProxy) Drain(timeout time.Duration) {
+	proxy.listener.Close()
+	go func() {
+		if !proxy.conns.wait(timeout) {
+			logrus.Debugf("Closing remaining connections of proxy on tcp/%v after %s", proxy.frontendAddr, timeout)
+		}
+		proxy.quitOnce.Do(func() { close(proxy.quit) })
+	}()
+}
+
+// FrontendAddr returns the TCP address on which the proxy is listening.
+func (proxy *TCPProxy) FrontendAddr() net.Addr { return proxy.frontendAddr }
+
+// BackendAddr returns the TCP proxied address.
+func (proxy *TCPProxy) BackendAddr() net.Addr { return proxy.backendAddr }
@@ -1,13 +1,14 @@
-package main
+package proxy
 
 import (
 	"encoding/binary"
-	"log"
 	"net"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -51,10 +52,15 @@ type UDPProxy struct {
 	backendAddr    *net.UDPAddr
 	connTrackTable connTrackMap
 	connTrackLock  sync.Mutex
+	maxConns       int
 }
 
 // NewUDPProxy creates a new UDPProxy.
 func NewUDPProxy(frontendAddr, backendAddr *net.UDPAddr) (*UDPProxy, error) {
+	return newUDPProxy(frontendAddr, backendAddr, 0)
+}
+
+func newUDPProxy(frontendAddr, backendAddr *net.UDPAddr, maxConns int) (*UDPProxy, error) {
 	// detect version of hostIP to bind only to correct version
 	ipVersion := ipv4
 	if frontendAddr.IP.To4() == nil {
@@ -69,6 +75,7 @@ func NewUDPProxy(frontendAddr, backendAddr *net.UDPAddr) (*UDPProxy, error) {
 		frontendAddr:   listener.LocalAddr().(*net.UDPAddr),
 		backendAddr:    backendAddr,
 		connTrackTable: make(connTrackMap),
+		maxConns:       maxConns,
 	}, nil
 }
 
@@ -116,7 +123,7 @@ func (proxy *UDPProxy) Run() {
 			// ECONNREFUSED like Read do (see comment in
 			// UDPProxy.replyLoop)
 			if !isClosedError(err) {
-				log.Printf("Stopping proxy on udp/%v for udp/%v (%s)", proxy.frontendAddr, proxy.backendAddr, err)
+				logrus.Infof("Stopping proxy on udp/%v for udp/%v (%s)", proxy.frontendAddr, proxy.backendAddr, err)
 			}
 			break
 		}
@@ -125,9 +132,14 @@ func (proxy *UDPProxy) Run() {
 		proxy.connTrackLock.Lock()
 		proxyConn, hit := proxy.connTrackTable[*fromKey]
 		if !hit {
+			if proxy.maxConns > 0 && len(proxy.connTrackTable) >= proxy.maxConns {
+				logrus.Debugf("Dropping datagram from %v to udp/%v: too many flows", from, proxy.frontendAddr)
+				proxy.connTrackLock.Unlock()
+				continue
+			}
 			proxyConn, err = net.DialUDP("udp", nil, proxy.backendAddr)
 			if err != nil {
-				log.Printf("Can't proxy a datagram to udp/%s: %s\n", proxy.backendAddr, err)
+				logrus.Errorf("Can't proxy a datagram to udp/%s: %s", proxy.backendAddr, err)
 				proxy.connTrackLock.Unlock()
 				continue
 			}
@@ -138,7 +150,7 @@ func (proxy *UDPProxy) Run() {
 		for i := 0; i != read; {
 			written, err := proxyConn.Write(readBuf[i:read])
 			if err != nil {
-				log.Printf("Can't proxy a datagram to udp/%s: %s\n", proxy.backendAddr, err)
+				logrus.Errorf("Can't proxy a datagram to udp/%s: %s", proxy.backendAddr, err)
 				break
 			}
 			i += written
@@ -156,6 +168,12 @@ func (proxy *UDPProxy) Close() {
 	}
 }
 
+// Drain stops forwarding the traffic. The replies of the UDP flows are sent
+// from the frontend, so they cannot outlive it and are closed immediately.
+func (proxy *UDPProxy) Drain(timeout time.Duration) {
+	proxy.Close()
+}
+
 // FrontendAddr returns the UDP address on which the proxy is listening.
 func (proxy *UDPProxy) FrontendAddr() net.Addr { return proxy.frontendAddr }
 
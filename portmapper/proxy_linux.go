@@ -7,7 +7,7 @@ import (
 	"syscall"
 )
 
-func newProxyCommand(proto string, hostIP net.IP, hostPort int, containerIP net.IP, containerPort int, proxyPath string) (userlandProxy, error) {
+func newProxyCommand(proto string, hostIP net.IP, hostPort int, containerIP net.IP, containerPort, proxyProtocol int, proxyPath string) (userlandProxy, error) {
 	path := proxyPath
 	if proxyPath == "" {
 		cmd, err := exec.LookPath(userlandProxyCommandName)
@@ -25,6 +25,9 @@ func newProxyCommand(proto string, hostIP net.IP, hostPort int, containerIP net.
 		"-container-ip", containerIP.String(),
 		"-container-port", strconv.Itoa(containerPort),
 	}
+	if proxyProtocol != 0 {
+		args = append(args, "-proxy-protocol", strconv.Itoa(proxyProtocol))
+	}
 
 	return &proxyCommand{
 		cmd: &exec.Cmd{
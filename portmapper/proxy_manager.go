@@ -0,0 +1,119 @@
+package portmapper
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/docker/libnetwork/portmapper/proxy"
+	"github.com/ishidawataru/sctp"
+)
+
+const (
+	// ProxyModeProcess runs a docker-proxy process per mapped port
+	ProxyModeProcess = "process"
+	// ProxyModeInProcess runs the userland proxies on goroutines of the calling process
+	ProxyModeInProcess = "in-process"
+)
+
+// ProxyManager runs the userland proxies of the port mappers it is set on
+// inside the calling process, in place of a docker-proxy process per port.
+type ProxyManager struct {
+	maxConns     int
+	drainTimeout time.Duration
+	proxies      map[*inProcessProxy]struct{}
+	sync.Mutex
+}
+
+// NewProxyManager returns a new ProxyManager. Each proxy tracks at most maxConns
+// connections (TCP, SCTP) or flows (UDP) at once, 0 meaning no limit. On unmap,
+// ongoing connections are given drainTimeout to complete before being closed.
+func NewProxyManager(maxConns int, drainTimeout time.Duration) *ProxyManager {
+	return &ProxyManager{
+		maxConns:     maxConns,
+		drainTimeout: drainTimeout,
+		proxies:      make(map[*inProcessProxy]struct{}),
+	}
+}
+
+// Len returns the number of running proxies
+func (m *ProxyManager) Len() int {
+	m.Lock()
+	defer m.Unlock()
+	return len(m.proxies)
+}
+
+// Close stops all the running proxies, closing their connections
+func (m *ProxyManager) Close() {
+	m.Lock()
+	proxies := m.proxies
+	m.proxies = make(map[*inProcessProxy]struct{})
+	m.Unlock()
+
+	for p := range proxies {
+		p.p.Close()
+	}
+}
+
+func (m *ProxyManager) newProxy(proto string, hostIP net.IP, hostPort int, containerIP net.IP, containerPort, proxyProtocol int) (userlandProxy, error) {
+	var frontend, backend net.Addr
+	switch proto {
+	case "tcp":
+		frontend = &net.TCPAddr{IP: hostIP, Port: hostPort}
+		backend = &net.TCPAddr{IP: containerIP, Port: containerPort}
+	case "udp":
+		frontend = &net.UDPAddr{IP: hostIP, Port: hostPort}
+		backend = &net.UDPAddr{IP: containerIP, Port: containerPort}
+	case "sctp":
+		frontend = &sctp.SCTPAddr{IPAddrs: []net.IPAddr{{IP: hostIP}}, Port: hostPort}
+		backend = &sctp.SCTPAddr{IPAddrs: []net.IPAddr{{IP: containerIP}}, Port: containerPort}
+	default:
+		return nil, fmt.Errorf("Unknown addr type: %s", proto)
+	}
+	return &inProcessProxy{
+		manager:  m,
+		frontend: frontend,
+		backend:  backend,
+		config:   proxy.Config{MaxConns: m.maxConns, ProxyProtocol: proxyProtocol},
+	}, nil
+}
+
+// inProcessProxy is a userland proxy run on goroutines by a ProxyManager
+type inProcessProxy struct {
+	manager  *ProxyManager
+	frontend net.Addr
+	backend  net.Addr
+	config   proxy.Config
+	p        proxy.Proxy
+}
+
+func (p *inProcessProxy) Start() error {
+	pp, err := proxy.NewProxyWithConfig(p.frontend, p.backend, p.config)
+	if err != nil {
+		return fmt.Errorf("Error starting userland proxy: %v", err)
+	}
+	p.p = pp
+
+	p.manager.Lock()
+	p.manager.proxies[p] = struct{}{}
+	p.manager.Unlock()
+
+	go pp.Run()
+	return nil
+}
+
+// Stop closes the proxy frontend and lets the ongoing connections drain
+// in background
+func (p *inProcessProxy) Stop() error {
+	if p.p == nil {
+		return nil
+	}
+
+	p.manager.Lock()
+	delete(p.manager.proxies, p)
+	p.manager.Unlock()
+
+	p.p.Drain(p.manager.drainTimeout)
+	return nil
+}
@@ -5,6 +5,6 @@ import (
 	"net"
 )
 
-func newProxyCommand(proto string, hostIP net.IP, hostPort int, containerIP net.IP, containerPort int, proxyPath string) (userlandProxy, error) {
+func newProxyCommand(proto string, hostIP net.IP, hostPort int, containerIP net.IP, containerPort, proxyProtocol int, proxyPath string) (userlandProxy, error) {
 	return nil, errors.New("proxy is unsupported on windows")
 }
@@ -0,0 +1,94 @@
+package libnetwork
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/libnetwork/driverapi"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+// ReconcileReport lists the drift found between the kernel state and the
+// controller state by a reconciliation pass
+type ReconcileReport struct {
+	// DryRun tells the drift was only reported, not repaired
+	DryRun bool
+	// Sandboxes holds the drift of each sandbox, keyed by sandbox ID
+	Sandboxes map[string][]types.Drift
+	// Drivers holds the drift of each driver, keyed by driver name
+	Drivers map[string][]types.Drift
+}
+
+func (c *controller) Reconcile(dryRun bool) (*ReconcileReport, error) {
+	report := &ReconcileReport{
+		DryRun:    dryRun,
+		Sandboxes: make(map[string][]types.Drift),
+		Drivers:   make(map[string][]types.Drift),
+	}
+	var errs []string
+
+	c.Lock()
+	sandboxes := make([]*sandbox, 0, len(c.sandboxes))
+	for _, sb := range c.sandboxes {
+		sandboxes = append(sandboxes, sb)
+	}
+	c.Unlock()
+
+	for _, sb := range sandboxes {
+		sb.Lock()
+		osSbox := sb.osSbox
+		sb.Unlock()
+		if osSbox == nil {
+			continue
+		}
+		drifts, err := osSbox.Reconcile(dryRun)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("sandbox %.7s: %v", sb.ID(), err))
+		}
+		if len(drifts) > 0 {
+			report.Sandboxes[sb.ID()] = append(report.Sandboxes[sb.ID()], drifts...)
+		}
+	}
+
+	for _, n := range c.Networks() {
+		sbID, drifts, err := n.(*network).reconcileLoadBalancers(dryRun)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("load balancer of network %s: %v", n.Name(), err))
+		}
+		if len(drifts) > 0 {
+			report.Sandboxes[sbID] = append(report.Sandboxes[sbID], drifts...)
+		}
+	}
+
+	c.drvRegistry.WalkDrivers(func(name string, driver driverapi.Driver, capability driverapi.Capability) bool {
+		r, ok := driver.(driverapi.Reconciler)
+		if !ok {
+			return false
+		}
+		drifts, err := r.Reconcile(dryRun)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("driver %s: %v", name, err))
+		}
+		if len(drifts) > 0 {
+			report.Drivers[name] = drifts
+		}
+		return false
+	})
+
+	for id, drifts := range report.Sandboxes {
+		for _, d := range drifts {
+			logrus.Infof("Drift in sandbox %.7s: %s", id, d)
+		}
+	}
+	for name, drifts := range report.Drivers {
+		for _, d := range drifts {
+			logrus.Infof("Drift in %s driver: %s", name, d)
+		}
+	}
+
+	if len(errs) > 0 {
+		return report, fmt.Errorf("reconciliation failed for %s", strings.Join(errs, ", "))
+	}
+	return report, nil
+}
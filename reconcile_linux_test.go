@@ -0,0 +1,109 @@
+package libnetwork
+
+import (
+	"testing"
+
+	"github.com/docker/libnetwork/testutils"
+	"github.com/docker/libnetwork/types"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+func TestReconcileSandboxes(t *testing.T) {
+	defer testutils.SetupTestOSContext(t)()
+
+	c := newJournalTestController(t)
+	defer c.Stop()
+
+	n, err := journalTestNetwork(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ep, err := journalTestEndpoint(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sb, err := c.NewSandbox("rcontainer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ep.Join(sb); err != nil {
+		t.Fatal(err)
+	}
+
+	nsh, err := netns.GetFromPath(sb.Key())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nsh.Close()
+	nlh, err := netlink.NewHandleAt(nsh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer nlh.Delete()
+
+	defaultRoutes := func() []netlink.Route {
+		routes, err := nlh.RouteList(nil, netlink.FAMILY_V4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var defaults []netlink.Route
+		for _, r := range routes {
+			if r.Dst == nil {
+				defaults = append(defaults, r)
+			}
+		}
+		return defaults
+	}
+	routes := defaultRoutes()
+	if len(routes) != 1 {
+		t.Fatalf("expected the default route, got %v", routes)
+	}
+	if err := nlh.RouteDel(&routes[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	sandboxDrift := func(dryRun bool) []types.Drift {
+		report, err := c.Reconcile(dryRun)
+		if err != nil {
+			t.Logf("reconciliation error: %v", err)
+		}
+		if report.DryRun != dryRun {
+			t.Fatalf("unexpected dry run %t in report", report.DryRun)
+		}
+		return report.Sandboxes[sb.ID()]
+	}
+
+	drifts := sandboxDrift(true)
+	if len(drifts) != 1 || drifts[0].Kind != types.DriftMissing || drifts[0].Object != "default route" || drifts[0].Repaired {
+		t.Fatalf("unexpected drift on dry run: %v", drifts)
+	}
+	if len(defaultRoutes()) != 0 {
+		t.Fatal("dry run repaired the default route")
+	}
+
+	drifts = sandboxDrift(false)
+	if len(drifts) != 1 || !drifts[0].Repaired {
+		t.Fatalf("unexpected drift: %v", drifts)
+	}
+	if len(defaultRoutes()) != 1 {
+		t.Fatal("default route not repaired")
+	}
+
+	if drifts := sandboxDrift(false); len(drifts) != 0 {
+		t.Fatalf("unexpected drift after repair: %v", drifts)
+	}
+
+	if err := ep.Leave(sb); err != nil {
+		t.Fatal(err)
+	}
+	if err := sb.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ep.Delete(false); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.Delete(); err != nil {
+		t.Fatal(err)
+	}
+}
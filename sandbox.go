@@ -37,6 +37,14 @@ type Sandbox interface {
 	Rename(name string) error
 	// Delete destroys this container after detaching it from all connected endpoints.
 	Delete() error
+	// ReplaceEndpoint connects the sandbox to the endpoint newEp in place of
+	// the connected endpoint oldEp, moving routes and gateway over. The new
+	// endpoint takes over the address of the old one when both share the
+	// IPAM pool.
+	ReplaceEndpoint(oldEp, newEp Endpoint) error
+	// Checkpoint exports the network identity of the sandbox, its endpoints
+	// and their addresses, for RestoreSandbox to recreate it on another host.
+	Checkpoint() ([]byte, error)
 	// Endpoints returns all the endpoints connected to the sandbox
 	Endpoints() []Endpoint
 	// ResolveService returns all the backend details about the containers or hosts
@@ -85,12 +93,20 @@ type sandbox struct {
 	ndotsSet           bool
 	oslTypes           []osl.SandboxType // slice of properties of this sandbox
 	loadBalancerNID    string            // NID that this SB is a load balancer for
+	replacing          *epReplacement    // endpoint replacement in progress
 	sync.Mutex
 	// This mutex is used to serialize service related operation for an endpoint
 	// The lock is here because the endpoint is saved into the store so is not unique
 	Service sync.Mutex
 }
 
+// epReplacement tracks an endpoint joining the sandbox in place of another
+type epReplacement struct {
+	old    *endpoint
+	newID  string
+	keepIP bool // the new endpoint took over the addresses of the old one
+}
+
 // These are the container configs used to customize container /etc/hosts file.
 type hostsPathConfig struct {
 	hostName        string
@@ -128,6 +144,7 @@ type containerConfig struct {
 	generic           map[string]interface{}
 	useDefaultSandBox bool
 	useExternalKey    bool
+	policyRouting     bool
 	prio              int // higher the value, more the priority
 	exposedPorts      []types.TransportPort
 }
@@ -317,6 +334,130 @@ func (sb *sandbox) Refresh(options ...SandboxOption) error {
 	return nil
 }
 
+func (sb *sandbox) ReplaceEndpoint(oldEp, newEp Endpoint) (err error) {
+	old, ok := oldEp.(*endpoint)
+	if !ok {
+		return types.BadRequestErrorf("not a valid Endpoint interface")
+	}
+	ep, ok := newEp.(*endpoint)
+	if !ok {
+		return types.BadRequestErrorf("not a valid Endpoint interface")
+	}
+	if old.ID() == ep.ID() {
+		return types.BadRequestErrorf("endpoint %s cannot replace itself", ep.Name())
+	}
+
+	sb.joinLeaveStart()
+	defer sb.joinLeaveEnd()
+
+	defer ep.schedule(jlJoin)()
+
+	if old = sb.getEndpoint(old.ID()); old == nil {
+		return types.ForbiddenErrorf("endpoint %s is not connected to sandbox %s", oldEp.Name(), sb.ID())
+	}
+
+	n, err := ep.getNetworkFromStore()
+	if err != nil {
+		return fmt.Errorf("failed to get network from store during endpoint replacement: %v", err)
+	}
+	if ep, err = n.getEndpointFromStore(ep.ID()); err != nil {
+		return fmt.Errorf("failed to get endpoint from store during endpoint replacement: %v", err)
+	}
+
+	r := &epReplacement{old: old, newID: ep.ID()}
+
+	// The new endpoint takes over the addresses of the old one it shares
+	// the pools with, as well as its MAC address for the neighbors to keep
+	// their entries.
+	prev := &endpointInterface{}
+	if old.getNetwork().ID() == n.ID() && !n.hasSpecialDriver() {
+		old.Lock()
+		ep.Lock()
+		oi, ei := old.iface, ep.iface
+		r.keepIP = oi.addr != nil && oi.v4PoolID != "" && oi.v4PoolID == ei.v4PoolID
+		ei.CopyTo(prev)
+		addr, addrv6, mac := oi.addr, ei.addrv6, oi.mac
+		if oi.addrv6 != nil && oi.v6PoolID != "" && oi.v6PoolID == ei.v6PoolID {
+			addrv6 = oi.addrv6
+		}
+		ep.Unlock()
+		old.Unlock()
+
+		if r.keepIP {
+			if err = ep.resetAddresses(addr, addrv6, mac); err != nil {
+				return err
+			}
+		}
+	}
+
+	sb.Lock()
+	prio := sb.epPriority[old.ID()]
+	sb.replacing = r
+	sb.Unlock()
+	defer func() {
+		sb.Lock()
+		sb.replacing = nil
+		sb.Unlock()
+	}()
+
+	if err = ep.sbJoin(sb, JoinOptionPriority(prio)); err != nil {
+		if r.keepIP {
+			if e := ep.resetAddresses(prev.addr, prev.addrv6, prev.mac); e != nil {
+				logrus.Warnf("Failed to restore the addresses of endpoint %s (%s) after failing to replace endpoint %s: %v",
+					ep.Name(), ep.ID(), old.Name(), e)
+			}
+		}
+		return err
+	}
+	if ep = sb.getEndpoint(ep.ID()); ep == nil {
+		return fmt.Errorf("could not find the sandbox endpoint data for endpoint %s", r.newID)
+	}
+
+	// Move the gateway over ahead of the old endpoint leaving, for the
+	// sandbox to keep a default route
+	if gwep := sb.getGatewayEndpoint(); gwep != nil && gwep.ID() == old.ID() && len(ep.Gateway()) != 0 {
+		if err := sb.updateGateway(ep); err != nil {
+			logrus.Warnf("Failed to move the gateway of sandbox %s to endpoint %s: %v", sb.ID(), ep.Name(), err)
+		}
+	}
+
+	if old.isServiceEnabled() && !ep.isServiceEnabled() {
+		if err := ep.addServiceInfoToCluster(sb); err != nil {
+			logrus.Warnf("Failed to add service info of endpoint %s on replacement: %v", ep.Name(), err)
+		} else {
+			ep.enableService()
+		}
+	}
+
+	if err = old.sbLeave(sb, false); err != nil {
+		return err
+	}
+
+	if r.keepIP {
+		ep.handOverAddresses(old, prev)
+	}
+
+	if doUpdateHostsFile(n, sb) {
+		if err := sb.updateHostsAddresses(old, ep); err != nil {
+			logrus.Warnf("Failed to update the hosts file of sandbox %s on endpoint replacement: %v", sb.ID(), err)
+		}
+	}
+
+	return nil
+}
+
+// replacement returns the endpoint replacement ep joins the sandbox for, if
+// any
+func (sb *sandbox) replacement(ep *endpoint) *epReplacement {
+	sb.Lock()
+	defer sb.Unlock()
+
+	if sb.replacing == nil || sb.replacing.newID != ep.ID() {
+		return nil
+	}
+	return sb.replacing
+}
+
 func (sb *sandbox) MarshalJSON() ([]byte, error) {
 	sb.Lock()
 	defer sb.Unlock()
@@ -731,6 +872,8 @@ func (sb *sandbox) DisableService() (err error) {
 }
 
 func releaseOSSboxResources(osSbox osl.Sandbox, ep *endpoint) {
+	ep.getNetwork().getController().stopMirrors(ep.ID())
+
 	for _, i := range osSbox.Info().Interfaces() {
 		// Only remove the interfaces owned by this endpoint from the sandbox.
 		if ep.hasInterface(i.SrcName()) {
@@ -788,7 +931,6 @@ func (sb *sandbox) restoreOslSandbox() error {
 	// restore osl sandbox
 	Ifaces := make(map[string][]osl.IfaceOption)
 	for _, ep := range sb.endpoints {
-		var ifaceOptions []osl.IfaceOption
 		ep.Lock()
 		joinInfo := ep.joinInfo
 		i := ep.iface
@@ -799,19 +941,12 @@ func (sb *sandbox) restoreOslSandbox() error {
 			continue
 		}
 
-		ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().Address(i.addr), sb.osSbox.InterfaceOptions().Routes(i.routes))
-		if i.addrv6 != nil && i.addrv6.IP.To16() != nil {
-			ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().AddressIPv6(i.addrv6))
-		}
-		if i.mac != nil {
-			ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().MacAddress(i.mac))
-		}
-		if len(i.llAddrs) != 0 {
-			ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().LinkLocalAddresses(i.llAddrs))
-		}
-		Ifaces[fmt.Sprintf("%s+%s", i.srcName, i.dstPrefix)] = ifaceOptions
+		Ifaces[fmt.Sprintf("%s+%s", i.srcName, i.dstPrefix)] = append(sb.interfaceOptions(i), sb.policyRoutingOptions(joinInfo)...)
 		if joinInfo != nil {
 			routes = append(routes, joinInfo.StaticRoutes...)
+			for _, ei := range joinInfo.extraIfaces {
+				Ifaces[fmt.Sprintf("%s+%s", ei.srcName, ei.dstPrefix)] = sb.interfaceOptions(ei)
+			}
 		}
 		if ep.needResolver() {
 			sb.startResolver(true)
@@ -828,6 +963,48 @@ func (sb *sandbox) restoreOslSandbox() error {
 	return err
 }
 
+// interfaceOptions returns the options moving the endpoint interface i
+// into the sandbox
+func (sb *sandbox) interfaceOptions(i *endpointInterface) []osl.IfaceOption {
+	var ifaceOptions []osl.IfaceOption
+
+	ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().Address(i.addr), sb.osSbox.InterfaceOptions().Routes(i.routes))
+	if i.addrv6 != nil && i.addrv6.IP.To16() != nil {
+		ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().AddressIPv6(i.addrv6))
+	}
+	if len(i.llAddrs) != 0 {
+		ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().LinkLocalAddresses(i.llAddrs))
+	}
+	if i.mac != nil {
+		ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().MacAddress(i.mac))
+	}
+	if i.options.HostDevice {
+		ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().HostDevice(true))
+	}
+	if i.options.KeepName {
+		ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().SkipRename(true))
+	}
+	if i.options.KeepMAC {
+		ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().KeepMAC(true))
+	}
+
+	return ifaceOptions
+}
+
+// policyRoutingOptions returns the option routing the traffic sourced from
+// the endpoint interface through a table of its own, with the endpoint
+// gateways as default routes, when the sandbox has policy routing on
+func (sb *sandbox) policyRoutingOptions(joinInfo *endpointJoinInfo) []osl.IfaceOption {
+	if !sb.config.policyRouting {
+		return nil
+	}
+	var gw, gw6 net.IP
+	if joinInfo != nil && !joinInfo.disableGatewayService {
+		gw, gw6 = joinInfo.gw, joinInfo.gw6
+	}
+	return []osl.IfaceOption{sb.osSbox.InterfaceOptions().PolicyRouting(gw, gw6)}
+}
+
 func (sb *sandbox) populateNetworkResources(ep *endpoint) error {
 	sb.Lock()
 	if sb.osSbox == nil {
@@ -848,20 +1025,12 @@ func (sb *sandbox) populateNetworkResources(ep *endpoint) error {
 	}
 
 	if i != nil && i.srcName != "" {
-		var ifaceOptions []osl.IfaceOption
-
-		ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().Address(i.addr), sb.osSbox.InterfaceOptions().Routes(i.routes))
-		if i.addrv6 != nil && i.addrv6.IP.To16() != nil {
-			ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().AddressIPv6(i.addrv6))
-		}
-		if len(i.llAddrs) != 0 {
-			ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().LinkLocalAddresses(i.llAddrs))
-		}
-		if i.mac != nil {
-			ifaceOptions = append(ifaceOptions, sb.osSbox.InterfaceOptions().MacAddress(i.mac))
-		}
-
-		if err := sb.osSbox.AddInterface(i.srcName, i.dstPrefix, ifaceOptions...); err != nil {
+		ifaceOptions := append(sb.interfaceOptions(i), sb.policyRoutingOptions(joinInfo)...)
+		if oldIface := sb.replacedInterface(ep); oldIface != nil {
+			if err := sb.osSbox.ReplaceInterface(oldIface, i.srcName, ifaceOptions...); err != nil {
+				return fmt.Errorf("failed to replace interface %s with %s in sandbox: %v", oldIface.DstName(), i.srcName, err)
+			}
+		} else if err := sb.osSbox.AddInterface(i.srcName, i.dstPrefix, ifaceOptions...); err != nil {
 			return fmt.Errorf("failed to add interface %s to sandbox: %v", i.srcName, err)
 		}
 
@@ -879,6 +1048,13 @@ func (sb *sandbox) populateNetworkResources(ep *endpoint) error {
 	}
 
 	if joinInfo != nil {
+		// Add the interfaces the driver handed in addition to the endpoint one.
+		for _, ei := range joinInfo.extraIfaces {
+			if err := sb.osSbox.AddInterface(ei.srcName, ei.dstPrefix, sb.interfaceOptions(ei)...); err != nil {
+				return fmt.Errorf("failed to add interface %s to sandbox: %v", ei.srcName, err)
+			}
+		}
+
 		// Set up non-interface routes.
 		for _, r := range joinInfo.StaticRoutes {
 			if err := sb.osSbox.AddStaticRoute(r); err != nil {
@@ -916,6 +1092,26 @@ func (sb *sandbox) populateNetworkResources(ep *endpoint) error {
 	return nil
 }
 
+// replacedInterface returns the sandbox interface of the endpoint ep
+// replaces taking over its addresses, if any
+func (sb *sandbox) replacedInterface(ep *endpoint) osl.Interface {
+	r := sb.replacement(ep)
+	if r == nil || !r.keepIP {
+		return nil
+	}
+
+	r.old.Lock()
+	srcName := r.old.iface.srcName
+	r.old.Unlock()
+
+	for _, i := range sb.osSbox.Info().Interfaces() {
+		if i.SrcName() == srcName {
+			return i
+		}
+	}
+	return nil
+}
+
 func (sb *sandbox) clearNetworkResources(origEp *endpoint) error {
 	ep := sb.getEndpoint(origEp.id)
 	if ep == nil {
@@ -1137,6 +1333,17 @@ func OptionUseExternalKey() SandboxOption {
 	}
 }
 
+// OptionPolicyRouting function returns an option setter for source based
+// routing in the sandbox: the traffic sourced from the address of an
+// endpoint is routed through a table of its own, with the endpoint gateway
+// as default route, so that it leaves through the endpoint interface
+// whichever endpoint provides the default gateway of the sandbox.
+func OptionPolicyRouting() SandboxOption {
+	return func(sb *sandbox) {
+		sb.config.policyRouting = true
+	}
+}
+
 // OptionGeneric function returns an option setter for Generic configuration
 // that is not managed by libNetwork but can be used by the Drivers during the call to
 // net container creation method. Container Labels are a good example.
@@ -0,0 +1,278 @@
+package libnetwork
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/docker/libnetwork/netlabel"
+	"github.com/docker/libnetwork/osl"
+	"github.com/docker/libnetwork/types"
+	"github.com/sirupsen/logrus"
+)
+
+// sbCheckpoint is the network identity of a sandbox, as exported by
+// Checkpoint for RestoreSandbox to recreate the sandbox, possibly on
+// another host. The endpoints are recorded the way they are stored.
+type sbCheckpoint struct {
+	State     sbState
+	Config    sbConfigCheckpoint
+	Endpoints []json.RawMessage
+	Neighbors []osl.NeighborEntry
+}
+
+// sbConfigCheckpoint is the part of the sandbox configuration which is not
+// tied to the host the sandbox runs on
+type sbConfigCheckpoint struct {
+	HostName     string
+	DomainName   string
+	ExtraHosts   []extraHostCheckpoint
+	DNS          []string
+	DNSSearch    []string
+	DNSOptions   []string
+	ExposedPorts []types.TransportPort
+	PortBindings []types.PortBinding
+}
+
+type extraHostCheckpoint struct {
+	Name string
+	IP   string
+}
+
+func (sb *sandbox) Checkpoint() ([]byte, error) {
+	sb.joinLeaveStart()
+	defer sb.joinLeaveEnd()
+
+	sb.Lock()
+	cp := &sbCheckpoint{
+		State: sbState{
+			ID:            sb.id,
+			Cid:           sb.containerID,
+			EpPriority:    make(map[string]int, len(sb.epPriority)),
+			ExtDNS2:       sb.extDNS,
+			PolicyRouting: sb.config.policyRouting,
+		},
+		Config: sbConfigCheckpoint{
+			HostName:     sb.config.hostName,
+			DomainName:   sb.config.domainName,
+			DNS:          sb.config.dnsList,
+			DNSSearch:    sb.config.dnsSearchList,
+			DNSOptions:   sb.config.dnsOptionsList,
+			ExposedPorts: sb.config.exposedPorts,
+		},
+	}
+	for eid, prio := range sb.epPriority {
+		cp.State.EpPriority[eid] = prio
+	}
+	for _, eh := range sb.config.extraHosts {
+		cp.Config.ExtraHosts = append(cp.Config.ExtraHosts, extraHostCheckpoint{Name: eh.name, IP: eh.IP})
+	}
+	if pbs, ok := sb.config.generic[netlabel.PortMap].([]types.PortBinding); ok {
+		cp.Config.PortBindings = pbs
+	}
+	osSbox := sb.osSbox
+	useDefaultSandbox := sb.config.useDefaultSandBox
+	sb.Unlock()
+
+	for _, ext := range cp.State.ExtDNS2 {
+		cp.State.ExtDNS = append(cp.State.ExtDNS, ext.IPStr)
+	}
+
+	for _, ep := range sb.getConnectedEndpoints() {
+		// The endpoints which are not persisted, and the gateway network
+		// endpoint set up along with the others, are not part of the
+		// sandbox identity
+		if ep.Skip() || ep.endpointInGWNetwork() {
+			continue
+		}
+		b, err := json.Marshal(ep)
+		if err != nil {
+			return nil, fmt.Errorf("failed to checkpoint endpoint %s of sandbox %s: %v", ep.Name(), sb.ID(), err)
+		}
+		cp.State.Eps = append(cp.State.Eps, epState{Nid: ep.getNetwork().ID(), Eid: ep.ID()})
+		cp.Endpoints = append(cp.Endpoints, b)
+	}
+
+	if osSbox != nil && !useDefaultSandbox {
+		var err error
+		if cp.Neighbors, err = osSbox.Neighbors(); err != nil {
+			return nil, fmt.Errorf("failed to checkpoint the neighbor entries of sandbox %s: %v", sb.ID(), err)
+		}
+	}
+
+	return json.Marshal(cp)
+}
+
+// options returns the sandbox options recreating the checkpointed
+// configuration
+func (cfg *sbConfigCheckpoint) options() []SandboxOption {
+	options := []SandboxOption{OptionHostname(cfg.HostName), OptionDomainname(cfg.DomainName)}
+	for _, eh := range cfg.ExtraHosts {
+		options = append(options, OptionExtraHost(eh.Name, eh.IP))
+	}
+	for _, dns := range cfg.DNS {
+		options = append(options, OptionDNS(dns))
+	}
+	for _, search := range cfg.DNSSearch {
+		options = append(options, OptionDNSSearch(search))
+	}
+	for _, opt := range cfg.DNSOptions {
+		options = append(options, OptionDNSOptions(opt))
+	}
+	if len(cfg.ExposedPorts) != 0 {
+		options = append(options, OptionExposedPorts(cfg.ExposedPorts))
+	}
+	if len(cfg.PortBindings) != 0 {
+		options = append(options, OptionPortMapping(cfg.PortBindings))
+	}
+	return options
+}
+
+// optionSandboxID sets the ID of a restored sandbox, for it to keep the
+// identity it had when checkpointed
+func optionSandboxID(id string) SandboxOption {
+	return func(sb *sandbox) {
+		sb.id = id
+	}
+}
+
+func (c *controller) RestoreSandbox(data []byte, options ...SandboxOption) (Sandbox, error) {
+	var cp sbCheckpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, types.BadRequestErrorf("invalid sandbox checkpoint: %v", err)
+	}
+	if cp.State.ID == "" || cp.State.Cid == "" || len(cp.State.Eps) != len(cp.Endpoints) {
+		return nil, types.BadRequestErrorf("invalid sandbox checkpoint")
+	}
+	if _, err := c.SandboxByID(cp.State.ID); err == nil {
+		return nil, types.ForbiddenErrorf("sandbox %s already exists", cp.State.ID)
+	}
+
+	// Check the networks of the endpoints ahead of creating anything
+	networks := make([]*network, len(cp.State.Eps))
+	eps := make([]*endpoint, len(cp.Endpoints))
+	for i, es := range cp.State.Eps {
+		n, err := c.getNetworkFromStore(es.Nid)
+		if err != nil {
+			return nil, types.NotFoundErrorf("network %s of endpoint %s not found: %v", es.Nid, es.Eid, err)
+		}
+		networks[i] = n
+	}
+	for i, b := range cp.Endpoints {
+		ep := &endpoint{}
+		if err := json.Unmarshal(b, ep); err != nil {
+			return nil, types.BadRequestErrorf("invalid endpoint in sandbox checkpoint: %v", err)
+		}
+		eps[i] = ep
+	}
+
+	options = append(append(cp.Config.options(), options...), optionSandboxID(cp.State.ID))
+	if cp.State.PolicyRouting {
+		options = append(options, OptionPolicyRouting())
+	}
+	sbox, err := c.NewSandbox(cp.State.Cid, options...)
+	if err != nil {
+		return nil, err
+	}
+	sb := sbox.(*sandbox)
+	defer func() {
+		if err != nil {
+			if e := sb.delete(true); e != nil {
+				logrus.Warnf("Failed to delete sandbox %s after failing to restore it: %v", sb.ID(), e)
+			}
+		}
+	}()
+
+	// Keep the external servers of the embedded resolver
+	sb.Lock()
+	if len(cp.State.ExtDNS2) > 0 {
+		sb.extDNS = cp.State.ExtDNS2
+	} else if len(cp.State.ExtDNS) > 0 {
+		sb.extDNS = nil
+		for _, dns := range cp.State.ExtDNS {
+			sb.extDNS = append(sb.extDNS, extDNSEntry{IPStr: dns})
+		}
+	}
+	sb.Unlock()
+
+	for i, ep := range eps {
+		var nep *endpoint
+		if nep, err = networks[i].restoreEndpoint(ep); err != nil {
+			return nil, err
+		}
+		if err = nep.Join(sb, JoinOptionPriority(cp.State.EpPriority[ep.id]), joinOptionReadvertise()); err != nil {
+			if e := nep.Delete(true); e != nil {
+				logrus.Warnf("Failed to delete endpoint %s after failing to restore sandbox %s: %v", nep.Name(), sb.ID(), e)
+			}
+			return nil, fmt.Errorf("failed to join restored endpoint %s: %v", nep.Name(), err)
+		}
+	}
+
+	if sb.osSbox != nil && !sb.config.useDefaultSandBox {
+		if err := sb.osSbox.RestoreNeighbors(cp.Neighbors); err != nil {
+			logrus.Warnf("Failed to restore the neighbor entries of sandbox %s: %v", sb.ID(), err)
+		}
+	}
+
+	return sb, nil
+}
+
+// restoreEndpoint creates the checkpointed endpoint ep on the network, with
+// the ID, addresses and MAC address it had
+func (n *network) restoreEndpoint(ep *endpoint) (*endpoint, error) {
+	if _, err := n.getEndpointFromStore(ep.id); err == nil {
+		return nil, types.ForbiddenErrorf("endpoint %s already exists in network %s", ep.id, n.Name())
+	}
+
+	generic := make(map[string]interface{}, len(ep.generic)+1)
+	for k, v := range ep.generic {
+		generic[k] = v
+	}
+	if ep.iface.mac != nil {
+		generic[netlabel.MacAddress] = ep.iface.mac
+	}
+
+	var (
+		ip, ip6 net.IP
+		llIPs   []net.IP
+	)
+	if ep.iface.addr != nil {
+		ip = ep.iface.addr.IP
+	}
+	if ep.iface.addrv6 != nil {
+		ip6 = ep.iface.addrv6.IP
+	}
+	for _, ll := range ep.iface.llAddrs {
+		llIPs = append(llIPs, ll.IP)
+	}
+
+	options := []EndpointOption{
+		endpointOptionID(ep.id),
+		EndpointOptionGeneric(generic),
+		CreateOptionIpam(ip, ip6, llIPs, nil),
+	}
+	if len(ep.exposedPorts) != 0 {
+		options = append(options, CreateOptionExposedPorts(ep.exposedPorts))
+	}
+	if ep.anonymous {
+		options = append(options, CreateOptionAnonymous())
+	}
+	if ep.disableResolution {
+		options = append(options, CreateOptionDisableResolution())
+	}
+	for _, alias := range ep.myAliases {
+		options = append(options, CreateOptionMyAlias(alias))
+	}
+	if ep.svcName != "" {
+		options = append(options, CreateOptionService(ep.svcName, ep.svcID, ep.virtualIP, ep.ingressPorts, ep.svcAliases))
+	}
+	if ep.loadBalancer {
+		options = append(options, CreateOptionLoadBalancer())
+	}
+
+	e, err := n.CreateEndpoint(ep.name, options...)
+	if err != nil {
+		return nil, err
+	}
+	return e.(*endpoint), nil
+}
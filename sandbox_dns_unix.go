@@ -107,6 +107,19 @@ func (sb *sandbox) updateHostsFile(ifaceIPs []string) error {
 		return nil
 	}
 
+	mhost := sb.hostsFileNames()
+	var extraContent []etchosts.Record
+	for _, ip := range ifaceIPs {
+		extraContent = append(extraContent, etchosts.Record{Hosts: mhost, IP: ip})
+	}
+
+	sb.addHostsEntries(extraContent)
+	return nil
+}
+
+// hostsFileNames returns the names of the container in its hosts file
+// records.
+func (sb *sandbox) hostsFileNames() string {
 	// User might have provided a FQDN in hostname or split it across hostname
 	// and domainname.  We want the FQDN and the bare hostname.
 	fqdn := sb.config.hostName
@@ -119,13 +132,39 @@ func (sb *sandbox) updateHostsFile(ifaceIPs []string) error {
 	if len(parts) == 2 {
 		mhost = fmt.Sprintf("%s %s", fqdn, parts[0])
 	}
+	return mhost
+}
 
-	var extraContent []etchosts.Record
-	for _, ip := range ifaceIPs {
-		extraContent = append(extraContent, etchosts.Record{Hosts: mhost, IP: ip})
+// updateHostsAddresses points the container records of the hosts file to
+// the addresses of the endpoint ep, which replaced the endpoint old.
+func (sb *sandbox) updateHostsAddresses(old, ep *endpoint) error {
+	if sb.config.originHostsPath != "" {
+		return nil
+	}
+
+	mhost := sb.hostsFileNames()
+	oldIP, oldIPv6 := old.getFirstInterfaceIPv4Address(), old.getFirstInterfaceIPv6Address()
+	ip, ipv6 := ep.getFirstInterfaceIPv4Address(), ep.getFirstInterfaceIPv6Address()
+
+	// etchosts.Update rewrites all the records of the hostname, drop the
+	// IPv6 one ahead of it
+	if oldIPv6 != nil {
+		sb.deleteHostsEntries([]etchosts.Record{{Hosts: mhost, IP: oldIPv6.String()}})
+	}
+	switch {
+	case ip != nil && oldIP != nil:
+		if err := etchosts.Update(sb.config.hostsPath, ip.String(), sb.config.hostName); err != nil {
+			return err
+		}
+	case ip != nil:
+		sb.addHostsEntries([]etchosts.Record{{Hosts: mhost, IP: ip.String()}})
+	case oldIP != nil:
+		sb.deleteHostsEntries([]etchosts.Record{{Hosts: mhost, IP: oldIP.String()}})
+	}
+	if ipv6 != nil {
+		sb.addHostsEntries([]etchosts.Record{{Hosts: mhost, IP: ipv6.String()}})
 	}
 
-	sb.addHostsEntries(extraContent)
 	return nil
 }
 
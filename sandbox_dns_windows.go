@@ -22,6 +22,10 @@ func (sb *sandbox) updateHostsFile(ifaceIP []string) error {
 	return nil
 }
 
+func (sb *sandbox) updateHostsAddresses(old, ep *endpoint) error {
+	return nil
+}
+
 func (sb *sandbox) addHostsEntries(recs []etchosts.Record) {
 
 }
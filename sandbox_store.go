@@ -32,6 +32,9 @@ type sbState struct {
 	// between >=1.14 and <1.14 versions.
 	ExtDNS  []string
 	ExtDNS2 []extDNSEntry
+	// PolicyRouting is persisted so that the policy routes of the
+	// endpoints are known on restore
+	PolicyRouting bool
 }
 
 func (sbs *sbState) Key() []string {
@@ -113,6 +116,7 @@ func (sbs *sbState) CopyTo(o datastore.KVObject) error {
 	dstSbs.dbIndex = sbs.dbIndex
 	dstSbs.dbExists = sbs.dbExists
 	dstSbs.EpPriority = sbs.EpPriority
+	dstSbs.PolicyRouting = sbs.PolicyRouting
 
 	dstSbs.Eps = append(dstSbs.Eps, sbs.Eps...)
 
@@ -137,11 +141,12 @@ func (sbs *sbState) DataScope() string {
 
 func (sb *sandbox) storeUpdate() error {
 	sbs := &sbState{
-		c:          sb.controller,
-		ID:         sb.id,
-		Cid:        sb.containerID,
-		EpPriority: sb.epPriority,
-		ExtDNS2:    sb.extDNS,
+		c:             sb.controller,
+		ID:            sb.id,
+		Cid:           sb.containerID,
+		EpPriority:    sb.epPriority,
+		ExtDNS2:       sb.extDNS,
+		PolicyRouting: sb.config.policyRouting,
 	}
 
 	for _, ext := range sb.extDNS {
@@ -202,6 +207,12 @@ func (c *controller) sandboxCleanup(activeSandboxes map[string]interface{}) {
 		return
 	}
 
+	sbStates := make([]*sbState, 0, len(kvol))
+	for _, kvo := range kvol {
+		sbStates = append(sbStates, kvo.(*sbState))
+	}
+	c.replayJoinJournals(sbStates)
+
 	// It's normal for no sandboxes to be found. Just bail out.
 	if err == datastore.ErrKeyNotFound {
 		return
@@ -220,6 +231,7 @@ func (c *controller) sandboxCleanup(activeSandboxes map[string]interface{}) {
 			isStub:             true,
 			dbExists:           true,
 		}
+		sb.config.policyRouting = sbs.PolicyRouting
 		// If we are restoring from a older version extDNSEntry won't have the
 		// HostLoopback field
 		if len(sbs.ExtDNS2) > 0 {
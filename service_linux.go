@@ -17,6 +17,7 @@ import (
 	"github.com/docker/docker/pkg/reexec"
 	"github.com/docker/libnetwork/iptables"
 	"github.com/docker/libnetwork/ns"
+	"github.com/docker/libnetwork/types"
 	"github.com/gogo/protobuf/proto"
 	"github.com/ishidawataru/sctp"
 	"github.com/moby/ipvs"
@@ -255,6 +256,115 @@ func (n *network) rmLBBackend(ip net.IP, lb *loadBalancer, rmService bool, fullR
 	}
 }
 
+// reconcileLoadBalancers verifies the ipvs services and real servers of the
+// load balancing sandbox of the network against its service bindings and
+// returns the drift found along with the sandbox ID. The networks with no
+// service binding are skipped, their bindings being synced back by the
+// cluster agent after a restart.
+func (n *network) reconcileLoadBalancers(dryRun bool) (string, []types.Drift, error) {
+	c := n.getController()
+	lbs := make(map[uint32]*loadBalancer)
+	c.Lock()
+	for _, s := range c.serviceBindings {
+		s.Lock()
+		if lb, ok := s.loadBalancers[n.ID()]; ok && !s.deleted && len(lb.vip) != 0 {
+			lbs[lb.fwMark] = lb
+		}
+		s.Unlock()
+	}
+	c.Unlock()
+	if len(lbs) == 0 {
+		return "", nil, nil
+	}
+
+	_, sb, err := n.findLBEndpointSandbox()
+	if err != nil || sb.osSbox == nil {
+		return "", nil, nil
+	}
+
+	i, err := ipvs.New(sb.Key())
+	if err != nil {
+		return sb.ID(), nil, fmt.Errorf("failed to create an ipvs handle for sbox %.7s: %v", sb.ID(), err)
+	}
+	defer i.Close()
+
+	services, err := i.GetServices()
+	if err != nil {
+		return sb.ID(), nil, fmt.Errorf("failed to list the ipvs services of sbox %.7s: %v", sb.ID(), err)
+	}
+
+	r := &types.DriftReport{DryRun: dryRun}
+	present := make(map[uint32]*ipvs.Service, len(services))
+	for _, s := range services {
+		if s.FWMark == 0 {
+			continue
+		}
+		if _, ok := lbs[s.FWMark]; !ok {
+			s := s
+			r.Add(types.DriftOrphan, "ipvs service", fmt.Sprintf("fwmark %d", s.FWMark), func() error {
+				return i.DelService(s)
+			})
+			continue
+		}
+		present[s.FWMark] = s
+	}
+
+	for fwMark, lb := range lbs {
+		lb.Lock()
+		backEnds := make(map[string]bool, len(lb.backEnds))
+		for _, be := range lb.backEnds {
+			backEnds[be.ip.String()] = backEnds[be.ip.String()] || !be.disabled
+		}
+		lb.Unlock()
+
+		lb := lb
+		s, ok := present[fwMark]
+		if !ok {
+			r.Add(types.DriftMissing, "ipvs service", fmt.Sprintf("fwmark %d for vip %s", fwMark, lb.vip), func() error {
+				for ip, enabled := range backEnds {
+					if enabled {
+						n.addLBBackend(net.ParseIP(ip), lb)
+					}
+				}
+				if !i.IsServicePresent(&ipvs.Service{AddressFamily: nl.FAMILY_V4, FWMark: fwMark}) {
+					return fmt.Errorf("failed to create the service")
+				}
+				return nil
+			})
+			continue
+		}
+
+		dests, err := i.GetDestinations(s)
+		if err != nil {
+			return sb.ID(), r.Drifts, fmt.Errorf("failed to list the real servers of fwmark %d in sbox %.7s: %v", fwMark, sb.ID(), err)
+		}
+		found := make(map[string]bool, len(dests))
+		for _, d := range dests {
+			ip := d.Address.String()
+			found[ip] = true
+			if _, ok := backEnds[ip]; ok {
+				continue
+			}
+			d := d
+			r.Add(types.DriftOrphan, "ipvs real server", fmt.Sprintf("%s of fwmark %d", ip, fwMark), func() error {
+				return i.DelDestination(s, d)
+			})
+		}
+		for ip, enabled := range backEnds {
+			if !enabled || found[ip] {
+				continue
+			}
+			ip := ip
+			r.Add(types.DriftMissing, "ipvs real server", fmt.Sprintf("%s of fwmark %d", ip, fwMark), func() error {
+				n.addLBBackend(net.ParseIP(ip), lb)
+				return nil
+			})
+		}
+	}
+
+	return sb.ID(), r.Drifts, nil
+}
+
 const ingressChain = "DOCKER-INGRESS"
 
 var (
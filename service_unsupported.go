@@ -5,6 +5,8 @@ package libnetwork
 import (
 	"fmt"
 	"net"
+
+	"github.com/docker/libnetwork/types"
 )
 
 func (c *controller) cleanupServiceBindings(nid string) {
@@ -21,5 +23,9 @@ func (c *controller) rmServiceBinding(name, sid, nid, eid string, vip net.IP, in
 func (sb *sandbox) populateLoadBalancers(ep *endpoint) {
 }
 
+func (n *network) reconcileLoadBalancers(dryRun bool) (string, []types.Drift, error) {
+	return "", nil, nil
+}
+
 func arrangeIngressFilterRule() {
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Microsoft/hcsshim"
 	"github.com/Microsoft/hcsshim/osversion"
+	"github.com/docker/libnetwork/types"
 	"github.com/sirupsen/logrus"
 )
 
@@ -169,5 +170,9 @@ func numEnabledBackends(lb *loadBalancer) int {
 func (sb *sandbox) populateLoadBalancers(ep *endpoint) {
 }
 
+func (n *network) reconcileLoadBalancers(dryRun bool) (string, []types.Drift, error) {
+	return "", nil, nil
+}
+
 func arrangeIngressFilterRule() {
 }
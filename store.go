@@ -243,6 +243,24 @@ retry:
 	return nil
 }
 
+// deleteStoreKey deletes the object stored under the key of kvObject, if
+// any
+func (c *controller) deleteStoreKey(kvObject datastore.KVObject) error {
+	cs := c.getStore(kvObject.DataScope())
+	if cs == nil {
+		return ErrDataStoreNotInitialized(kvObject.DataScope())
+	}
+
+	if err := cs.GetObject(datastore.Key(kvObject.Key()...), kvObject); err != nil {
+		if err == datastore.ErrKeyNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return c.deleteFromStore(kvObject)
+}
+
 type netWatch struct {
 	localEps  map[string]*endpoint
 	remoteEps map[string]*endpoint
@@ -7,6 +7,7 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ishidawataru/sctp"
 )
@@ -86,11 +87,23 @@ type PortBinding struct {
 	Proto       Protocol
 	IP          net.IP
 	Port        uint16
+	PortEnd     uint16
 	HostIP      net.IP
 	HostPort    uint16
 	HostPortEnd uint16
+	// ProxyProtocol is the version of the PROXY protocol header the
+	// userland proxy prepends to the backend connections, 0 for none
+	ProxyProtocol uint8
 }
 
+// PROXY protocol versions supported for the port bindings
+const (
+	// ProxyProtocolV1 is the human-readable header format
+	ProxyProtocolV1 = 1
+	// ProxyProtocolV2 is the binary header format
+	ProxyProtocolV2 = 2
+)
+
 // HostAddr returns the host side transport address
 func (p PortBinding) HostAddr() (net.Addr, error) {
 	switch p.Proto {
@@ -119,34 +132,52 @@ func (p PortBinding) ContainerAddr() (net.Addr, error) {
 	}
 }
 
+// PortRange returns the number of container ports the binding spans.
+// PortEnd is zero for single port bindings.
+func (p PortBinding) PortRange() int {
+	if p.PortEnd <= p.Port {
+		return 1
+	}
+	return int(p.PortEnd-p.Port) + 1
+}
+
 // GetCopy returns a copy of this PortBinding structure instance
 func (p *PortBinding) GetCopy() PortBinding {
 	return PortBinding{
-		Proto:       p.Proto,
-		IP:          GetIPCopy(p.IP),
-		Port:        p.Port,
-		HostIP:      GetIPCopy(p.HostIP),
-		HostPort:    p.HostPort,
-		HostPortEnd: p.HostPortEnd,
+		Proto:         p.Proto,
+		IP:            GetIPCopy(p.IP),
+		Port:          p.Port,
+		PortEnd:       p.PortEnd,
+		HostIP:        GetIPCopy(p.HostIP),
+		HostPort:      p.HostPort,
+		HostPortEnd:   p.HostPortEnd,
+		ProxyProtocol: p.ProxyProtocol,
 	}
 }
 
-// String returns the PortBinding structure in string form
+// String returns the PortBinding structure in string form, the ports of a
+// range binding in "port-portEnd" form
 func (p *PortBinding) String() string {
-	ret := fmt.Sprintf("%s/", p.Proto)
-	if p.IP != nil {
-		ret += p.IP.String()
+	return fmt.Sprintf("%s/%s/%s", p.Proto,
+		ipPortString(p.IP, p.Port, p.PortEnd),
+		ipPortString(p.HostIP, p.HostPort, p.HostPortEnd))
+}
+
+func ipPortString(ip net.IP, port, portEnd uint16) string {
+	var host string
+	if ip != nil {
+		host = ip.String()
 	}
-	ret = fmt.Sprintf("%s:%d/", ret, p.Port)
-	if p.HostIP != nil {
-		ret += p.HostIP.String()
+	portstr := strconv.Itoa(int(port))
+	if portEnd > port {
+		portstr = fmt.Sprintf("%d-%d", port, portEnd)
 	}
-	ret = fmt.Sprintf("%s:%d", ret, p.HostPort)
-	return ret
+	return net.JoinHostPort(host, portstr)
 }
 
 // FromString reads the PortBinding structure from string s.
-// String s is a triple of "protocol/containerIP:port/hostIP:port"
+// String s is a triple of "protocol/containerIP:port/hostIP:port", where
+// each port can be a "port-portEnd" range.
 // containerIP and hostIP can be in dotted decimal ("192.0.2.1") or IPv6 ("2001:db8::68") form.
 // Zoned addresses ("169.254.0.23%eth0" or "fe80::1ff:fe23:4567:890a%eth0") are not supported.
 // If string s is incorrectly formatted or the IP addresses or ports cannot be parsed, FromString
@@ -160,34 +191,46 @@ func (p *PortBinding) FromString(s string) error {
 	p.Proto = ParseProtocol(ps[0])
 
 	var err error
-	if p.IP, p.Port, err = parseIPPort(ps[1]); err != nil {
+	if p.IP, p.Port, p.PortEnd, err = parseIPPort(ps[1]); err != nil {
 		return BadRequestErrorf("failed to parse Container IP/Port in port binding: %s", err.Error())
 	}
 
-	if p.HostIP, p.HostPort, err = parseIPPort(ps[2]); err != nil {
+	if p.HostIP, p.HostPort, p.HostPortEnd, err = parseIPPort(ps[2]); err != nil {
 		return BadRequestErrorf("failed to parse Host IP/Port in port binding: %s", err.Error())
 	}
 
 	return nil
 }
 
-func parseIPPort(s string) (net.IP, uint16, error) {
+// parseIPPort parses the "ip:port" or "ip:port-portEnd" string, portEnd
+// being zero for a single port
+func parseIPPort(s string) (net.IP, uint16, uint16, error) {
 	hoststr, portstr, err := net.SplitHostPort(s)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, 0, err
 	}
 
 	ip := net.ParseIP(hoststr)
 	if ip == nil {
-		return nil, 0, BadRequestErrorf("invalid ip: %s", hoststr)
+		return nil, 0, 0, BadRequestErrorf("invalid ip: %s", hoststr)
 	}
 
-	port, err := strconv.ParseUint(portstr, 10, 16)
+	startstr, endstr := portstr, ""
+	if i := strings.Index(portstr, "-"); i >= 0 {
+		startstr, endstr = portstr[:i], portstr[i+1:]
+	}
+	port, err := strconv.ParseUint(startstr, 10, 16)
 	if err != nil {
-		return nil, 0, BadRequestErrorf("invalid port: %s", portstr)
+		return nil, 0, 0, BadRequestErrorf("invalid port: %s", portstr)
+	}
+	var portEnd uint64
+	if endstr != "" {
+		if portEnd, err = strconv.ParseUint(endstr, 10, 16); err != nil || portEnd < port {
+			return nil, 0, 0, BadRequestErrorf("invalid port range: %s", portstr)
+		}
 	}
 
-	return ip, uint16(port), nil
+	return ip, uint16(port), uint16(portEnd), nil
 }
 
 // Equal checks if this instance of PortBinding is equal to the passed one
@@ -200,8 +243,9 @@ func (p *PortBinding) Equal(o *PortBinding) bool {
 		return false
 	}
 
-	if p.Proto != o.Proto || p.Port != o.Port ||
-		p.HostPort != o.HostPort || p.HostPortEnd != o.HostPortEnd {
+	if p.Proto != o.Proto || p.Port != o.Port || p.PortEnd != o.PortEnd ||
+		p.HostPort != o.HostPort || p.HostPortEnd != o.HostPortEnd ||
+		p.ProxyProtocol != o.ProxyProtocol {
 		return false
 	}
 
@@ -429,6 +473,45 @@ func ParseCIDR(cidr string) (n *net.IPNet, e error) {
 	return
 }
 
+// ParseAddressRange returns the first and last addresses of the range
+// represented by the passed "first-last" addresses, CIDR or single address
+// notation
+func ParseAddressRange(r string) (net.IP, net.IP, error) {
+	if strings.Contains(r, "/") {
+		_, nw, err := net.ParseCIDR(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		last, err := GetBroadcastIP(nw.IP, nw.Mask)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nw.IP, last, nil
+	}
+
+	parts := strings.SplitN(r, "-", 2)
+	first := net.ParseIP(strings.TrimSpace(parts[0]))
+	if first == nil {
+		return nil, nil, fmt.Errorf("invalid address range %q", r)
+	}
+	last := first
+	if len(parts) == 2 {
+		if last = net.ParseIP(strings.TrimSpace(parts[1])); last == nil {
+			return nil, nil, fmt.Errorf("invalid address range %q", r)
+		}
+	}
+	if (first.To4() == nil) != (last.To4() == nil) {
+		return nil, nil, fmt.Errorf("mixed address families in range %q", r)
+	}
+	if first.To4() != nil {
+		first, last = first.To4(), last.To4()
+	}
+	if bytes.Compare(first, last) > 0 {
+		return nil, nil, fmt.Errorf("invalid address range %q: %s is after %s", r, first, last)
+	}
+	return first, last, nil
+}
+
 const (
 	// NEXTHOP indicates a StaticRoute with an IP next hop.
 	NEXTHOP = iota
@@ -474,6 +557,77 @@ func (is *InterfaceStatistics) String() string {
 		is.RxBytes, is.RxPackets, is.RxErrors, is.RxDropped, is.TxBytes, is.TxPackets, is.TxErrors, is.TxDropped)
 }
 
+// PluginHealth is the health of a remote driver plugin, as tracked by the
+// circuit breaker of its calls
+type PluginHealth struct {
+	// Healthy is false while the calls to the plugin fail fast
+	Healthy bool
+	// Failures is the number of consecutive failed calls
+	Failures int
+	// LastError is the error of the last failed call
+	LastError string
+	// Since is the time Healthy last changed
+	Since time.Time
+}
+
+// DriftKind tells how the kernel state departs from the expected one
+type DriftKind string
+
+const (
+	// DriftMissing is an expected object missing from the kernel
+	DriftMissing DriftKind = "missing"
+	// DriftOrphan is an object left in the kernel while not expected
+	DriftOrphan DriftKind = "orphan"
+	// DriftChanged is an object present with other settings than expected
+	DriftChanged DriftKind = "changed"
+)
+
+// Drift is a difference found between the state libnetwork expects in a
+// sandbox or a driver and the state of the kernel
+type Drift struct {
+	Kind DriftKind
+	// Object is the type of the drifted object, as "address" or "rule"
+	Object string
+	// Name identifies the object
+	Name string
+	// Repaired is set once the drift is fixed in the kernel
+	Repaired bool
+	// Error is the reason the drift could not be repaired
+	Error string `json:",omitempty"`
+}
+
+func (d Drift) String() string {
+	s := fmt.Sprintf("%s %s %s", d.Kind, d.Object, d.Name)
+	switch {
+	case d.Repaired:
+		s += " (repaired)"
+	case d.Error != "":
+		s += fmt.Sprintf(" (not repaired: %s)", d.Error)
+	}
+	return s
+}
+
+// DriftReport collects the drift found by a reconciliation pass, repaired
+// as it is found unless DryRun is set
+type DriftReport struct {
+	DryRun bool
+	Drifts []Drift
+}
+
+// Add records a drift, calling repair to fix it unless the report is a dry
+// run. A repair returning an error leaves the drift unrepaired.
+func (r *DriftReport) Add(kind DriftKind, object, name string, repair func() error) {
+	d := Drift{Kind: kind, Object: object, Name: name}
+	if !r.DryRun && repair != nil {
+		if err := repair(); err != nil {
+			d.Error = err.Error()
+		} else {
+			d.Repaired = true
+		}
+	}
+	r.Drifts = append(r.Drifts, d)
+}
+
 /******************************
  * Well-known Error Interfaces
  ******************************/
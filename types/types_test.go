@@ -62,6 +62,22 @@ func TestTransportPortBindingConv(t *testing.T) {
 				HostPort: uint16(8001),
 			},
 		},
+		{ // Port range
+			sform: "udp/172.28.30.23:5000-5009/112.0.43.56:6000-6009",
+			pb: PortBinding{
+				Proto:       UDP,
+				IP:          net.IPv4(172, 28, 30, 23),
+				Port:        uint16(5000),
+				PortEnd:     uint16(5009),
+				HostIP:      net.IPv4(112, 0, 43, 56),
+				HostPort:    uint16(6000),
+				HostPortEnd: uint16(6009),
+			},
+		},
+		{ // Reversed port range
+			sform:      "udp/172.28.30.23:5009-5000/112.0.43.56:6000",
+			shouldFail: true,
+		},
 		{ // IPv4 -> IPv4 zoned
 			sform:      "tcp/172.28.30.23:80/169.254.0.23%eth0:8001",
 			shouldFail: true,
@@ -80,6 +96,9 @@ func TestTransportPortBindingConv(t *testing.T) {
 		} else {
 			assert.NilError(t, err)
 			assert.Assert(t, is.DeepEqual(in.pb, *rc), "input %s: expected %#v, got %#v", in.sform, in.pb, rc)
+			rt := &PortBinding{}
+			assert.NilError(t, rt.FromString(rc.String()))
+			assert.Assert(t, is.DeepEqual(*rc, *rt), "input %s: expected %#v, got %#v", rc.String(), rc, rt)
 		}
 	}
 }
@@ -416,3 +435,31 @@ func TestParseCIDR(t *testing.T) {
 		}
 	}
 }
+
+func TestParseAddressRange(t *testing.T) {
+	input := []struct {
+		r           string
+		first, last string
+	}{
+		{"172.28.0.1-172.28.0.20", "172.28.0.1", "172.28.0.20"},
+		{"172.28.0.200 - 172.28.0.254", "172.28.0.200", "172.28.0.254"},
+		{"172.28.1.0/25", "172.28.1.0", "172.28.1.127"},
+		{"172.28.2.7", "172.28.2.7", "172.28.2.7"},
+		{"2001:db8::10-2001:db8::1:0", "2001:db8::10", "2001:db8::1:0"},
+	}
+	for _, i := range input {
+		first, last, err := ParseAddressRange(i.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first.String() != i.first || last.String() != i.last {
+			t.Fatalf("Unexpected range for %q: %s-%s", i.r, first, last)
+		}
+	}
+
+	for _, r := range []string{"", "172.28.0.20-172.28.0.1", "172.28.0.1-2001:db8::1", "172.28.0.1-", "10.0.0.0/33"} {
+		if _, _, err := ParseAddressRange(r); err == nil {
+			t.Fatalf("Expected failure for %q", r)
+		}
+	}
+}